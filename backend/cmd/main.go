@@ -1,11 +1,15 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"os"
 
 	"release-management/internal/config"
 	"release-management/internal/database"
+	"release-management/internal/events"
+	"release-management/internal/repository/gormrepo"
 	"release-management/internal/router"
 )
 
@@ -16,13 +20,23 @@ func main() {
 		log.Fatal("Failed to load configuration:", err)
 	}
 
+	// Schema management runs instead of the server
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		os.Exit(runMigrate(cfg, os.Args[2:]))
+	}
+
 	// Connect to database
 	if err := database.Connect(cfg); err != nil {
 		log.Fatal("Failed to connect to database:", err)
 	}
 
+	store := gormrepo.NewStore(database.DB)
+
+	// Send outbound webhooks in the background
+	go events.NewDispatcher(store, cfg.Webhooks).Run(context.Background())
+
 	// Setup router
-	r := router.Setup(cfg)
+	r := router.Setup(cfg, store)
 
 	// Start server
 	addr := fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port)
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/google/uuid"
+)
+
+// errDrift is returned by "envs drift" after printing a report with differences
+var errDrift = errors.New("drift found")
+
+// errNotFound is returned when a name or ID does not resolve to an entity
+var errNotFound = errors.New("not found")
+
+// usageError is an invalid command line or incomplete configuration
+type usageError struct {
+	msg string
+}
+
+func (e *usageError) Error() string {
+	return e.msg
+}
+
+// Helper function to return a usage error
+func usagef(format string, args ...interface{}) error {
+	return &usageError{msg: fmt.Sprintf(format, args...)}
+}
+
+// app holds the state of one command run
+type app struct {
+	name   string
+	usage  string
+	stdout io.Writer
+	stderr io.Writer
+
+	// Flags shared by every command
+	profile string
+	server  string
+	token   string
+	output  string
+}
+
+// Helper function to create the flag set of the command, including the shared flags
+func (a *app) flags() *flag.FlagSet {
+	fs := flag.NewFlagSet("relctl "+a.name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "usage: relctl %s %s\n\nFlags:\n", a.name, a.usage)
+		fs.PrintDefaults()
+	}
+
+	fs.StringVar(&a.profile, "profile", "", "configuration profile, defaults to $RELCTL_PROFILE or the current profile")
+	fs.StringVar(&a.server, "server", "", "API server URL, overrides the profile and $RELCTL_SERVER")
+	fs.StringVar(&a.token, "token", "", "API token, overrides the profile and $RELCTL_TOKEN")
+	fs.StringVar(&a.output, "output", "table", "output format: table, json or yaml")
+	fs.StringVar(&a.output, "o", "table", "shorthand for --output")
+	return fs
+}
+
+// Helper function to parse flags that may appear before, between and after the positional arguments.
+// It returns the positional arguments and fails unless there are exactly positional of them.
+func (a *app) parse(fs *flag.FlagSet, args []string, positional int) ([]string, error) {
+	var rest []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, &usageError{msg: err.Error()}
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		rest = append(rest, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+
+	if len(rest) != positional {
+		return nil, usagef("usage: relctl %s %s", a.name, a.usage)
+	}
+	switch a.output {
+	case "table", "json", "yaml":
+	default:
+		return nil, usagef("invalid output format %q, use table, json or yaml", a.output)
+	}
+	return rest, nil
+}
+
+// Helper function to create an API client from the flags, the environment and the selected profile
+func (a *app) client() (*client, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	name := firstNonEmpty(a.profile, os.Getenv("RELCTL_PROFILE"), cfg.CurrentProfile, defaultProfile)
+	p, ok := cfg.Profiles[name]
+	if !ok && name != defaultProfile {
+		return nil, usagef("profile %q does not exist, create it with relctl config set %s --server URL --token TOKEN", name, name)
+	}
+
+	server := firstNonEmpty(a.server, os.Getenv("RELCTL_SERVER"), p.Server, defaultServer)
+	token := firstNonEmpty(a.token, os.Getenv("RELCTL_TOKEN"), p.Token)
+	if token == "" {
+		return nil, usagef("no API token configured, pass --token or run relctl config set %s --token TOKEN", name)
+	}
+	return newClient(server, token), nil
+}
+
+// column is a column of table output
+type column[T any] struct {
+	header string
+	value  func(item *T) string
+}
+
+// Helper function to print items as a table with columns, or as JSON or YAML
+func printList[T any](a *app, items []T, columns []column[T]) error {
+	if a.output != "table" {
+		if items == nil {
+			items = []T{}
+		}
+		return a.printValue(items)
+	}
+
+	w := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+	headers := make([]string, len(columns))
+	for i, col := range columns {
+		headers[i] = col.header
+	}
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+	for i := range items {
+		values := make([]string, len(columns))
+		for j, col := range columns {
+			values[j] = col.value(&items[i])
+		}
+		fmt.Fprintln(w, strings.Join(values, "\t"))
+	}
+	return w.Flush()
+}
+
+// Helper function to print a single item as a table with columns, or as JSON or YAML
+func printItem[T any](a *app, item *T, columns []column[T]) error {
+	if a.output != "table" {
+		return a.printValue(item)
+	}
+	return printList(a, []T{*item}, columns)
+}
+
+// Helper function to print a value as JSON or YAML, keeping the field names of the API
+func (a *app) printValue(value interface{}) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	if a.output == "yaml" {
+		if data, err = yaml.JSONToYAML(data); err != nil {
+			return err
+		}
+		_, err = a.stdout.Write(data)
+		return err
+	}
+	_, err = fmt.Fprintln(a.stdout, string(data))
+	return err
+}
+
+// Helper function to print a message in table mode, where structured output is not wanted
+func (a *app) printMessage(format string, args ...interface{}) {
+	if a.output == "table" {
+		fmt.Fprintf(a.stdout, format+"\n", args...)
+	}
+}
+
+// Helper function to return the first non-empty value
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// Helper function to find the ID of the only item named name
+func matchName[T any](items []T, kind, name string, fields func(item *T) (id, name string)) (string, error) {
+	var ids []string
+	for i := range items {
+		if id, itemName := fields(&items[i]); itemName == name {
+			ids = append(ids, id)
+		}
+	}
+
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("%s %q: %w", kind, name, errNotFound)
+	case 1:
+		return ids[0], nil
+	default:
+		return "", usagef("%d %ss are named %q, use an ID instead", len(ids), kind, name)
+	}
+}
+
+// Helper function to check whether a command line value is an ID rather than a name
+func isID(value string) bool {
+	_, err := uuid.Parse(value)
+	return err == nil
+}
+
+// Helper function to parse a date given as YYYY-MM-DD or RFC3339
+func parseDate(value string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, usagef("invalid date %q, use YYYY-MM-DD or RFC3339", value)
+	}
+	return t, nil
+}
+
+// Helper function to build query parameters from name and value pairs, leaving out empty values
+func queryOf(pairs ...string) url.Values {
+	query := url.Values{}
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if pairs[i+1] != "" {
+			query.Set(pairs[i], pairs[i+1])
+		}
+	}
+	return query
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"release-management/internal/models/api"
+)
+
+var buildColumns = []column[api.BuildResponse]{
+	{"ID", func(b *api.BuildResponse) string { return b.ID }},
+	{"SYSTEM", func(b *api.BuildResponse) string { return b.SystemName }},
+	{"VERSION", func(b *api.BuildResponse) string { return b.Version }},
+	{"RELEASE", func(b *api.BuildResponse) string { return b.ReleaseName }},
+	{"BUILD DATE", func(b *api.BuildResponse) string { return b.BuildDate.Format(time.RFC3339) }},
+}
+
+// relctl builds list
+func buildsList(a *app, args []string) error {
+	flags := a.flags()
+	system := flags.String("system", "", "only builds of this system, by ID or name")
+	release := flags.String("release", "", "only builds of this release, by ID or name")
+	commit := flags.String("commit", "", "only builds of this commit, which may be abbreviated")
+	digest := flags.String("image-digest", "", "only builds with this container image digest")
+	sortField := flags.String("sort", "", "sort field, prefixed with - for descending order")
+	limit := flags.Int("limit", 50, "maximum number of builds")
+	all := flags.Bool("all", false, "list every build instead of the first page")
+	if _, err := a.parse(flags, args, 0); err != nil {
+		return err
+	}
+
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+	query := queryOf("sort", *sortField, "commit_sha", *commit, "image_digest", *digest)
+	if *system != "" {
+		id, err := resolveSystem(c, *system)
+		if err != nil {
+			return err
+		}
+		query.Set("system_id", id)
+	}
+	if *release != "" {
+		id, err := resolveRelease(c, *release)
+		if err != nil {
+			return err
+		}
+		query.Set("release_id", id)
+	}
+
+	builds, err := list[api.BuildResponse](c, "/builds", query, *limit, *all)
+	if err != nil {
+		return err
+	}
+	return printList(a, builds, buildColumns)
+}
+
+// relctl builds register
+func buildsRegister(a *app, args []string) error {
+	flags := a.flags()
+	system := flags.String("system", "", "system the build belongs to, by ID or name (required)")
+	version := flags.String("version", "", "build version (required)")
+	release := flags.String("release", "", "release the build is part of, by ID or name")
+	date := flags.String("date", "", "build date, YYYY-MM-DD or RFC3339, now by default")
+	changelog := flags.String("changelog", "", "changes in the build, shown in release notes")
+	repository := flags.String("repository", "", "URL of the repository the build was made from")
+	commit := flags.String("commit", "", "commit SHA the build was made from")
+	branch := flags.String("branch", "", "branch the build was made from")
+	pipeline := flags.String("pipeline-url", "", "URL of the pipeline run that made the build")
+	imageDigest := flags.String("image-digest", "", "digest of the container image, e.g. sha256:<hex>")
+	packageChecksum := flags.String("package-checksum", "", "checksum of the package, e.g. sha256:<hex>")
+	if _, err := a.parse(flags, args, 0); err != nil {
+		return err
+	}
+	if *system == "" || *version == "" {
+		return usagef("--system and --version are required")
+	}
+
+	req := api.BuildRequest{
+		Version:   *version,
+		BuildDate: time.Now().UTC().Truncate(time.Second),
+		Changelog: *changelog,
+		BuildProvenance: api.BuildProvenance{
+			RepositoryURL:   *repository,
+			CommitSHA:       *commit,
+			Branch:          *branch,
+			PipelineURL:     *pipeline,
+			ImageDigest:     *imageDigest,
+			PackageChecksum: *packageChecksum,
+		},
+	}
+	if *date != "" {
+		buildDate, err := parseDate(*date)
+		if err != nil {
+			return err
+		}
+		req.BuildDate = buildDate
+	}
+
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+	if req.SystemID, err = resolveSystem(c, *system); err != nil {
+		return err
+	}
+	if *release != "" {
+		releaseID, err := resolveRelease(c, *release)
+		if err != nil {
+			return err
+		}
+		req.ReleaseID = &releaseID
+	}
+
+	var build api.BuildResponse
+	if err := c.do(http.MethodPost, "/builds", nil, req, &build); err != nil {
+		return err
+	}
+	return printItem(a, &build, buildColumns)
+}
+
+// Helper function to resolve a system ID or name to an ID
+func resolveSystem(c *client, value string) (string, error) {
+	if isID(value) {
+		return value, nil
+	}
+
+	systems, err := list[api.SystemResponse](c, "/systems", queryOf("name", value), 0, true)
+	if err != nil {
+		return "", err
+	}
+	return matchName(systems, "system", value, func(s *api.SystemResponse) (string, string) { return s.ID, s.Name })
+}
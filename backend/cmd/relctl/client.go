@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"release-management/internal/models/api"
+)
+
+// client calls the REST API with a bearer token
+type client struct {
+	server string
+	token  string
+	http   *http.Client
+	// override is sent as the justification for changes during a freeze window
+	override string
+}
+
+func newClient(server, token string) *client {
+	return &client{
+		server: strings.TrimSuffix(server, "/"),
+		token:  token,
+		http:   &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// apiError is a response with an error status
+type apiError struct {
+	Status  int
+	Message string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%s (HTTP %d)", e.Message, e.Status)
+}
+
+// Helper function to map the status of a failed request to the exit code of relctl
+func (e *apiError) exitCode() int {
+	switch {
+	case e.Status == http.StatusUnauthorized || e.Status == http.StatusForbidden:
+		return exitAuth
+	case e.Status == http.StatusNotFound:
+		return exitNotFound
+	case e.Status >= 400 && e.Status < 500:
+		return exitRejected
+	default:
+		return exitError
+	}
+}
+
+// Helper function to send a request with an optional JSON body and decode the JSON response into out
+func (c *client) do(method, path string, query url.Values, body, out interface{}) error {
+	target := c.server + "/api" + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, target, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.override != "" {
+		req.Header.Set("X-Freeze-Override", c.override)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		var errResp api.ErrorResponse
+		if err := json.NewDecoder(resp.Body).Decode(&errResp); err != nil || errResp.Error == "" {
+			errResp.Error = http.StatusText(resp.StatusCode)
+		}
+		return &apiError{Status: resp.StatusCode, Message: errResp.Error}
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("invalid response from %s %s: %w", method, path, err)
+	}
+	return nil
+}
+
+// Helper function to fetch a list endpoint. With all set it follows next_cursor until the last page,
+// otherwise it returns the first page of up to limit items.
+func list[T any](c *client, path string, query url.Values, limit int, all bool) ([]T, error) {
+	if query == nil {
+		query = url.Values{}
+	}
+	if all {
+		limit = 500
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	var items []T
+	for {
+		var page api.ListResponse[T]
+		if err := c.do(http.MethodGet, path, query, nil, &page); err != nil {
+			return nil, err
+		}
+		items = append(items, page.Data...)
+		if !all || page.NextCursor == "" {
+			return items, nil
+		}
+		query.Set("cursor", page.NextCursor)
+	}
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/goccy/go-yaml"
+)
+
+const (
+	defaultProfile = "default"
+	defaultServer  = "http://localhost:8080"
+)
+
+// config is the relctl configuration file, by default ~/.config/relctl/config.yaml
+type config struct {
+	CurrentProfile string             `yaml:"current_profile,omitempty"`
+	Profiles       map[string]profile `yaml:"profiles"`
+}
+
+// profile holds the connection settings for one server
+type profile struct {
+	Server string `yaml:"server,omitempty"`
+	Token  string `yaml:"token,omitempty"`
+}
+
+// Helper function to return the path of the configuration file, which $RELCTL_CONFIG overrides
+func configPath() (string, error) {
+	if path := os.Getenv("RELCTL_CONFIG"); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "relctl", "config.yaml"), nil
+}
+
+// Helper function to read the configuration file, which may not exist yet
+func loadConfig() (*config, error) {
+	cfg := &config{Profiles: make(map[string]profile)}
+
+	path, err := configPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("invalid configuration file %s: %w", path, err)
+	}
+	if cfg.Profiles == nil {
+		cfg.Profiles = make(map[string]profile)
+	}
+	return cfg, nil
+}
+
+// Helper function to write the configuration file, readable only by its owner since it holds tokens
+func saveConfig(cfg *config) error {
+	path, err := configPath()
+	if err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// relctl config set <profile> [--server URL] [--token TOKEN]
+func configSet(a *app, args []string) error {
+	flags := a.flags()
+	rest, err := a.parse(flags, args, 1)
+	if err != nil {
+		return err
+	}
+	name := rest[0]
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	p := cfg.Profiles[name]
+	if a.server != "" {
+		p.Server = a.server
+	}
+	if a.token != "" {
+		p.Token = a.token
+	}
+	cfg.Profiles[name] = p
+
+	// The first profile becomes the current one
+	if cfg.CurrentProfile == "" {
+		cfg.CurrentProfile = name
+	}
+	if err := saveConfig(cfg); err != nil {
+		return err
+	}
+
+	a.printMessage("Profile %q saved", name)
+	return nil
+}
+
+// relctl config use <profile>
+func configUse(a *app, args []string) error {
+	flags := a.flags()
+	rest, err := a.parse(flags, args, 1)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if _, ok := cfg.Profiles[rest[0]]; !ok {
+		return fmt.Errorf("profile %q: %w", rest[0], errNotFound)
+	}
+	cfg.CurrentProfile = rest[0]
+	if err := saveConfig(cfg); err != nil {
+		return err
+	}
+
+	a.printMessage("Using profile %q", rest[0])
+	return nil
+}
+
+// profileView is a profile as shown by config view, which never prints tokens
+type profileView struct {
+	Name     string `json:"name"`
+	Server   string `json:"server"`
+	HasToken bool   `json:"has_token"`
+	Current  bool   `json:"current"`
+}
+
+// relctl config view
+func configView(a *app, args []string) error {
+	flags := a.flags()
+	if _, err := a.parse(flags, args, 0); err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	views := make([]profileView, 0, len(cfg.Profiles))
+	for name, p := range cfg.Profiles {
+		views = append(views, profileView{Name: name, Server: firstNonEmpty(p.Server, defaultServer), HasToken: p.Token != "", Current: name == cfg.CurrentProfile})
+	}
+	sort.Slice(views, func(i, j int) bool { return views[i].Name < views[j].Name })
+
+	return printList(a, views, []column[profileView]{
+		{"CURRENT", func(v *profileView) string { return mark(v.Current) }},
+		{"NAME", func(v *profileView) string { return v.Name }},
+		{"SERVER", func(v *profileView) string { return v.Server }},
+		{"TOKEN", func(v *profileView) string {
+			if v.HasToken {
+				return "set"
+			}
+			return "missing"
+		}},
+	})
+}
+
+// Helper function to mark a table cell for true values
+func mark(value bool) string {
+	if value {
+		return "*"
+	}
+	return ""
+}
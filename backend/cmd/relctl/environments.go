@@ -0,0 +1,256 @@
+package main
+
+import (
+	"flag"
+	"net/http"
+
+	"release-management/internal/models/api"
+)
+
+var environmentColumns = []column[api.EnvironmentResponse]{
+	{"ID", func(e *api.EnvironmentResponse) string { return e.ID }},
+	{"NAME", func(e *api.EnvironmentResponse) string { return e.Name }},
+	{"TYPE", func(e *api.EnvironmentResponse) string { return e.Type }},
+	{"STATUS", func(e *api.EnvironmentResponse) string { return e.Status }},
+	{"RELEASE", func(e *api.EnvironmentResponse) string { return e.ReleaseID }},
+}
+
+var environmentSystemColumns = []column[api.SimpleSystemInfo]{
+	{"SYSTEM", func(s *api.SimpleSystemInfo) string { return s.SystemName }},
+	{"VERSION", func(s *api.SimpleSystemInfo) string { return s.Version }},
+	{"STATUS", func(s *api.SimpleSystemInfo) string { return s.Status }},
+}
+
+// driftRow is one difference of a drift report
+type driftRow struct {
+	kind string
+	item api.DriftItem
+}
+
+var driftColumns = []column[driftRow]{
+	{"DRIFT", func(r *driftRow) string { return r.kind }},
+	{"SYSTEM", func(r *driftRow) string { return r.item.SystemName }},
+	{"DEPLOYED", func(r *driftRow) string { return r.item.DeployedVersion }},
+	{"RELEASE", func(r *driftRow) string { return r.item.ReleaseVersion }},
+}
+
+var promotionColumns = []column[api.PromotionChange]{
+	{"ACTION", func(p *api.PromotionChange) string { return p.Action }},
+	{"SYSTEM", func(p *api.PromotionChange) string { return p.SystemName }},
+	{"FROM", func(p *api.PromotionChange) string { return p.FromVersion }},
+	{"TO", func(p *api.PromotionChange) string { return p.ToVersion }},
+}
+
+// relctl envs list
+func envsList(a *app, args []string) error {
+	flags := a.flags()
+	release := flags.String("release", "", "only environments of this release, by ID or name")
+	group := flags.String("group", "", "only environments of this environment group ID")
+	envType := flags.String("type", "", "only environments of this type")
+	status := flags.String("status", "", "only environments with this status")
+	limit := flags.Int("limit", 50, "maximum number of environments")
+	all := flags.Bool("all", false, "list every environment instead of the first page")
+	if _, err := a.parse(flags, args, 0); err != nil {
+		return err
+	}
+
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+	query := queryOf("environment_group_id", *group, "type", *envType, "status", *status)
+	if *release != "" {
+		id, err := resolveRelease(c, *release)
+		if err != nil {
+			return err
+		}
+		query.Set("release_id", id)
+	}
+
+	environments, err := list[api.EnvironmentResponse](c, "/environments", query, *limit, *all)
+	if err != nil {
+		return err
+	}
+	return printList(a, environments, environmentColumns)
+}
+
+// relctl envs systems <env>
+func envsSystems(a *app, args []string) error {
+	c, id, err := a.environmentCommand(a.flags(), args)
+	if err != nil {
+		return err
+	}
+
+	var response api.EnvironmentSystemsResponse
+	if err := c.do(http.MethodGet, "/environments/"+id+"/systems", nil, nil, &response); err != nil {
+		return err
+	}
+	if a.output != "table" {
+		return a.printValue(response)
+	}
+	return printList(a, response.Systems, environmentSystemColumns)
+}
+
+// relctl envs add-system <env> --system S
+func envsAddSystem(a *app, args []string) error {
+	flags := a.flags()
+	system := flags.String("system", "", "system to add with its subsystems, by ID or name (required)")
+	version := flags.String("version", "", "version to deploy, the version in the release by default")
+	status := flags.String("status", "", "status of the system in the environment")
+	override := flags.String("override", "", "justification for overriding an active freeze window")
+	c, id, err := a.environmentCommand(flags, args)
+	if err != nil {
+		return err
+	}
+	c.override = *override
+	if *system == "" {
+		return usagef("--system is required")
+	}
+
+	req := api.EnvironmentSystemRequest{Version: *version, Status: *status}
+	if req.SystemID, err = resolveSystem(c, *system); err != nil {
+		return err
+	}
+
+	var response api.EnvironmentSystemsAddedResponse
+	if err := c.do(http.MethodPost, "/environments/"+id+"/systems", nil, req, &response); err != nil {
+		return err
+	}
+	if a.output != "table" {
+		return a.printValue(response)
+	}
+	a.printMessage("%s", response.Message)
+	return printList(a, response.Systems, environmentSystemColumns)
+}
+
+// relctl envs sync <env>
+func envsSync(a *app, args []string) error {
+	flags := a.flags()
+	override := flags.String("override", "", "justification for overriding an active freeze window")
+	c, id, err := a.environmentCommand(flags, args)
+	if err != nil {
+		return err
+	}
+	c.override = *override
+
+	var response api.EnvironmentSystemsSyncResponse
+	if err := c.do(http.MethodPost, "/environments/"+id+"/systems/sync", nil, nil, &response); err != nil {
+		return err
+	}
+	if a.output != "table" {
+		return a.printValue(response)
+	}
+	a.printMessage("%s", response.Message)
+	return nil
+}
+
+// relctl envs promote <env>
+func envsPromote(a *app, args []string) error {
+	flags := a.flags()
+	target := flags.String("target", "", "environment to promote to, by ID or name, the next stage of the promotion path by default")
+	dryRun := flags.Bool("dry-run", false, "only show the changes")
+	override := flags.String("override", "", "justification for overriding an active freeze window")
+	c, id, err := a.environmentCommand(flags, args)
+	if err != nil {
+		return err
+	}
+	c.override = *override
+
+	req := api.PromotionRequest{DryRun: *dryRun}
+	if *target != "" {
+		if req.TargetEnvironmentID, err = resolveEnvironment(c, *target); err != nil {
+			return err
+		}
+	}
+
+	var response api.PromotionResponse
+	if err := c.do(http.MethodPost, "/environments/"+id+"/promote", nil, req, &response); err != nil {
+		return err
+	}
+	if a.output != "table" {
+		return a.printValue(response)
+	}
+
+	verb := "Promoted"
+	if response.DryRun {
+		verb = "Would promote"
+	}
+	a.printMessage("%s %s to %s", verb, response.SourceEnvironmentName, response.TargetEnvironmentName)
+	return printList(a, response.Changes, promotionColumns)
+}
+
+// relctl envs drift <env>
+func envsDrift(a *app, args []string) error {
+	c, id, err := a.environmentCommand(a.flags(), args)
+	if err != nil {
+		return err
+	}
+
+	var report api.EnvironmentDriftReport
+	if err := c.do(http.MethodGet, "/environments/"+id+"/drift", nil, nil, &report); err != nil {
+		return err
+	}
+
+	if a.output != "table" {
+		if err := a.printValue(report); err != nil {
+			return err
+		}
+	} else if report.InSync {
+		a.printMessage("%s is in sync with release %s", report.EnvironmentName, report.ReleaseName)
+	} else {
+		a.printMessage("%s has drifted from release %s", report.EnvironmentName, report.ReleaseName)
+		var rows []driftRow
+		for _, group := range []struct {
+			kind  string
+			items []api.DriftItem
+		}{
+			{"missing", report.MissingSystems},
+			{"extra", report.ExtraSystems},
+			{"version", report.VersionMismatches},
+			{"unreleased", report.UnreleasedBuilds},
+		} {
+			for _, item := range group.items {
+				rows = append(rows, driftRow{kind: group.kind, item: item})
+			}
+		}
+		if err := printList(a, rows, driftColumns); err != nil {
+			return err
+		}
+	}
+
+	if !report.InSync {
+		return errDrift
+	}
+	return nil
+}
+
+// Helper function to parse the flags of a command on one environment and resolve the environment
+func (a *app) environmentCommand(flags *flag.FlagSet, args []string) (*client, string, error) {
+	rest, err := a.parse(flags, args, 1)
+	if err != nil {
+		return nil, "", err
+	}
+
+	c, err := a.client()
+	if err != nil {
+		return nil, "", err
+	}
+	id, err := resolveEnvironment(c, rest[0])
+	if err != nil {
+		return nil, "", err
+	}
+	return c, id, nil
+}
+
+// Helper function to resolve an environment ID or name to an ID
+func resolveEnvironment(c *client, value string) (string, error) {
+	if isID(value) {
+		return value, nil
+	}
+
+	environments, err := list[api.EnvironmentResponse](c, "/environments", nil, 0, true)
+	if err != nil {
+		return "", err
+	}
+	return matchName(environments, "environment", value, func(e *api.EnvironmentResponse) (string, string) { return e.ID, e.Name })
+}
@@ -0,0 +1,133 @@
+// Command relctl is a command-line client for the release management API.
+//
+// It covers the day-to-day operations that automation otherwise scripts with curl: listing and creating
+// releases, registering builds from a pipeline, adding systems to environments, syncing, promoting and
+// reporting drift. Server URLs and tokens come from named profiles, see "relctl config".
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Exit codes, so that scripts can tell failures apart without parsing messages
+const (
+	exitOK = 0
+	// exitError covers network failures, server errors and anything unexpected
+	exitError = 1
+	// exitUsage covers invalid arguments and incomplete configuration
+	exitUsage    = 2
+	exitNotFound = 3
+	// exitRejected covers requests the API refused as invalid or conflicting
+	exitRejected = 4
+	// exitAuth covers missing, invalid or insufficient credentials
+	exitAuth = 5
+	// exitDrift reports that "envs drift" found differences
+	exitDrift = 6
+)
+
+// command is a subcommand such as "releases list"
+type command struct {
+	usage   string
+	summary string
+	run     func(a *app, args []string) error
+}
+
+var commands = map[string]command{
+	"releases list":   {"[--status S] [--type T] [--sort F] [--limit N] [--all]", "List releases", releasesList},
+	"releases get":    {"<id|name>", "Show a release with its builds", releasesGet},
+	"releases create": {"--name N --type T --date D [--description D]", "Create a release", releasesCreate},
+
+	"builds list":     {"[--system S] [--release R] [--commit SHA] [--image-digest D] [--sort F] [--limit N] [--all]", "List builds", buildsList},
+	"builds register": {"--system S --version V [--release R] [--date D] [--changelog TEXT] [--commit SHA] [--branch B] [--repository URL] [--pipeline-url URL] [--image-digest D] [--package-checksum C]", "Register a build, e.g. at the end of a pipeline", buildsRegister},
+
+	"envs list":       {"[--release R] [--group G] [--type T] [--status S] [--all]", "List environments", envsList},
+	"envs systems":    {"<env>", "List the systems of an environment", envsSystems},
+	"envs add-system": {"<env> --system S [--version V] [--status S]", "Add a system and its subsystems to an environment", envsAddSystem},
+	"envs sync":       {"<env>", "Set the system versions of an environment to those of its release", envsSync},
+	"envs promote":    {"<env> [--target ENV] [--dry-run]", "Copy the system versions of an environment into the next stage", envsPromote},
+	"envs drift":      {"<env>", "Compare an environment with its release, exit code 6 on drift", envsDrift},
+
+	"config set":  {"<profile> [--server URL] [--token TOKEN]", "Create or update a profile", configSet},
+	"config use":  {"<profile>", "Make a profile the default", configUse},
+	"config view": {"", "Show the profiles, without their tokens", configView},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes a command line and returns the process exit code
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) > 0 && (args[0] == "help" || args[0] == "-h" || args[0] == "--help") {
+		printUsage(stdout)
+		return exitOK
+	}
+	if len(args) < 2 {
+		printUsage(stderr)
+		return exitUsage
+	}
+
+	name := args[0] + " " + args[1]
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(stderr, "relctl: unknown command %q\n\n", strings.Join(args[:2], " "))
+		printUsage(stderr)
+		return exitUsage
+	}
+
+	a := &app{name: name, usage: cmd.usage, stdout: stdout, stderr: stderr}
+	if err := cmd.run(a, args[2:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		if !errors.Is(err, errDrift) {
+			fmt.Fprintln(stderr, "relctl:", err)
+		}
+		return exitCode(err)
+	}
+	return exitOK
+}
+
+// Helper function to map an error to the exit code scripts can check
+func exitCode(err error) int {
+	var usageErr *usageError
+	var apiErr *apiError
+	switch {
+	case errors.Is(err, errDrift):
+		return exitDrift
+	case errors.As(err, &usageErr):
+		return exitUsage
+	case errors.Is(err, errNotFound):
+		return exitNotFound
+	case errors.As(err, &apiErr):
+		return apiErr.exitCode()
+	default:
+		return exitError
+	}
+}
+
+// Helper function to print the list of commands
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "usage: relctl <resource> <command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-16s %s\n", name, commands[name].summary)
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Every command accepts --profile, --server, --token and --output (table, json or yaml).")
+	fmt.Fprintln(w, "Exit codes: 0 ok, 1 error, 2 usage, 3 not found, 4 rejected, 5 unauthorized, 6 drift.")
+}
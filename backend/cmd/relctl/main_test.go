@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	appconfig "release-management/internal/config"
+	"release-management/internal/models/api"
+	"release-management/internal/models/domain"
+	"release-management/internal/repository"
+	"release-management/internal/repository/memory"
+	"release-management/internal/router"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Helper function to start an API server on an in-memory store and return its URL and an admin token
+func startServer(t *testing.T) (string, string, repository.Store) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	store := memory.NewStore()
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Users().Create(context.Background(), &domain.User{Email: "admin@example.com", Password: string(hash), Role: domain.RoleAdmin}); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &appconfig.Config{JWT: appconfig.JWTConfig{Secret: "test"}}
+	server := httptest.NewServer(router.Setup(cfg, store))
+	t.Cleanup(server.Close)
+
+	body, _ := json.Marshal(api.LoginRequest{Email: "admin@example.com", Password: "secret"})
+	resp, err := http.Post(server.URL+"/api/auth/login", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var auth api.AuthResponse
+	if err := json.NewDecoder(resp.Body).Decode(&auth); err != nil || auth.Token == "" {
+		t.Fatalf("login: %d %v", resp.StatusCode, err)
+	}
+	return server.URL, auth.Token, store
+}
+
+// Helper function to run relctl and check its exit code, returning the standard output
+func runRelctl(t *testing.T, wantCode int, args ...string) string {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	if code := run(args, &stdout, &stderr); code != wantCode {
+		t.Fatalf("relctl %s: exit code %d, want %d\nstdout: %s\nstderr: %s", strings.Join(args, " "), code, wantCode, stdout.String(), stderr.String())
+	}
+	return stdout.String()
+}
+
+func TestReleaseWorkflow(t *testing.T) {
+	serverURL, token, store := startServer(t)
+	t.Setenv("RELCTL_CONFIG", filepath.Join(t.TempDir(), "config.yaml"))
+	t.Setenv("RELCTL_PROFILE", "")
+	t.Setenv("RELCTL_SERVER", "")
+	t.Setenv("RELCTL_TOKEN", "")
+
+	// Commands that need the API fail with a usage error until a profile has a token
+	runRelctl(t, exitUsage, "releases", "list")
+	runRelctl(t, exitOK, "config", "set", "local", "--server", serverURL, "--token", token)
+	if out := runRelctl(t, exitOK, "config", "view"); !strings.Contains(out, serverURL) || strings.Contains(out, token) {
+		t.Errorf("config view should show the server but not the token:\n%s", out)
+	}
+
+	runRelctl(t, exitOK, "releases", "create", "--name", "Spring", "--type", "Minor", "--date", "2024-04-01")
+	if out := runRelctl(t, exitOK, "releases", "list", "-o", "json"); !strings.Contains(out, `"name": "Spring"`) {
+		t.Errorf("releases list -o json:\n%s", out)
+	}
+	runRelctl(t, exitNotFound, "releases", "get", "Autumn")
+	runRelctl(t, exitUsage, "releases", "create", "--name", "Incomplete")
+
+	ctx := context.Background()
+	payments := &domain.System{Name: "payments", Type: domain.SystemTypeSystem}
+	if err := store.Systems().Create(ctx, payments); err != nil {
+		t.Fatal(err)
+	}
+
+	// A pipeline registers its build by system and release name
+	out := runRelctl(t, exitOK, "builds", "register", "--system", "payments", "--version", "1.0.0", "--release", "Spring", "--commit", "9FCEB02D0AE598E95DC970B74767F19372D61AF8", "-o", "yaml")
+	if !strings.Contains(out, "version: 1.0.0") || !strings.Contains(out, "release_name: Spring") || !strings.Contains(out, "commit_sha: 9fceb02d0ae598e95dc970b74767f19372d61af8") {
+		t.Errorf("builds register -o yaml:\n%s", out)
+	}
+	if out := runRelctl(t, exitOK, "builds", "list", "--commit", "9fceb02"); !strings.Contains(out, "1.0.0") {
+		t.Errorf("builds list --commit should find the build by its abbreviated commit:\n%s", out)
+	}
+	runRelctl(t, exitRejected, "builds", "register", "--system", "payments", "--version", "1.0.1", "--release", "Spring")
+	if out := runRelctl(t, exitOK, "releases", "get", "Spring"); !strings.Contains(out, "1.0.0") {
+		t.Errorf("releases get should list the builds of the release:\n%s", out)
+	}
+
+	releases, err := store.Releases().List(ctx)
+	if err != nil || len(releases) != 1 {
+		t.Fatalf("releases: %v %v", releases, err)
+	}
+	staging := &domain.Environment{Name: "staging", Type: domain.EnvTypeStaging, ReleaseID: releases[0].ID}
+	if err := store.Environments().Create(ctx, staging); err != nil {
+		t.Fatal(err)
+	}
+
+	// The environment runs an older build than the release
+	runRelctl(t, exitOK, "builds", "register", "--system", "payments", "--version", "0.9.0", "--date", "2024-03-01")
+	runRelctl(t, exitOK, "envs", "add-system", "staging", "--system", "payments", "--version", "0.9.0")
+	if out := runRelctl(t, exitDrift, "envs", "drift", "staging"); !strings.Contains(out, "0.9.0") {
+		t.Errorf("envs drift should report the version mismatch:\n%s", out)
+	}
+
+	// An active freeze window rejects the sync until it is overridden with a justification
+	stagingType := domain.EnvTypeStaging
+	freeze := &domain.FreezeWindow{Name: "Demo day", Scope: domain.FreezeScopeEnvironmentType, EnvironmentType: &stagingType,
+		StartsAt: time.Now().Add(-time.Hour), EndsAt: time.Now().Add(time.Hour)}
+	if err := store.FreezeWindows().Create(ctx, freeze); err != nil {
+		t.Fatal(err)
+	}
+	runRelctl(t, exitRejected, "envs", "sync", "staging")
+	if out := runRelctl(t, exitOK, "envs", "sync", "staging", "--override", "Demo needs the release build"); !strings.Contains(out, "Updated 1 system version(s)") {
+		t.Errorf("envs sync:\n%s", out)
+	}
+	runRelctl(t, exitOK, "envs", "drift", "staging")
+
+	// Flags work after the positional argument and the token can be overridden
+	runRelctl(t, exitAuth, "envs", "systems", "staging", "--token", "invalid")
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"release-management/internal/models/api"
+)
+
+var releaseColumns = []column[api.ReleaseResponse]{
+	{"ID", func(r *api.ReleaseResponse) string { return r.ID }},
+	{"NAME", func(r *api.ReleaseResponse) string { return r.Name }},
+	{"TYPE", func(r *api.ReleaseResponse) string { return r.Type }},
+	{"STATUS", func(r *api.ReleaseResponse) string { return r.Status }},
+	{"RELEASE DATE", func(r *api.ReleaseResponse) string { return r.ReleaseDate.Format(time.DateOnly) }},
+}
+
+// relctl releases list
+func releasesList(a *app, args []string) error {
+	flags := a.flags()
+	status := flags.String("status", "", "only releases with this status")
+	releaseType := flags.String("type", "", "only releases of this type")
+	sortField := flags.String("sort", "", "sort field, prefixed with - for descending order")
+	limit := flags.Int("limit", 50, "maximum number of releases")
+	all := flags.Bool("all", false, "list every release instead of the first page")
+	if _, err := a.parse(flags, args, 0); err != nil {
+		return err
+	}
+
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+	releases, err := list[api.ReleaseResponse](c, "/releases", queryOf("status", *status, "type", *releaseType, "sort", *sortField), *limit, *all)
+	if err != nil {
+		return err
+	}
+	return printList(a, releases, releaseColumns)
+}
+
+// relctl releases get <id|name>
+func releasesGet(a *app, args []string) error {
+	rest, err := a.parse(a.flags(), args, 1)
+	if err != nil {
+		return err
+	}
+
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+	id, err := resolveRelease(c, rest[0])
+	if err != nil {
+		return err
+	}
+
+	var release api.ReleaseResponse
+	if err := c.do(http.MethodGet, "/releases/"+id, nil, nil, &release); err != nil {
+		return err
+	}
+	if err := printItem(a, &release, releaseColumns); err != nil {
+		return err
+	}
+	if a.output != "table" {
+		return nil
+	}
+
+	builds, err := list[api.BuildResponse](c, "/releases/"+id+"/builds", nil, 0, false)
+	if err != nil {
+		return err
+	}
+	if len(builds) > 0 {
+		fmt.Fprintln(a.stdout)
+		return printList(a, builds, buildColumns)
+	}
+	return nil
+}
+
+// relctl releases create
+func releasesCreate(a *app, args []string) error {
+	flags := a.flags()
+	name := flags.String("name", "", "release name (required)")
+	releaseType := flags.String("type", "", "release type: Major, Minor or Hotfix (required)")
+	date := flags.String("date", "", "planned release date, YYYY-MM-DD or RFC3339 (required)")
+	description := flags.String("description", "", "release description")
+	if _, err := a.parse(flags, args, 0); err != nil {
+		return err
+	}
+	if *name == "" || *releaseType == "" || *date == "" {
+		return usagef("--name, --type and --date are required")
+	}
+	releaseDate, err := parseDate(*date)
+	if err != nil {
+		return err
+	}
+
+	req := api.ReleaseRequest{Name: *name, Type: *releaseType, ReleaseDate: releaseDate}
+	if *description != "" {
+		req.Description = description
+	}
+
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+	var release api.ReleaseResponse
+	if err := c.do(http.MethodPost, "/releases", nil, req, &release); err != nil {
+		return err
+	}
+	return printItem(a, &release, releaseColumns)
+}
+
+// Helper function to resolve a release ID or name to an ID
+func resolveRelease(c *client, value string) (string, error) {
+	if isID(value) {
+		return value, nil
+	}
+
+	releases, err := list[api.ReleaseResponse](c, "/releases", nil, 0, true)
+	if err != nil {
+		return "", err
+	}
+	return matchName(releases, "release", value, func(r *api.ReleaseResponse) (string, string) { return r.ID, r.Name })
+}
@@ -0,0 +1,128 @@
+// Package audit records who created, updated or deleted which entity, with a diff of the change.
+package audit
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"release-management/internal/models/domain"
+	"release-management/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ActorKey is the context key handlers set to name the actor of requests that are not made by a user
+const ActorKey = "auditActor"
+
+// RequestIDKey is the context key holding the ID of the current request
+const RequestIDKey = "requestID"
+
+// Fields that change on every write and would only add noise to a diff
+var ignoredFields = map[string]bool{
+	"updated_at": true,
+}
+
+// Record stores an audit entry for a change to an entity made by the request in c.
+// before and after are API representations of the entity, before is nil for a creation
+// and after is nil for a deletion. Nested relationships are left out of the snapshots.
+func Record(tx repository.Store, c *gin.Context, entityType domain.AuditEntityType, entityID string, before, after interface{}) error {
+	entry := &domain.AuditEntry{
+		EntityType: entityType,
+		EntityID:   entityID,
+		Before:     Snapshot(before),
+		After:      Snapshot(after),
+		RequestID:  c.GetString(RequestIDKey),
+		SourceIP:   c.ClientIP(),
+	}
+
+	switch {
+	case entry.Before == nil:
+		entry.Action = domain.AuditActionCreate
+	case entry.After == nil:
+		entry.Action = domain.AuditActionDelete
+	default:
+		entry.Action = domain.AuditActionUpdate
+		entry.Changes = Diff(entry.Before, entry.After)
+		if len(entry.Changes) == 0 {
+			// Nothing the audit log tracks has changed
+			return nil
+		}
+	}
+
+	if userID := c.GetUint("userID"); userID != 0 {
+		entry.ActorID = &userID
+		entry.Actor = actorName(tx, c, userID)
+	} else {
+		entry.Actor = c.GetString(ActorKey)
+	}
+
+	return tx.Audit().Create(c.Request.Context(), entry)
+}
+
+// Snapshot converts an entity to a JSON object holding its own fields, or nil if v is nil
+func Snapshot(v interface{}) map[string]interface{} {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+
+	var snapshot map[string]interface{}
+	if err := json.Unmarshal(data, &snapshot); err != nil || snapshot == nil {
+		return nil
+	}
+
+	for field, value := range snapshot {
+		if isRelationship(value) {
+			delete(snapshot, field)
+		}
+	}
+	return snapshot
+}
+
+// Diff returns the fields whose values differ between two snapshots
+func Diff(before, after map[string]interface{}) map[string]domain.AuditChange {
+	changes := make(map[string]domain.AuditChange)
+	for field, oldValue := range before {
+		if ignoredFields[field] {
+			continue
+		}
+		if newValue, ok := after[field]; !ok || !reflect.DeepEqual(oldValue, newValue) {
+			changes[field] = domain.AuditChange{Before: oldValue, After: after[field]}
+		}
+	}
+	for field, newValue := range after {
+		if _, ok := before[field]; !ok && !ignoredFields[field] {
+			changes[field] = domain.AuditChange{Before: nil, After: newValue}
+		}
+	}
+	return changes
+}
+
+// Helper function to check if a snapshot value is a nested entity or a list of them
+func isRelationship(value interface{}) bool {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return true
+	case []interface{}:
+		for _, item := range v {
+			if _, ok := item.(map[string]interface{}); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Helper function to name the user behind a request, cached for the rest of the request
+func actorName(tx repository.Store, c *gin.Context, userID uint) string {
+	if email := c.GetString("userEmail"); email != "" {
+		return email
+	}
+
+	user, err := tx.Users().Get(c.Request.Context(), userID)
+	if err != nil {
+		return ""
+	}
+	c.Set("userEmail", user.Email)
+	return user.Email
+}
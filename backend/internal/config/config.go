@@ -3,6 +3,8 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -12,6 +14,11 @@ type Config struct {
 	Server   ServerConfig
 	JWT      JWTConfig
 	Admin    AdminConfig
+	Webhooks WebhookConfig
+	Events   EventsConfig
+	// ChangeRequests is the sign-off policy for environments that only change through change requests
+	ChangeRequests ChangeRequestConfig
+	ReleaseNotes   ReleaseNotesConfig
 }
 
 type DatabaseConfig struct {
@@ -21,6 +28,8 @@ type DatabaseConfig struct {
 	Password string
 	Name     string
 	SSLMode  string
+	// AutoMigrate applies pending migrations when the server starts
+	AutoMigrate bool
 }
 
 type ServerConfig struct {
@@ -37,6 +46,39 @@ type AdminConfig struct {
 	Password string
 }
 
+type WebhookConfig struct {
+	GitHubSecret  string
+	GitLabSecret  string
+	JenkinsSecret string
+	// SystemMapping maps a provider project name (repository, project path or job name) to a system name
+	SystemMapping map[string]string
+	// DeliveryMaxAttempts is how often an event is sent to a webhook subscription before its delivery is dead-lettered
+	DeliveryMaxAttempts int
+	// DeliveryTimeout limits a single attempt to send an event
+	DeliveryTimeout time.Duration
+}
+
+type EventsConfig struct {
+	// StreamBufferSize is how many of the latest events the event stream keeps for clients that resume
+	StreamBufferSize int
+}
+
+type ChangeRequestConfig struct {
+	// EnvironmentTypes are the environment types whose versions only change by applying an approved change request
+	EnvironmentTypes []string
+	// RequiredApprovals is how many users other than the author must approve a change request
+	RequiredApprovals int
+	// ApproverRole is the least privileged global role that may approve or reject a change request
+	ApproverRole string
+	// TTL is how long a change request stays open before it expires
+	TTL time.Duration
+}
+
+type ReleaseNotesConfig struct {
+	// TemplateDir holds the release notes templates of teams, see package releasenotes. Empty uses the built-in templates.
+	TemplateDir string
+}
+
 func Load() (*Config, error) {
 	// Load .env file
 	if err := godotenv.Load(); err != nil {
@@ -53,6 +95,31 @@ func Load() (*Config, error) {
 		dbPort = 5432
 	}
 
+	deliveryMaxAttempts, err := strconv.Atoi(getEnv("WEBHOOK_DELIVERY_MAX_ATTEMPTS", "8"))
+	if err != nil || deliveryMaxAttempts < 1 {
+		deliveryMaxAttempts = 8
+	}
+
+	deliveryTimeout, err := time.ParseDuration(getEnv("WEBHOOK_DELIVERY_TIMEOUT", "10s"))
+	if err != nil || deliveryTimeout <= 0 {
+		deliveryTimeout = 10 * time.Second
+	}
+
+	streamBufferSize, err := strconv.Atoi(getEnv("EVENT_STREAM_BUFFER_SIZE", "1000"))
+	if err != nil || streamBufferSize < 1 {
+		streamBufferSize = 1000
+	}
+
+	requiredApprovals, err := strconv.Atoi(getEnv("CHANGE_REQUEST_REQUIRED_APPROVALS", "2"))
+	if err != nil || requiredApprovals < 1 {
+		requiredApprovals = 2
+	}
+
+	changeRequestTTL, err := time.ParseDuration(getEnv("CHANGE_REQUEST_TTL", "72h"))
+	if err != nil || changeRequestTTL <= 0 {
+		changeRequestTTL = 72 * time.Hour
+	}
+
 	return &Config{
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -61,6 +128,8 @@ func Load() (*Config, error) {
 			Password: getEnv("DB_PASSWORD", ""),
 			Name:     getEnv("DB_NAME", "releasemanagement"),
 			SSLMode:  getEnv("DB_SSLMODE", "disable"),
+			// Replicas can all migrate on start, the migration lock lets only one of them run
+			AutoMigrate: getEnv("DB_AUTO_MIGRATE", "true") != "false",
 		},
 		Server: ServerConfig{
 			Host: getEnv("SERVER_HOST", "0.0.0.0"),
@@ -73,9 +142,58 @@ func Load() (*Config, error) {
 			Email:    getEnv("ADMIN_EMAIL", "admin@admin.test"),
 			Password: getEnv("ADMIN_PASSWORD", "admin123"),
 		},
+		Webhooks: WebhookConfig{
+			GitHubSecret:  getEnv("WEBHOOK_GITHUB_SECRET", ""),
+			GitLabSecret:  getEnv("WEBHOOK_GITLAB_SECRET", ""),
+			JenkinsSecret: getEnv("WEBHOOK_JENKINS_SECRET", ""),
+			SystemMapping: parseMapping(getEnv("WEBHOOK_SYSTEM_MAPPING", "")),
+
+			DeliveryMaxAttempts: deliveryMaxAttempts,
+			DeliveryTimeout:     deliveryTimeout,
+		},
+		Events: EventsConfig{
+			StreamBufferSize: streamBufferSize,
+		},
+		ChangeRequests: ChangeRequestConfig{
+			EnvironmentTypes:  parseList(getEnv("CHANGE_REQUEST_ENVIRONMENT_TYPES", "prod")),
+			RequiredApprovals: requiredApprovals,
+			ApproverRole:      getEnv("CHANGE_REQUEST_APPROVER_ROLE", "release-manager"),
+			TTL:               changeRequestTTL,
+		},
+		ReleaseNotes: ReleaseNotesConfig{
+			TemplateDir: getEnv("RELEASE_NOTES_TEMPLATE_DIR", ""),
+		},
 	}, nil
 }
 
+// parseMapping parses a comma separated list of key=value pairs
+func parseMapping(value string) map[string]string {
+	mapping := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		key, val, found := strings.Cut(pair, "=")
+		if !found {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		val = strings.TrimSpace(val)
+		if key != "" && val != "" {
+			mapping[key] = val
+		}
+	}
+	return mapping
+}
+
+// parseList parses a comma separated list, dropping empty entries
+func parseList(value string) []string {
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
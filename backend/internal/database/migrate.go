@@ -0,0 +1,301 @@
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"log"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"release-management/internal/database/migrations"
+)
+
+// migrationLockID is the Postgres advisory lock key held while migrations run,
+// so replicas starting at the same time apply each migration only once
+const migrationLockID = 727100412
+
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// Migration is a numbered schema change with the SQL to apply and revert it
+type Migration struct {
+	Version  int64
+	Name     string
+	Up       string
+	Down     string
+	Checksum string
+}
+
+// Reversible checks if the migration can be rolled back
+func (m Migration) Reversible() bool {
+	return m.Down != ""
+}
+
+// MigrationStatus describes whether a migration has been applied to the database
+type MigrationStatus struct {
+	Migration
+	Applied   bool
+	AppliedAt *time.Time
+	// Modified is set when the applied migration no longer matches its file
+	Modified bool
+}
+
+// LoadMigrations reads and orders the migrations in fsys
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name %q, expected NNNN_name.up.sql or NNNN_name.down.sql", entry.Name())
+		}
+
+		version, _ := strconv.ParseInt(match[1], 10, 64)
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has files with different names: %s and %s", version, migration.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	result := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up file", migration.Version, migration.Name)
+		}
+		sum := sha256.Sum256([]byte(migration.Up))
+		migration.Checksum = hex.EncodeToString(sum[:])
+		result = append(result, *migration)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Version < result[j].Version
+	})
+	return result, nil
+}
+
+// MigrateUp applies all pending migrations in order and returns the ones it applied
+func MigrateUp(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+	err := withMigrationLock(ctx, func(conn *sql.Conn) error {
+		statuses, err := migrationStatuses(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, status := range statuses {
+			if status.Modified {
+				return fmt.Errorf("migration %d_%s was changed after it was applied", status.Version, status.Name)
+			}
+		}
+
+		for _, status := range statuses {
+			if status.Applied {
+				continue
+			}
+			log.Printf("Applying migration %d_%s", status.Version, status.Name)
+			if err := runMigration(ctx, conn, status.Migration, true); err != nil {
+				return err
+			}
+			applied = append(applied, status.Migration)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// MigrateDown reverts the most recently applied migrations, newest first, and returns the ones it reverted
+func MigrateDown(ctx context.Context, steps int) ([]Migration, error) {
+	var reverted []Migration
+	err := withMigrationLock(ctx, func(conn *sql.Conn) error {
+		statuses, err := migrationStatuses(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(statuses) - 1; i >= 0 && len(reverted) < steps; i-- {
+			status := statuses[i]
+			if !status.Applied {
+				continue
+			}
+			if !status.Reversible() {
+				return fmt.Errorf("migration %d_%s cannot be reverted", status.Version, status.Name)
+			}
+			log.Printf("Reverting migration %d_%s", status.Version, status.Name)
+			if err := runMigration(ctx, conn, status.Migration, false); err != nil {
+				return err
+			}
+			reverted = append(reverted, status.Migration)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// MigrationStatuses lists every known migration and whether it has been applied
+func MigrationStatuses(ctx context.Context) ([]MigrationStatus, error) {
+	var statuses []MigrationStatus
+	err := withMigrationLock(ctx, func(conn *sql.Conn) error {
+		var err error
+		statuses, err = migrationStatuses(ctx, conn)
+		return err
+	})
+	return statuses, err
+}
+
+// Helper function to run fn on a single connection while holding the migration lock
+func withMigrationLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	sqlDB, err := DB.DB()
+	if err != nil {
+		return err
+	}
+
+	// Advisory locks belong to a session, so everything must run on the same connection
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationLockID); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", migrationLockID)
+
+	if _, err := conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version bigint PRIMARY KEY,
+		name text NOT NULL,
+		checksum varchar(64) NOT NULL,
+		applied_at timestamptz NOT NULL DEFAULT now()
+	)`); err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+
+	return fn(conn)
+}
+
+// Helper function to compare the embedded migrations with the schema_migrations table
+func migrationStatuses(ctx context.Context, conn *sql.Conn) ([]MigrationStatus, error) {
+	known, err := LoadMigrations(migrations.FS)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := conn.QueryContext(ctx, "SELECT version, checksum, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	type appliedMigration struct {
+		checksum  string
+		appliedAt time.Time
+	}
+	applied := make(map[int64]appliedMigration)
+	for rows.Next() {
+		var version int64
+		var row appliedMigration
+		if err := rows.Scan(&version, &row.checksum, &row.appliedAt); err != nil {
+			return nil, err
+		}
+		applied[version] = row
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, len(known))
+	for i, migration := range known {
+		statuses[i] = MigrationStatus{Migration: migration}
+		if row, ok := applied[migration.Version]; ok {
+			appliedAt := row.appliedAt
+			statuses[i].Applied = true
+			statuses[i].AppliedAt = &appliedAt
+			statuses[i].Modified = row.checksum != migration.Checksum
+			delete(applied, migration.Version)
+		}
+	}
+
+	// Migrations recorded in the database must still exist in this build
+	if len(applied) > 0 {
+		missing := make([]string, 0, len(applied))
+		for version := range applied {
+			missing = append(missing, strconv.FormatInt(version, 10))
+		}
+		sort.Strings(missing)
+		return nil, fmt.Errorf("migrations %s are applied to the database but missing from this build", strings.Join(missing, ", "))
+	}
+
+	return statuses, nil
+}
+
+// Helper function to apply or revert a migration and record it, in one transaction
+func runMigration(ctx context.Context, conn *sql.Conn, migration Migration, up bool) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	script := migration.Up
+	if !up {
+		script = migration.Down
+	}
+
+	if hasStatements(script) {
+		if _, err := tx.ExecContext(ctx, script); err != nil {
+			return fmt.Errorf("migration %d_%s failed: %w", migration.Version, migration.Name, err)
+		}
+	}
+
+	if up {
+		_, err = tx.ExecContext(ctx, "INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)",
+			migration.Version, migration.Name, migration.Checksum)
+	} else {
+		_, err = tx.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = $1", migration.Version)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to record migration %d_%s: %w", migration.Version, migration.Name, err)
+	}
+
+	return tx.Commit()
+}
+
+// Helper function to check if a script contains anything besides comments and whitespace
+func hasStatements(script string) bool {
+	for _, line := range strings.Split(script, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "--") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS environment_systems;
+DROP TABLE IF EXISTS environments;
+DROP TABLE IF EXISTS environment_groups;
+DROP TABLE IF EXISTS builds;
+DROP TABLE IF EXISTS systems;
+DROP TABLE IF EXISTS releases;
+DROP TABLE IF EXISTS users;
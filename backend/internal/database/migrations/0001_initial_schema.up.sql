@@ -0,0 +1,90 @@
+-- Tables that existed before versioned migrations. IF NOT EXISTS keeps this safe
+-- for databases that were created by GORM AutoMigrate.
+CREATE TABLE IF NOT EXISTS users (
+    id bigserial,
+    email text NOT NULL,
+    password text NOT NULL,
+    is_admin boolean DEFAULT false,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email ON users (email);
+
+CREATE TABLE IF NOT EXISTS releases (
+    id varchar(36),
+    name text NOT NULL,
+    description text,
+    release_date timestamptz,
+    status varchar(20) DEFAULT 'planned',
+    type varchar(10) NOT NULL,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS systems (
+    id varchar(36),
+    name text NOT NULL,
+    description text,
+    parent_id varchar(36),
+    type varchar(20),
+    status varchar(20) DEFAULT 'active',
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_systems_subsystems FOREIGN KEY (parent_id) REFERENCES systems(id)
+);
+
+CREATE TABLE IF NOT EXISTS builds (
+    id varchar(36),
+    system_id varchar(36) NOT NULL,
+    release_id varchar(36),
+    version text NOT NULL,
+    build_date timestamptz,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_systems_builds FOREIGN KEY (system_id) REFERENCES systems(id),
+    CONSTRAINT fk_releases_builds FOREIGN KEY (release_id) REFERENCES releases(id)
+);
+
+CREATE TABLE IF NOT EXISTS environment_groups (
+    id varchar(36),
+    name text NOT NULL,
+    description text,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS environments (
+    id varchar(36),
+    name text NOT NULL,
+    type varchar(20) NOT NULL,
+    status varchar(20) DEFAULT 'active',
+    url text,
+    description text,
+    release_id varchar(36) NOT NULL,
+    environment_group_id varchar(36),
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_releases_environments FOREIGN KEY (release_id) REFERENCES releases(id),
+    CONSTRAINT fk_environment_groups_environments FOREIGN KEY (environment_group_id) REFERENCES environment_groups(id)
+);
+
+CREATE TABLE IF NOT EXISTS environment_systems (
+    id uuid DEFAULT gen_random_uuid(),
+    environment_id varchar(36) NOT NULL,
+    system_id varchar(36) NOT NULL,
+    version varchar(50),
+    status varchar(20) DEFAULT 'active',
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_environment_systems_system FOREIGN KEY (system_id) REFERENCES systems(id),
+    CONSTRAINT fk_environments_environment_systems FOREIGN KEY (environment_id) REFERENCES environments(id)
+);
+CREATE INDEX IF NOT EXISTS idx_environment_systems_system_id ON environment_systems (system_id);
+CREATE INDEX IF NOT EXISTS idx_environment_systems_environment_id ON environment_systems (environment_id);
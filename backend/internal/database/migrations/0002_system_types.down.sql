@@ -0,0 +1 @@
+ALTER TABLE systems ALTER COLUMN type DROP NOT NULL;
@@ -0,0 +1,10 @@
+-- Systems with a parent are subsystems, systems with subsystems are parent systems
+-- and everything else is a standalone system
+UPDATE systems s SET type = CASE
+    WHEN s.parent_id IS NOT NULL AND s.parent_id <> '' THEN 'subsystems'
+    WHEN EXISTS (SELECT 1 FROM systems child WHERE child.parent_id = s.id) THEN 'parent_systems'
+    ELSE 'systems'
+END
+WHERE s.type IS NULL OR s.type = '';
+
+ALTER TABLE systems ALTER COLUMN type SET NOT NULL;
@@ -0,0 +1 @@
+ALTER TABLE systems ALTER COLUMN status DROP NOT NULL;
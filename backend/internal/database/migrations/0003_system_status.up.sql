@@ -0,0 +1,3 @@
+UPDATE systems SET status = 'active' WHERE status IS NULL OR status = '';
+
+ALTER TABLE systems ALTER COLUMN status SET NOT NULL;
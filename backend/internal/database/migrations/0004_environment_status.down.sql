@@ -0,0 +1 @@
+ALTER TABLE environments ALTER COLUMN status DROP NOT NULL;
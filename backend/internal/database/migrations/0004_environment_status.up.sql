@@ -0,0 +1,3 @@
+UPDATE environments SET status = 'active' WHERE status IS NULL OR status = '';
+
+ALTER TABLE environments ALTER COLUMN status SET NOT NULL;
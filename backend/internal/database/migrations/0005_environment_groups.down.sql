@@ -0,0 +1 @@
+-- Environments keep the group they were assigned, there is no record of which ones were ungrouped
@@ -0,0 +1,11 @@
+ALTER TABLE environments ADD COLUMN IF NOT EXISTS environment_group_id varchar(36);
+
+-- Environments without a group are moved into the first group, which is created if there is none
+INSERT INTO environment_groups (id, name, description, created_at, updated_at)
+SELECT gen_random_uuid()::text, 'Default Environment Group', 'Default group for existing environments', now(), now()
+WHERE NOT EXISTS (SELECT 1 FROM environment_groups)
+  AND EXISTS (SELECT 1 FROM environments WHERE environment_group_id IS NULL);
+
+UPDATE environments
+SET environment_group_id = (SELECT id FROM environment_groups ORDER BY created_at, id LIMIT 1)
+WHERE environment_group_id IS NULL;
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS release_transitions;
+
+UPDATE releases SET status = 'completed' WHERE status = 'released';
+UPDATE releases SET status = 'in-progress' WHERE status = 'frozen';
+UPDATE releases SET status = 'planned' WHERE status = 'cancelled';
@@ -0,0 +1,16 @@
+-- Releases that used the legacy 'completed' status are now 'released'
+UPDATE releases SET status = 'released' WHERE status = 'completed';
+UPDATE releases SET status = 'planned' WHERE status IS NULL OR status = '';
+
+CREATE TABLE IF NOT EXISTS release_transitions (
+    id varchar(36),
+    release_id varchar(36) NOT NULL,
+    from_status varchar(20) NOT NULL,
+    to_status varchar(20) NOT NULL,
+    user_id bigint NOT NULL,
+    comment text,
+    created_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_release_transitions_user FOREIGN KEY (user_id) REFERENCES users(id)
+);
+CREATE INDEX IF NOT EXISTS idx_release_transitions_release_id ON release_transitions (release_id);
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS deployments (
+    id varchar(36),
+    environment_id varchar(36) NOT NULL,
+    system_id varchar(36) NOT NULL,
+    old_version varchar(50),
+    new_version varchar(50),
+    build_id varchar(36),
+    user_id bigint,
+    source varchar(20) NOT NULL,
+    created_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_deployments_system FOREIGN KEY (system_id) REFERENCES systems(id),
+    CONSTRAINT fk_deployments_user FOREIGN KEY (user_id) REFERENCES users(id)
+);
+CREATE INDEX IF NOT EXISTS idx_deployments_created_at ON deployments (created_at);
+CREATE INDEX IF NOT EXISTS idx_deployments_system_id ON deployments (system_id);
+CREATE INDEX IF NOT EXISTS idx_deployments_environment_id ON deployments (environment_id);
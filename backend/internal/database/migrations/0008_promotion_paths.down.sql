@@ -0,0 +1 @@
+ALTER TABLE environment_groups DROP COLUMN IF EXISTS promotion_path;
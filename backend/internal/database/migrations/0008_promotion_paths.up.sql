@@ -0,0 +1 @@
+ALTER TABLE environment_groups ADD COLUMN IF NOT EXISTS promotion_path text;
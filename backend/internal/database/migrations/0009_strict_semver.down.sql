@@ -0,0 +1 @@
+ALTER TABLE systems DROP COLUMN IF EXISTS strict_semver;
@@ -0,0 +1 @@
+ALTER TABLE systems ADD COLUMN IF NOT EXISTS strict_semver boolean DEFAULT false;
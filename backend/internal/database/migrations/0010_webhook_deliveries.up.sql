@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id varchar(36),
+    provider varchar(20) NOT NULL,
+    delivery_id varchar(255) NOT NULL,
+    status varchar(20) NOT NULL,
+    build_id varchar(36),
+    message text,
+    created_at timestamptz,
+    PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_webhook_deliveries_provider_delivery ON webhook_deliveries (provider, delivery_id);
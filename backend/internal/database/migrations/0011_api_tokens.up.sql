@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS api_tokens (
+    id varchar(36),
+    name text NOT NULL,
+    kind varchar(20) NOT NULL,
+    user_id bigint NOT NULL,
+    prefix varchar(16) NOT NULL,
+    token_hash varchar(64) NOT NULL,
+    scopes text,
+    expires_at timestamptz,
+    last_used_at timestamptz,
+    revoked_at timestamptz,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_api_tokens_user FOREIGN KEY (user_id) REFERENCES users(id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_api_tokens_token_hash ON api_tokens (token_hash);
+CREATE INDEX IF NOT EXISTS idx_api_tokens_user_id ON api_tokens (user_id);
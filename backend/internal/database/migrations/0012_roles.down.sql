@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS environment_group_role_grants;
+ALTER TABLE environment_groups DROP COLUMN IF EXISTS required_role;
+ALTER TABLE users DROP COLUMN IF EXISTS role;
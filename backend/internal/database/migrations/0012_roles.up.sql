@@ -0,0 +1,20 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role varchar(20) DEFAULT 'viewer';
+
+-- Users created before roles existed are viewers, except admins who keep full access
+UPDATE users SET role = 'admin' WHERE is_admin = true;
+UPDATE users SET role = 'viewer' WHERE role IS NULL OR role = '';
+
+ALTER TABLE environment_groups ADD COLUMN IF NOT EXISTS required_role varchar(20);
+
+CREATE TABLE IF NOT EXISTS environment_group_role_grants (
+    id varchar(36),
+    environment_group_id varchar(36) NOT NULL,
+    user_id bigint NOT NULL,
+    role varchar(20) NOT NULL,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_environment_group_role_grants_environment_group FOREIGN KEY (environment_group_id) REFERENCES environment_groups(id),
+    CONSTRAINT fk_environment_group_role_grants_user FOREIGN KEY (user_id) REFERENCES users(id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_group_role_grant ON environment_group_role_grants (environment_group_id, user_id);
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS audit_entries (
+    id varchar(36),
+    action varchar(20) NOT NULL,
+    entity_type varchar(30) NOT NULL,
+    entity_id varchar(36) NOT NULL,
+    actor_id bigint,
+    actor varchar(255),
+    before jsonb,
+    after jsonb,
+    changes jsonb,
+    request_id varchar(64),
+    source_ip varchar(64),
+    created_at timestamptz,
+    PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_audit_entries_created_at ON audit_entries (created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_entries_request_id ON audit_entries (request_id);
+CREATE INDEX IF NOT EXISTS idx_audit_entries_actor_id ON audit_entries (actor_id);
+CREATE INDEX IF NOT EXISTS idx_audit_entity ON audit_entries (entity_type, entity_id);
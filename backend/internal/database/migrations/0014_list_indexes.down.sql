@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_environments_created_at;
+DROP INDEX IF EXISTS idx_systems_created_at;
+DROP INDEX IF EXISTS idx_builds_build_date;
+DROP INDEX IF EXISTS idx_builds_created_at;
+DROP INDEX IF EXISTS idx_releases_created_at;
@@ -0,0 +1,5 @@
+CREATE INDEX IF NOT EXISTS idx_releases_created_at ON releases (created_at, id);
+CREATE INDEX IF NOT EXISTS idx_builds_created_at ON builds (created_at, id);
+CREATE INDEX IF NOT EXISTS idx_builds_build_date ON builds (build_date, id);
+CREATE INDEX IF NOT EXISTS idx_systems_created_at ON systems (created_at, id);
+CREATE INDEX IF NOT EXISTS idx_environments_created_at ON environments (created_at, id);
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_environments_search;
+ALTER TABLE environments DROP COLUMN IF EXISTS search_vector;
+DROP INDEX IF EXISTS idx_builds_search;
+ALTER TABLE builds DROP COLUMN IF EXISTS search_vector;
+DROP INDEX IF EXISTS idx_systems_search;
+ALTER TABLE systems DROP COLUMN IF EXISTS search_vector;
+DROP INDEX IF EXISTS idx_releases_search;
+ALTER TABLE releases DROP COLUMN IF EXISTS search_vector;
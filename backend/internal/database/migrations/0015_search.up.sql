@@ -0,0 +1,25 @@
+-- Search vectors are generated columns so they always follow the searched fields.
+-- The simple configuration keeps names and versions as they are instead of stemming them as English words.
+ALTER TABLE releases ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('simple', coalesce(description, '')), 'B')
+) STORED;
+CREATE INDEX IF NOT EXISTS idx_releases_search ON releases USING GIN (search_vector);
+
+ALTER TABLE systems ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('simple', coalesce(description, '')), 'B')
+) STORED;
+CREATE INDEX IF NOT EXISTS idx_systems_search ON systems USING GIN (search_vector);
+
+ALTER TABLE builds ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', coalesce(version, '')), 'A')
+) STORED;
+CREATE INDEX IF NOT EXISTS idx_builds_search ON builds USING GIN (search_vector);
+
+ALTER TABLE environments ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('simple', coalesce(url, '')), 'B') ||
+    setweight(to_tsvector('simple', coalesce(description, '')), 'C')
+) STORED;
+CREATE INDEX IF NOT EXISTS idx_environments_search ON environments USING GIN (search_vector);
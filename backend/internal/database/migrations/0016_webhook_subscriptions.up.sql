@@ -0,0 +1,41 @@
+CREATE TABLE IF NOT EXISTS events (
+    id varchar(36),
+    type varchar(50) NOT NULL,
+    data jsonb,
+    created_at timestamptz,
+    PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_events_created_at ON events (created_at);
+
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id varchar(36),
+    name text NOT NULL,
+    url text NOT NULL,
+    secret varchar(255) NOT NULL,
+    event_types text,
+    active boolean NOT NULL DEFAULT true,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS event_deliveries (
+    id varchar(36),
+    subscription_id varchar(36) NOT NULL,
+    event_id varchar(36) NOT NULL,
+    event_type varchar(50) NOT NULL,
+    status varchar(20) NOT NULL,
+    attempts integer NOT NULL DEFAULT 0,
+    next_attempt_at timestamptz,
+    last_attempt_at timestamptz,
+    response_status integer,
+    last_error text,
+    delivered_at timestamptz,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_event_deliveries_subscription FOREIGN KEY (subscription_id) REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,
+    CONSTRAINT fk_event_deliveries_event FOREIGN KEY (event_id) REFERENCES events(id)
+);
+CREATE INDEX IF NOT EXISTS idx_event_deliveries_subscription ON event_deliveries (subscription_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_event_deliveries_due ON event_deliveries (next_attempt_at) WHERE status IN ('pending', 'retrying');
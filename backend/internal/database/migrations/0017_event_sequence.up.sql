@@ -0,0 +1,3 @@
+-- Orders events for the event stream, existing events are numbered in no particular order
+ALTER TABLE events ADD COLUMN IF NOT EXISTS sequence bigserial;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_events_sequence ON events (sequence);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS freeze_overrides;
+DROP TABLE IF EXISTS freeze_windows;
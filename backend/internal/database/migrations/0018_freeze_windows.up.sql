@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS freeze_windows (
+    id varchar(36),
+    name text NOT NULL,
+    reason text,
+    scope varchar(30) NOT NULL,
+    environment_group_id varchar(36),
+    environment_type varchar(50),
+    starts_at timestamptz NOT NULL,
+    ends_at timestamptz NOT NULL,
+    exempt_system_ids text,
+    exempt_environment_ids text,
+    created_by bigint,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_freeze_windows_environment_group FOREIGN KEY (environment_group_id) REFERENCES environment_groups(id) ON DELETE CASCADE,
+    CONSTRAINT fk_freeze_windows_created_by FOREIGN KEY (created_by) REFERENCES users(id)
+);
+CREATE INDEX IF NOT EXISTS idx_freeze_windows_period ON freeze_windows (starts_at, ends_at);
+
+CREATE TABLE IF NOT EXISTS freeze_overrides (
+    id varchar(36),
+    freeze_window_id varchar(36) NOT NULL,
+    freeze_window_name text NOT NULL,
+    environment_id varchar(36) NOT NULL,
+    system_id varchar(36) NOT NULL,
+    old_version varchar(50),
+    new_version varchar(50),
+    justification text NOT NULL,
+    user_id bigint,
+    created_at timestamptz,
+    PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_freeze_overrides_freeze_window_id ON freeze_overrides (freeze_window_id);
+CREATE INDEX IF NOT EXISTS idx_freeze_overrides_environment_id ON freeze_overrides (environment_id);
+CREATE INDEX IF NOT EXISTS idx_freeze_overrides_created_at ON freeze_overrides (created_at);
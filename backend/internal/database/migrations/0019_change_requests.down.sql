@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS change_request_comments;
+DROP TABLE IF EXISTS change_request_approvals;
+DROP TABLE IF EXISTS change_request_changes;
+DROP TABLE IF EXISTS change_requests;
@@ -0,0 +1,59 @@
+CREATE TABLE IF NOT EXISTS change_requests (
+    id varchar(36),
+    title text NOT NULL,
+    description text,
+    environment_id varchar(36) NOT NULL,
+    status varchar(20) NOT NULL,
+    required_approvals integer NOT NULL,
+    approver_role varchar(20) NOT NULL,
+    author_id bigint NOT NULL,
+    expires_at timestamptz NOT NULL,
+    rejected_by bigint,
+    rejection_reason text,
+    applied_by bigint,
+    applied_at timestamptz,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_change_requests_author FOREIGN KEY (author_id) REFERENCES users(id),
+    CONSTRAINT fk_change_requests_rejected_by FOREIGN KEY (rejected_by) REFERENCES users(id),
+    CONSTRAINT fk_change_requests_applied_by FOREIGN KEY (applied_by) REFERENCES users(id)
+);
+CREATE INDEX IF NOT EXISTS idx_change_requests_environment_id ON change_requests (environment_id);
+CREATE INDEX IF NOT EXISTS idx_change_requests_status_expires_at ON change_requests (status, expires_at);
+CREATE INDEX IF NOT EXISTS idx_change_requests_created_at ON change_requests (created_at);
+
+CREATE TABLE IF NOT EXISTS change_request_changes (
+    id varchar(36),
+    change_request_id varchar(36) NOT NULL,
+    position integer NOT NULL,
+    system_id varchar(36) NOT NULL,
+    from_version varchar(50),
+    to_version varchar(50) NOT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_change_request_changes_request FOREIGN KEY (change_request_id) REFERENCES change_requests(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_change_request_changes_change_request_id ON change_request_changes (change_request_id);
+
+CREATE TABLE IF NOT EXISTS change_request_approvals (
+    id varchar(36),
+    change_request_id varchar(36) NOT NULL,
+    user_id bigint NOT NULL,
+    created_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_change_request_approvals_request FOREIGN KEY (change_request_id) REFERENCES change_requests(id) ON DELETE CASCADE,
+    CONSTRAINT fk_change_request_approvals_user FOREIGN KEY (user_id) REFERENCES users(id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_change_request_approvals_user ON change_request_approvals (change_request_id, user_id);
+
+CREATE TABLE IF NOT EXISTS change_request_comments (
+    id varchar(36),
+    change_request_id varchar(36) NOT NULL,
+    user_id bigint NOT NULL,
+    body text NOT NULL,
+    created_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_change_request_comments_request FOREIGN KEY (change_request_id) REFERENCES change_requests(id) ON DELETE CASCADE,
+    CONSTRAINT fk_change_request_comments_user FOREIGN KEY (user_id) REFERENCES users(id)
+);
+CREATE INDEX IF NOT EXISTS idx_change_request_comments_change_request_id ON change_request_comments (change_request_id);
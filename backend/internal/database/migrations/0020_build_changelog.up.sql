@@ -0,0 +1 @@
+ALTER TABLE builds ADD COLUMN IF NOT EXISTS changelog text;
@@ -0,0 +1,11 @@
+ALTER TABLE systems DROP COLUMN IF EXISTS strict_digests;
+
+DROP INDEX IF EXISTS idx_builds_image_digest;
+DROP INDEX IF EXISTS idx_builds_commit_sha;
+
+ALTER TABLE builds DROP COLUMN IF EXISTS package_checksum;
+ALTER TABLE builds DROP COLUMN IF EXISTS image_digest;
+ALTER TABLE builds DROP COLUMN IF EXISTS pipeline_url;
+ALTER TABLE builds DROP COLUMN IF EXISTS branch;
+ALTER TABLE builds DROP COLUMN IF EXISTS commit_sha;
+ALTER TABLE builds DROP COLUMN IF EXISTS repository_url;
@@ -0,0 +1,12 @@
+ALTER TABLE builds ADD COLUMN IF NOT EXISTS repository_url text;
+ALTER TABLE builds ADD COLUMN IF NOT EXISTS commit_sha varchar(64);
+ALTER TABLE builds ADD COLUMN IF NOT EXISTS branch text;
+ALTER TABLE builds ADD COLUMN IF NOT EXISTS pipeline_url text;
+ALTER TABLE builds ADD COLUMN IF NOT EXISTS image_digest text;
+ALTER TABLE builds ADD COLUMN IF NOT EXISTS package_checksum text;
+
+-- Builds are looked up by abbreviated commits, which needs an index that supports prefix matches
+CREATE INDEX IF NOT EXISTS idx_builds_commit_sha ON builds (commit_sha varchar_pattern_ops);
+CREATE INDEX IF NOT EXISTS idx_builds_image_digest ON builds (image_digest);
+
+ALTER TABLE systems ADD COLUMN IF NOT EXISTS strict_digests boolean DEFAULT false;
@@ -0,0 +1,2 @@
+-- Deployments of systems deleted in the meantime are left unchecked
+ALTER TABLE deployments ADD CONSTRAINT fk_deployments_system FOREIGN KEY (system_id) REFERENCES systems(id) NOT VALID;
@@ -0,0 +1,2 @@
+-- Deployment history is an append-only record that must outlive the systems it mentions, so systems can still be deleted
+ALTER TABLE deployments DROP CONSTRAINT IF EXISTS fk_deployments_system;
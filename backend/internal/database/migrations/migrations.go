@@ -0,0 +1,12 @@
+// Package migrations embeds the numbered SQL migrations of the database schema.
+//
+// Each migration is a NNNN_name.up.sql file with an optional NNNN_name.down.sql file
+// that reverts it. Applied migrations must never be edited, add a new one instead.
+package migrations
+
+import "embed"
+
+// FS holds the SQL migration files
+//
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,228 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"release-management/internal/config"
+	"release-management/internal/models/domain"
+	"release-management/internal/models/mapper"
+	"release-management/internal/repository"
+)
+
+// Headers sent with every delivery. The signature is "sha256=<hex>", the HMAC of "<timestamp>.<body>"
+// with the secret of the subscription, so receivers can reject both forged and replayed payloads.
+const (
+	HeaderEvent     = "X-Release-Event"
+	HeaderDelivery  = "X-Release-Delivery"
+	HeaderTimestamp = "X-Release-Timestamp"
+	HeaderSignature = "X-Release-Signature"
+)
+
+const (
+	// pollInterval is how often the dispatcher looks for due deliveries
+	pollInterval = 5 * time.Second
+	// batchSize caps the deliveries sent at the same time
+	batchSize = 20
+	// retryBaseDelay is the delay after the first failed attempt, doubled with every further attempt up to retryMaxDelay
+	retryBaseDelay = 30 * time.Second
+	retryMaxDelay  = time.Hour
+	// maxErrorBody caps how much of a failed response is kept in the delivery log
+	maxErrorBody = 200
+)
+
+// Dispatcher sends queued deliveries to their subscriptions, retrying failed attempts with exponential backoff
+// until a delivery runs out of attempts and is dead-lettered. Several dispatchers can share a database.
+type Dispatcher struct {
+	store       repository.Store
+	client      *http.Client
+	maxAttempts int
+	now         func() time.Time
+}
+
+// NewDispatcher creates a dispatcher for the deliveries of store
+func NewDispatcher(store repository.Store, cfg config.WebhookConfig) *Dispatcher {
+	return &Dispatcher{
+		store:       store,
+		client:      &http.Client{Timeout: cfg.DeliveryTimeout},
+		maxAttempts: cfg.DeliveryMaxAttempts,
+		now:         time.Now,
+	}
+}
+
+// Run dispatches due deliveries until ctx is cancelled
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Keep going while full batches are due so that a backlog drains quickly
+		for {
+			sent, err := d.DispatchDue(ctx)
+			if err != nil && ctx.Err() == nil {
+				log.Printf("Failed to dispatch webhook deliveries: %v", err)
+			}
+			if err != nil || sent < batchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchDue sends the deliveries that are due now and returns how many it attempted
+func (d *Dispatcher) DispatchDue(ctx context.Context) (int, error) {
+	// The lease outlasts an attempt, so no other dispatcher sends the same delivery in the meantime
+	deliveries, err := d.store.WebhookSubscriptions().ClaimDueDeliveries(ctx, d.now(), 2*d.client.Timeout, batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(deliveries))
+	for i := range deliveries {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = d.dispatch(ctx, &deliveries[i])
+		}(i)
+	}
+	wg.Wait()
+	return len(deliveries), errors.Join(errs...)
+}
+
+// Helper function to make one attempt at a delivery and record its outcome
+func (d *Dispatcher) dispatch(ctx context.Context, delivery *domain.EventDelivery) error {
+	subscription, err := d.store.WebhookSubscriptions().Get(ctx, delivery.SubscriptionID)
+	if errors.Is(err, repository.ErrNotFound) {
+		// The subscription was deleted with its deliveries
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case !subscription.Active:
+		// Kept in the dead-letter queue so the delivery can be redelivered once the subscription is active again
+		delivery.Status = domain.EventDeliveryDead
+		delivery.NextAttemptAt = nil
+		delivery.LastError = "Subscription is inactive"
+	case delivery.Event == nil:
+		delivery.Status = domain.EventDeliveryDead
+		delivery.NextAttemptAt = nil
+		delivery.LastError = "Event not found"
+	default:
+		d.attempt(ctx, subscription, delivery)
+	}
+
+	err = d.store.WebhookSubscriptions().UpdateDelivery(ctx, delivery)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+// Helper function to send a delivery once and update it with the response
+func (d *Dispatcher) attempt(ctx context.Context, subscription *domain.WebhookSubscription, delivery *domain.EventDelivery) {
+	now := d.now()
+	delivery.Attempts++
+	delivery.LastAttemptAt = &now
+	delivery.ResponseStatus = nil
+
+	status, err := d.send(ctx, subscription, delivery)
+	if status != 0 {
+		delivery.ResponseStatus = &status
+	}
+	if err == nil {
+		delivery.Status = domain.EventDeliveryDelivered
+		delivery.NextAttemptAt = nil
+		delivery.DeliveredAt = &now
+		delivery.LastError = ""
+		return
+	}
+
+	delivery.LastError = err.Error()
+	if delivery.Attempts >= d.maxAttempts {
+		delivery.Status = domain.EventDeliveryDead
+		delivery.NextAttemptAt = nil
+		return
+	}
+	next := now.Add(backoff(delivery.Attempts))
+	delivery.Status = domain.EventDeliveryRetrying
+	delivery.NextAttemptAt = &next
+}
+
+// Helper function to post the signed event of a delivery, returning the response status if there was a response
+func (d *Dispatcher) send(ctx context.Context, subscription *domain.WebhookSubscription, delivery *domain.EventDelivery) (int, error) {
+	body, err := json.Marshal(mapper.EventDomainToAPI(delivery.Event))
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := d.now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "release-management-webhooks")
+	req.Header.Set(HeaderEvent, string(delivery.EventType))
+	req.Header.Set(HeaderDelivery, delivery.ID)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(subscription.Secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		message := fmt.Sprintf("Unexpected response status %d", resp.StatusCode)
+		if text := strings.TrimSpace(string(snippet)); text != "" {
+			message += ": " + text
+		}
+		return resp.StatusCode, errors.New(message)
+	}
+	// Drain the body so the connection can be reused
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	return resp.StatusCode, nil
+}
+
+// Sign returns the signature header value of a payload sent at timestamp, in Unix seconds
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Helper function to compute the delay before the next attempt after a number of failed attempts
+func backoff(attempts int) time.Duration {
+	delay := retryBaseDelay
+	for i := 1; i < attempts && delay < retryMaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, retryMaxDelay)
+}
@@ -0,0 +1,203 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"release-management/internal/config"
+	"release-management/internal/models/api"
+	"release-management/internal/models/domain"
+	"release-management/internal/repository"
+	"release-management/internal/repository/memory"
+)
+
+// receiver records the requests of a test endpoint and answers with a configurable status
+type receiver struct {
+	mu       sync.Mutex
+	status   int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, req)
+	r.bodies = append(r.bodies, body)
+	w.WriteHeader(r.status)
+	w.Write([]byte("not today"))
+}
+
+func TestPublishAndDispatch(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	recv := &receiver{status: http.StatusNoContent}
+	server := httptest.NewServer(recv)
+	defer server.Close()
+
+	builds := &domain.WebhookSubscription{Name: "Builds", URL: server.URL, Secret: "whsec_test", EventTypes: []domain.EventType{domain.EventBuildRegistered}, Active: true}
+	everything := &domain.WebhookSubscription{Name: "Everything", URL: server.URL, Secret: "whsec_other", Active: true}
+	inactive := &domain.WebhookSubscription{Name: "Inactive", URL: server.URL, Secret: "whsec_off", Active: false}
+	for _, subscription := range []*domain.WebhookSubscription{builds, everything, inactive} {
+		if err := store.WebhookSubscriptions().Create(ctx, subscription); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Only subscriptions that accept the event type get a delivery
+	data := api.BuildEvent{Build: api.BuildResponse{ID: "build-1", SystemName: "payments", Version: "1.0.0"}}
+	if err := Publish(ctx, store, domain.EventBuildRegistered, data); err != nil {
+		t.Fatal(err)
+	}
+	if err := Publish(ctx, store, domain.EventReleaseTransitioned, api.ReleaseTransitionedEvent{From: "planned", To: "in-progress"}); err != nil {
+		t.Fatal(err)
+	}
+	expectDeliveries(t, store, builds.ID, domain.EventDeliveryPending)
+	expectDeliveries(t, store, everything.ID, domain.EventDeliveryPending, domain.EventDeliveryPending)
+	expectDeliveries(t, store, inactive.ID)
+
+	now := time.Now()
+	dispatcher := NewDispatcher(store, config.WebhookConfig{DeliveryMaxAttempts: 3, DeliveryTimeout: time.Second})
+	dispatcher.now = func() time.Time { return now }
+
+	sent, err := dispatcher.DispatchDue(ctx)
+	if err != nil || sent != 3 {
+		t.Fatalf("DispatchDue() = %d, %v, want 3 deliveries", sent, err)
+	}
+	expectDeliveries(t, store, builds.ID, domain.EventDeliveryDelivered)
+	expectDeliveries(t, store, everything.ID, domain.EventDeliveryDelivered, domain.EventDeliveryDelivered)
+
+	// Every request is signed with the secret of its subscription
+	for i, req := range recv.requests {
+		timestamp, err := strconv.ParseInt(req.Header.Get(HeaderTimestamp), 10, 64)
+		if err != nil {
+			t.Fatalf("request %d: invalid timestamp %q", i, req.Header.Get(HeaderTimestamp))
+		}
+		signature := req.Header.Get(HeaderSignature)
+		if signature != Sign("whsec_test", timestamp, recv.bodies[i]) && signature != Sign("whsec_other", timestamp, recv.bodies[i]) {
+			t.Errorf("request %d: signature %q does not match the body", i, signature)
+		}
+		if req.Header.Get(HeaderDelivery) == "" || req.Header.Get(HeaderEvent) == "" {
+			t.Errorf("request %d: missing delivery headers %v", i, req.Header)
+		}
+
+		var payload api.EventPayload
+		if err := json.Unmarshal(recv.bodies[i], &payload); err != nil || payload.ID == "" || payload.Type != req.Header.Get(HeaderEvent) {
+			t.Errorf("request %d: payload %s", i, recv.bodies[i])
+		}
+		if payload.Type == string(domain.EventBuildRegistered) && payload.Data["build"].(map[string]interface{})["system_name"] != "payments" {
+			t.Errorf("request %d: build data %v", i, payload.Data)
+		}
+	}
+
+	// Nothing is due once everything is delivered
+	if sent, err := dispatcher.DispatchDue(ctx); err != nil || sent != 0 {
+		t.Fatalf("DispatchDue() after delivery = %d, %v, want nothing", sent, err)
+	}
+}
+
+func TestDispatchRetriesAndDeadLetters(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	recv := &receiver{status: http.StatusServiceUnavailable}
+	server := httptest.NewServer(recv)
+	defer server.Close()
+
+	subscription := &domain.WebhookSubscription{Name: "Flaky", URL: server.URL, Secret: "whsec_test", Active: true}
+	if err := store.WebhookSubscriptions().Create(ctx, subscription); err != nil {
+		t.Fatal(err)
+	}
+	if err := Publish(ctx, store, domain.EventBuildRegistered, api.BuildEvent{}); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	dispatcher := NewDispatcher(store, config.WebhookConfig{DeliveryMaxAttempts: 3, DeliveryTimeout: time.Second})
+	dispatcher.now = func() time.Time { return now }
+
+	// Failed attempts are retried after 30s and then 60s
+	for attempt, wait := range []time.Duration{30 * time.Second, time.Minute} {
+		if sent, err := dispatcher.DispatchDue(ctx); err != nil || sent != 1 {
+			t.Fatalf("attempt %d: DispatchDue() = %d, %v", attempt+1, sent, err)
+		}
+		delivery := expectDeliveries(t, store, subscription.ID, domain.EventDeliveryRetrying)[0]
+		if delivery.Attempts != attempt+1 || delivery.NextAttemptAt == nil || !delivery.NextAttemptAt.Equal(now.Add(wait)) {
+			t.Fatalf("attempt %d: delivery = %+v, want the next attempt in %s", attempt+1, delivery, wait)
+		}
+		if delivery.ResponseStatus == nil || *delivery.ResponseStatus != http.StatusServiceUnavailable || delivery.LastError != "Unexpected response status 503: not today" {
+			t.Errorf("attempt %d: delivery log = %v %q", attempt+1, delivery.ResponseStatus, delivery.LastError)
+		}
+
+		if sent, _ := dispatcher.DispatchDue(ctx); sent != 0 {
+			t.Fatalf("attempt %d: retried before the backoff", attempt+1)
+		}
+		now = now.Add(wait)
+	}
+
+	// The last attempt moves the delivery to the dead-letter queue
+	if sent, err := dispatcher.DispatchDue(ctx); err != nil || sent != 1 {
+		t.Fatalf("last attempt: DispatchDue() = %d, %v", sent, err)
+	}
+	delivery := expectDeliveries(t, store, subscription.ID, domain.EventDeliveryDead)[0]
+	if delivery.Attempts != 3 || delivery.NextAttemptAt != nil {
+		t.Errorf("dead delivery = %+v", delivery)
+	}
+	now = now.Add(24 * time.Hour)
+	if sent, _ := dispatcher.DispatchDue(ctx); sent != 0 {
+		t.Errorf("dead delivery was attempted again")
+	}
+
+	// Deliveries of a deactivated subscription are dead-lettered without an attempt
+	subscription.Active = false
+	if err := store.WebhookSubscriptions().Update(ctx, subscription); err != nil {
+		t.Fatal(err)
+	}
+	delivery.Status = domain.EventDeliveryPending
+	delivery.NextAttemptAt = &now
+	if err := store.WebhookSubscriptions().UpdateDelivery(ctx, &delivery); err != nil {
+		t.Fatal(err)
+	}
+	requests := len(recv.requests)
+	if _, err := dispatcher.DispatchDue(ctx); err != nil {
+		t.Fatal(err)
+	}
+	delivery = expectDeliveries(t, store, subscription.ID, domain.EventDeliveryDead)[0]
+	if len(recv.requests) != requests || delivery.LastError != "Subscription is inactive" {
+		t.Errorf("inactive subscription: %d requests, last error %q", len(recv.requests)-requests, delivery.LastError)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	for attempts, want := range map[int]time.Duration{1: 30 * time.Second, 2: time.Minute, 3: 2 * time.Minute, 8: time.Hour, 50: time.Hour} {
+		if got := backoff(attempts); got != want {
+			t.Errorf("backoff(%d) = %s, want %s", attempts, got, want)
+		}
+	}
+}
+
+// Helper function to check the statuses of the deliveries of a subscription, oldest first, and return them
+func expectDeliveries(t *testing.T, store repository.Store, subscriptionID string, want ...domain.EventDeliveryStatus) []domain.EventDelivery {
+	t.Helper()
+	page, err := store.WebhookSubscriptions().ListDeliveries(context.Background(), repository.EventDeliveryFilter{SubscriptionID: subscriptionID},
+		repository.PageRequest{Sort: repository.Sort{Field: "created_at"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Items) != len(want) {
+		t.Fatalf("deliveries of %s = %+v, want statuses %v", subscriptionID, page.Items, want)
+	}
+	for i := range want {
+		if page.Items[i].Status != want[i] {
+			t.Errorf("delivery %d of %s = %s, want %s", i, subscriptionID, page.Items[i].Status, want[i])
+		}
+	}
+	return page.Items
+}
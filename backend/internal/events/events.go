@@ -0,0 +1,58 @@
+// Package events publishes what happens in the catalog to outbound webhook subscriptions and the event stream.
+//
+// Handlers call Publish in the transaction of the change, which stores the event and queues a delivery
+// for every interested subscription, so that an event is sent if and only if its change is committed.
+// A Dispatcher sends the queued deliveries in the background, see dispatcher.go, and a Stream follows
+// the stored events for the clients of the event stream, see stream.go.
+package events
+
+import (
+	"context"
+	"encoding/json"
+
+	"release-management/internal/models/domain"
+	"release-management/internal/repository"
+)
+
+// Publish stores an event of a type with its data, the API representation of what happened,
+// and queues a delivery for every active subscription to the type
+func Publish(ctx context.Context, tx repository.Store, eventType domain.EventType, data interface{}) error {
+	event := &domain.Event{Type: eventType, Data: document(data)}
+	if err := tx.Events().Create(ctx, event); err != nil {
+		return err
+	}
+
+	subscriptions, err := tx.WebhookSubscriptions().List(ctx)
+	if err != nil {
+		return err
+	}
+	for i := range subscriptions {
+		if !subscriptions[i].Accepts(eventType) {
+			continue
+		}
+		delivery := &domain.EventDelivery{
+			SubscriptionID: subscriptions[i].ID,
+			EventID:        event.ID,
+			EventType:      eventType,
+			Status:         domain.EventDeliveryPending,
+			NextAttemptAt:  &event.CreatedAt,
+		}
+		if err := tx.WebhookSubscriptions().CreateDelivery(ctx, delivery); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Helper function to convert the data of an event to a JSON object
+func document(data interface{}) map[string]interface{} {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(encoded, &doc); err != nil {
+		return nil
+	}
+	return doc
+}
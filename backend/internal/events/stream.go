@@ -0,0 +1,243 @@
+package events
+
+import (
+	"context"
+	"log"
+	"slices"
+	"sync"
+	"time"
+
+	"release-management/internal/models/domain"
+	"release-management/internal/repository"
+)
+
+const (
+	// streamPollInterval is how often the stream looks for new events while clients are connected
+	streamPollInterval = time.Second
+	// streamPollBatch caps the events read at once
+	streamPollBatch = 100
+	// streamGapTimeout is how long the stream waits for an event missing from the sequence, whose transaction
+	// may still commit, before it skips it. Transactions that roll back leave gaps for good.
+	streamGapTimeout = 5 * time.Second
+	// subscriberBuffer is how far a subscriber may fall behind before it is disconnected
+	subscriberBuffer = 64
+)
+
+// Stream follows the stored events and fans them out to the clients of the event stream. The last events are kept
+// in a bounded buffer so that a client that reconnects can resume after the last event it received.
+//
+// Reading the events table instead of listening to Publish means that only committed events are streamed, in the
+// order they were stored, and that every server streams the changes made through the others.
+type Stream struct {
+	store repository.Store
+	size  int
+	now   func() time.Time
+
+	mu          sync.Mutex
+	loaded      bool
+	running     bool
+	buffer      []domain.Event
+	last        int64
+	dropped     int64
+	gapSince    time.Time
+	subscribers map[*Subscription]struct{}
+}
+
+// Filter selects the events a subscriber receives. Empty fields match every event.
+type Filter struct {
+	Types []domain.EventType
+	// ReleaseID matches events about the release, its builds, its environments and their systems
+	ReleaseID string
+	// EnvironmentGroupID matches events about the environments of the group and their systems
+	EnvironmentGroupID string
+}
+
+// Subscription receives the events of a stream that match its filter until it is closed
+type Subscription struct {
+	stream *Stream
+	filter Filter
+	events chan domain.Event
+}
+
+// NewStream creates a stream of the events of store that can replay the last size events, at least one
+func NewStream(store repository.Store, size int) *Stream {
+	return &Stream{
+		store:       store,
+		size:        max(size, 1),
+		now:         time.Now,
+		subscribers: make(map[*Subscription]struct{}),
+	}
+}
+
+// Subscribe registers a subscriber for the events matching filter. With a lastEventID, the sequence of the last
+// event the client received, it also returns the buffered events after it. complete is false when some of them
+// are no longer buffered, in which case the client has to reload the current state.
+func (s *Stream) Subscribe(ctx context.Context, filter Filter, lastEventID int64) (sub *Subscription, replay []domain.Event, complete bool, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Catch up first so that the replay ends where the subscription begins
+	if err := s.catchUp(ctx); err != nil {
+		return nil, nil, false, err
+	}
+
+	complete = true
+	if lastEventID > 0 {
+		complete = lastEventID >= s.dropped
+		for _, event := range s.buffer {
+			if event.Sequence > lastEventID && filter.Matches(&event) {
+				replay = append(replay, event)
+			}
+		}
+	}
+
+	sub = &Subscription{stream: s, filter: filter, events: make(chan domain.Event, subscriberBuffer)}
+	s.subscribers[sub] = struct{}{}
+	if !s.running {
+		s.running = true
+		go s.run()
+	}
+	return sub, replay, complete, nil
+}
+
+// Events returns the channel of new events, which is closed if the subscriber falls too far behind
+func (sub *Subscription) Events() <-chan domain.Event {
+	return sub.events
+}
+
+// Close unregisters the subscriber
+func (sub *Subscription) Close() {
+	sub.stream.mu.Lock()
+	defer sub.stream.mu.Unlock()
+	sub.stream.remove(sub)
+}
+
+// Matches checks if an event passes the filter
+func (f Filter) Matches(event *domain.Event) bool {
+	if len(f.Types) > 0 && !slices.Contains(f.Types, event.Type) {
+		return false
+	}
+	releaseID, groupID := scope(event)
+	if f.ReleaseID != "" && releaseID != f.ReleaseID {
+		return false
+	}
+	if f.EnvironmentGroupID != "" && groupID != f.EnvironmentGroupID {
+		return false
+	}
+	return true
+}
+
+// Helper function to poll for new events while there are subscribers
+func (s *Stream) run() {
+	ticker := time.NewTicker(streamPollInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		s.mu.Lock()
+		if len(s.subscribers) == 0 {
+			// Stop polling until the next client connects, which catches up on what happened in the meantime
+			s.running = false
+			s.mu.Unlock()
+			return
+		}
+		if err := s.catchUp(context.Background()); err != nil {
+			log.Printf("Failed to read events for the event stream: %v", err)
+		}
+		s.mu.Unlock()
+	}
+}
+
+// Helper function to read the events stored since the last one read and send them to the subscribers.
+// The first call fills the replay buffer with the latest events. Must be called with the lock held.
+func (s *Stream) catchUp(ctx context.Context) error {
+	if !s.loaded {
+		latest, err := s.store.Events().ListLatest(ctx, s.size)
+		if err != nil {
+			return err
+		}
+		s.buffer = latest
+		if len(latest) > 0 {
+			s.last = latest[len(latest)-1].Sequence
+			if len(latest) == s.size {
+				// Older events may exist but cannot be replayed
+				s.dropped = latest[0].Sequence - 1
+			}
+		}
+		s.loaded = true
+	}
+
+	for {
+		events, err := s.store.Events().ListAfter(ctx, s.last, streamPollBatch)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			if s.last != 0 && event.Sequence != s.last+1 {
+				if s.gapSince.IsZero() {
+					s.gapSince = s.now()
+				}
+				if s.now().Sub(s.gapSince) < streamGapTimeout {
+					return nil
+				}
+			}
+			s.gapSince = time.Time{}
+			s.append(event)
+		}
+		if len(events) < streamPollBatch {
+			return nil
+		}
+	}
+}
+
+// Helper function to add an event to the buffer and send it to the matching subscribers
+func (s *Stream) append(event domain.Event) {
+	s.last = event.Sequence
+	s.buffer = append(s.buffer, event)
+	if over := len(s.buffer) - s.size; over > 0 {
+		s.dropped = s.buffer[over-1].Sequence
+		s.buffer = s.buffer[over:]
+	}
+
+	for sub := range s.subscribers {
+		if !sub.filter.Matches(&event) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			// A subscriber that cannot keep up is disconnected and resumes from its last event when it reconnects
+			s.remove(sub)
+		}
+	}
+}
+
+// Helper function to unregister a subscriber and close its channel. Must be called with the lock held.
+func (s *Stream) remove(sub *Subscription) {
+	if _, ok := s.subscribers[sub]; ok {
+		delete(s.subscribers, sub)
+		close(sub.events)
+	}
+}
+
+// Helper function to read the release and environment group an event is about from its data
+func scope(event *domain.Event) (releaseID, groupID string) {
+	switch event.Type.Resource() {
+	case domain.ResourceReleases:
+		return stringField(event.Data, "release", "id"), ""
+	case domain.ResourceBuilds:
+		return stringField(event.Data, "build", "release_id"), ""
+	}
+	if _, ok := event.Data["environment"]; ok {
+		return stringField(event.Data, "environment", "release_id"), stringField(event.Data, "environment", "environment_group_id")
+	}
+	return stringField(event.Data, "release_id"), stringField(event.Data, "environment_group_id")
+}
+
+// Helper function to look up a string in nested JSON objects, empty if it is missing
+func stringField(data map[string]interface{}, path ...string) string {
+	for _, key := range path[:len(path)-1] {
+		data, _ = data[key].(map[string]interface{})
+	}
+	value, _ := data[path[len(path)-1]].(string)
+	return value
+}
@@ -0,0 +1,136 @@
+package events
+
+import (
+	"context"
+	"testing"
+
+	"release-management/internal/models/api"
+	"release-management/internal/models/domain"
+	"release-management/internal/repository/memory"
+)
+
+func TestStreamReplayAndFilters(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	releaseID, groupID := "release-1", "group-1"
+
+	publish := func(eventType domain.EventType, data interface{}) {
+		t.Helper()
+		if err := Publish(ctx, store, eventType, data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	publish(domain.EventReleaseCreated, api.ReleaseEvent{Release: api.ReleaseResponse{ID: releaseID}})
+	publish(domain.EventReleaseCreated, api.ReleaseEvent{Release: api.ReleaseResponse{ID: "release-2"}})
+	publish(domain.EventBuildRegistered, api.BuildEvent{Build: api.BuildResponse{ID: "build-1", ReleaseID: &releaseID}})
+	publish(domain.EventEnvironmentCreated, api.EnvironmentEvent{Environment: api.EnvironmentResponse{ID: "env-1", ReleaseID: releaseID, EnvironmentGroupID: &groupID}})
+
+	// The buffer holds the last three events, so resuming after the first is complete and after nothing is not
+	stream := NewStream(store, 3)
+	all, replay, complete, err := stream.Subscribe(ctx, Filter{}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer all.Close()
+	if !complete || len(replay) != 3 || replay[0].Sequence != 2 || replay[2].Type != domain.EventEnvironmentCreated {
+		t.Fatalf("replay after 1 = %+v, complete %v", replay, complete)
+	}
+	latest, replay, complete, err := stream.Subscribe(ctx, Filter{}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	latest.Close()
+	if len(replay) != 0 || !complete {
+		t.Errorf("subscribe without a last event ID replayed %+v", replay)
+	}
+
+	release, replay, _, err := stream.Subscribe(ctx, Filter{ReleaseID: releaseID}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release.Close()
+	if len(replay) != 2 || replay[0].Type != domain.EventBuildRegistered {
+		t.Errorf("replay for the release = %+v", replay)
+	}
+
+	group, replay, _, err := stream.Subscribe(ctx, Filter{EnvironmentGroupID: groupID, Types: []domain.EventType{domain.EventEnvironmentSystemChanged}}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer group.Close()
+	if len(replay) != 0 {
+		t.Errorf("replay for system changes in the group = %+v", replay)
+	}
+
+	// New events are sent to the subscribers they match, and the oldest buffered event is dropped
+	publish(domain.EventEnvironmentSystemChanged, api.EnvironmentSystemChangedEvent{EnvironmentID: "env-1", ReleaseID: releaseID, EnvironmentGroupID: &groupID})
+	publish(domain.EventReleaseDeleted, api.ReleaseEvent{Release: api.ReleaseResponse{ID: "release-2"}})
+	stream.mu.Lock()
+	if err := stream.catchUp(ctx); err != nil {
+		t.Fatal(err)
+	}
+	stream.mu.Unlock()
+
+	expectStreamed(t, all, domain.EventEnvironmentSystemChanged, domain.EventReleaseDeleted)
+	expectStreamed(t, release, domain.EventEnvironmentSystemChanged)
+	expectStreamed(t, group, domain.EventEnvironmentSystemChanged)
+
+	resumed, replay, complete, err := stream.Subscribe(ctx, Filter{}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resumed.Close()
+	if complete || len(replay) != 3 {
+		t.Errorf("replay after a dropped event = %d events, complete %v", len(replay), complete)
+	}
+}
+
+func TestStreamDisconnectsSlowSubscribers(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	stream := NewStream(store, 10)
+	sub, _, _, err := stream.Subscribe(ctx, Filter{}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for range subscriberBuffer + 1 {
+		if err := Publish(ctx, store, domain.EventReleaseUpdated, api.ReleaseEvent{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	stream.mu.Lock()
+	if err := stream.catchUp(ctx); err != nil {
+		t.Fatal(err)
+	}
+	stream.mu.Unlock()
+
+	received := 0
+	for range sub.Events() {
+		received++
+	}
+	if received != subscriberBuffer {
+		t.Errorf("slow subscriber received %d events before it was disconnected, want %d", received, subscriberBuffer)
+	}
+	sub.Close()
+}
+
+// Helper function to check the types of the events a subscriber has received
+func expectStreamed(t *testing.T, sub *Subscription, want ...domain.EventType) {
+	t.Helper()
+	for _, eventType := range want {
+		select {
+		case event := <-sub.Events():
+			if event.Type != eventType {
+				t.Errorf("streamed %s, want %s", event.Type, eventType)
+			}
+		default:
+			t.Fatalf("nothing streamed, want %s", eventType)
+		}
+	}
+	select {
+	case event := <-sub.Events():
+		t.Errorf("unexpected event %s", event.Type)
+	default:
+	}
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"release-management/internal/middleware"
+	"release-management/internal/models/api"
+	"release-management/internal/models/domain"
+	"release-management/internal/models/mapper"
+	"release-management/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+type APITokenHandler struct {
+	store repository.Store
+}
+
+func NewAPITokenHandler(store repository.Store) *APITokenHandler {
+	return &APITokenHandler{store: store}
+}
+
+// GET /tokens
+func (h *APITokenHandler) GetTokens(c *gin.Context) {
+	tokens, err := h.store.APITokens().ListByUser(c.Request.Context(), c.GetUint("userID"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch API tokens"})
+		return
+	}
+
+	respondWithList(c, tokens, mapper.APITokenDomainToAPI)
+}
+
+// GET /tokens/:id
+func (h *APITokenHandler) GetToken(c *gin.Context) {
+	token, ok := h.ownToken(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.APITokenDomainToAPI(token))
+}
+
+// POST /tokens
+func (h *APITokenHandler) CreateToken(c *gin.Context) {
+	var req api.APITokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	domainToken := mapper.APITokenAPIToDomain(&req)
+	domainToken.UserID = c.GetUint("userID")
+
+	if domainToken.Kind == "" {
+		domainToken.Kind = domain.TokenKindPersonal
+	}
+	if !domainToken.Kind.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid token kind, must be one of: personal, service"})
+		return
+	}
+
+	// Service tokens act for automation rather than a person, so only admins may issue them
+	if domainToken.Kind == domain.TokenKindService {
+		user, err := h.store.Users().Get(c.Request.Context(), domainToken.UserID)
+		if err != nil || user.Role != domain.RoleAdmin {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only admins can create service tokens"})
+			return
+		}
+	}
+
+	for _, scope := range domainToken.Scopes {
+		if !scope.IsValid() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid scope: " + string(scope)})
+			return
+		}
+	}
+
+	if domainToken.ExpiresAt != nil && !domainToken.ExpiresAt.After(time.Now()) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Token expiry must be in the future"})
+		return
+	}
+
+	token, tokenHash, err := middleware.GenerateAPIToken()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate API token"})
+		return
+	}
+	domainToken.TokenHash = tokenHash
+	domainToken.Prefix = token[:len(middleware.APITokenPrefix)+8]
+
+	if err := h.store.APITokens().Create(c.Request.Context(), domainToken); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create API token"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, api.APITokenCreatedResponse{
+		APITokenResponse: *mapper.APITokenDomainToAPI(domainToken),
+		Token:            token,
+	})
+}
+
+// DELETE /tokens/:id
+func (h *APITokenHandler) RevokeToken(c *gin.Context) {
+	token, ok := h.ownToken(c)
+	if !ok {
+		return
+	}
+
+	if token.RevokedAt == nil {
+		now := time.Now()
+		token.RevokedAt = &now
+		if err := h.store.APITokens().Update(c.Request.Context(), token); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke API token"})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "API token revoked successfully"})
+}
+
+// Helper function to load a token of the current user, responding with 404 for tokens of other users
+func (h *APITokenHandler) ownToken(c *gin.Context) (*domain.APIToken, bool) {
+	token, err := h.store.APITokens().Get(c.Request.Context(), c.Param("id"))
+	if err != nil || token.UserID != c.GetUint("userID") {
+		c.JSON(http.StatusNotFound, gin.H{"error": "API token not found"})
+		return nil, false
+	}
+	return token, true
+}
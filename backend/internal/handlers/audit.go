@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"release-management/internal/models/domain"
+	"release-management/internal/models/mapper"
+	"release-management/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+const auditExportFlushEvery = 500
+
+type AuditHandler struct {
+	store repository.Store
+}
+
+func NewAuditHandler(store repository.Store) *AuditHandler {
+	return &AuditHandler{store: store}
+}
+
+// GET /audit
+func (h *AuditHandler) GetAuditEntries(c *gin.Context) {
+	filter, ok := auditFilter(c)
+	if !ok {
+		return
+	}
+
+	// Newest first unless sorted otherwise
+	page, ok := pageRequest(c, repository.AuditSortFields, repository.Sort{Field: "created_at", Desc: true})
+	if !ok {
+		return
+	}
+
+	entries, err := h.store.Audit().ListPage(c.Request.Context(), filter, page)
+	if err != nil {
+		respondWithListError(c, err, "Failed to fetch audit entries")
+		return
+	}
+
+	respondWithPage(c, entries, mapper.AuditEntryDomainToAPI)
+}
+
+// GET /audit/export
+func (h *AuditHandler) ExportAuditEntries(c *gin.Context) {
+	filter, ok := auditFilter(c)
+	if !ok {
+		return
+	}
+
+	// Stream oldest first entry by entry so large exports do not have to fit in memory.
+	// The headers are only sent with the first entry so a failing query can still respond with an error.
+	encoder := json.NewEncoder(c.Writer)
+	count := 0
+	err := h.store.Audit().Each(c.Request.Context(), filter, func(entry *domain.AuditEntry) error {
+		if count == 0 {
+			writeAuditExportHeaders(c)
+		}
+		count++
+
+		if err := encoder.Encode(mapper.AuditEntryDomainToAPI(entry)); err != nil {
+			return err
+		}
+		if count%auditExportFlushEvery == 0 {
+			c.Writer.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		if count == 0 {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to export audit entries"})
+			return
+		}
+		// Headers are already sent, so the export can only be cut short
+		c.Error(err)
+		return
+	}
+
+	if count == 0 {
+		writeAuditExportHeaders(c)
+	}
+}
+
+// Helper function to send the headers of an NDJSON audit export
+func writeAuditExportHeaders(c *gin.Context) {
+	c.Header("Content-Type", "application/x-ndjson")
+	c.Header("Content-Disposition", "attachment; filename=audit-"+time.Now().UTC().Format("20060102T150405Z")+".ndjson")
+	c.Status(http.StatusOK)
+	c.Writer.WriteHeaderNow()
+}
+
+// Helper function to build the audit filter from the entity, entity_id, action, actor, since and until query parameters
+func auditFilter(c *gin.Context) (repository.AuditFilter, bool) {
+	var filter repository.AuditFilter
+
+	if entity := c.Query("entity"); entity != "" {
+		if !domain.AuditEntityType(entity).IsValid() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'entity' parameter. Valid values are: release, build, system, environment, environment_group, environment_system, webhook, freeze_window, change_request"})
+			return filter, false
+		}
+		filter.EntityType = domain.AuditEntityType(entity)
+	}
+
+	if entityID := c.Query("entity_id"); entityID != "" {
+		filter.EntityID = entityID
+	}
+
+	if action := c.Query("action"); action != "" {
+		if !domain.AuditAction(action).IsValid() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'action' parameter. Valid values are: create, update, delete"})
+			return filter, false
+		}
+		filter.Action = domain.AuditAction(action)
+	}
+
+	// Actors can be given by user ID or by name, e.g. an email address or webhook:github
+	if actor := c.Query("actor"); actor != "" {
+		if actorID, err := strconv.ParseUint(actor, 10, 64); err == nil {
+			id := uint(actorID)
+			filter.ActorID = &id
+		} else {
+			filter.Actor = actor
+		}
+	}
+
+	if since := c.Query("since"); since != "" {
+		sinceTime, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'since' parameter. Use RFC3339 format"})
+			return filter, false
+		}
+		filter.Since = &sinceTime
+	}
+
+	if until := c.Query("until"); until != "" {
+		untilTime, err := time.Parse(time.RFC3339, until)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'until' parameter. Use RFC3339 format"})
+			return filter, false
+		}
+		filter.Until = &untilTime
+	}
+
+	return filter, true
+}
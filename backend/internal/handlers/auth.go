@@ -1,15 +1,16 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
 
 	"release-management/internal/config"
-	"release-management/internal/database"
 	"release-management/internal/models/api"
-	"release-management/internal/models/db"
+	"release-management/internal/models/domain"
 	"release-management/internal/models/mapper"
+	"release-management/internal/repository"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
@@ -17,11 +18,12 @@ import (
 )
 
 type AuthHandler struct {
-	cfg *config.Config
+	cfg   *config.Config
+	store repository.Store
 }
 
-func NewAuthHandler(cfg *config.Config) *AuthHandler {
-	return &AuthHandler{cfg: cfg}
+func NewAuthHandler(cfg *config.Config, store repository.Store) *AuthHandler {
+	return &AuthHandler{cfg: cfg, store: store}
 }
 
 func (h *AuthHandler) Login(c *gin.Context) {
@@ -31,25 +33,24 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	var dbUser db.User
-	if err := database.DB.Where("email = ?", req.Email).First(&dbUser).Error; err != nil {
+	user, err := h.store.Users().GetByEmail(c.Request.Context(), req.Email)
+	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid credentials"})
 		return
 	}
 
-	if err := bcrypt.CompareHashAndPassword([]byte(dbUser.Password), []byte(req.Password)); err != nil {
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid credentials"})
 		return
 	}
 
-	token, err := h.generateJWT(dbUser.ID)
+	token, err := h.generateJWT(user.ID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
 		return
 	}
 
-	domainUser := mapper.UserDBToDomain(&dbUser)
-	userResponse := mapper.UserDomainToAPI(domainUser)
+	userResponse := mapper.UserDomainToAPI(user)
 
 	c.JSON(http.StatusOK, api.AuthResponse{
 		Token: token,
@@ -64,9 +65,10 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		return
 	}
 
+	ctx := c.Request.Context()
+
 	// Check if user already exists
-	var existingUser db.User
-	if err := database.DB.Where("email = ?", req.Email).First(&existingUser).Error; err == nil {
+	if _, err := h.store.Users().GetByEmail(ctx, req.Email); err == nil {
 		c.JSON(http.StatusConflict, gin.H{"error": "User already exists"})
 		return
 	}
@@ -79,25 +81,29 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	}
 
 	// Create user
-	dbUser := db.User{
+	user := &domain.User{
 		Email:    req.Email,
 		Password: string(hashedPassword),
 		IsAdmin:  false,
+		Role:     domain.RoleViewer,
 	}
 
-	if err := database.DB.Create(&dbUser).Error; err != nil {
+	if err := h.store.Users().Create(ctx, user); err != nil {
+		if errors.Is(err, repository.ErrDuplicate) {
+			c.JSON(http.StatusConflict, gin.H{"error": "User already exists"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create user"})
 		return
 	}
 
-	token, err := h.generateJWT(dbUser.ID)
+	token, err := h.generateJWT(user.ID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
 		return
 	}
 
-	domainUser := mapper.UserDBToDomain(&dbUser)
-	userResponse := mapper.UserDomainToAPI(domainUser)
+	userResponse := mapper.UserDomainToAPI(user)
 
 	c.JSON(http.StatusCreated, api.AuthResponse{
 		Token: token,
@@ -106,22 +112,18 @@ func (h *AuthHandler) Register(c *gin.Context) {
 }
 
 func (h *AuthHandler) Me(c *gin.Context) {
-	userID, exists := c.Get("userID")
-	if !exists {
+	if _, exists := c.Get("userID"); !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
 		return
 	}
 
-	var dbUser db.User
-	if err := database.DB.First(&dbUser, userID).Error; err != nil {
+	user, err := h.store.Users().Get(c.Request.Context(), c.GetUint("userID"))
+	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
 		return
 	}
 
-	domainUser := mapper.UserDBToDomain(&dbUser)
-	userResponse := mapper.UserDomainToAPI(domainUser)
-
-	c.JSON(http.StatusOK, userResponse)
+	c.JSON(http.StatusOK, mapper.UserDomainToAPI(user))
 }
 
 func (h *AuthHandler) generateJWT(userID uint) (string, error) {
@@ -1,56 +1,79 @@
 package handlers
 
 import (
+	"context"
+	"errors"
 	"net/http"
+	"regexp"
+	"sort"
+	"strings"
 
-	"release-management/internal/database"
+	"release-management/internal/audit"
+	"release-management/internal/events"
 	"release-management/internal/models/api"
-	"release-management/internal/models/db"
 	"release-management/internal/models/domain"
 	"release-management/internal/models/mapper"
+	"release-management/internal/repository"
+	"release-management/internal/service"
+	"release-management/internal/version"
 
 	"github.com/gin-gonic/gin"
 )
 
-type BuildHandler struct{}
+// commitPrefixPattern matches the commits, or their abbreviations, that builds can be looked up by
+var commitPrefixPattern = regexp.MustCompile(`^[0-9a-f]{4,64}$`)
 
-func NewBuildHandler() *BuildHandler {
-	return &BuildHandler{}
+type BuildHandler struct {
+	store repository.Store
+}
+
+func NewBuildHandler(store repository.Store) *BuildHandler {
+	return &BuildHandler{store: store}
 }
 
 // GET /builds
 func (h *BuildHandler) GetBuilds(c *gin.Context) {
-	var dbBuilds []db.Build
-	if err := database.DB.Preload("System").Preload("Release").Find(&dbBuilds).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch builds"})
+	filter := repository.BuildFilter{
+		SystemID:    c.Query("system_id"),
+		ReleaseID:   c.Query("release_id"),
+		CommitSHA:   mapper.NormalizeDigest(c.Query("commit_sha")),
+		ImageDigest: mapper.NormalizeDigest(c.Query("image_digest")),
+	}
+	if filter.CommitSHA != "" && !commitPrefixPattern.MatchString(filter.CommitSHA) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'commit_sha' parameter. Must be 4 to 64 hexadecimal characters"})
+		return
+	}
+	var ok bool
+	if filter.BuiltAfter, ok = timeQuery(c, "built_after"); !ok {
+		return
+	}
+	if filter.BuiltBefore, ok = timeQuery(c, "built_before"); !ok {
+		return
+	}
+
+	page, ok := pageRequest(c, repository.BuildSortFields, repository.Sort{Field: "created_at"})
+	if !ok {
 		return
 	}
 
-	// Convert to API responses
-	apiBuilds := make([]api.BuildResponse, len(dbBuilds))
-	for i, dbBuild := range dbBuilds {
-		domainBuild := mapper.BuildDBToDomain(&dbBuild)
-		apiBuild := mapper.BuildDomainToAPI(domainBuild)
-		apiBuilds[i] = *apiBuild
+	builds, err := h.store.Builds().ListPage(c.Request.Context(), filter, page)
+	if err != nil {
+		respondWithListError(c, err, "Failed to fetch builds")
+		return
 	}
 
-	c.JSON(http.StatusOK, apiBuilds)
+	respondWithPage(c, builds, mapper.BuildDomainToAPI)
 }
 
 // GET /builds/:id
 func (h *BuildHandler) GetBuild(c *gin.Context) {
-	id := c.Param("id")
-	var dbBuild db.Build
-
-	if err := database.DB.Preload("System").Preload("Release").First(&dbBuild, "id = ?", id).Error; err != nil {
+	build, err := h.store.Builds().Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Build not found"})
 		return
 	}
 
-	domainBuild := mapper.BuildDBToDomain(&dbBuild)
-	apiBuild := mapper.BuildDomainToAPI(domainBuild)
-
-	c.JSON(http.StatusOK, apiBuild)
+	c.JSON(http.StatusOK, mapper.BuildDomainToAPI(build))
 }
 
 // POST /builds
@@ -61,60 +84,35 @@ func (h *BuildHandler) CreateBuild(c *gin.Context) {
 		return
 	}
 
-	// Validate that System exists
-	var system db.System
-	if err := database.DB.First(&system, "id = ?", req.SystemID).Error; err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "System not found"})
-		return
-	}
-
-	// Validate that only subsystems and systems can have builds (not parent_systems)
-	if system.Type == string(domain.SystemTypeParent) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Cannot create builds for parent_systems. Only systems and subsystems can have builds"})
-		return
-	}
+	ctx := c.Request.Context()
+	build := mapper.BuildAPIToDomain(&req)
 
-	// Only validate Release if ReleaseID is provided
-	if req.ReleaseID != nil && *req.ReleaseID != "" {
-		var release db.Release
-		if err := database.DB.First(&release, "id = ?", *req.ReleaseID).Error; err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Release not found"})
-			return
+	if err := h.store.Transaction(ctx, func(tx repository.Store) error {
+		if err := service.NewBuildService(tx).Create(ctx, build); err != nil {
+			return err
 		}
-
-		// Check for duplicate system in the same release
-		var existingBuild db.Build
-		if err := database.DB.Where("release_id = ? AND system_id = ?", *req.ReleaseID, req.SystemID).First(&existingBuild).Error; err == nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "A build for this system already exists in this release. Each release can only have one build per system"})
-			return
+		if err := audit.Record(tx, c, domain.AuditEntityBuild, build.ID, nil, mapper.BuildDomainToAPI(build)); err != nil {
+			return err
 		}
-	}
-
-	// Convert to domain and then to DB
-	domainBuild := mapper.BuildAPIToDomain(&req)
-	dbBuild := mapper.BuildDomainToDB(domainBuild)
-
-	if err := database.DB.Create(&dbBuild).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create build"})
+		return publishBuild(ctx, tx, domain.EventBuildRegistered, build)
+	}); err != nil {
+		respondWithError(c, err, "Failed to create build")
 		return
 	}
 
 	// Load relationships for response
-	database.DB.Preload("System").Preload("Release").First(dbBuild, "id = ?", dbBuild.ID)
-
-	// Convert back for response
-	savedDomain := mapper.BuildDBToDomain(dbBuild)
-	response := mapper.BuildDomainToAPI(savedDomain)
+	if saved, err := h.store.Builds().Get(ctx, build.ID); err == nil {
+		build = saved
+	}
 
-	c.JSON(http.StatusCreated, response)
+	c.JSON(http.StatusCreated, mapper.BuildDomainToAPI(build))
 }
 
 // PUT /builds/:id
 func (h *BuildHandler) UpdateBuild(c *gin.Context) {
-	id := c.Param("id")
-	var dbBuild db.Build
-
-	if err := database.DB.First(&dbBuild, "id = ?", id).Error; err != nil {
+	ctx := c.Request.Context()
+	build, err := h.store.Builds().Get(ctx, c.Param("id"))
+	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Build not found"})
 		return
 	}
@@ -125,64 +123,142 @@ func (h *BuildHandler) UpdateBuild(c *gin.Context) {
 		return
 	}
 
-	// Prevent system_id changes - builds cannot be moved between systems
-	if updateReq.SystemID != "" && updateReq.SystemID != dbBuild.SystemID {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Cannot change system_id of existing build. System ID is immutable after creation"})
-		return
-	}
-
-	if updateReq.ReleaseID != nil && *updateReq.ReleaseID != "" {
-		// Check if ReleaseID is actually changing
-		if dbBuild.ReleaseID == nil || *updateReq.ReleaseID != *dbBuild.ReleaseID {
-			var release db.Release
-			if err := database.DB.First(&release, "id = ?", *updateReq.ReleaseID).Error; err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{"error": "Release not found"})
-				return
-			}
-
-			// Check for duplicate system in the target release (excluding current build)
-			var existingBuild db.Build
-			if err := database.DB.Where("release_id = ? AND system_id = ? AND id != ?", *updateReq.ReleaseID, dbBuild.SystemID, dbBuild.ID).First(&existingBuild).Error; err == nil {
-				c.JSON(http.StatusBadRequest, gin.H{"error": "A build for this system already exists in the target release. Each release can only have one build per system"})
-				return
-			}
-		}
-	}
+	before := mapper.BuildDomainToAPI(build)
 
 	// Apply updates
 	if updateReq.Version != "" {
-		dbBuild.Version = updateReq.Version
+		build.Version = updateReq.Version
 	}
 	if !updateReq.BuildDate.IsZero() {
-		dbBuild.BuildDate = updateReq.BuildDate
+		build.BuildDate = updateReq.BuildDate
+	}
+	if updateReq.SystemID != "" {
+		build.SystemID = updateReq.SystemID
 	}
 	if updateReq.ReleaseID != nil {
-		dbBuild.ReleaseID = updateReq.ReleaseID
+		build.ReleaseID = updateReq.ReleaseID
+	}
+	if updateReq.Changelog != nil {
+		build.Changelog = strings.TrimSpace(*updateReq.Changelog)
+	}
+	if updateReq.RepositoryURL != nil {
+		build.RepositoryURL = strings.TrimSpace(*updateReq.RepositoryURL)
+	}
+	if updateReq.CommitSHA != nil {
+		build.CommitSHA = mapper.NormalizeDigest(*updateReq.CommitSHA)
+	}
+	if updateReq.Branch != nil {
+		build.Branch = strings.TrimSpace(*updateReq.Branch)
+	}
+	if updateReq.PipelineURL != nil {
+		build.PipelineURL = strings.TrimSpace(*updateReq.PipelineURL)
+	}
+	if updateReq.ImageDigest != nil {
+		build.ImageDigest = mapper.NormalizeDigest(*updateReq.ImageDigest)
+	}
+	if updateReq.PackageChecksum != nil {
+		build.PackageChecksum = mapper.NormalizeDigest(*updateReq.PackageChecksum)
 	}
 
-	if err := database.DB.Save(&dbBuild).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update build"})
+	if err := h.store.Transaction(ctx, func(tx repository.Store) error {
+		if err := service.NewBuildService(tx).Update(ctx, build); err != nil {
+			return err
+		}
+		if err := audit.Record(tx, c, domain.AuditEntityBuild, build.ID, before, mapper.BuildDomainToAPI(build)); err != nil {
+			return err
+		}
+		return publishBuild(ctx, tx, domain.EventBuildUpdated, build)
+	}); err != nil {
+		respondWithError(c, err, "Failed to update build")
 		return
 	}
 
 	// Load relationships for response
-	database.DB.Preload("System").Preload("Release").First(&dbBuild, "id = ?", dbBuild.ID)
-
-	// Convert to response
-	domainBuild := mapper.BuildDBToDomain(&dbBuild)
-	response := mapper.BuildDomainToAPI(domainBuild)
+	if saved, err := h.store.Builds().Get(ctx, build.ID); err == nil {
+		build = saved
+	}
 
-	c.JSON(http.StatusOK, response)
+	c.JSON(http.StatusOK, mapper.BuildDomainToAPI(build))
 }
 
 // DELETE /builds/:id
 func (h *BuildHandler) DeleteBuild(c *gin.Context) {
-	id := c.Param("id")
+	ctx := c.Request.Context()
+	build, err := h.store.Builds().Get(ctx, c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Build not found"})
+		return
+	}
 
-	if err := database.DB.Delete(&db.Build{}, "id = ?", id).Error; err != nil {
+	if err := h.store.Transaction(ctx, func(tx repository.Store) error {
+		if err := tx.Builds().Delete(ctx, build.ID); err != nil {
+			return err
+		}
+		if err := audit.Record(tx, c, domain.AuditEntityBuild, build.ID, mapper.BuildDomainToAPI(build), nil); err != nil {
+			return err
+		}
+		return publishBuild(ctx, tx, domain.EventBuildDeleted, build)
+	}); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete build"})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Build deleted successfully"})
 }
+
+// Helper function to sort builds by system name and then newest version first
+func sortBuildsByVersion(builds []domain.Build) {
+	sort.SliceStable(builds, func(i, j int) bool {
+		if nameI, nameJ := systemName(builds[i].System), systemName(builds[j].System); nameI != nameJ {
+			return nameI < nameJ
+		}
+		return version.Compare(builds[i].Version, builds[j].Version) > 0
+	})
+}
+
+// buildVersionSortFields sorts the builds of a single system by version, see pageOfBuildsByVersion
+var buildVersionSortFields = repository.SortFields[domain.Build]{
+	ID: func(b *domain.Build) string { return b.ID },
+	Keys: map[string]func(*domain.Build) string{
+		"version": func(b *domain.Build) string { return b.Version },
+	},
+}
+
+// Helper function to cut a page out of the builds of a single system, sorted by version with version.Compare.
+// Versions are unique within a system, so the cursor only needs the version of the last build on the previous page.
+func pageOfBuildsByVersion(builds []domain.Build, page repository.PageRequest) (*repository.Page[domain.Build], error) {
+	compare := func(a, b string) int {
+		if page.Sort.Desc {
+			return version.Compare(b, a)
+		}
+		return version.Compare(a, b)
+	}
+	sort.SliceStable(builds, func(i, j int) bool { return compare(builds[i].Version, builds[j].Version) < 0 })
+
+	result := &repository.Page[domain.Build]{Total: int64(len(builds))}
+	if page.Cursor != "" {
+		cursor, err := repository.DecodeCursor(page.Cursor, page.Sort)
+		if err != nil {
+			return nil, err
+		}
+		start := sort.Search(len(builds), func(i int) bool { return compare(builds[i].Version, cursor.Key) > 0 })
+		builds = builds[start:]
+	}
+
+	if page.Limit > 0 && len(builds) > page.Limit {
+		builds = builds[:page.Limit]
+		result.NextCursor = buildVersionSortFields.CursorAfter(&builds[len(builds)-1], page.Sort)
+	}
+	result.Items = builds
+	return result, nil
+}
+
+// Helper function to publish an event about a build, with the names of its system and release unless it was deleted
+func publishBuild(ctx context.Context, tx repository.Store, eventType domain.EventType, build *domain.Build) error {
+	if saved, err := tx.Builds().Get(ctx, build.ID); err == nil {
+		build = saved
+	} else if !errors.Is(err, repository.ErrNotFound) {
+		return err
+	}
+	return events.Publish(ctx, tx, eventType, api.BuildEvent{Build: *mapper.BuildDomainToAPI(build)})
+}
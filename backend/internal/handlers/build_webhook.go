@@ -0,0 +1,409 @@
+package handlers
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"release-management/internal/audit"
+	"release-management/internal/config"
+	"release-management/internal/models/api"
+	"release-management/internal/models/domain"
+	"release-management/internal/models/mapper"
+	"release-management/internal/repository"
+	"release-management/internal/service"
+	"release-management/internal/version"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxWebhookPayloadSize limits the size of CI webhook payloads
+const maxWebhookPayloadSize = 1 << 20
+
+// buildEvent is the provider independent result of parsing a CI payload
+type buildEvent struct {
+	DeliveryID string
+	// Projects lists the names the payload's project is known by, most specific first
+	Projects  []string
+	Version   string
+	BuildDate time.Time
+	// Provenance of the build as far as the provider sends it
+	RepositoryURL string
+	CommitSHA     string
+	Branch        string
+	PipelineURL   string
+	// Ready is false for events that do not describe a successful build, such as pipelines that are still running
+	Ready  bool
+	Reason string
+}
+
+type BuildWebhookHandler struct {
+	cfg   *config.Config
+	store repository.Store
+}
+
+func NewBuildWebhookHandler(cfg *config.Config, store repository.Store) *BuildWebhookHandler {
+	return &BuildWebhookHandler{cfg: cfg, store: store}
+}
+
+// POST /hooks/builds/:provider
+func (h *BuildWebhookHandler) ReceiveBuildWebhook(c *gin.Context) {
+	provider := c.Param("provider")
+
+	secret, supported := h.secretFor(provider)
+	if !supported {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Unknown webhook provider"})
+		return
+	}
+	if secret == "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Webhook provider is not configured"})
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxWebhookPayloadSize))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read webhook payload"})
+		return
+	}
+
+	if !verifyWebhookRequest(provider, secret, c.Request.Header, body) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid webhook signature"})
+		return
+	}
+
+	var event *buildEvent
+	switch provider {
+	case "github":
+		event, err = parseGitHubEvent(c.Request.Header, body)
+	case "gitlab":
+		event, err = parseGitLabEvent(c.Request.Header, body)
+	case "jenkins":
+		event, err = parseJenkinsEvent(c.Request.Header, body)
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if event.DeliveryID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Webhook payload has no delivery ID"})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	// Redeliveries return the result of the first delivery
+	if existing, err := h.store.WebhookDeliveries().Get(ctx, provider, event.DeliveryID); err == nil {
+		c.JSON(http.StatusOK, h.webhookDeliveryResponse(ctx, existing, true))
+		return
+	} else if !errors.Is(err, repository.ErrNotFound) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check webhook delivery"})
+		return
+	}
+
+	if !event.Ready {
+		delivery := &domain.WebhookDelivery{
+			Provider:   provider,
+			DeliveryID: event.DeliveryID,
+			Status:     domain.WebhookDeliveryIgnored,
+			Message:    event.Reason,
+		}
+		if err := h.store.WebhookDeliveries().Create(ctx, delivery); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record webhook delivery"})
+			return
+		}
+		c.JSON(http.StatusAccepted, h.webhookDeliveryResponse(ctx, delivery, false))
+		return
+	}
+
+	// Unresolvable deliveries are not recorded so the provider can redeliver once the mapping is fixed
+	system, err := h.resolveSystem(ctx, event.Projects)
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+
+	if event.Version == "" {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Could not determine a build version from the webhook payload"})
+		return
+	}
+
+	delivery := &domain.WebhookDelivery{
+		Provider:   provider,
+		DeliveryID: event.DeliveryID,
+		Status:     domain.WebhookDeliveryProcessed,
+	}
+	status := http.StatusCreated
+
+	err = h.store.Transaction(ctx, func(tx repository.Store) error {
+		// A build that was already registered for this version is reused
+		build, err := tx.Builds().FindByVersion(ctx, system.ID, event.Version)
+		if errors.Is(err, repository.ErrNotFound) {
+			build = &domain.Build{
+				SystemID:      system.ID,
+				Version:       event.Version,
+				BuildDate:     event.BuildDate,
+				RepositoryURL: event.RepositoryURL,
+				CommitSHA:     mapper.NormalizeDigest(event.CommitSHA),
+				Branch:        event.Branch,
+				PipelineURL:   event.PipelineURL,
+			}
+			// Provenance is best effort, a provider sending it in an unexpected format must not keep the build out
+			if build.ValidateProvenance() != nil {
+				build.RepositoryURL, build.CommitSHA, build.Branch, build.PipelineURL = "", "", "", ""
+			}
+			if err := service.NewBuildService(tx).Create(ctx, build); err != nil {
+				return err
+			}
+			c.Set(audit.ActorKey, "webhook:"+provider)
+			if err := audit.Record(tx, c, domain.AuditEntityBuild, build.ID, nil, mapper.BuildDomainToAPI(build)); err != nil {
+				return err
+			}
+			if err := publishBuild(ctx, tx, domain.EventBuildRegistered, build); err != nil {
+				return err
+			}
+			delivery.Message = "Build registered"
+		} else if err != nil {
+			return err
+		} else {
+			delivery.Message = "Build already registered"
+			status = http.StatusOK
+		}
+
+		delivery.BuildID = &build.ID
+		return tx.WebhookDeliveries().Create(ctx, delivery)
+	})
+	// Rule violations are not recorded either, for the same reason
+	var domainErr *domain.Error
+	if errors.As(err, &domainErr) {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": domainErr.Error()})
+		return
+	}
+	if err != nil {
+		// A concurrent redelivery may have won the race on the unique delivery index
+		if existing, err := h.store.WebhookDeliveries().Get(ctx, provider, event.DeliveryID); err == nil {
+			c.JSON(http.StatusOK, h.webhookDeliveryResponse(ctx, existing, true))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to register build"})
+		return
+	}
+
+	c.JSON(status, h.webhookDeliveryResponse(ctx, delivery, false))
+}
+
+// Helper function to look up the secret of a provider
+func (h *BuildWebhookHandler) secretFor(provider string) (string, bool) {
+	switch provider {
+	case "github":
+		return h.cfg.Webhooks.GitHubSecret, true
+	case "gitlab":
+		return h.cfg.Webhooks.GitLabSecret, true
+	case "jenkins":
+		return h.cfg.Webhooks.JenkinsSecret, true
+	}
+	return "", false
+}
+
+// Helper function to find the system a CI project builds, using the configured mapping first
+func (h *BuildWebhookHandler) resolveSystem(ctx context.Context, projects []string) (*domain.System, error) {
+	var names []string
+	for _, project := range projects {
+		if name, found := h.cfg.Webhooks.SystemMapping[project]; found {
+			names = append(names, name)
+		}
+	}
+	names = append(names, projects...)
+
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		systems, err := h.store.Systems().List(ctx, repository.SystemFilter{Name: name})
+		if err != nil {
+			return nil, err
+		}
+		if len(systems) > 0 {
+			return &systems[0], nil
+		}
+	}
+	return nil, fmt.Errorf("No system found for project %s. Add it to WEBHOOK_SYSTEM_MAPPING", strings.Join(projects, " / "))
+}
+
+// Helper function to build the response for a stored delivery
+func (h *BuildWebhookHandler) webhookDeliveryResponse(ctx context.Context, delivery *domain.WebhookDelivery, duplicate bool) api.BuildWebhookResponse {
+	response := api.BuildWebhookResponse{
+		DeliveryID: delivery.DeliveryID,
+		Status:     string(delivery.Status),
+		Message:    delivery.Message,
+		Duplicate:  duplicate,
+	}
+
+	if delivery.BuildID != nil {
+		if build, err := h.store.Builds().Get(ctx, *delivery.BuildID); err == nil {
+			response.Build = mapper.BuildDomainToAPI(build)
+		}
+	}
+
+	return response
+}
+
+// Helper function to check that a webhook request was sent by the configured provider.
+// GitHub and Jenkins sign the payload with HMAC-SHA256, GitLab sends its secret token.
+func verifyWebhookRequest(provider, secret string, header http.Header, body []byte) bool {
+	switch provider {
+	case "github":
+		return verifyHMACSignature(secret, header.Get("X-Hub-Signature-256"), body)
+	case "jenkins":
+		return verifyHMACSignature(secret, header.Get("X-Jenkins-Signature-256"), body)
+	case "gitlab":
+		token := header.Get("X-Gitlab-Token")
+		return token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(secret)) == 1
+	}
+	return false
+}
+
+// Helper function to verify a "sha256=<hex>" HMAC signature of the payload
+func verifyHMACSignature(secret, signature string, body []byte) bool {
+	signature = strings.TrimPrefix(signature, "sha256=")
+	expected, err := hex.DecodeString(signature)
+	if err != nil || len(expected) == 0 {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// Helper function to parse a GitHub Actions workflow_run event
+func parseGitHubEvent(header http.Header, body []byte) (*buildEvent, error) {
+	event := &buildEvent{DeliveryID: header.Get("X-GitHub-Delivery")}
+
+	if eventType := header.Get("X-GitHub-Event"); eventType != "workflow_run" {
+		event.Reason = fmt.Sprintf("Event type '%s' does not register builds", eventType)
+		return event, nil
+	}
+
+	var payload api.GitHubWorkflowRunEvent
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("Invalid GitHub workflow_run payload: %v", err)
+	}
+
+	run := payload.WorkflowRun
+	event.Projects = []string{payload.Repository.FullName, payload.Repository.Name}
+	event.BuildDate = run.UpdatedAt
+	event.Version = versionFromRef(run.HeadBranch, run.RunNumber)
+	event.RepositoryURL = payload.Repository.HTMLURL
+	event.CommitSHA = run.HeadSHA
+	event.Branch = run.HeadBranch
+	event.PipelineURL = run.HTMLURL
+
+	if payload.Action != "completed" || run.Conclusion != "success" {
+		event.Reason = fmt.Sprintf("Workflow run is '%s' with conclusion '%s'", payload.Action, run.Conclusion)
+		return event, nil
+	}
+
+	event.Ready = true
+	return event, nil
+}
+
+// Helper function to parse a GitLab pipeline event
+func parseGitLabEvent(header http.Header, body []byte) (*buildEvent, error) {
+	var payload api.GitLabPipelineEvent
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("Invalid GitLab pipeline payload: %v", err)
+	}
+
+	pipeline := payload.ObjectAttributes
+	event := &buildEvent{DeliveryID: header.Get("X-Gitlab-Event-UUID")}
+	if event.DeliveryID == "" && pipeline.ID != 0 {
+		event.DeliveryID = fmt.Sprintf("pipeline-%d-%s", pipeline.ID, pipeline.Status)
+	}
+
+	if payload.ObjectKind != "pipeline" {
+		event.Reason = fmt.Sprintf("Event kind '%s' does not register builds", payload.ObjectKind)
+		return event, nil
+	}
+
+	event.Projects = []string{payload.Project.PathWithNamespace, payload.Project.Name}
+	event.BuildDate = parseGitLabTime(pipeline.FinishedAt)
+	if pipeline.Tag {
+		event.Version = versionFromRef(pipeline.Ref, pipeline.IID)
+	} else {
+		event.Version = strconv.FormatInt(pipeline.IID, 10)
+		event.Branch = pipeline.Ref
+	}
+	event.RepositoryURL = payload.Project.WebURL
+	event.CommitSHA = pipeline.SHA
+	event.PipelineURL = pipeline.URL
+
+	if pipeline.Status != "success" {
+		event.Reason = fmt.Sprintf("Pipeline status is '%s'", pipeline.Status)
+		return event, nil
+	}
+
+	event.Ready = true
+	return event, nil
+}
+
+// Helper function to parse a Jenkins notification plugin event
+func parseJenkinsEvent(header http.Header, body []byte) (*buildEvent, error) {
+	var payload api.JenkinsNotification
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("Invalid Jenkins notification payload: %v", err)
+	}
+
+	build := payload.Build
+	event := &buildEvent{DeliveryID: header.Get("X-Jenkins-Delivery")}
+	if event.DeliveryID == "" && payload.Name != "" {
+		event.DeliveryID = fmt.Sprintf("%s#%d:%s", payload.Name, build.Number, build.Phase)
+	}
+
+	event.Projects = []string{payload.Name}
+	event.BuildDate = time.Now()
+	event.CommitSHA = build.SCM.Commit
+	event.Branch = build.SCM.Branch
+	event.PipelineURL = build.FullURL
+	if v := build.Parameters["VERSION"]; v != "" {
+		event.Version = v
+	} else {
+		event.Version = strconv.FormatInt(build.Number, 10)
+	}
+
+	if (build.Phase != "COMPLETED" && build.Phase != "FINALIZED") || build.Status != "SUCCESS" {
+		event.Reason = fmt.Sprintf("Build phase is '%s' with status '%s'", build.Phase, build.Status)
+		return event, nil
+	}
+
+	event.Ready = true
+	return event, nil
+}
+
+// Helper function to use a git ref as the version when it looks like one, e.g. a v1.2.3 tag
+func versionFromRef(ref string, runNumber int64) string {
+	ref = strings.TrimPrefix(ref, "refs/tags/")
+	if ref != "" && version.Parse(ref).Scheme != version.SchemeUnknown {
+		return ref
+	}
+	return strconv.FormatInt(runNumber, 10)
+}
+
+// Helper function to parse the timestamps GitLab sends, falling back to the current time
+func parseGitLabTime(value string) time.Time {
+	for _, layout := range []string{"2006-01-02 15:04:05 MST", time.RFC3339} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t
+		}
+	}
+	return time.Now()
+}
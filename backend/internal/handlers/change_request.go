@@ -0,0 +1,548 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"release-management/internal/audit"
+	"release-management/internal/config"
+	"release-management/internal/middleware"
+	"release-management/internal/models/api"
+	"release-management/internal/models/domain"
+	"release-management/internal/models/mapper"
+	"release-management/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ChangeRequestHandler struct {
+	cfg   *config.Config
+	store repository.Store
+}
+
+func NewChangeRequestHandler(cfg *config.Config, store repository.Store) *ChangeRequestHandler {
+	return &ChangeRequestHandler{cfg: cfg, store: store}
+}
+
+// GET /change-requests
+func (h *ChangeRequestHandler) GetChangeRequests(c *gin.Context) {
+	filter := repository.ChangeRequestFilter{
+		Status:        domain.ChangeRequestStatus(c.Query("status")),
+		EnvironmentID: c.Query("environment_id"),
+	}
+	if filter.Status != "" && !filter.Status.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'status' parameter. Valid values are: pending, approved, rejected, applied, expired"})
+		return
+	}
+	if authorID := c.Query("author_id"); authorID != "" {
+		id, err := strconv.ParseUint(authorID, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'author_id' parameter"})
+			return
+		}
+		filter.AuthorID = uint(id)
+	}
+
+	// Newest first unless sorted otherwise
+	page, ok := pageRequest(c, repository.ChangeRequestSortFields, repository.Sort{Field: "created_at", Desc: true})
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := h.store.ChangeRequests().Expire(ctx, time.Now()); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch change requests"})
+		return
+	}
+	requests, err := h.store.ChangeRequests().List(ctx, filter, page)
+	if err != nil {
+		respondWithListError(c, err, "Failed to fetch change requests")
+		return
+	}
+
+	respondWithPage(c, requests, mapper.ChangeRequestDomainToAPI)
+}
+
+// GET /change-requests/:id
+func (h *ChangeRequestHandler) GetChangeRequest(c *gin.Context) {
+	ctx := c.Request.Context()
+	if err := h.store.ChangeRequests().Expire(ctx, time.Now()); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch change request"})
+		return
+	}
+
+	request, err := h.store.ChangeRequests().Get(ctx, c.Param("id"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Change request not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch change request"})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ChangeRequestDomainToAPI(request))
+}
+
+// POST /change-requests
+func (h *ChangeRequestHandler) CreateChangeRequest(c *gin.Context) {
+	var req api.ChangeRequestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// The approvals a request needs are fixed when it is made, so changing the policy does not affect open requests
+	request := mapper.ChangeRequestAPIToDomain(&req)
+	request.Status = domain.ChangeRequestStatusPending
+	request.RequiredApprovals = h.requiredApprovals()
+	request.ApproverRole = h.approverRole()
+	request.AuthorID = c.GetUint("userID")
+	request.ExpiresAt = time.Now().Add(h.ttl())
+
+	ctx := c.Request.Context()
+	if err := h.store.Transaction(ctx, func(tx repository.Store) error {
+		env, err := tx.Environments().Get(ctx, request.EnvironmentID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return domain.ErrChangeRequestEnvironmentNotFound
+			}
+			return err
+		}
+		allowed, err := canChangeEnvironment(c, tx, env)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return domain.ErrChangeRequestNotPermitted
+		}
+
+		// Record the versions the systems run now, so that approvers see the whole change
+		for i := range request.Changes {
+			change := &request.Changes[i]
+			current, err := tx.Environments().GetSystem(ctx, env.ID, change.SystemID)
+			if err == nil {
+				change.FromVersion = current.Version
+			} else if !errors.Is(err, repository.ErrNotFound) {
+				return err
+			}
+		}
+		if err := request.Validate(); err != nil {
+			return err
+		}
+		for _, change := range request.Changes {
+			if err := checkChangeRequestVersion(c, tx, change); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.ChangeRequests().Create(ctx, request); err != nil {
+			return err
+		}
+		return audit.Record(tx, c, domain.AuditEntityChangeRequest, request.ID, nil, mapper.ChangeRequestDomainToAPI(request))
+	}); err != nil {
+		respondWithError(c, err, "Failed to create change request")
+		return
+	}
+
+	c.JSON(http.StatusCreated, mapper.ChangeRequestDomainToAPI(request))
+}
+
+// POST /change-requests/:id/approve
+func (h *ChangeRequestHandler) ApproveChangeRequest(c *gin.Context) {
+	h.review(c, "Failed to approve change request", func(tx repository.Store, request *domain.ChangeRequest) error {
+		approval, err := request.Approve(c.GetUint("userID"), time.Now())
+		if err != nil {
+			return err
+		}
+		if err := tx.ChangeRequests().AddApproval(c.Request.Context(), approval); err != nil {
+			if errors.Is(err, repository.ErrDuplicate) {
+				return domain.ErrChangeRequestAlreadyApproved
+			}
+			return err
+		}
+		request.Approvals[len(request.Approvals)-1] = *approval
+		return nil
+	})
+}
+
+// POST /change-requests/:id/reject
+func (h *ChangeRequestHandler) RejectChangeRequest(c *gin.Context) {
+	var req api.ChangeRequestRejectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.review(c, "Failed to reject change request", func(tx repository.Store, request *domain.ChangeRequest) error {
+		return request.Reject(c.GetUint("userID"), strings.TrimSpace(req.Reason), time.Now())
+	})
+}
+
+// POST /change-requests/:id/comments
+func (h *ChangeRequestHandler) CommentOnChangeRequest(c *gin.Context) {
+	var req api.ChangeRequestCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Comment cannot be empty"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	request, err := h.store.ChangeRequests().Get(ctx, c.Param("id"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Change request not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch change request"})
+		return
+	}
+
+	// Comments stay possible after a request is closed, e.g. to follow up on an applied change
+	comment := &domain.ChangeRequestComment{ChangeRequestID: request.ID, UserID: c.GetUint("userID"), Body: body}
+	if err := h.store.ChangeRequests().AddComment(ctx, comment); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add comment"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, api.ChangeRequestCommentResponse{
+		ID:        comment.ID,
+		UserID:    comment.UserID,
+		Body:      comment.Body,
+		CreatedAt: comment.CreatedAt,
+	})
+}
+
+// POST /change-requests/:id/apply
+func (h *ChangeRequestHandler) ApplyChangeRequest(c *gin.Context) {
+	ctx := c.Request.Context()
+	var request *domain.ChangeRequest
+
+	// Every change goes through or none does
+	if err := h.store.Transaction(ctx, func(tx repository.Store) error {
+		var err error
+		if request, err = lockChangeRequest(ctx, tx, c.Param("id")); err != nil {
+			return err
+		}
+		before := mapper.ChangeRequestDomainToAPI(request)
+
+		env, err := tx.Environments().Get(ctx, request.EnvironmentID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return domain.ErrChangeRequestEnvironmentNotFound
+			}
+			return err
+		}
+		allowed, err := canChangeEnvironment(c, tx, env)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return domain.ErrChangeRequestNotPermitted
+		}
+		if err := request.Apply(c.GetUint("userID"), time.Now()); err != nil {
+			return err
+		}
+
+		for _, change := range request.Changes {
+			if err := applyChangeRequestChange(c, tx, env.ID, change); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.ChangeRequests().Update(ctx, request); err != nil {
+			return err
+		}
+		return audit.Record(tx, c, domain.AuditEntityChangeRequest, request.ID, before, mapper.ChangeRequestDomainToAPI(request))
+	}); err != nil {
+		respondWithError(c, err, "Failed to apply change request")
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ChangeRequestDomainToAPI(request))
+}
+
+// Helper function to approve or reject a change request. Reviewers need at least the approver role of the request
+// and must sign in themselves, API tokens cannot review.
+func (h *ChangeRequestHandler) review(c *gin.Context, fallback string, decide func(tx repository.Store, request *domain.ChangeRequest) error) {
+	if c.GetString("authMethod") == middleware.AuthMethodAPIToken {
+		respondWithError(c, domain.ErrChangeRequestApprovalByToken, fallback)
+		return
+	}
+
+	ctx := c.Request.Context()
+	var request *domain.ChangeRequest
+	if err := h.store.Transaction(ctx, func(tx repository.Store) error {
+		var err error
+		if request, err = lockChangeRequest(ctx, tx, c.Param("id")); err != nil {
+			return err
+		}
+		before := mapper.ChangeRequestDomainToAPI(request)
+
+		role, err := middleware.UserRole(c, tx)
+		if err != nil {
+			return err
+		}
+		if !role.AtLeast(request.ApproverRole) {
+			return domain.ErrChangeRequestApproverRole.WithDetail("approver_role", request.ApproverRole)
+		}
+		if err := decide(tx, request); err != nil {
+			return err
+		}
+
+		if err := tx.ChangeRequests().Update(ctx, request); err != nil {
+			return err
+		}
+		return audit.Record(tx, c, domain.AuditEntityChangeRequest, request.ID, before, mapper.ChangeRequestDomainToAPI(request))
+	}); err != nil {
+		respondWithError(c, err, fallback)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ChangeRequestDomainToAPI(request))
+}
+
+// Helper function to lock a change request for the rest of a transaction and load it with its stored approvals,
+// so concurrent reviews and applies see each other's decisions instead of overwriting them
+func lockChangeRequest(ctx context.Context, tx repository.Store, id string) (*domain.ChangeRequest, error) {
+	if err := tx.ChangeRequests().Lock(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, domain.ErrChangeRequestNotFound
+		}
+		return nil, err
+	}
+	request, err := tx.ChangeRequests().Get(ctx, id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, domain.ErrChangeRequestNotFound
+	}
+	return request, err
+}
+
+// Helper function to apply one change of a request. The system must still run the version the request was made against,
+// and the change is recorded like any other, so freeze windows apply.
+func applyChangeRequestChange(c *gin.Context, tx repository.Store, environmentID string, change domain.ChangeRequestChange) error {
+	ctx := c.Request.Context()
+	envSystem, err := tx.Environments().GetSystem(ctx, environmentID, change.SystemID)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return err
+	}
+
+	currentVersion := ""
+	if envSystem != nil {
+		currentVersion = envSystem.Version
+	}
+	if currentVersion != change.FromVersion {
+		return domain.ErrChangeRequestStale.
+			WithDetail("system_id", change.SystemID).
+			WithDetail("expected_version", change.FromVersion).
+			WithDetail("current_version", currentVersion)
+	}
+	if err := checkChangeRequestVersion(c, tx, change); err != nil {
+		return err
+	}
+
+	if envSystem == nil {
+		envSystem = &domain.EnvironmentSystem{EnvironmentID: environmentID, SystemID: change.SystemID, Version: change.ToVersion}
+		if err := tx.Environments().AddSystem(ctx, envSystem); err != nil {
+			return err
+		}
+		if err := auditEnvironmentSystem(tx, c, nil, envSystem); err != nil {
+			return err
+		}
+	} else {
+		before := *envSystem
+		envSystem.Version = change.ToVersion
+		if err := tx.Environments().UpdateSystem(ctx, envSystem); err != nil {
+			return err
+		}
+		if err := auditEnvironmentSystem(tx, c, &before, envSystem); err != nil {
+			return err
+		}
+	}
+
+	return recordDeployment(c, tx, envSystem, change.FromVersion, domain.DeploymentSourceChangeRequest)
+}
+
+// Helper function to check that the system of a change exists and has a build of the version it moves to
+func checkChangeRequestVersion(c *gin.Context, tx repository.Store, change domain.ChangeRequestChange) error {
+	ctx := c.Request.Context()
+	if _, err := tx.Systems().Get(ctx, change.SystemID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return domain.ErrChangeRequestSystemNotFound.WithDetail("system_id", change.SystemID)
+		}
+		return err
+	}
+	isValid, err := isValidVersionForSystem(ctx, tx, change.SystemID, change.ToVersion)
+	if err != nil {
+		return err
+	}
+	if !isValid {
+		return domain.ErrChangeRequestVersionNotFound.
+			WithDetail("system_id", change.SystemID).
+			WithDetail("version", change.ToVersion)
+	}
+	return nil
+}
+
+// Helper function to check that the caller may change the systems of an environment, including the scopes of an API token
+func canChangeEnvironment(c *gin.Context, tx repository.Store, env *domain.Environment) (bool, error) {
+	if c.GetString("authMethod") == middleware.AuthMethodAPIToken {
+		token, ok := c.MustGet("apiToken").(*domain.APIToken)
+		if !ok || !token.HasScope(domain.TokenScope(domain.ResourceEnvironments+":write")) {
+			return false, nil
+		}
+	}
+	groupID := ""
+	if env.EnvironmentGroupID != nil {
+		groupID = *env.EnvironmentGroupID
+	}
+	return middleware.Authorize(c, tx, domain.ResourceEnvironments, domain.ActionWrite, groupID)
+}
+
+// Helper function to reject a version change made directly to an environment whose type only changes through change requests
+func (h *EnvironmentHandler) allowDirectChange(c *gin.Context, environmentID string) bool {
+	if len(h.cfg.ChangeRequests.EnvironmentTypes) == 0 {
+		return true
+	}
+
+	env, err := h.store.Environments().Get(c.Request.Context(), environmentID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Environment not found"})
+			return false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch environment"})
+		return false
+	}
+	if slices.Contains(h.cfg.ChangeRequests.EnvironmentTypes, string(env.Type)) {
+		respondWithError(c, domain.ErrChangeRequestRequired.WithDetail("environment_type", env.Type), "Failed to check change requests")
+		return false
+	}
+	return true
+}
+
+// Helper function to hold an import to the same rule as direct changes. The version changes it makes to environments whose type
+// only changes through change requests must be described exactly by approved change requests referenced with the change_request
+// query parameter, which are applied along with the import. Environments are gated by their type before or after the import.
+func (h *ManifestHandler) applyImportChangeRequests(c *gin.Context, tx repository.Store, plan *domain.ManifestPlan, environments []domain.Environment) error {
+	types := h.cfg.ChangeRequests.EnvironmentTypes
+	if len(types) == 0 {
+		return nil
+	}
+
+	gated := make(map[string]domain.EnvironmentType)
+	for _, env := range environments {
+		if slices.Contains(types, string(env.Type)) {
+			gated[env.ID] = env.Type
+		}
+	}
+	for _, change := range plan.Changes {
+		if env, ok := change.After.(*domain.Environment); ok && slices.Contains(types, string(env.Type)) {
+			gated[env.ID] = env.Type
+		}
+	}
+
+	// Collect the version changes to gated environments in the order of the plan
+	var environmentIDs []string
+	changes := make(map[string][]domain.ChangeRequestChange)
+	for _, change := range plan.Changes {
+		var environmentID string
+		var versionChange domain.ChangeRequestChange
+		if before, ok := change.Before.(*domain.EnvironmentSystem); ok {
+			environmentID, versionChange.SystemID, versionChange.FromVersion = before.EnvironmentID, before.SystemID, before.Version
+		}
+		if after, ok := change.After.(*domain.EnvironmentSystem); ok {
+			environmentID, versionChange.SystemID, versionChange.ToVersion = after.EnvironmentID, after.SystemID, after.Version
+		}
+		if _, ok := gated[environmentID]; !ok || versionChange.FromVersion == versionChange.ToVersion {
+			continue
+		}
+		if _, ok := changes[environmentID]; !ok {
+			environmentIDs = append(environmentIDs, environmentID)
+		}
+		changes[environmentID] = append(changes[environmentID], versionChange)
+	}
+
+	ctx := c.Request.Context()
+	var requests []*domain.ChangeRequest
+	byEnvironment := make(map[string]*domain.ChangeRequest)
+	for _, id := range c.QueryArray("change_request") {
+		request, err := lockChangeRequest(ctx, tx, id)
+		if err != nil {
+			if errors.Is(err, domain.ErrChangeRequestNotFound) {
+				return domain.ErrChangeRequestNotFound.WithDetail("change_request_id", id)
+			}
+			return err
+		}
+		if !sameChanges(request.Changes, changes[request.EnvironmentID]) {
+			return domain.ErrChangeRequestMismatch.WithDetail("change_request_id", request.ID)
+		}
+		requests = append(requests, request)
+		byEnvironment[request.EnvironmentID] = request
+	}
+	for _, environmentID := range environmentIDs {
+		if byEnvironment[environmentID] == nil {
+			return domain.ErrChangeRequestRequired.
+				WithDetail("environment_id", environmentID).
+				WithDetail("environment_type", gated[environmentID])
+		}
+	}
+
+	for _, request := range requests {
+		before := mapper.ChangeRequestDomainToAPI(request)
+		if err := request.Apply(c.GetUint("userID"), time.Now()); err != nil {
+			return err
+		}
+		if err := tx.ChangeRequests().Update(ctx, request); err != nil {
+			return err
+		}
+		if err := audit.Record(tx, c, domain.AuditEntityChangeRequest, request.ID, before, mapper.ChangeRequestDomainToAPI(request)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Helper function to check if the changes of a request are exactly the version changes made to its environment
+func sameChanges(requested, made []domain.ChangeRequestChange) bool {
+	if len(requested) != len(made) {
+		return false
+	}
+	for _, change := range made {
+		if !slices.Contains(requested, change) {
+			return false
+		}
+	}
+	return true
+}
+
+// Helper function to return the approvals new change requests need, at least one
+func (h *ChangeRequestHandler) requiredApprovals() int {
+	return max(h.cfg.ChangeRequests.RequiredApprovals, 1)
+}
+
+// Helper function to return the role that may approve new change requests, release managers unless configured otherwise
+func (h *ChangeRequestHandler) approverRole() domain.Role {
+	if role := domain.Role(h.cfg.ChangeRequests.ApproverRole); role.IsValid() {
+		return role
+	}
+	return domain.RoleReleaseManager
+}
+
+// Helper function to return how long new change requests stay open, three days unless configured otherwise
+func (h *ChangeRequestHandler) ttl() time.Duration {
+	if h.cfg.ChangeRequests.TTL > 0 {
+		return h.cfg.ChangeRequests.TTL
+	}
+	return 72 * time.Hour
+}
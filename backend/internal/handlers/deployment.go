@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"release-management/internal/events"
+	"release-management/internal/middleware"
+	"release-management/internal/models/api"
+	"release-management/internal/models/domain"
+	"release-management/internal/models/mapper"
+	"release-management/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetEnvironmentHistory gets the deployment history of every system in an environment
+func (h *EnvironmentHandler) GetEnvironmentHistory(c *gin.Context) {
+	h.respondWithHistory(c, "")
+}
+
+// GetEnvironmentSystemHistory gets the deployment history of a single system in an environment
+func (h *EnvironmentHandler) GetEnvironmentSystemHistory(c *gin.Context) {
+	h.respondWithHistory(c, c.Param("systemId"))
+}
+
+// Helper function to respond with the deployment history of an environment, optionally limited to one system
+func (h *EnvironmentHandler) respondWithHistory(c *gin.Context, systemID string) {
+	ctx := c.Request.Context()
+
+	// Check if environment exists
+	environment, err := h.store.Environments().Get(ctx, c.Param("id"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Environment not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch environment"})
+		return
+	}
+
+	filter, ok := deploymentHistoryFilter(c)
+	if !ok {
+		return
+	}
+	filter.EnvironmentID = environment.ID
+	filter.SystemID = systemID
+
+	page, ok := pageRequest(c, repository.DeploymentSortFields, repository.Sort{Field: "created_at", Desc: true})
+	if !ok {
+		return
+	}
+
+	deployments, err := h.store.Deployments().ListPage(ctx, filter, page)
+	if err != nil {
+		respondWithListError(c, err, "Failed to fetch deployment history")
+		return
+	}
+
+	respondWithPage(c, deployments, mapper.DeploymentDomainToAPI)
+}
+
+// Helper function to build the history filter from the since/until query parameters
+func deploymentHistoryFilter(c *gin.Context) (repository.DeploymentFilter, bool) {
+	var filter repository.DeploymentFilter
+
+	if since := c.Query("since"); since != "" {
+		sinceTime, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'since' parameter. Use RFC3339 format"})
+			return filter, false
+		}
+		filter.Since = &sinceTime
+	}
+
+	if until := c.Query("until"); until != "" {
+		untilTime, err := time.Parse(time.RFC3339, until)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'until' parameter. Use RFC3339 format"})
+			return filter, false
+		}
+		filter.Until = &untilTime
+	}
+
+	return filter, true
+}
+
+// Helper function to append a version change made by the caller to the deployment history and publish it to webhook subscriptions.
+// Changes that land in an active freeze window fail unless the caller overrides the freeze.
+func recordDeployment(c *gin.Context, tx repository.Store, envSystem *domain.EnvironmentSystem, oldVersion string, source domain.DeploymentSource) error {
+	ctx := c.Request.Context()
+	if err := enforceFreezeWindows(c, tx, envSystem.EnvironmentID, envSystem.SystemID, oldVersion, envSystem.Version); err != nil {
+		return err
+	}
+
+	deployment := &domain.Deployment{
+		EnvironmentID: envSystem.EnvironmentID,
+		SystemID:      envSystem.SystemID,
+		OldVersion:    oldVersion,
+		NewVersion:    envSystem.Version,
+		Source:        source,
+	}
+
+	if userID := c.GetUint("userID"); userID != 0 {
+		deployment.UserID = &userID
+	}
+
+	if envSystem.Version != "" {
+		build, err := tx.Builds().FindByVersion(ctx, envSystem.SystemID, envSystem.Version)
+		if err == nil {
+			deployment.BuildID = &build.ID
+		} else if !errors.Is(err, repository.ErrNotFound) {
+			return err
+		}
+	}
+
+	if err := tx.Deployments().Create(ctx, deployment); err != nil {
+		return err
+	}
+	return publishEnvironmentSystemChanged(ctx, tx, &api.EnvironmentSystemChangedEvent{
+		EnvironmentID: deployment.EnvironmentID,
+		SystemID:      deployment.SystemID,
+		OldVersion:    deployment.OldVersion,
+		NewVersion:    deployment.NewVersion,
+		BuildID:       deployment.BuildID,
+		UserID:        deployment.UserID,
+		Source:        string(deployment.Source),
+	})
+}
+
+// Helper function to publish an environment_system.changed event with the release and group of its environment
+func publishEnvironmentSystemChanged(ctx context.Context, tx repository.Store, event *api.EnvironmentSystemChangedEvent) error {
+	env, err := tx.Environments().Get(ctx, event.EnvironmentID)
+	if err == nil {
+		event.ReleaseID = env.ReleaseID
+		event.EnvironmentGroupID = env.EnvironmentGroupID
+	} else if !errors.Is(err, repository.ErrNotFound) {
+		return err
+	}
+	return events.Publish(ctx, tx, domain.EventEnvironmentSystemChanged, event)
+}
+
+// Helper function to determine whether a change was made by hand or by an automation client
+func deploymentSourceFromRequest(c *gin.Context) domain.DeploymentSource {
+	if c.GetString("authMethod") == middleware.AuthMethodAPIToken {
+		return domain.DeploymentSourceAPI
+	}
+	if source := domain.DeploymentSource(c.GetHeader("X-Deployment-Source")); source == domain.DeploymentSourceAPI {
+		return source
+	}
+	return domain.DeploymentSourceManual
+}
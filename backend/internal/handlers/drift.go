@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+
+	"release-management/internal/models/api"
+	"release-management/internal/models/domain"
+	"release-management/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetEnvironmentDrift reports how an environment differs from its release without changing anything
+func (h *EnvironmentHandler) GetEnvironmentDrift(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	// Check if environment exists
+	environment, err := h.store.Environments().Get(ctx, c.Param("id"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Environment not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch environment"})
+		return
+	}
+
+	report, _, err := computeEnvironmentDrift(ctx, h.store, environment)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to compute environment drift"})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// GET /environment-groups/:id/drift
+func (h *EnvironmentGroupHandler) GetEnvironmentGroupDrift(c *gin.Context) {
+	ctx := c.Request.Context()
+	group, err := h.store.EnvironmentGroups().Get(ctx, c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Environment Group not found"})
+		return
+	}
+
+	report := api.EnvironmentGroupDriftReport{
+		EnvironmentGroupID:   group.ID,
+		EnvironmentGroupName: group.Name,
+		InSync:               true,
+		Environments:         []api.EnvironmentDriftReport{},
+		Systems:              []api.SystemVersionMatrixEntry{},
+	}
+
+	matrix := make(map[string]*api.SystemVersionMatrixEntry)
+	for i := range group.Environments {
+		environment := &group.Environments[i]
+		envReport, envSystems, err := computeEnvironmentDrift(ctx, h.store, environment)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to compute environment drift"})
+			return
+		}
+
+		report.Environments = append(report.Environments, *envReport)
+		if !envReport.InSync {
+			report.InSync = false
+		}
+
+		// Line up the deployed versions of each system across the group
+		for _, envSystem := range envSystems {
+			entry, found := matrix[envSystem.SystemID]
+			if !found {
+				entry = &api.SystemVersionMatrixEntry{
+					SystemID:   envSystem.SystemID,
+					SystemName: systemName(envSystem.System),
+					Versions:   make(map[string]string),
+				}
+				matrix[envSystem.SystemID] = entry
+			}
+			entry.Versions[environment.ID] = envSystem.Version
+		}
+	}
+
+	for _, entry := range matrix {
+		entry.Consistent = true
+		var firstVersion *string
+		for _, version := range entry.Versions {
+			if firstVersion == nil {
+				v := version
+				firstVersion = &v
+			} else if *firstVersion != version {
+				entry.Consistent = false
+			}
+		}
+		report.Systems = append(report.Systems, *entry)
+	}
+
+	sort.Slice(report.Systems, func(i, j int) bool {
+		return report.Systems[i].SystemName < report.Systems[j].SystemName
+	})
+
+	c.JSON(http.StatusOK, report)
+}
+
+// Helper function to compare an environment's systems with the builds of its release
+func computeEnvironmentDrift(ctx context.Context, store repository.Store, environment *domain.Environment) (*api.EnvironmentDriftReport, []domain.EnvironmentSystem, error) {
+	release, err := store.Releases().Get(ctx, environment.ReleaseID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	builds, err := store.Builds().List(ctx, repository.BuildFilter{ReleaseID: release.ID})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	envSystems, err := store.Environments().ListSystems(ctx, environment.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	report := &api.EnvironmentDriftReport{
+		EnvironmentID:     environment.ID,
+		EnvironmentName:   environment.Name,
+		EnvironmentType:   string(environment.Type),
+		ReleaseID:         release.ID,
+		ReleaseName:       release.Name,
+		MissingSystems:    []api.DriftItem{},
+		ExtraSystems:      []api.DriftItem{},
+		VersionMismatches: []api.DriftItem{},
+		UnreleasedBuilds:  []api.DriftItem{},
+	}
+
+	deployed := make(map[string]bool)
+	for _, envSystem := range envSystems {
+		deployed[envSystem.SystemID] = true
+
+		releaseVersion := getSystemVersionFromRelease(builds, envSystem.SystemID)
+		item := api.DriftItem{
+			SystemID:        envSystem.SystemID,
+			SystemName:      systemName(envSystem.System),
+			DeployedVersion: envSystem.Version,
+			ReleaseVersion:  releaseVersion,
+		}
+
+		if releaseVersion == "" {
+			report.ExtraSystems = append(report.ExtraSystems, item)
+		} else if releaseVersion != envSystem.Version {
+			report.VersionMismatches = append(report.VersionMismatches, item)
+		}
+
+		// Flag deployed versions whose build was never assigned to any release
+		if envSystem.Version != "" {
+			build, err := store.Builds().FindByVersion(ctx, envSystem.SystemID, envSystem.Version)
+			if err == nil {
+				if build.ReleaseID == nil || *build.ReleaseID == "" {
+					item.BuildID = build.ID
+					report.UnreleasedBuilds = append(report.UnreleasedBuilds, item)
+				}
+			} else if !errors.Is(err, repository.ErrNotFound) {
+				return nil, nil, err
+			}
+		}
+	}
+
+	for _, build := range builds {
+		if !deployed[build.SystemID] {
+			report.MissingSystems = append(report.MissingSystems, api.DriftItem{
+				SystemID:       build.SystemID,
+				SystemName:     systemName(build.System),
+				ReleaseVersion: build.Version,
+				BuildID:        build.ID,
+			})
+		}
+	}
+
+	report.InSync = len(report.MissingSystems) == 0 &&
+		len(report.ExtraSystems) == 0 &&
+		len(report.VersionMismatches) == 0 &&
+		len(report.UnreleasedBuilds) == 0
+
+	return report, envSystems, nil
+}
@@ -1,66 +1,72 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
 
-	"release-management/internal/database"
+	"release-management/internal/audit"
+	"release-management/internal/config"
+	"release-management/internal/events"
 	"release-management/internal/models/api"
-	"release-management/internal/models/db"
 	"release-management/internal/models/domain"
 	"release-management/internal/models/mapper"
+	"release-management/internal/repository"
 
 	"github.com/gin-gonic/gin"
 )
 
-type EnvironmentHandler struct{}
+type EnvironmentHandler struct {
+	cfg   *config.Config
+	store repository.Store
+}
 
-func NewEnvironmentHandler() *EnvironmentHandler {
-	return &EnvironmentHandler{}
+func NewEnvironmentHandler(cfg *config.Config, store repository.Store) *EnvironmentHandler {
+	return &EnvironmentHandler{cfg: cfg, store: store}
 }
 
 // Helper function to validate environment status
 func isValidEnvironmentStatus(status domain.EnvironmentStatus) bool {
-	switch status {
-	case domain.EnvStatusActive, domain.EnvStatusDecommissioned, domain.EnvStatusMaintenance, domain.EnvStatusPending:
-		return true
-	default:
-		return false
-	}
+	return status.IsValid()
 }
 
 // GET /environments
 func (h *EnvironmentHandler) GetEnvironments(c *gin.Context) {
-	var dbEnvs []db.Environment
-	if err := database.DB.Find(&dbEnvs).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch environments"})
+	filter := repository.EnvironmentFilter{
+		ReleaseID:          c.Query("release_id"),
+		EnvironmentGroupID: c.Query("environment_group_id"),
+		Type:               domain.EnvironmentType(c.Query("type")),
+	}
+	if status := c.Query("status"); status != "" {
+		if !isValidEnvironmentStatus(domain.EnvironmentStatus(status)) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'status' parameter. Valid values are: active, decommissioned, maintenance, pending"})
+			return
+		}
+		filter.Status = domain.EnvironmentStatus(status)
+	}
+
+	page, ok := pageRequest(c, repository.EnvironmentSortFields, repository.Sort{Field: "created_at"})
+	if !ok {
 		return
 	}
 
-	// Convert to API responses
-	apiEnvs := make([]api.EnvironmentResponse, len(dbEnvs))
-	for i, dbEnv := range dbEnvs {
-		domainEnv := mapper.EnvironmentDBToDomain(&dbEnv)
-		apiEnv := mapper.EnvironmentDomainToAPI(domainEnv)
-		apiEnvs[i] = *apiEnv
+	environments, err := h.store.Environments().ListPage(c.Request.Context(), filter, page)
+	if err != nil {
+		respondWithListError(c, err, "Failed to fetch environments")
+		return
 	}
 
-	c.JSON(http.StatusOK, apiEnvs)
+	respondWithPage(c, environments, mapper.EnvironmentDomainToAPI)
 }
 
 // GET /environments/:id
 func (h *EnvironmentHandler) GetEnvironment(c *gin.Context) {
-	id := c.Param("id")
-	var dbEnv db.Environment
-
-	if err := database.DB.First(&dbEnv, "id = ?", id).Error; err != nil {
+	env, err := h.store.Environments().Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Environment not found"})
 		return
 	}
 
-	domainEnv := mapper.EnvironmentDBToDomain(&dbEnv)
-	apiEnv := mapper.EnvironmentDomainToAPI(domainEnv)
-
-	c.JSON(http.StatusOK, apiEnv)
+	c.JSON(http.StatusOK, mapper.EnvironmentDomainToAPI(env))
 }
 
 // POST /environments
@@ -78,47 +84,49 @@ func (h *EnvironmentHandler) CreateEnvironment(c *gin.Context) {
 		return
 	}
 
+	ctx := c.Request.Context()
+
 	// Validate that Release exists
-	var release db.Release
-	if err := database.DB.First(&release, "id = ?", req.ReleaseID).Error; err != nil {
+	if _, err := h.store.Releases().Get(ctx, req.ReleaseID); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Release not found"})
 		return
 	}
 
 	// Validate that EnvironmentGroup exists if provided
 	if req.EnvironmentGroupID != nil && *req.EnvironmentGroupID != "" {
-		var envGroup db.EnvironmentGroup
-		if err := database.DB.First(&envGroup, "id = ?", *req.EnvironmentGroupID).Error; err != nil {
+		envGroup, err := h.store.EnvironmentGroups().Get(ctx, *req.EnvironmentGroupID)
+		if err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Environment Group not found"})
 			return
 		}
+		if !authorizeEnvironmentGroupChange(c, h.store, envGroup.ID) {
+			return
+		}
 	}
 
-	// Convert to domain and then to DB
-	domainEnv := mapper.EnvironmentAPIToDomain(&req)
-	dbEnv := mapper.EnvironmentDomainToDB(domainEnv)
+	env := mapper.EnvironmentAPIToDomain(&req)
 
-	if err := database.DB.Create(&dbEnv).Error; err != nil {
+	if err := h.store.Transaction(ctx, func(tx repository.Store) error {
+		if err := tx.Environments().Create(ctx, env); err != nil {
+			return err
+		}
+		if err := audit.Record(tx, c, domain.AuditEntityEnvironment, env.ID, nil, mapper.EnvironmentDomainToAPI(env)); err != nil {
+			return err
+		}
+		return publishEnvironment(ctx, tx, domain.EventEnvironmentCreated, env)
+	}); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create environment"})
 		return
 	}
 
-	// Load the created environment for response
-	database.DB.First(dbEnv, "id = ?", dbEnv.ID)
-
-	// Convert back for response
-	savedDomain := mapper.EnvironmentDBToDomain(dbEnv)
-	response := mapper.EnvironmentDomainToAPI(savedDomain)
-
-	c.JSON(http.StatusCreated, response)
+	c.JSON(http.StatusCreated, mapper.EnvironmentDomainToAPI(env))
 }
 
 // PUT /environments/:id
 func (h *EnvironmentHandler) UpdateEnvironment(c *gin.Context) {
-	id := c.Param("id")
-	var dbEnv db.Environment
-
-	if err := database.DB.First(&dbEnv, "id = ?", id).Error; err != nil {
+	ctx := c.Request.Context()
+	env, err := h.store.Environments().Get(ctx, c.Param("id"))
+	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Environment not found"})
 		return
 	}
@@ -139,9 +147,8 @@ func (h *EnvironmentHandler) UpdateEnvironment(c *gin.Context) {
 	}
 
 	// Validate that Release exists if it's being updated
-	if updateReq.ReleaseID != "" && updateReq.ReleaseID != dbEnv.ReleaseID {
-		var release db.Release
-		if err := database.DB.First(&release, "id = ?", updateReq.ReleaseID).Error; err != nil {
+	if updateReq.ReleaseID != "" && updateReq.ReleaseID != env.ReleaseID {
+		if _, err := h.store.Releases().Get(ctx, updateReq.ReleaseID); err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Release not found"})
 			return
 		}
@@ -150,52 +157,78 @@ func (h *EnvironmentHandler) UpdateEnvironment(c *gin.Context) {
 	// Validate that EnvironmentGroup exists if it's being updated
 	if updateReq.EnvironmentGroupID != nil && *updateReq.EnvironmentGroupID != "" {
 		// Check if EnvironmentGroupID is actually changing
-		if dbEnv.EnvironmentGroupID == nil || *updateReq.EnvironmentGroupID != *dbEnv.EnvironmentGroupID {
-			var envGroup db.EnvironmentGroup
-			if err := database.DB.First(&envGroup, "id = ?", *updateReq.EnvironmentGroupID).Error; err != nil {
+		if env.EnvironmentGroupID == nil || *updateReq.EnvironmentGroupID != *env.EnvironmentGroupID {
+			envGroup, err := h.store.EnvironmentGroups().Get(ctx, *updateReq.EnvironmentGroupID)
+			if err != nil {
 				c.JSON(http.StatusBadRequest, gin.H{"error": "Environment Group not found"})
 				return
 			}
+			if !authorizeEnvironmentGroupChange(c, h.store, envGroup.ID) {
+				return
+			}
 		}
 	}
 
+	before := mapper.EnvironmentDomainToAPI(env)
+
 	// Apply updates
 	if updateReq.Name != "" {
-		dbEnv.Name = updateReq.Name
+		env.Name = updateReq.Name
 	}
 	if updateReq.ReleaseID != "" {
-		dbEnv.ReleaseID = updateReq.ReleaseID
+		env.ReleaseID = updateReq.ReleaseID
 	}
 	if updateReq.Status != "" {
-		dbEnv.Status = updateReq.Status
+		env.Status = domain.EnvironmentStatus(updateReq.Status)
 	}
 	if updateReq.EnvironmentGroupID != nil {
-		dbEnv.EnvironmentGroupID = updateReq.EnvironmentGroupID
+		env.EnvironmentGroupID = updateReq.EnvironmentGroupID
 	}
 
-	if err := database.DB.Save(&dbEnv).Error; err != nil {
+	if err := h.store.Transaction(ctx, func(tx repository.Store) error {
+		if err := tx.Environments().Update(ctx, env); err != nil {
+			return err
+		}
+		if err := audit.Record(tx, c, domain.AuditEntityEnvironment, env.ID, before, mapper.EnvironmentDomainToAPI(env)); err != nil {
+			return err
+		}
+		return publishEnvironment(ctx, tx, domain.EventEnvironmentUpdated, env)
+	}); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update environment"})
 		return
 	}
 
-	// Load the updated environment for response
-	database.DB.First(&dbEnv, "id = ?", dbEnv.ID)
-
-	// Convert to response
-	domainEnv := mapper.EnvironmentDBToDomain(&dbEnv)
-	response := mapper.EnvironmentDomainToAPI(domainEnv)
-
-	c.JSON(http.StatusOK, response)
+	c.JSON(http.StatusOK, mapper.EnvironmentDomainToAPI(env))
 }
 
 // DELETE /environments/:id
 func (h *EnvironmentHandler) DeleteEnvironment(c *gin.Context) {
-	id := c.Param("id")
+	ctx := c.Request.Context()
+	env, err := h.store.Environments().Get(ctx, c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Environment not found"})
+		return
+	}
 
-	if err := database.DB.Delete(&db.Environment{}, "id = ?", id).Error; err != nil {
+	if err := h.store.Transaction(ctx, func(tx repository.Store) error {
+		if err := tx.Environments().Delete(ctx, env.ID); err != nil {
+			return err
+		}
+		if err := audit.Record(tx, c, domain.AuditEntityEnvironment, env.ID, mapper.EnvironmentDomainToAPI(env), nil); err != nil {
+			return err
+		}
+		return publishEnvironment(ctx, tx, domain.EventEnvironmentDeleted, env)
+	}); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete environment"})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Environment deleted successfully"})
 }
+
+// Helper function to publish an event about an environment, without its systems which have their own events
+func publishEnvironment(ctx context.Context, tx repository.Store, eventType domain.EventType, env *domain.Environment) error {
+	withoutSystems := *env
+	withoutSystems.EnvironmentSystems = nil
+	return events.Publish(ctx, tx, eventType, api.EnvironmentEvent{Environment: *mapper.EnvironmentDomainToAPI(&withoutSystems)})
+}
@@ -3,53 +3,48 @@ package handlers
 import (
 	"net/http"
 
-	"release-management/internal/database"
+	"release-management/internal/audit"
 	"release-management/internal/models/api"
-	"release-management/internal/models/db"
+	"release-management/internal/models/domain"
 	"release-management/internal/models/mapper"
+	"release-management/internal/repository"
 
 	"github.com/gin-gonic/gin"
 )
 
-type EnvironmentGroupHandler struct{}
+type EnvironmentGroupHandler struct {
+	store repository.Store
+}
 
-func NewEnvironmentGroupHandler() *EnvironmentGroupHandler {
-	return &EnvironmentGroupHandler{}
+func NewEnvironmentGroupHandler(store repository.Store) *EnvironmentGroupHandler {
+	return &EnvironmentGroupHandler{store: store}
 }
 
 // GET /environment-groups
 func (h *EnvironmentGroupHandler) GetEnvironmentGroups(c *gin.Context) {
-	var dbGroups []db.EnvironmentGroup
-	if err := database.DB.Preload("Environments").Find(&dbGroups).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch environment groups"})
+	page, ok := pageRequest(c, repository.EnvironmentGroupSortFields, repository.Sort{Field: "created_at"})
+	if !ok {
 		return
 	}
 
-	// Convert to API responses
-	apiGroups := make([]api.EnvironmentGroupResponse, len(dbGroups))
-	for i, dbGroup := range dbGroups {
-		domainGroup := mapper.EnvironmentGroupDBToDomain(&dbGroup)
-		apiGroup := mapper.EnvironmentGroupDomainToAPI(domainGroup)
-		apiGroups[i] = *apiGroup
+	groups, err := h.store.EnvironmentGroups().ListPage(c.Request.Context(), page)
+	if err != nil {
+		respondWithListError(c, err, "Failed to fetch environment groups")
+		return
 	}
 
-	c.JSON(http.StatusOK, apiGroups)
+	respondWithPage(c, groups, mapper.EnvironmentGroupDomainToAPI)
 }
 
 // GET /environment-groups/:id
 func (h *EnvironmentGroupHandler) GetEnvironmentGroup(c *gin.Context) {
-	id := c.Param("id")
-	var dbGroup db.EnvironmentGroup
-
-	if err := database.DB.Preload("Environments").First(&dbGroup, "id = ?", id).Error; err != nil {
+	group, err := h.store.EnvironmentGroups().Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Environment Group not found"})
 		return
 	}
 
-	domainGroup := mapper.EnvironmentGroupDBToDomain(&dbGroup)
-	apiGroup := mapper.EnvironmentGroupDomainToAPI(domainGroup)
-
-	c.JSON(http.StatusOK, apiGroup)
+	c.JSON(http.StatusOK, mapper.EnvironmentGroupDomainToAPI(group))
 }
 
 // POST /environment-groups
@@ -60,31 +55,34 @@ func (h *EnvironmentGroupHandler) CreateEnvironmentGroup(c *gin.Context) {
 		return
 	}
 
-	// Convert to domain and then to DB
-	domainGroup := mapper.EnvironmentGroupAPIToDomain(&req)
-	dbGroup := mapper.EnvironmentGroupDomainToDB(domainGroup)
+	group := mapper.EnvironmentGroupAPIToDomain(&req)
+	if err := domain.ValidatePromotionPath(group.PromotionPath); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if group.RequiredRole != "" && !authorizeRequiredRoleChange(c, h.store, group.RequiredRole) {
+		return
+	}
 
-	if err := database.DB.Create(&dbGroup).Error; err != nil {
+	ctx := c.Request.Context()
+	if err := h.store.Transaction(ctx, func(tx repository.Store) error {
+		if err := tx.EnvironmentGroups().Create(ctx, group); err != nil {
+			return err
+		}
+		return audit.Record(tx, c, domain.AuditEntityEnvironmentGroup, group.ID, nil, mapper.EnvironmentGroupDomainToAPI(group))
+	}); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create environment group"})
 		return
 	}
 
-	// Load relationships for response
-	database.DB.Preload("Environments").First(dbGroup, "id = ?", dbGroup.ID)
-
-	// Convert back for response
-	savedDomain := mapper.EnvironmentGroupDBToDomain(dbGroup)
-	response := mapper.EnvironmentGroupDomainToAPI(savedDomain)
-
-	c.JSON(http.StatusCreated, response)
+	c.JSON(http.StatusCreated, mapper.EnvironmentGroupDomainToAPI(group))
 }
 
 // PUT /environment-groups/:id
 func (h *EnvironmentGroupHandler) UpdateEnvironmentGroup(c *gin.Context) {
-	id := c.Param("id")
-	var dbGroup db.EnvironmentGroup
-
-	if err := database.DB.First(&dbGroup, "id = ?", id).Error; err != nil {
+	ctx := c.Request.Context()
+	group, err := h.store.EnvironmentGroups().Get(ctx, c.Param("id"))
+	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Environment Group not found"})
 		return
 	}
@@ -95,43 +93,64 @@ func (h *EnvironmentGroupHandler) UpdateEnvironmentGroup(c *gin.Context) {
 		return
 	}
 
+	before := mapper.EnvironmentGroupDomainToAPI(group)
+
 	// Apply updates
 	if updateReq.Name != "" {
-		dbGroup.Name = updateReq.Name
+		group.Name = updateReq.Name
 	}
 	if updateReq.Description != nil {
-		dbGroup.Description = updateReq.Description
+		group.Description = updateReq.Description
+	}
+	if updateReq.PromotionPath != nil {
+		promotionPath := mapper.PromotionPathAPIToDomain(updateReq.PromotionPath)
+		if err := domain.ValidatePromotionPath(promotionPath); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		group.PromotionPath = promotionPath
+	}
+	if updateReq.RequiredRole != nil && domain.Role(*updateReq.RequiredRole) != group.RequiredRole {
+		if !authorizeRequiredRoleChange(c, h.store, domain.Role(*updateReq.RequiredRole)) {
+			return
+		}
+		group.RequiredRole = domain.Role(*updateReq.RequiredRole)
 	}
 
-	if err := database.DB.Save(&dbGroup).Error; err != nil {
+	if err := h.store.Transaction(ctx, func(tx repository.Store) error {
+		if err := tx.EnvironmentGroups().Update(ctx, group); err != nil {
+			return err
+		}
+		return audit.Record(tx, c, domain.AuditEntityEnvironmentGroup, group.ID, before, mapper.EnvironmentGroupDomainToAPI(group))
+	}); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update environment group"})
 		return
 	}
 
-	// Load relationships for response
-	database.DB.Preload("Environments").First(&dbGroup, "id = ?", dbGroup.ID)
-
-	// Convert to response
-	domainGroup := mapper.EnvironmentGroupDBToDomain(&dbGroup)
-	response := mapper.EnvironmentGroupDomainToAPI(domainGroup)
-
-	c.JSON(http.StatusOK, response)
+	c.JSON(http.StatusOK, mapper.EnvironmentGroupDomainToAPI(group))
 }
 
 // DELETE /environment-groups/:id
 func (h *EnvironmentGroupHandler) DeleteEnvironmentGroup(c *gin.Context) {
-	id := c.Param("id")
+	ctx := c.Request.Context()
+	group, err := h.store.EnvironmentGroups().Get(ctx, c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Environment Group not found"})
+		return
+	}
 
 	// Check if environment group has associated environments
-	var environmentCount int64
-	database.DB.Model(&db.Environment{}).Where("environment_group_id = ?", id).Count(&environmentCount)
-
-	if environmentCount > 0 {
+	if len(group.Environments) > 0 {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Cannot delete environment group that has associated environments. Please reassign or delete environments first."})
 		return
 	}
 
-	if err := database.DB.Delete(&db.EnvironmentGroup{}, "id = ?", id).Error; err != nil {
+	if err := h.store.Transaction(ctx, func(tx repository.Store) error {
+		if err := tx.EnvironmentGroups().Delete(ctx, group.ID); err != nil {
+			return err
+		}
+		return audit.Record(tx, c, domain.AuditEntityEnvironmentGroup, group.ID, mapper.EnvironmentGroupDomainToAPI(group), nil)
+	}); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete environment group"})
 		return
 	}
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"release-management/internal/middleware"
+	"release-management/internal/models/api"
+	"release-management/internal/models/domain"
+	"release-management/internal/models/mapper"
+	"release-management/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GET /environment-groups/:id/roles
+func (h *EnvironmentGroupHandler) GetEnvironmentGroupRoles(c *gin.Context) {
+	ctx := c.Request.Context()
+	group, err := h.store.EnvironmentGroups().Get(ctx, c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Environment Group not found"})
+		return
+	}
+
+	grants, err := h.store.EnvironmentGroups().ListRoleGrants(ctx, group.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch role grants"})
+		return
+	}
+
+	respondWithList(c, grants, mapper.EnvironmentGroupRoleGrantDomainToAPI)
+}
+
+// PUT /environment-groups/:id/roles/:userId
+func (h *EnvironmentGroupHandler) SetEnvironmentGroupRole(c *gin.Context) {
+	groupID := c.Param("id")
+
+	userID, err := strconv.ParseUint(c.Param("userId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	var req api.RoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	role := domain.Role(req.Role)
+	if !role.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role. Valid values are: viewer, engineer, release-manager, admin"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	if _, err := h.store.EnvironmentGroups().Get(ctx, groupID); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Environment Group not found"})
+		return
+	}
+
+	user, err := h.store.Users().Get(ctx, uint(userID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+
+	// Replace any existing grant for the user in this group
+	grant := &domain.EnvironmentGroupRoleGrant{
+		EnvironmentGroupID: groupID,
+		UserID:             user.ID,
+		Role:               role,
+	}
+	if err := h.store.EnvironmentGroups().SaveRoleGrant(ctx, grant); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to grant role"})
+		return
+	}
+
+	grant.User = user
+	c.JSON(http.StatusOK, mapper.EnvironmentGroupRoleGrantDomainToAPI(grant))
+}
+
+// DELETE /environment-groups/:id/roles/:userId
+func (h *EnvironmentGroupHandler) DeleteEnvironmentGroupRole(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("userId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	if err := h.store.EnvironmentGroups().DeleteRoleGrant(c.Request.Context(), c.Param("id"), uint(userID)); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Role grant not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke role"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Role grant revoked successfully"})
+}
+
+// Helper function to check that the caller may change environments in a group, responding with 403 if not
+func authorizeEnvironmentGroupChange(c *gin.Context, store repository.Store, groupID string) bool {
+	allowed, err := middleware.Authorize(c, store, domain.ResourceEnvironments, domain.ActionWrite, groupID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check permissions"})
+		return false
+	}
+	if !allowed {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to change environments in this environment group"})
+		return false
+	}
+	return true
+}
+
+// Helper function to validate a group's required role and check that only admins change it
+func authorizeRequiredRoleChange(c *gin.Context, store repository.Store, role domain.Role) bool {
+	if role != "" && !role.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid required role. Valid values are: viewer, engineer, release-manager, admin"})
+		return false
+	}
+
+	userRole, err := middleware.UserRole(c, store)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check permissions"})
+		return false
+	}
+	if userRole != domain.RoleAdmin {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only admins can change the required role of an environment group"})
+		return false
+	}
+	return true
+}
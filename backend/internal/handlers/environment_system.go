@@ -1,27 +1,29 @@
 package handlers
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 
-	"release-management/internal/database"
+	"release-management/internal/audit"
 	"release-management/internal/models/api"
-	"release-management/internal/models/db"
 	"release-management/internal/models/domain"
+	"release-management/internal/models/mapper"
+	"release-management/internal/repository"
+	"release-management/internal/version"
 
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
-	"gorm.io/gorm"
 )
 
 // GetEnvironmentSystems gets all systems for an environment
-func GetEnvironmentSystems(c *gin.Context) {
-	envID := c.Param("id")
+func (h *EnvironmentHandler) GetEnvironmentSystems(c *gin.Context) {
+	ctx := c.Request.Context()
 
 	// Check if environment exists
-	var environment db.Environment
-	if err := database.DB.First(&environment, "id = ?", envID).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
+	environment, err := h.store.Environments().Get(ctx, c.Param("id"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Environment not found"})
 			return
 		}
@@ -29,23 +31,16 @@ func GetEnvironmentSystems(c *gin.Context) {
 		return
 	}
 
-	var envSystems []db.EnvironmentSystem
-	if err := database.DB.Preload("System").
-		Where("environment_id = ?", envID).
-		Find(&envSystems).Error; err != nil {
+	envSystems, err := h.store.Environments().ListSystems(ctx, environment.ID)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch environment systems"})
 		return
 	}
 
 	// Create simplified response
 	var systems []api.SimpleSystemInfo
-	for _, envSystem := range envSystems {
-		systems = append(systems, api.SimpleSystemInfo{
-			SystemID:   envSystem.SystemID,
-			SystemName: envSystem.System.Name,
-			Status:     envSystem.Status,
-			Version:    envSystem.Version,
-		})
+	for i := range envSystems {
+		systems = append(systems, simpleSystemInfo(&envSystems[i]))
 	}
 
 	response := api.EnvironmentSystemsResponse{
@@ -58,15 +53,12 @@ func GetEnvironmentSystems(c *gin.Context) {
 }
 
 // GetEnvironmentSystem gets a specific system in an environment
-func GetEnvironmentSystem(c *gin.Context) {
-	envID := c.Param("id")
-	systemID := c.Param("systemId")
-
-	var envSystem db.EnvironmentSystem
-	if err := database.DB.Preload("System").
-		Where("environment_id = ? AND system_id = ?", envID, systemID).
-		First(&envSystem).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
+func (h *EnvironmentHandler) GetEnvironmentSystem(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	envSystem, err := h.store.Environments().GetSystem(ctx, c.Param("id"), c.Param("systemId"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Environment system not found"})
 			return
 		}
@@ -75,62 +67,57 @@ func GetEnvironmentSystem(c *gin.Context) {
 	}
 
 	// Get available versions for this system
-	availableVersions, err := getAvailableVersionsForSystem(systemID)
+	availableVersions, err := getAvailableVersionsForSystem(ctx, h.store, envSystem.SystemID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch available versions"})
 		return
 	}
 
-	response := api.SimpleSystemInfo{
-		SystemID:   envSystem.SystemID,
-		SystemName: envSystem.System.Name,
-		Status:     envSystem.Status,
-		Version:    envSystem.Version,
-	}
-
-	c.JSON(http.StatusOK, gin.H{
-		"system":             response,
-		"available_versions": availableVersions,
+	c.JSON(http.StatusOK, api.EnvironmentSystemDetailResponse{
+		System:            simpleSystemInfo(envSystem),
+		AvailableVersions: availableVersions,
 	})
 }
 
 // AddSystemToEnvironment adds a system to an environment
-func AddSystemToEnvironment(c *gin.Context) {
-	envID := c.Param("id")
-
+func (h *EnvironmentHandler) AddSystemToEnvironment(c *gin.Context) {
 	var req api.EnvironmentSystemRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
+	ctx := c.Request.Context()
+
 	// Check if environment exists
-	var environment db.Environment
-	if err := database.DB.First(&environment, "id = ?", envID).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
+	environment, err := h.store.Environments().Get(ctx, c.Param("id"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Environment not found"})
 			return
 		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch environment"})
 		return
 	}
+	if !h.allowDirectChange(c, environment.ID) {
+		return
+	}
 
 	// Get the release and its builds separately
-	var release db.Release
-	var builds []db.Build
-	if err := database.DB.First(&release, "id = ?", environment.ReleaseID).Error; err != nil {
+	if _, err := h.store.Releases().Get(ctx, environment.ReleaseID); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch environment's release"})
 		return
 	}
-	if err := database.DB.Where("release_id = ?", release.ID).Find(&builds).Error; err != nil {
+	builds, err := h.store.Builds().List(ctx, repository.BuildFilter{ReleaseID: environment.ReleaseID})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch release builds"})
 		return
 	}
 
 	// Check if system exists
-	var system db.System
-	if err := database.DB.Preload("Subsystems").First(&system, "id = ?", req.SystemID).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
+	system, err := h.store.Systems().Get(ctx, req.SystemID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "System not found"})
 			return
 		}
@@ -138,128 +125,96 @@ func AddSystemToEnvironment(c *gin.Context) {
 		return
 	}
 
-	// Start transaction
-	tx := database.DB.Begin()
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
-		}
-	}()
-
-	var systemsToAdd []db.System
-
 	// If it's a parent system, add all its subsystems
-	if system.Type == string(domain.SystemTypeParent) {
-		systemsToAdd = append(systemsToAdd, system.Subsystems...)
-	} else {
-		systemsToAdd = append(systemsToAdd, system)
-	}
-
-	var addedSystems []db.EnvironmentSystem
-
-	for _, sys := range systemsToAdd {
-		// Check if system is already in environment
-		var existingEnvSystem db.EnvironmentSystem
-		if err := tx.Where("environment_id = ? AND system_id = ?", envID, sys.ID).
-			First(&existingEnvSystem).Error; err == nil {
-			// System already exists, skip
-			continue
+	systemsToAdd := []domain.System{*system}
+	if system.Type == domain.SystemTypeParent {
+		systemsToAdd, err = h.store.Systems().List(ctx, repository.SystemFilter{ParentID: system.ID})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch system"})
+			return
 		}
+	}
 
-		// Determine version from release builds
-		version := req.Version
-		if version == "" {
-			version = getSystemVersionFromRelease(builds, sys.ID)
-		} else {
-			// Validate version against available builds
-			isValid, err := isValidVersionForSystem(sys.ID, version)
+	// Validate the requested version against the available builds before touching anything
+	if req.Version != "" {
+		for _, sys := range systemsToAdd {
+			isValid, err := isValidVersionForSystem(ctx, h.store, sys.ID, req.Version)
 			if err != nil {
-				tx.Rollback()
 				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate version"})
 				return
 			}
 			if !isValid {
-				tx.Rollback()
-				availableVersions, _ := getAvailableVersionsForSystem(sys.ID)
+				availableVersions, _ := getAvailableVersionsForSystem(ctx, h.store, sys.ID)
 				c.JSON(http.StatusBadRequest, gin.H{
-					"error": fmt.Sprintf("Version %s not found for system %s. Available versions: %v", version, sys.Name, availableVersions),
+					"error": fmt.Sprintf("Version %s not found for system %s. Available versions: %v", req.Version, sys.Name, availableVersions),
 				})
 				return
 			}
 		}
+	}
 
-		// Create environment system entry
-		envSystem := db.EnvironmentSystem{
-			ID:            uuid.New().String(),
-			EnvironmentID: envID,
-			SystemID:      sys.ID,
-			Version:       version,
-			Status:        "active",
-		}
-
-		if req.Status != "" {
-			envSystem.Status = req.Status
-		}
-
-		if err := tx.Create(&envSystem).Error; err != nil {
-			tx.Rollback()
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add system to environment"})
-			return
-		}
+	var systems []api.SimpleSystemInfo
+	if err := h.store.Transaction(ctx, func(tx repository.Store) error {
+		for i := range systemsToAdd {
+			sys := &systemsToAdd[i]
+
+			// Skip systems that are already in the environment
+			if _, err := tx.Environments().GetSystem(ctx, environment.ID, sys.ID); err == nil {
+				continue
+			} else if !errors.Is(err, repository.ErrNotFound) {
+				return err
+			}
 
-		addedSystems = append(addedSystems, envSystem)
-	}
+			// Determine version from release builds
+			version := req.Version
+			if version == "" {
+				version = getSystemVersionFromRelease(builds, sys.ID)
+			}
 
-	if err := tx.Commit().Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to commit transaction"})
-		return
-	}
+			envSystem := &domain.EnvironmentSystem{
+				EnvironmentID: environment.ID,
+				SystemID:      sys.ID,
+				Version:       version,
+				Status:        req.Status,
+			}
+			if err := tx.Environments().AddSystem(ctx, envSystem); err != nil {
+				return err
+			}
+			if err := recordDeployment(c, tx, envSystem, "", deploymentSourceFromRequest(c)); err != nil {
+				return err
+			}
+			if err := auditEnvironmentSystem(tx, c, nil, envSystem); err != nil {
+				return err
+			}
 
-	// Reload with relationships for simplified response
-	var systems []api.SimpleSystemInfo
-	for _, envSystem := range addedSystems {
-		var reloaded db.EnvironmentSystem
-		if err := database.DB.Preload("System").Where("environment_id = ? AND system_id = ?", envSystem.EnvironmentID, envSystem.SystemID).First(&reloaded).Error; err != nil {
-			// Fallback: create response from what we have
-			var system db.System
-			database.DB.First(&system, envSystem.SystemID)
-			systems = append(systems, api.SimpleSystemInfo{
-				SystemID:   envSystem.SystemID,
-				SystemName: system.Name,
-				Status:     envSystem.Status,
-				Version:    envSystem.Version,
-			})
-		} else {
-			systems = append(systems, api.SimpleSystemInfo{
-				SystemID:   reloaded.SystemID,
-				SystemName: reloaded.System.Name,
-				Status:     reloaded.Status,
-				Version:    reloaded.Version,
-			})
+			envSystem.System = sys
+			systems = append(systems, simpleSystemInfo(envSystem))
 		}
+		return nil
+	}); err != nil {
+		respondWithError(c, err, "Failed to add system to environment")
+		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{
-		"message": fmt.Sprintf("Added %d system(s) to environment", len(addedSystems)),
-		"systems": systems,
+	c.JSON(http.StatusCreated, api.EnvironmentSystemsAddedResponse{
+		Message: fmt.Sprintf("Added %d system(s) to environment", len(systems)),
+		Systems: systems,
 	})
 }
 
 // UpdateEnvironmentSystem updates a system in an environment
-func UpdateEnvironmentSystem(c *gin.Context) {
-	envID := c.Param("id")
-	systemID := c.Param("systemId")
-
+func (h *EnvironmentHandler) UpdateEnvironmentSystem(c *gin.Context) {
 	var req api.EnvironmentSystemUpdateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	var envSystem db.EnvironmentSystem
-	if err := database.DB.Where("environment_id = ? AND system_id = ?", envID, systemID).
-		First(&envSystem).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
+	ctx := c.Request.Context()
+
+	envSystem, err := h.store.Environments().GetSystem(ctx, c.Param("id"), c.Param("systemId"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Environment system not found"})
 			return
 		}
@@ -267,16 +222,19 @@ func UpdateEnvironmentSystem(c *gin.Context) {
 		return
 	}
 
+	before := *envSystem
+	oldVersion := envSystem.Version
+
 	// Update fields
 	if req.Version != "" {
 		// Validate version against available builds
-		isValid, err := isValidVersionForSystem(systemID, req.Version)
+		isValid, err := isValidVersionForSystem(ctx, h.store, envSystem.SystemID, req.Version)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate version"})
 			return
 		}
 		if !isValid {
-			availableVersions, _ := getAvailableVersionsForSystem(systemID)
+			availableVersions, _ := getAvailableVersionsForSystem(ctx, h.store, envSystem.SystemID)
 			c.JSON(http.StatusBadRequest, gin.H{
 				"error": fmt.Sprintf("Version %s not found for system. Available versions: %v", req.Version, availableVersions),
 			})
@@ -291,43 +249,59 @@ func UpdateEnvironmentSystem(c *gin.Context) {
 		}
 		envSystem.Status = req.Status
 	}
-
-	if err := database.DB.Save(&envSystem).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update environment system"})
+	if envSystem.Version != oldVersion && !h.allowDirectChange(c, envSystem.EnvironmentID) {
 		return
 	}
 
-	// Reload with relationships
-	database.DB.Preload("System").First(&envSystem, envSystem.ID)
-
-	response := api.SimpleSystemInfo{
-		SystemID:   envSystem.SystemID,
-		SystemName: envSystem.System.Name,
-		Status:     envSystem.Status,
-		Version:    envSystem.Version,
+	if err := h.store.Transaction(ctx, func(tx repository.Store) error {
+		if err := tx.Environments().UpdateSystem(ctx, envSystem); err != nil {
+			return err
+		}
+		if envSystem.Version != oldVersion {
+			if err := recordDeployment(c, tx, envSystem, oldVersion, deploymentSourceFromRequest(c)); err != nil {
+				return err
+			}
+		}
+		return auditEnvironmentSystem(tx, c, &before, envSystem)
+	}); err != nil {
+		respondWithError(c, err, "Failed to update environment system")
+		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	c.JSON(http.StatusOK, simpleSystemInfo(envSystem))
 }
 
 // RemoveSystemFromEnvironment removes a system from an environment
-func RemoveSystemFromEnvironment(c *gin.Context) {
-	envID := c.Param("id")
-	systemID := c.Param("systemId")
-
-	var envSystem db.EnvironmentSystem
-	if err := database.DB.Where("environment_id = ? AND system_id = ?", envID, systemID).
-		First(&envSystem).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
+func (h *EnvironmentHandler) RemoveSystemFromEnvironment(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	envSystem, err := h.store.Environments().GetSystem(ctx, c.Param("id"), c.Param("systemId"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Environment system not found"})
 			return
 		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch environment system"})
 		return
 	}
+	if !h.allowDirectChange(c, envSystem.EnvironmentID) {
+		return
+	}
+
+	if err := h.store.Transaction(ctx, func(tx repository.Store) error {
+		if err := tx.Environments().RemoveSystem(ctx, envSystem.EnvironmentID, envSystem.SystemID); err != nil {
+			return err
+		}
+		if err := auditEnvironmentSystem(tx, c, envSystem, nil); err != nil {
+			return err
+		}
 
-	if err := database.DB.Delete(&envSystem).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove system from environment"})
+		// Record the removal as a change to an empty version
+		removed := *envSystem
+		removed.Version = ""
+		return recordDeployment(c, tx, &removed, envSystem.Version, deploymentSourceFromRequest(c))
+	}); err != nil {
+		respondWithError(c, err, "Failed to remove system from environment")
 		return
 	}
 
@@ -335,61 +309,121 @@ func RemoveSystemFromEnvironment(c *gin.Context) {
 }
 
 // SyncEnvironmentSystemVersions syncs system versions with the environment's release
-func SyncEnvironmentSystemVersions(c *gin.Context) {
-	envID := c.Param("id")
+func (h *EnvironmentHandler) SyncEnvironmentSystemVersions(c *gin.Context) {
+	ctx := c.Request.Context()
 
 	// Check if environment exists
-	var environment db.Environment
-	if err := database.DB.First(&environment, "id = ?", envID).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
+	environment, err := h.store.Environments().Get(ctx, c.Param("id"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Environment not found"})
 			return
 		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch environment"})
 		return
 	}
+	if !h.allowDirectChange(c, environment.ID) {
+		return
+	}
 
 	// Get the release and its builds separately
-	var release db.Release
-	var builds []db.Build
-	if err := database.DB.First(&release, "id = ?", environment.ReleaseID).Error; err != nil {
+	if _, err := h.store.Releases().Get(ctx, environment.ReleaseID); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch environment's release"})
 		return
 	}
-	if err := database.DB.Where("release_id = ?", release.ID).Find(&builds).Error; err != nil {
+	builds, err := h.store.Builds().List(ctx, repository.BuildFilter{ReleaseID: environment.ReleaseID})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch release builds"})
 		return
 	}
 
 	// Get all environment systems
-	var envSystems []db.EnvironmentSystem
-	if err := database.DB.Where("environment_id = ?", envID).Find(&envSystems).Error; err != nil {
+	envSystems, err := h.store.Environments().ListSystems(ctx, environment.ID)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch environment systems"})
 		return
 	}
 
 	// Update versions
-	var updated []db.EnvironmentSystem
-	for _, envSystem := range envSystems {
-		newVersion := getSystemVersionFromRelease(builds, envSystem.SystemID)
-		if newVersion != envSystem.Version {
+	updatedCount := 0
+	if err := h.store.Transaction(ctx, func(tx repository.Store) error {
+		for i := range envSystems {
+			envSystem := &envSystems[i]
+			newVersion := getSystemVersionFromRelease(builds, envSystem.SystemID)
+			if newVersion == envSystem.Version {
+				continue
+			}
+
+			before := *envSystem
 			envSystem.Version = newVersion
-			if err := database.DB.Save(&envSystem).Error; err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update system version"})
-				return
+			if err := tx.Environments().UpdateSystem(ctx, envSystem); err != nil {
+				return err
+			}
+			if err := recordDeployment(c, tx, envSystem, before.Version, domain.DeploymentSourceSync); err != nil {
+				return err
 			}
-			updated = append(updated, envSystem)
+			if err := auditEnvironmentSystem(tx, c, &before, envSystem); err != nil {
+				return err
+			}
+			updatedCount++
 		}
+		return nil
+	}); err != nil {
+		respondWithError(c, err, "Failed to update system version")
+		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"message":       fmt.Sprintf("Updated %d system version(s)", len(updated)),
-		"updated_count": len(updated),
+	c.JSON(http.StatusOK, api.EnvironmentSystemsSyncResponse{
+		Message:      fmt.Sprintf("Updated %d system version(s)", updatedCount),
+		UpdatedCount: updatedCount,
 	})
 }
 
+// Helper function to record a change to an environment-system link in the audit log.
+// before is nil when a system was added and after is nil when it was removed.
+func auditEnvironmentSystem(tx repository.Store, c *gin.Context, before, after *domain.EnvironmentSystem) error {
+	var beforeSnapshot, afterSnapshot *api.EnvironmentSystemResponse
+	entityID := ""
+	if before != nil {
+		beforeSnapshot = mapper.EnvironmentSystemDomainToAPI(withoutRelations(before))
+		entityID = before.ID
+	}
+	if after != nil {
+		afterSnapshot = mapper.EnvironmentSystemDomainToAPI(withoutRelations(after))
+		entityID = after.ID
+	}
+	return audit.Record(tx, c, domain.AuditEntityEnvironmentSystem, entityID, beforeSnapshot, afterSnapshot)
+}
+
+// Helper function to copy an environment-system link without its environment and system,
+// so audit snapshots only hold the link's own fields
+func withoutRelations(envSystem *domain.EnvironmentSystem) *domain.EnvironmentSystem {
+	link := *envSystem
+	link.Environment = nil
+	link.System = nil
+	return &link
+}
+
+// Helper function to convert an environment-system link to its simplified response
+func simpleSystemInfo(envSystem *domain.EnvironmentSystem) api.SimpleSystemInfo {
+	return api.SimpleSystemInfo{
+		SystemID:   envSystem.SystemID,
+		SystemName: systemName(envSystem.System),
+		Status:     envSystem.Status,
+		Version:    envSystem.Version,
+	}
+}
+
+// Helper function to get the name of a loaded system, empty if it was not loaded
+func systemName(system *domain.System) string {
+	if system == nil {
+		return ""
+	}
+	return system.Name
+}
+
 // Helper function to get system version from release builds
-func getSystemVersionFromRelease(builds []db.Build, systemID string) string {
+func getSystemVersionFromRelease(builds []domain.Build, systemID string) string {
 	for _, build := range builds {
 		if build.SystemID == systemID {
 			return build.Version
@@ -399,9 +433,9 @@ func getSystemVersionFromRelease(builds []db.Build, systemID string) string {
 }
 
 // Helper function to get all available versions for a system
-func getAvailableVersionsForSystem(systemID string) ([]string, error) {
-	var builds []db.Build
-	if err := database.DB.Where("system_id = ?", systemID).Find(&builds).Error; err != nil {
+func getAvailableVersionsForSystem(ctx context.Context, store repository.Store, systemID string) ([]string, error) {
+	builds, err := store.Builds().List(ctx, repository.BuildFilter{SystemID: systemID})
+	if err != nil {
 		return nil, err
 	}
 
@@ -409,24 +443,26 @@ func getAvailableVersionsForSystem(systemID string) ([]string, error) {
 	for _, build := range builds {
 		versions = append(versions, build.Version)
 	}
+
+	// Newest version first
+	version.Sort(versions)
+	for i, j := 0, len(versions)-1; i < j; i, j = i+1, j-1 {
+		versions[i], versions[j] = versions[j], versions[i]
+	}
 	return versions, nil
 }
 
 // Helper function to validate version against available builds
-func isValidVersionForSystem(systemID, version string) (bool, error) {
+func isValidVersionForSystem(ctx context.Context, store repository.Store, systemID, version string) (bool, error) {
 	if version == "" {
 		return true, nil // Empty version is allowed
 	}
 
-	availableVersions, err := getAvailableVersionsForSystem(systemID)
-	if err != nil {
-		return false, err
-	}
-
-	for _, availableVersion := range availableVersions {
-		if availableVersion == version {
-			return true, nil
+	if _, err := store.Builds().FindByVersion(ctx, systemID, version); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return false, nil
 		}
+		return false, err
 	}
-	return false, nil
+	return true, nil
 }
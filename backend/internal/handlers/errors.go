@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"release-management/internal/models/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Helper function to respond with the status matching a domain error, or with fallback for any other error
+func respondWithError(c *gin.Context, err error, fallback string) {
+	var domainErr *domain.Error
+	if !errors.As(err, &domainErr) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+		return
+	}
+
+	body := gin.H{"error": domainErr.Error()}
+	for key, value := range domainErr.Details {
+		body[key] = value
+	}
+	c.JSON(domainErrorStatus(domainErr), body)
+}
+
+// Helper function to map the kind of a domain error to an HTTP status
+func domainErrorStatus(err *domain.Error) int {
+	switch err.Kind {
+	case domain.ErrorKindNotFound:
+		return http.StatusNotFound
+	case domain.ErrorKindConflict:
+		return http.StatusConflict
+	case domain.ErrorKindForbidden:
+		return http.StatusForbidden
+	default:
+		return http.StatusBadRequest
+	}
+}
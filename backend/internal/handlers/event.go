@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"release-management/internal/events"
+	"release-management/internal/models/domain"
+	"release-management/internal/models/mapper"
+	"release-management/internal/repository"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+)
+
+// streamHeartbeatInterval keeps idle event streams from being closed by proxies
+const streamHeartbeatInterval = 15 * time.Second
+
+type EventHandler struct {
+	store  repository.Store
+	stream *events.Stream
+}
+
+func NewEventHandler(store repository.Store, stream *events.Stream) *EventHandler {
+	return &EventHandler{store: store, stream: stream}
+}
+
+// GET /events/stream
+func (h *EventHandler) StreamEvents(c *gin.Context) {
+	filter := events.Filter{
+		ReleaseID:          c.Query("release_id"),
+		EnvironmentGroupID: c.Query("environment_group_id"),
+	}
+
+	requested := domain.EventTypes
+	if typeParam := c.Query("type"); typeParam != "" {
+		requested = nil
+		for _, value := range strings.Split(typeParam, ",") {
+			eventType := domain.EventType(strings.TrimSpace(value))
+			if !eventType.IsValid() {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'type' parameter. Valid values are: " + eventTypeNames()})
+				return
+			}
+			requested = append(requested, eventType)
+		}
+	}
+
+	// Only stream the events about resources the caller could read through their own endpoints
+	for _, eventType := range requested {
+		allowed, err := canRead(c, h.store, eventType.Resource())
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check permissions"})
+			return
+		}
+		if allowed {
+			filter.Types = append(filter.Types, eventType)
+		}
+	}
+	if len(filter.Types) == 0 {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to read these events"})
+		return
+	}
+
+	// Browsers send the header when they reconnect, the parameter lets clients resume on their first connection
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+	var after int64
+	if lastEventID != "" {
+		var err error
+		if after, err = strconv.ParseInt(lastEventID, 10, 64); err != nil || after < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Last-Event-ID. Use the id of the last event received"})
+			return
+		}
+	}
+
+	ctx := c.Request.Context()
+	sub, replay, complete, err := h.stream.Subscribe(ctx, filter, after)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to open event stream"})
+		return
+	}
+	defer sub.Close()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	if !complete {
+		c.Render(-1, sse.Event{Event: "reset", Data: gin.H{"message": "Some events since the last event ID are no longer available. Reload the current state"}})
+	}
+	for i := range replay {
+		renderStreamEvent(c, &replay[i])
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-sub.Events():
+			if !ok {
+				// Fell too far behind, the client reconnects and resumes from its last event
+				return
+			}
+			renderStreamEvent(c, &event)
+		case <-heartbeat.C:
+			c.Writer.WriteString(": keep-alive\n\n")
+		}
+		c.Writer.Flush()
+	}
+}
+
+// Helper function to write an event to the stream, identified by its sequence so that clients can resume after it
+func renderStreamEvent(c *gin.Context, event *domain.Event) {
+	c.Render(-1, sse.Event{
+		Id:    strconv.FormatInt(event.Sequence, 10),
+		Event: string(event.Type),
+		Data:  mapper.EventDomainToAPI(event),
+	})
+}
@@ -0,0 +1,312 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"release-management/internal/audit"
+	"release-management/internal/middleware"
+	"release-management/internal/models/api"
+	"release-management/internal/models/domain"
+	"release-management/internal/models/mapper"
+	"release-management/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// freezeOverrideHeader carries the justification for a change that lands in an active freeze window
+const freezeOverrideHeader = "X-Freeze-Override"
+
+type FreezeWindowHandler struct {
+	store repository.Store
+}
+
+func NewFreezeWindowHandler(store repository.Store) *FreezeWindowHandler {
+	return &FreezeWindowHandler{store: store}
+}
+
+// GET /freeze-windows
+func (h *FreezeWindowHandler) GetFreezeWindows(c *gin.Context) {
+	var filter repository.FreezeWindowFilter
+	var ok bool
+	if filter.Since, ok = timeQuery(c, "since"); !ok {
+		return
+	}
+	if filter.Until, ok = timeQuery(c, "until"); !ok {
+		return
+	}
+	if c.Query("active") == "true" {
+		now := time.Now()
+		filter.Since, filter.Until = &now, &now
+	}
+
+	ctx := c.Request.Context()
+	windows, err := h.store.FreezeWindows().List(ctx, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch freeze windows"})
+		return
+	}
+
+	// Narrow the calendar down to the windows that apply to an environment
+	if envID := c.Query("environment_id"); envID != "" {
+		env, err := h.store.Environments().Get(ctx, envID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Environment not found"})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch environment"})
+			return
+		}
+		covering := windows[:0]
+		for _, window := range windows {
+			if window.Covers(env) {
+				covering = append(covering, window)
+			}
+		}
+		windows = covering
+	}
+
+	respondWithList(c, windows, mapper.FreezeWindowDomainToAPI)
+}
+
+// GET /freeze-windows/:id
+func (h *FreezeWindowHandler) GetFreezeWindow(c *gin.Context) {
+	window, err := h.store.FreezeWindows().Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Freeze window not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.FreezeWindowDomainToAPI(window))
+}
+
+// POST /freeze-windows
+func (h *FreezeWindowHandler) CreateFreezeWindow(c *gin.Context) {
+	var req api.FreezeWindowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	window := mapper.FreezeWindowAPIToDomain(&req)
+	if userID := c.GetUint("userID"); userID != 0 {
+		window.CreatedBy = &userID
+	}
+
+	ctx := c.Request.Context()
+	if err := h.store.Transaction(ctx, func(tx repository.Store) error {
+		if err := validateFreezeWindow(c, tx, window); err != nil {
+			return err
+		}
+		if err := tx.FreezeWindows().Create(ctx, window); err != nil {
+			return err
+		}
+		return audit.Record(tx, c, domain.AuditEntityFreezeWindow, window.ID, nil, mapper.FreezeWindowDomainToAPI(window))
+	}); err != nil {
+		respondWithError(c, err, "Failed to create freeze window")
+		return
+	}
+
+	c.JSON(http.StatusCreated, mapper.FreezeWindowDomainToAPI(window))
+}
+
+// PUT /freeze-windows/:id
+func (h *FreezeWindowHandler) UpdateFreezeWindow(c *gin.Context) {
+	ctx := c.Request.Context()
+	window, err := h.store.FreezeWindows().Get(ctx, c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Freeze window not found"})
+		return
+	}
+
+	var req api.FreezeWindowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	before := mapper.FreezeWindowDomainToAPI(window)
+	updated := mapper.FreezeWindowAPIToDomain(&req)
+	updated.ID = window.ID
+	updated.CreatedBy = window.CreatedBy
+	updated.CreatedAt = window.CreatedAt
+
+	if err := h.store.Transaction(ctx, func(tx repository.Store) error {
+		if err := validateFreezeWindow(c, tx, updated); err != nil {
+			return err
+		}
+		if err := tx.FreezeWindows().Update(ctx, updated); err != nil {
+			return err
+		}
+		return audit.Record(tx, c, domain.AuditEntityFreezeWindow, updated.ID, before, mapper.FreezeWindowDomainToAPI(updated))
+	}); err != nil {
+		respondWithError(c, err, "Failed to update freeze window")
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.FreezeWindowDomainToAPI(updated))
+}
+
+// DELETE /freeze-windows/:id
+func (h *FreezeWindowHandler) DeleteFreezeWindow(c *gin.Context) {
+	ctx := c.Request.Context()
+	window, err := h.store.FreezeWindows().Get(ctx, c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Freeze window not found"})
+		return
+	}
+
+	if err := h.store.Transaction(ctx, func(tx repository.Store) error {
+		if err := tx.FreezeWindows().Delete(ctx, window.ID); err != nil {
+			return err
+		}
+		return audit.Record(tx, c, domain.AuditEntityFreezeWindow, window.ID, mapper.FreezeWindowDomainToAPI(window), nil)
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete freeze window"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Freeze window deleted successfully"})
+}
+
+// GET /freeze-overrides
+func (h *FreezeWindowHandler) GetFreezeOverrides(c *gin.Context) {
+	filter := repository.FreezeOverrideFilter{
+		FreezeWindowID: c.Query("freeze_window_id"),
+		EnvironmentID:  c.Query("environment_id"),
+	}
+
+	// Newest first unless sorted otherwise
+	page, ok := pageRequest(c, repository.FreezeOverrideSortFields, repository.Sort{Field: "created_at", Desc: true})
+	if !ok {
+		return
+	}
+
+	overrides, err := h.store.FreezeWindows().ListOverrides(c.Request.Context(), filter, page)
+	if err != nil {
+		respondWithListError(c, err, "Failed to fetch freeze overrides")
+		return
+	}
+
+	respondWithPage(c, overrides, mapper.FreezeOverrideDomainToAPI)
+}
+
+// Helper function to check the rules of a window and that the group and exemptions it refers to exist
+func validateFreezeWindow(c *gin.Context, tx repository.Store, window *domain.FreezeWindow) error {
+	if err := window.Validate(); err != nil {
+		return err
+	}
+
+	ctx := c.Request.Context()
+	if window.EnvironmentGroupID != nil {
+		if _, err := tx.EnvironmentGroups().Get(ctx, *window.EnvironmentGroupID); err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return domain.ErrFreezeWindowGroupNotFound
+			}
+			return err
+		}
+	}
+	for _, systemID := range window.ExemptSystemIDs {
+		if _, err := tx.Systems().Get(ctx, systemID); err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return domain.ErrFreezeWindowExemptNotFound.WithDetail("system_id", systemID)
+			}
+			return err
+		}
+	}
+	for _, envID := range window.ExemptEnvironmentIDs {
+		if _, err := tx.Environments().Get(ctx, envID); err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return domain.ErrFreezeWindowExemptNotFound.WithDetail("environment_id", envID)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// Helper function to stop a version change that lands in an active freeze window. Users who may override the freeze
+// let the change through with a justification in the X-Freeze-Override header, which is logged for every window it overrides.
+func enforceFreezeWindows(c *gin.Context, tx repository.Store, environmentID, systemID, oldVersion, newVersion string) error {
+	ctx := c.Request.Context()
+	env, err := tx.Environments().Get(ctx, environmentID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	now := time.Now()
+	windows, err := tx.FreezeWindows().List(ctx, repository.FreezeWindowFilter{Since: &now})
+	if err != nil {
+		return err
+	}
+	var blocking []domain.FreezeWindow
+	for _, window := range windows {
+		if window.IsActive(now) && window.Blocks(env, systemID) {
+			blocking = append(blocking, window)
+		}
+	}
+	if len(blocking) == 0 {
+		return nil
+	}
+
+	justification := strings.TrimSpace(c.GetHeader(freezeOverrideHeader))
+	if justification == "" {
+		return domain.ErrEnvironmentFrozen.WithDetail("freeze_windows", freezeWindowsToAPI(blocking))
+	}
+	allowed, err := canOverrideFreeze(c, tx, env)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return domain.ErrFreezeOverrideNotPermitted.WithDetail("freeze_windows", freezeWindowsToAPI(blocking))
+	}
+
+	for _, window := range blocking {
+		override := &domain.FreezeOverride{
+			FreezeWindowID:   window.ID,
+			FreezeWindowName: window.Name,
+			EnvironmentID:    env.ID,
+			SystemID:         systemID,
+			OldVersion:       oldVersion,
+			NewVersion:       newVersion,
+			Justification:    justification,
+		}
+		if userID := c.GetUint("userID"); userID != 0 {
+			override.UserID = &userID
+		}
+		if err := tx.FreezeWindows().CreateOverride(ctx, override); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Helper function to check that the caller may override the freezes of an environment, including the scopes of an API token
+func canOverrideFreeze(c *gin.Context, tx repository.Store, env *domain.Environment) (bool, error) {
+	if c.GetString("authMethod") == middleware.AuthMethodAPIToken {
+		token, ok := c.MustGet("apiToken").(*domain.APIToken)
+		if !ok || !token.HasScope(domain.TokenScope(domain.ResourceFreezeWindows+":write")) {
+			return false, nil
+		}
+	}
+	groupID := ""
+	if env.EnvironmentGroupID != nil {
+		groupID = *env.EnvironmentGroupID
+	}
+	return middleware.Authorize(c, tx, domain.ResourceFreezeWindows, domain.ActionOverride, groupID)
+}
+
+// Helper function to convert the windows blocking a change for the error response
+func freezeWindowsToAPI(windows []domain.FreezeWindow) []api.FreezeWindowResponse {
+	apiWindows := make([]api.FreezeWindowResponse, len(windows))
+	for i := range windows {
+		apiWindows[i] = *mapper.FreezeWindowDomainToAPI(&windows[i])
+	}
+	return apiWindows
+}
@@ -0,0 +1,241 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"release-management/internal/audit"
+	"release-management/internal/config"
+	"release-management/internal/models/api"
+	"release-management/internal/models/domain"
+	"release-management/internal/models/mapper"
+	"release-management/internal/repository"
+	"release-management/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/goccy/go-yaml"
+)
+
+// maxManifestSize limits the size of an imported manifest
+const maxManifestSize = 10 << 20
+
+// manifestResources lists the resources a manifest describes
+var manifestResources = []string{
+	domain.ResourceSystems,
+	domain.ResourceReleases,
+	domain.ResourceBuilds,
+	domain.ResourceEnvironmentGroups,
+	domain.ResourceEnvironments,
+}
+
+type ManifestHandler struct {
+	cfg   *config.Config
+	store repository.Store
+}
+
+func NewManifestHandler(cfg *config.Config, store repository.Store) *ManifestHandler {
+	return &ManifestHandler{cfg: cfg, store: store}
+}
+
+// GET /export
+func (h *ManifestHandler) ExportManifest(c *gin.Context) {
+	format := c.DefaultQuery("format", "yaml")
+	if format != "yaml" && format != "json" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'format' parameter. Valid values are: yaml, json"})
+		return
+	}
+
+	// The manifest holds every entity, so the caller must be able to list each of them
+	for _, resource := range manifestResources {
+		allowed, err := canRead(c, h.store, resource)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check permissions"})
+			return
+		}
+		if !allowed {
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to read " + resource})
+			return
+		}
+	}
+
+	manifest, err := service.NewManifestService(h.store).Export(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to export catalog"})
+		return
+	}
+
+	filename := "catalog-" + time.Now().UTC().Format("20060102T150405Z") + "." + format
+	c.Header("Content-Disposition", "attachment; filename="+filename)
+	if format == "json" {
+		c.JSON(http.StatusOK, mapper.ManifestDomainToAPI(manifest))
+		return
+	}
+
+	data, err := json.Marshal(mapper.ManifestDomainToAPI(manifest))
+	if err == nil {
+		data, err = yaml.JSONToYAML(data)
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to export catalog"})
+		return
+	}
+	c.Data(http.StatusOK, "application/yaml", data)
+}
+
+// POST /import
+func (h *ManifestHandler) ImportManifest(c *gin.Context) {
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxManifestSize))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read manifest"})
+		return
+	}
+
+	var req api.Manifest
+	if err := decodeManifest(body, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid manifest: " + err.Error()})
+		return
+	}
+	manifest := mapper.ManifestAPIToDomain(&req)
+
+	ctx := c.Request.Context()
+	var plan *domain.ManifestPlan
+	if c.Query("dry_run") == "true" {
+		plan, err = service.NewManifestService(h.store).Import(ctx, manifest, false)
+	} else {
+		err = h.store.Transaction(ctx, func(tx repository.Store) error {
+			environments, err := tx.Environments().List(ctx, repository.EnvironmentFilter{})
+			if err != nil {
+				return err
+			}
+			if plan, err = service.NewManifestService(tx).Import(ctx, manifest, true); err != nil {
+				return err
+			}
+			if err := h.applyImportChangeRequests(c, tx, plan, environments); err != nil {
+				return err
+			}
+			for _, change := range plan.Changes {
+				if err := audit.Record(tx, c, change.EntityType, change.EntityID, manifestSnapshot(change.Before), manifestSnapshot(change.After)); err != nil {
+					return err
+				}
+				if change.EntityType == domain.AuditEntityEnvironmentSystem {
+					if err := recordManifestDeployment(c, tx, &change); err != nil {
+						return err
+					}
+					continue
+				}
+				if err := publishManifestChange(ctx, tx, &change); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+	if err != nil {
+		respondWithError(c, err, "Failed to import manifest")
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ManifestPlanDomainToAPI(plan))
+}
+
+// Helper function to decode a YAML or JSON manifest, rejecting fields a manifest does not have
+func decodeManifest(body []byte, manifest *api.Manifest) error {
+	if strings.TrimSpace(string(body)) == "" {
+		return errors.New("manifest is empty")
+	}
+
+	// JSON is valid YAML, so both formats go through the same conversion
+	data, err := yaml.YAMLToJSON(body)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(manifest)
+}
+
+// Helper function to publish the events of a release, build or environment changed by an import
+func publishManifestChange(ctx context.Context, tx repository.Store, change *domain.ManifestChange) error {
+	switch change.EntityType {
+	case domain.AuditEntityRelease:
+		eventType := map[domain.AuditAction]domain.EventType{
+			domain.AuditActionCreate: domain.EventReleaseCreated,
+			domain.AuditActionUpdate: domain.EventReleaseUpdated,
+			domain.AuditActionDelete: domain.EventReleaseDeleted,
+		}[change.Action]
+		if release, ok := manifestChangeEntity(change).(*domain.Release); ok {
+			return publishRelease(ctx, tx, eventType, release)
+		}
+	case domain.AuditEntityBuild:
+		eventType := map[domain.AuditAction]domain.EventType{
+			domain.AuditActionCreate: domain.EventBuildRegistered,
+			domain.AuditActionUpdate: domain.EventBuildUpdated,
+			domain.AuditActionDelete: domain.EventBuildDeleted,
+		}[change.Action]
+		if build, ok := manifestChangeEntity(change).(*domain.Build); ok {
+			return publishBuild(ctx, tx, eventType, build)
+		}
+	case domain.AuditEntityEnvironment:
+		eventType := map[domain.AuditAction]domain.EventType{
+			domain.AuditActionCreate: domain.EventEnvironmentCreated,
+			domain.AuditActionUpdate: domain.EventEnvironmentUpdated,
+			domain.AuditActionDelete: domain.EventEnvironmentDeleted,
+		}[change.Action]
+		if env, ok := manifestChangeEntity(change).(*domain.Environment); ok {
+			return publishEnvironment(ctx, tx, eventType, env)
+		}
+	}
+	return nil
+}
+
+// Helper function to record a version change made by an import like any other version change, so that freeze windows apply
+// and the change shows up in the deployment history. Removals of the systems the import deletes are recorded too.
+func recordManifestDeployment(c *gin.Context, tx repository.Store, change *domain.ManifestChange) error {
+	var envSystem domain.EnvironmentSystem
+	var oldVersion string
+	if before, ok := change.Before.(*domain.EnvironmentSystem); ok {
+		// A removal is recorded as a change to an empty version
+		envSystem, oldVersion = *before, before.Version
+		envSystem.Version = ""
+	}
+	if after, ok := change.After.(*domain.EnvironmentSystem); ok {
+		envSystem = *after
+	}
+	if envSystem.EnvironmentID == "" || oldVersion == envSystem.Version {
+		return nil
+	}
+	return recordDeployment(c, tx, &envSystem, oldVersion, domain.DeploymentSourceImport)
+}
+
+// Helper function to pick the entity an event about an import change describes, the entity before it was deleted or after it changed
+func manifestChangeEntity(change *domain.ManifestChange) interface{} {
+	if change.Action == domain.AuditActionDelete {
+		return change.Before
+	}
+	return change.After
+}
+
+// Helper function to convert an entity changed by an import to its API representation for the audit log
+func manifestSnapshot(entity interface{}) interface{} {
+	switch entity := entity.(type) {
+	case *domain.System:
+		return mapper.SystemDomainToAPI(entity)
+	case *domain.Release:
+		return mapper.ReleaseDomainToAPI(entity)
+	case *domain.Build:
+		return mapper.BuildDomainToAPI(entity)
+	case *domain.EnvironmentGroup:
+		return mapper.EnvironmentGroupDomainToAPI(entity)
+	case *domain.Environment:
+		return mapper.EnvironmentDomainToAPI(entity)
+	case *domain.EnvironmentSystem:
+		return mapper.EnvironmentSystemDomainToAPI(entity)
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"net/http"
+
+	"release-management/internal/openapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OpenAPIHandler struct {
+	doc *openapi.Document
+}
+
+func NewOpenAPIHandler(doc *openapi.Document) *OpenAPIHandler {
+	return &OpenAPIHandler{doc: doc}
+}
+
+// GET /openapi.json
+func (h *OpenAPIHandler) GetSpec(c *gin.Context) {
+	c.JSON(http.StatusOK, h.doc)
+}
+
+// GET /docs
+func (h *OpenAPIHandler) GetDocs(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", openapi.SwaggerUI)
+}
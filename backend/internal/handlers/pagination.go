@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"release-management/internal/models/api"
+	"release-management/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultPageLimit = 50
+	maxPageLimit     = 500
+
+	totalCountHeader = "X-Total-Count"
+)
+
+// Helper function to read the limit, cursor and sort query parameters of a list endpoint.
+// The sort parameter names a field, prefixed with "-" for descending order, e.g. ?sort=-created_at.
+func pageRequest[T any](c *gin.Context, fields repository.SortFields[T], defaultSort repository.Sort) (repository.PageRequest, bool) {
+	page := repository.PageRequest{Limit: defaultPageLimit, Sort: defaultSort, Cursor: c.Query("cursor")}
+
+	if limitParam := c.Query("limit"); limitParam != "" {
+		limit, err := strconv.Atoi(limitParam)
+		if err != nil || limit < 1 || limit > maxPageLimit {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'limit' parameter. Use a number between 1 and " + strconv.Itoa(maxPageLimit)})
+			return page, false
+		}
+		page.Limit = limit
+	}
+
+	if sortParam := c.Query("sort"); sortParam != "" {
+		field, desc := strings.CutPrefix(sortParam, "-")
+		if !fields.Has(field) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'sort' parameter. Valid fields are: " + strings.Join(fields.Names(), ", ")})
+			return page, false
+		}
+		page.Sort = repository.Sort{Field: field, Desc: desc}
+	}
+
+	return page, true
+}
+
+// Helper function to respond with a page of a list in the list envelope.
+// The total count goes into the X-Total-Count header and the next page into a Link header.
+func respondWithPage[T any, R any](c *gin.Context, page *repository.Page[T], toAPI func(*T) *R) {
+	data := make([]R, len(page.Items))
+	for i := range page.Items {
+		data[i] = *toAPI(&page.Items[i])
+	}
+
+	c.Header(totalCountHeader, strconv.FormatInt(page.Total, 10))
+	if page.NextCursor != "" {
+		next := *c.Request.URL
+		query := next.Query()
+		query.Set("cursor", page.NextCursor)
+		next.RawQuery = query.Encode()
+		c.Header("Link", "<"+next.RequestURI()+`>; rel="next"`)
+	}
+
+	c.JSON(http.StatusOK, api.ListResponse[R]{Data: data, NextCursor: page.NextCursor})
+}
+
+// Helper function to respond with a complete list in the list envelope, for lists that are not paginated
+func respondWithList[T any, R any](c *gin.Context, items []T, toAPI func(*T) *R) {
+	respondWithPage(c, &repository.Page[T]{Items: items, Total: int64(len(items))}, toAPI)
+}
+
+// Helper function to respond to a failed list query, which fails with ErrInvalidCursor for a bad cursor parameter
+func respondWithListError(c *gin.Context, err error, fallback string) {
+	if errors.Is(err, repository.ErrInvalidCursor) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'cursor' parameter. Use the next_cursor of a previous page with the same sort"})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+}
+
+// Helper function to parse an optional RFC3339 time query parameter
+func timeQuery(c *gin.Context, name string) (*time.Time, bool) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, true
+	}
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid '" + name + "' parameter. Use RFC3339 format"})
+		return nil, false
+	}
+	return &parsed, true
+}
@@ -0,0 +1,257 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+
+	"release-management/internal/models/api"
+	"release-management/internal/models/domain"
+	"release-management/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PromoteEnvironment copies the system/version set of an environment into the next stage of its group's promotion path
+func (h *EnvironmentHandler) PromoteEnvironment(c *gin.Context) {
+	var req api.PromotionRequest
+	if err := c.ShouldBindJSON(&req); err != nil && err != io.EOF {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if c.Query("dry_run") == "true" {
+		req.DryRun = true
+	}
+
+	ctx := c.Request.Context()
+
+	// Check if environment exists
+	source, err := h.store.Environments().Get(ctx, c.Param("id"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Environment not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch environment"})
+		return
+	}
+
+	if source.EnvironmentGroupID == nil || *source.EnvironmentGroupID == "" {
+		c.JSON(http.StatusConflict, gin.H{"error": "Environment does not belong to an environment group"})
+		return
+	}
+
+	group, err := h.store.EnvironmentGroups().Get(ctx, *source.EnvironmentGroupID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch environment group"})
+		return
+	}
+
+	// Find the next stage of the promotion path
+	nextStage, ok := group.NextStage(source.Type)
+	if !ok {
+		c.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("Environment type '%s' has no next stage in the promotion path of group %s", source.Type, group.Name)})
+		return
+	}
+
+	target, err := findPromotionTarget(group.Environments, nextStage, req.TargetEnvironmentID)
+	if err != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Environments that are not in service cannot receive promotions
+	if target.Status == domain.EnvStatusMaintenance || target.Status == domain.EnvStatusDecommissioned {
+		c.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("Cannot promote into environment %s while it is in status '%s'", target.Name, target.Status)})
+		return
+	}
+
+	sourceSystems, err := h.store.Environments().ListSystems(ctx, source.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch environment systems"})
+		return
+	}
+	targetSystems, err := h.store.Environments().ListSystems(ctx, target.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch environment systems"})
+		return
+	}
+
+	changes := diffEnvironmentSystems(sourceSystems, targetSystems)
+
+	// Every version being promoted must still have a build
+	var missingBuilds []string
+	for _, change := range changes {
+		if change.Action == "remove" || change.ToVersion == "" {
+			continue
+		}
+		isValid, err := isValidVersionForSystem(ctx, h.store, change.SystemID, change.ToVersion)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate version"})
+			return
+		}
+		if !isValid {
+			missingBuilds = append(missingBuilds, fmt.Sprintf("%s %s", change.SystemName, change.ToVersion))
+		}
+	}
+	if len(missingBuilds) > 0 {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":          "Cannot promote environment because some builds no longer exist",
+			"missing_builds": missingBuilds,
+		})
+		return
+	}
+
+	response := api.PromotionResponse{
+		SourceEnvironmentID:   source.ID,
+		SourceEnvironmentName: source.Name,
+		TargetEnvironmentID:   target.ID,
+		TargetEnvironmentName: target.Name,
+		DryRun:                req.DryRun,
+		Changes:               changes,
+	}
+
+	if req.DryRun {
+		c.JSON(http.StatusOK, response)
+		return
+	}
+	if !h.allowDirectChange(c, target.ID) {
+		return
+	}
+
+	if err := h.store.Transaction(ctx, func(tx repository.Store) error {
+		return applyPromotionChanges(tx, c, target.ID, changes, targetSystems)
+	}); err != nil {
+		respondWithError(c, err, "Failed to promote environment")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Helper function to pick the environment a promotion lands in
+func findPromotionTarget(environments []domain.Environment, stage domain.EnvironmentType, targetID string) (*domain.Environment, error) {
+	var candidates []domain.Environment
+	for _, env := range environments {
+		if env.Type == stage {
+			candidates = append(candidates, env)
+		}
+	}
+
+	if targetID != "" {
+		for i := range candidates {
+			if candidates[i].ID == targetID {
+				return &candidates[i], nil
+			}
+		}
+		return nil, fmt.Errorf("Target environment is not a '%s' environment in the same group", stage)
+	}
+
+	switch len(candidates) {
+	case 0:
+		return nil, fmt.Errorf("No '%s' environment exists in the same group", stage)
+	case 1:
+		return &candidates[0], nil
+	default:
+		return nil, fmt.Errorf("Several '%s' environments exist in the same group. Specify target_environment_id", stage)
+	}
+}
+
+// Helper function to compute the changes that make target run exactly the systems and versions of source
+func diffEnvironmentSystems(source, target []domain.EnvironmentSystem) []api.PromotionChange {
+	targetBySystem := make(map[string]domain.EnvironmentSystem)
+	for _, envSystem := range target {
+		targetBySystem[envSystem.SystemID] = envSystem
+	}
+
+	changes := []api.PromotionChange{}
+	sourceSystemIDs := make(map[string]bool)
+	for _, envSystem := range source {
+		sourceSystemIDs[envSystem.SystemID] = true
+
+		existing, found := targetBySystem[envSystem.SystemID]
+		if !found {
+			changes = append(changes, api.PromotionChange{
+				SystemID:   envSystem.SystemID,
+				SystemName: systemName(envSystem.System),
+				Action:     "add",
+				ToVersion:  envSystem.Version,
+			})
+		} else if existing.Version != envSystem.Version {
+			changes = append(changes, api.PromotionChange{
+				SystemID:    envSystem.SystemID,
+				SystemName:  systemName(envSystem.System),
+				Action:      "update",
+				FromVersion: existing.Version,
+				ToVersion:   envSystem.Version,
+			})
+		}
+	}
+
+	for _, envSystem := range target {
+		if !sourceSystemIDs[envSystem.SystemID] {
+			changes = append(changes, api.PromotionChange{
+				SystemID:    envSystem.SystemID,
+				SystemName:  systemName(envSystem.System),
+				Action:      "remove",
+				FromVersion: envSystem.Version,
+			})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].SystemName < changes[j].SystemName
+	})
+
+	return changes
+}
+
+// Helper function to apply promotion changes to the target environment within a transaction
+func applyPromotionChanges(tx repository.Store, c *gin.Context, targetID string, changes []api.PromotionChange, targetSystems []domain.EnvironmentSystem) error {
+	ctx := c.Request.Context()
+	targetBySystem := make(map[string]domain.EnvironmentSystem)
+	for _, envSystem := range targetSystems {
+		targetBySystem[envSystem.SystemID] = envSystem
+	}
+
+	for _, change := range changes {
+		envSystem, found := targetBySystem[change.SystemID]
+		before := envSystem
+		if !found {
+			envSystem = domain.EnvironmentSystem{
+				EnvironmentID: targetID,
+				SystemID:      change.SystemID,
+			}
+		}
+		envSystem.Version = change.ToVersion
+
+		var auditErr error
+		switch change.Action {
+		case "add":
+			if err := tx.Environments().AddSystem(ctx, &envSystem); err != nil {
+				return err
+			}
+			auditErr = auditEnvironmentSystem(tx, c, nil, &envSystem)
+		case "update":
+			if err := tx.Environments().UpdateSystem(ctx, &envSystem); err != nil {
+				return err
+			}
+			auditErr = auditEnvironmentSystem(tx, c, &before, &envSystem)
+		case "remove":
+			if err := tx.Environments().RemoveSystem(ctx, targetID, change.SystemID); err != nil {
+				return err
+			}
+			auditErr = auditEnvironmentSystem(tx, c, &before, nil)
+		}
+		if auditErr != nil {
+			return auditErr
+		}
+
+		if err := recordDeployment(c, tx, &envSystem, change.FromVersion, domain.DeploymentSourcePromotion); err != nil {
+			return err
+		}
+	}
+	return nil
+}
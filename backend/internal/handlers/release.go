@@ -1,55 +1,65 @@
 package handlers
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
 
-	"release-management/internal/database"
+	"release-management/internal/audit"
+	"release-management/internal/config"
+	"release-management/internal/events"
 	"release-management/internal/models/api"
-	"release-management/internal/models/db"
+	"release-management/internal/models/domain"
 	"release-management/internal/models/mapper"
+	"release-management/internal/repository"
 
 	"github.com/gin-gonic/gin"
 )
 
-type ReleaseHandler struct{}
+type ReleaseHandler struct {
+	cfg   *config.Config
+	store repository.Store
+}
 
-func NewReleaseHandler() *ReleaseHandler {
-	return &ReleaseHandler{}
+func NewReleaseHandler(cfg *config.Config, store repository.Store) *ReleaseHandler {
+	return &ReleaseHandler{cfg: cfg, store: store}
 }
 
 // GET /releases
 func (h *ReleaseHandler) GetReleases(c *gin.Context) {
-	var dbReleases []db.Release
-	if err := database.DB.Find(&dbReleases).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch releases"})
+	filter := repository.ReleaseFilter{Type: domain.ReleaseType(c.Query("type"))}
+	if status := c.Query("status"); status != "" {
+		if !domain.ReleaseStatus(status).IsValid() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'status' parameter. Valid values are: planned, in-progress, frozen, released, cancelled"})
+			return
+		}
+		filter.Status = domain.ReleaseStatus(status)
+	}
+
+	page, ok := pageRequest(c, repository.ReleaseSortFields, repository.Sort{Field: "created_at"})
+	if !ok {
 		return
 	}
 
-	// Convert to API responses
-	apiReleases := make([]api.ReleaseResponse, len(dbReleases))
-	for i, dbRel := range dbReleases {
-		domainRel := mapper.ReleaseDBToDomain(&dbRel)
-		apiRel := mapper.ReleaseDomainToAPI(domainRel)
-		apiReleases[i] = *apiRel
+	releases, err := h.store.Releases().ListPage(c.Request.Context(), filter, page)
+	if err != nil {
+		respondWithListError(c, err, "Failed to fetch releases")
+		return
 	}
 
-	c.JSON(http.StatusOK, apiReleases)
+	respondWithPage(c, releases, mapper.ReleaseDomainToAPI)
 }
 
 // GET /releases/:id
 func (h *ReleaseHandler) GetRelease(c *gin.Context) {
-	id := c.Param("id")
-	var dbRel db.Release
-
-	if err := database.DB.First(&dbRel, "id = ?", id).Error; err != nil {
+	release, err := h.store.Releases().Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Release not found"})
 		return
 	}
 
-	domainRel := mapper.ReleaseDBToDomain(&dbRel)
-	apiRel := mapper.ReleaseDomainToAPI(domainRel)
-
-	c.JSON(http.StatusOK, apiRel)
+	c.JSON(http.StatusOK, mapper.ReleaseDomainToAPI(release))
 }
 
 // POST /releases
@@ -60,28 +70,39 @@ func (h *ReleaseHandler) CreateRelease(c *gin.Context) {
 		return
 	}
 
-	// Convert to domain and then to DB
-	domainRel := mapper.ReleaseAPIToDomain(&req)
-	dbRel := mapper.ReleaseDomainToDB(domainRel)
+	// New releases always start at the beginning of the lifecycle
+	if req.Status == "" {
+		req.Status = string(domain.StatusPlanned)
+	}
+	if domain.ReleaseStatus(req.Status) != domain.StatusPlanned {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "New releases must start in status 'planned'. Use POST /api/releases/:id/transitions to change status"})
+		return
+	}
+
+	release := mapper.ReleaseAPIToDomain(&req)
 
-	if err := database.DB.Create(&dbRel).Error; err != nil {
+	ctx := c.Request.Context()
+	if err := h.store.Transaction(ctx, func(tx repository.Store) error {
+		if err := tx.Releases().Create(ctx, release); err != nil {
+			return err
+		}
+		if err := audit.Record(tx, c, domain.AuditEntityRelease, release.ID, nil, mapper.ReleaseDomainToAPI(release)); err != nil {
+			return err
+		}
+		return publishRelease(ctx, tx, domain.EventReleaseCreated, release)
+	}); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create release"})
 		return
 	}
 
-	// Convert back for response
-	savedDomain := mapper.ReleaseDBToDomain(dbRel)
-	response := mapper.ReleaseDomainToAPI(savedDomain)
-
-	c.JSON(http.StatusCreated, response)
+	c.JSON(http.StatusCreated, mapper.ReleaseDomainToAPI(release))
 }
 
 // PUT /releases/:id
 func (h *ReleaseHandler) UpdateRelease(c *gin.Context) {
-	id := c.Param("id")
-	var dbRel db.Release
-
-	if err := database.DB.First(&dbRel, "id = ?", id).Error; err != nil {
+	ctx := c.Request.Context()
+	release, err := h.store.Releases().Get(ctx, c.Param("id"))
+	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Release not found"})
 		return
 	}
@@ -92,40 +113,62 @@ func (h *ReleaseHandler) UpdateRelease(c *gin.Context) {
 		return
 	}
 
+	// Status changes must go through the lifecycle transitions
+	if updateReq.Status != "" && updateReq.Status != string(release.Status) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Release status cannot be changed directly. Use POST /api/releases/:id/transitions"})
+		return
+	}
+
+	before := mapper.ReleaseDomainToAPI(release)
+
 	// Apply updates
 	if updateReq.Name != "" {
-		dbRel.Name = updateReq.Name
+		release.Name = updateReq.Name
 	}
 	if !updateReq.ReleaseDate.IsZero() {
-		dbRel.ReleaseDate = updateReq.ReleaseDate
-	}
-	if updateReq.Status != "" {
-		dbRel.Status = updateReq.Status
+		release.ReleaseDate = updateReq.ReleaseDate
 	}
 	if updateReq.Type != "" {
-		dbRel.Type = updateReq.Type
+		release.Type = domain.ReleaseType(updateReq.Type)
 	}
 	if updateReq.Description != nil {
-		dbRel.Description = updateReq.Description
+		release.Description = updateReq.Description
 	}
 
-	if err := database.DB.Save(&dbRel).Error; err != nil {
+	if err := h.store.Transaction(ctx, func(tx repository.Store) error {
+		if err := tx.Releases().Update(ctx, release); err != nil {
+			return err
+		}
+		if err := audit.Record(tx, c, domain.AuditEntityRelease, release.ID, before, mapper.ReleaseDomainToAPI(release)); err != nil {
+			return err
+		}
+		return publishRelease(ctx, tx, domain.EventReleaseUpdated, release)
+	}); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update release"})
 		return
 	}
 
-	// Convert to response
-	domainRel := mapper.ReleaseDBToDomain(&dbRel)
-	response := mapper.ReleaseDomainToAPI(domainRel)
-
-	c.JSON(http.StatusOK, response)
+	c.JSON(http.StatusOK, mapper.ReleaseDomainToAPI(release))
 }
 
 // DELETE /releases/:id
 func (h *ReleaseHandler) DeleteRelease(c *gin.Context) {
-	id := c.Param("id")
+	ctx := c.Request.Context()
+	release, err := h.store.Releases().Get(ctx, c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Release not found"})
+		return
+	}
 
-	if err := database.DB.Delete(&db.Release{}, "id = ?", id).Error; err != nil {
+	if err := h.store.Transaction(ctx, func(tx repository.Store) error {
+		if err := tx.Releases().Delete(ctx, release.ID); err != nil {
+			return err
+		}
+		if err := audit.Record(tx, c, domain.AuditEntityRelease, release.ID, mapper.ReleaseDomainToAPI(release), nil); err != nil {
+			return err
+		}
+		return publishRelease(ctx, tx, domain.EventReleaseDeleted, release)
+	}); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete release"})
 		return
 	}
@@ -135,21 +178,199 @@ func (h *ReleaseHandler) DeleteRelease(c *gin.Context) {
 
 // GET /releases/:id/builds
 func (h *ReleaseHandler) GetReleaseBuilds(c *gin.Context) {
+	builds, err := h.store.Builds().List(c.Request.Context(), repository.BuildFilter{ReleaseID: c.Param("id")})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch release builds"})
+		return
+	}
+
+	// A release has at most one build per system, so the list is not paginated
+	sortBuildsByVersion(builds)
+	respondWithList(c, builds, mapper.BuildDomainToAPI)
+}
+
+// POST /releases/:id/transitions
+func (h *ReleaseHandler) TransitionRelease(c *gin.Context) {
+	ctx := c.Request.Context()
+	release, err := h.store.Releases().Get(ctx, c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Release not found"})
+		return
+	}
+
+	var req api.ReleaseTransitionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	from := release.Status
+	to := domain.ReleaseStatus(req.To)
+
+	if !to.IsValid() {
+		c.JSON(http.StatusBadRequest, newReleaseTransitionError(from, to, "invalid_status",
+			fmt.Sprintf("Invalid release status '%s'. Valid values are: planned, in-progress, frozen, released, cancelled", req.To), nil))
+		return
+	}
+
+	if !from.CanTransitionTo(to) {
+		c.JSON(http.StatusConflict, newReleaseTransitionError(from, to, "invalid_transition",
+			fmt.Sprintf("Release cannot move from '%s' to '%s'", from, to), nil))
+		return
+	}
+
+	failedGuards, err := h.checkReleaseTransitionGuards(ctx, release, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to evaluate transition guards"})
+		return
+	}
+	if len(failedGuards) > 0 {
+		c.JSON(http.StatusConflict, newReleaseTransitionError(from, to, "guard_failed",
+			fmt.Sprintf("Release cannot move from '%s' to '%s' until all guards pass", from, to), failedGuards))
+		return
+	}
+
+	transition := &domain.ReleaseTransition{
+		ReleaseID:  release.ID,
+		FromStatus: from,
+		ToStatus:   to,
+		UserID:     c.GetUint("userID"),
+		Comment:    req.Comment,
+	}
+
+	err = h.store.Transaction(ctx, func(tx repository.Store) error {
+		// Only move the release if nobody else changed its status in the meantime
+		if err := tx.Releases().Transition(ctx, transition); err != nil {
+			return err
+		}
+
+		before := mapper.ReleaseDomainToAPI(release)
+		release.Status = to
+		if err := audit.Record(tx, c, domain.AuditEntityRelease, release.ID, before, mapper.ReleaseDomainToAPI(release)); err != nil {
+			return err
+		}
+
+		return events.Publish(ctx, tx, domain.EventReleaseTransitioned, api.ReleaseTransitionedEvent{
+			Release: releaseEventData(release),
+			From:    string(from),
+			To:      string(to),
+			Comment: req.Comment,
+		})
+	})
+	if errors.Is(err, repository.ErrConflict) {
+		c.JSON(http.StatusConflict, newReleaseTransitionError(from, to, "concurrent_transition",
+			"Release status was changed by another request. Reload and try again", nil))
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update release status"})
+		return
+	}
+
+	// Load relationships for response
+	if user, err := h.store.Users().Get(ctx, transition.UserID); err == nil {
+		transition.User = user
+	}
+
+	c.JSON(http.StatusCreated, mapper.ReleaseTransitionDomainToAPI(transition))
+}
+
+// GET /releases/:id/transitions
+func (h *ReleaseHandler) GetReleaseTransitions(c *gin.Context) {
+	ctx := c.Request.Context()
 	id := c.Param("id")
-	var dbBuilds []db.Build
 
-	if err := database.DB.Where("release_id = ?", id).Preload("System").Find(&dbBuilds).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch release builds"})
+	if _, err := h.store.Releases().Get(ctx, id); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Release not found"})
 		return
 	}
 
-	// Convert to API responses
-	apiBuilds := make([]api.BuildResponse, len(dbBuilds))
-	for i, dbBuild := range dbBuilds {
-		domainBuild := mapper.BuildDBToDomain(&dbBuild)
-		apiBuild := mapper.BuildDomainToAPI(domainBuild)
-		apiBuilds[i] = *apiBuild
+	transitions, err := h.store.Releases().ListTransitions(ctx, id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch release transitions"})
+		return
 	}
 
-	c.JSON(http.StatusOK, apiBuilds)
+	respondWithList(c, transitions, mapper.ReleaseTransitionDomainToAPI)
+}
+
+// Helper function to build the structured error returned for rejected transitions
+func newReleaseTransitionError(from, to domain.ReleaseStatus, code, message string, failedGuards []string) api.ReleaseTransitionError {
+	allowed := from.AllowedTransitions()
+	allowedStrings := make([]string, len(allowed))
+	for i, status := range allowed {
+		allowedStrings[i] = string(status)
+	}
+
+	return api.ReleaseTransitionError{
+		Error:              message,
+		Code:               code,
+		From:               string(from),
+		To:                 string(to),
+		AllowedTransitions: allowedStrings,
+		FailedGuards:       failedGuards,
+	}
+}
+
+// Helper function to check the guards a release must pass before entering a status
+func (h *ReleaseHandler) checkReleaseTransitionGuards(ctx context.Context, release *domain.Release, to domain.ReleaseStatus) ([]string, error) {
+	var failedGuards []string
+
+	if to != domain.StatusFrozen && to != domain.StatusReleased {
+		return failedGuards, nil
+	}
+
+	// The release must contain builds and every build must belong to an existing system
+	builds, err := h.store.Builds().List(ctx, repository.BuildFilter{ReleaseID: release.ID})
+	if err != nil {
+		return nil, err
+	}
+	if len(builds) == 0 {
+		failedGuards = append(failedGuards, "Release has no builds")
+	}
+	for _, build := range builds {
+		if build.System == nil {
+			failedGuards = append(failedGuards, fmt.Sprintf("Build %s (version %s) references a system that no longer exists", build.ID, build.Version))
+		}
+	}
+
+	// Every system deployed to the release's environments must have a build in the release
+	environments, err := h.store.Environments().List(ctx, repository.EnvironmentFilter{ReleaseID: release.ID})
+	if err != nil {
+		return nil, err
+	}
+	for _, env := range environments {
+		envSystems, err := h.store.Environments().ListSystems(ctx, env.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, envSystem := range envSystems {
+			if getSystemVersionFromRelease(builds, envSystem.SystemID) == "" {
+				failedGuards = append(failedGuards, fmt.Sprintf("System %s in environment %s has no build in this release", systemName(envSystem.System), env.Name))
+			}
+		}
+	}
+
+	// A release can only be released once none of its environments are still pending
+	if to == domain.StatusReleased {
+		for _, env := range environments {
+			if env.Status == domain.EnvStatusPending {
+				failedGuards = append(failedGuards, fmt.Sprintf("Environment %s is still pending", env.Name))
+			}
+		}
+	}
+
+	return failedGuards, nil
+}
+
+// Helper function to publish an event about a release
+func publishRelease(ctx context.Context, tx repository.Store, eventType domain.EventType, release *domain.Release) error {
+	return events.Publish(ctx, tx, eventType, api.ReleaseEvent{Release: releaseEventData(release)})
+}
+
+// Helper function to convert a release to the data of an event, without its builds which have their own events
+func releaseEventData(release *domain.Release) api.ReleaseResponse {
+	withoutBuilds := *release
+	withoutBuilds.Builds = nil
+	return *mapper.ReleaseDomainToAPI(&withoutBuilds)
 }
@@ -0,0 +1,261 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"release-management/internal/models/api"
+	"release-management/internal/models/domain"
+	"release-management/internal/models/mapper"
+	"release-management/internal/repository"
+	"release-management/internal/version"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GET /releases/:id/compare/:otherId
+func (h *ReleaseHandler) CompareReleases(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	target, err := h.store.Releases().Get(ctx, c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Release not found"})
+		return
+	}
+	base, err := h.store.Releases().Get(ctx, c.Param("otherId"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Release to compare with not found"})
+		return
+	}
+
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "markdown" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid format. Must be 'json' or 'markdown'"})
+		return
+	}
+
+	targetBuilds, err := h.store.Builds().List(ctx, repository.BuildFilter{ReleaseID: target.ID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch release builds"})
+		return
+	}
+	baseBuilds, err := h.store.Builds().List(ctx, repository.BuildFilter{ReleaseID: base.ID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch release builds"})
+		return
+	}
+
+	systems, err := compareReleaseBuilds(ctx, h.store, baseBuilds, targetBuilds)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch parent systems"})
+		return
+	}
+
+	comparison := &domain.ReleaseComparison{
+		Base:    base,
+		Target:  target,
+		Systems: systems,
+	}
+	response := mapper.ReleaseComparisonDomainToAPI(comparison)
+
+	if format == "markdown" {
+		c.Data(http.StatusOK, "text/markdown; charset=utf-8", []byte(renderReleaseComparisonMarkdown(response)))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Helper function to line up the builds of two releases by system and roll subsystems up into their parents
+func compareReleaseBuilds(ctx context.Context, store repository.Store, baseBuilds, targetBuilds []domain.Build) ([]domain.SystemComparison, error) {
+	baseBySystem := make(map[string]domain.Build)
+	for _, build := range baseBuilds {
+		baseBySystem[build.SystemID] = build
+	}
+	targetBySystem := make(map[string]domain.Build)
+	for _, build := range targetBuilds {
+		targetBySystem[build.SystemID] = build
+	}
+
+	// Collect every system that has a build in either release
+	systemsByID := make(map[string]domain.System)
+	for _, build := range append(append([]domain.Build{}, baseBuilds...), targetBuilds...) {
+		if build.System != nil {
+			systemsByID[build.SystemID] = *build.System
+		} else {
+			systemsByID[build.SystemID] = domain.System{ID: build.SystemID}
+		}
+	}
+
+	var leaves []domain.SystemComparison
+	for systemID, system := range systemsByID {
+		baseBuild, inBase := baseBySystem[systemID]
+		targetBuild, inTarget := targetBySystem[systemID]
+
+		comparison := domain.SystemComparison{
+			SystemID:   systemID,
+			SystemName: system.Name,
+			SystemType: system.Type,
+		}
+
+		switch {
+		case inBase && !inTarget:
+			comparison.Change = domain.ChangeRemoved
+			comparison.BaseVersion = baseBuild.Version
+		case !inBase && inTarget:
+			comparison.Change = domain.ChangeAdded
+			comparison.TargetVersion = targetBuild.Version
+		default:
+			comparison.BaseVersion = baseBuild.Version
+			comparison.TargetVersion = targetBuild.Version
+			switch version.Compare(baseBuild.Version, targetBuild.Version) {
+			case -1:
+				comparison.Change = domain.ChangeUpgraded
+			case 1:
+				comparison.Change = domain.ChangeDowngraded
+			default:
+				comparison.Change = domain.ChangeUnchanged
+			}
+		}
+
+		leaves = append(leaves, comparison)
+	}
+
+	// Load the parents of subsystems so their changes can be rolled up
+	var parentIDs []string
+	for _, system := range systemsByID {
+		if system.ParentID != nil && *system.ParentID != "" {
+			parentIDs = append(parentIDs, *system.ParentID)
+		}
+	}
+	parentsByID := make(map[string]domain.System)
+	if len(parentIDs) > 0 {
+		parents, err := store.Systems().List(ctx, repository.SystemFilter{IDs: parentIDs})
+		if err != nil {
+			return nil, err
+		}
+		for _, parent := range parents {
+			parentsByID[parent.ID] = parent
+		}
+	}
+
+	var result []domain.SystemComparison
+	rollups := make(map[string]*domain.SystemComparison)
+	for _, leaf := range leaves {
+		system := systemsByID[leaf.SystemID]
+		if system.ParentID == nil {
+			result = append(result, leaf)
+			continue
+		}
+		parent, found := parentsByID[*system.ParentID]
+		if !found {
+			result = append(result, leaf)
+			continue
+		}
+
+		rollup, found := rollups[parent.ID]
+		if !found {
+			rollup = &domain.SystemComparison{
+				SystemID:   parent.ID,
+				SystemName: parent.Name,
+				SystemType: parent.Type,
+			}
+			rollups[parent.ID] = rollup
+		}
+		rollup.Subsystems = append(rollup.Subsystems, leaf)
+	}
+
+	for _, rollup := range rollups {
+		sortSystemComparisons(rollup.Subsystems)
+		rollup.Change = rollupSystemChange(rollup.Subsystems)
+		result = append(result, *rollup)
+	}
+
+	sortSystemComparisons(result)
+	return result, nil
+}
+
+// Helper function to derive the change of a parent system from its subsystems
+func rollupSystemChange(subsystems []domain.SystemComparison) domain.SystemChange {
+	var change domain.SystemChange
+	for _, sub := range subsystems {
+		if sub.Change == domain.ChangeUnchanged {
+			continue
+		}
+		if change == "" {
+			change = sub.Change
+		} else if change != sub.Change {
+			return domain.ChangeModified
+		}
+	}
+	if change == "" {
+		return domain.ChangeUnchanged
+	}
+
+	// A parent is only added or removed as a whole when every subsystem was
+	if change == domain.ChangeAdded || change == domain.ChangeRemoved {
+		for _, sub := range subsystems {
+			if sub.Change != change {
+				return domain.ChangeModified
+			}
+		}
+	}
+	return change
+}
+
+// Helper function to sort comparisons by system name
+func sortSystemComparisons(systems []domain.SystemComparison) {
+	sort.Slice(systems, func(i, j int) bool {
+		return systems[i].SystemName < systems[j].SystemName
+	})
+}
+
+// Helper function to render a release comparison as a Markdown table for change tickets
+func renderReleaseComparisonMarkdown(comparison *api.ReleaseComparisonResponse) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "## Release comparison: %s → %s\n\n", comparison.Base.Name, comparison.Target.Name)
+	fmt.Fprintf(&sb, "%d added, %d removed, %d upgraded, %d downgraded, %d unchanged\n\n",
+		comparison.Summary[string(domain.ChangeAdded)],
+		comparison.Summary[string(domain.ChangeRemoved)],
+		comparison.Summary[string(domain.ChangeUpgraded)],
+		comparison.Summary[string(domain.ChangeDowngraded)],
+		comparison.Summary[string(domain.ChangeUnchanged)])
+
+	fmt.Fprintf(&sb, "| System | Change | %s | %s |\n", escapeMarkdownCell(comparison.Base.Name), escapeMarkdownCell(comparison.Target.Name))
+	sb.WriteString("|---|---|---|---|\n")
+	for _, system := range comparison.Systems {
+		writeMarkdownComparisonRow(&sb, system, "")
+		for _, sub := range system.Subsystems {
+			writeMarkdownComparisonRow(&sb, sub, "↳ ")
+		}
+	}
+
+	return sb.String()
+}
+
+// Helper function to write one comparison row of the Markdown table
+func writeMarkdownComparisonRow(sb *strings.Builder, system api.SystemComparisonResponse, prefix string) {
+	fmt.Fprintf(sb, "| %s%s | %s | %s | %s |\n",
+		prefix,
+		escapeMarkdownCell(system.SystemName),
+		system.Change,
+		markdownVersionCell(system.BaseVersion),
+		markdownVersionCell(system.TargetVersion))
+}
+
+// Helper function to format a version for a Markdown table cell
+func markdownVersionCell(v string) string {
+	if v == "" {
+		return "–"
+	}
+	return "`" + escapeMarkdownCell(v) + "`"
+}
+
+// Helper function to escape characters that would break a Markdown table cell
+func escapeMarkdownCell(s string) string {
+	return strings.ReplaceAll(s, "|", "\\|")
+}
@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"sort"
+
+	"release-management/internal/models/domain"
+	"release-management/internal/models/mapper"
+	"release-management/internal/releasenotes"
+	"release-management/internal/repository"
+	"release-management/internal/version"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GET /releases/:id/notes
+func (h *ReleaseHandler) GetReleaseNotes(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	release, err := h.store.Releases().Get(ctx, c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Release not found"})
+		return
+	}
+
+	format := domain.ReleaseNotesFormat(c.DefaultQuery("format", string(domain.ReleaseNotesJSON)))
+	if !format.IsValid() {
+		respondWithError(c, domain.ErrReleaseNotesInvalidFormat, "Invalid format")
+		return
+	}
+
+	builds, err := h.store.Builds().List(ctx, repository.BuildFilter{ReleaseID: release.ID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch release builds"})
+		return
+	}
+
+	systems, err := releaseNotesEntries(ctx, h.store, release, builds)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch previous releases"})
+		return
+	}
+
+	response := mapper.ReleaseNotesDomainToAPI(&domain.ReleaseNotes{Release: release, Systems: systems})
+	if format == domain.ReleaseNotesJSON {
+		c.JSON(http.StatusOK, response)
+		return
+	}
+
+	// Render into a buffer so that a failing template does not leave half written notes behind
+	var notes bytes.Buffer
+	if err := releasenotes.NewRenderer(h.cfg.ReleaseNotes.TemplateDir).Render(&notes, format, c.Query("team"), response); err != nil {
+		respondWithError(c, err, "Failed to render release notes")
+		return
+	}
+
+	c.Data(http.StatusOK, releasenotes.ContentType(format), notes.Bytes())
+}
+
+// Helper function to line up every build of a release with the latest earlier release containing its system
+// and group subsystems under their parents
+func releaseNotesEntries(ctx context.Context, store repository.Store, release *domain.Release, builds []domain.Build) ([]domain.ReleaseNotesEntry, error) {
+	var leaves []domain.ReleaseNotesEntry
+	systemsByID := make(map[string]domain.System)
+	for _, build := range builds {
+		system := domain.System{ID: build.SystemID}
+		if build.System != nil {
+			system = *build.System
+		}
+		systemsByID[build.SystemID] = system
+
+		entry := domain.ReleaseNotesEntry{
+			SystemID:   system.ID,
+			SystemName: system.Name,
+			SystemType: system.Type,
+			Change:     domain.ChangeAdded,
+			Version:    build.Version,
+			BuildDate:  build.BuildDate,
+			Changelog:  build.Changelog,
+		}
+
+		previous, err := previousReleaseBuild(ctx, store, release, build.SystemID)
+		if err != nil {
+			return nil, err
+		}
+		if previous != nil {
+			entry.PreviousVersion = previous.Version
+			entry.PreviousRelease = previous.Release
+			switch version.Compare(previous.Version, build.Version) {
+			case -1:
+				entry.Change = domain.ChangeUpgraded
+			case 1:
+				entry.Change = domain.ChangeDowngraded
+			default:
+				entry.Change = domain.ChangeUnchanged
+			}
+		}
+
+		leaves = append(leaves, entry)
+	}
+
+	// Load the parents of subsystems so their builds can be grouped
+	var parentIDs []string
+	for _, system := range systemsByID {
+		if system.ParentID != nil && *system.ParentID != "" {
+			parentIDs = append(parentIDs, *system.ParentID)
+		}
+	}
+	parentsByID := make(map[string]domain.System)
+	if len(parentIDs) > 0 {
+		parents, err := store.Systems().List(ctx, repository.SystemFilter{IDs: parentIDs})
+		if err != nil {
+			return nil, err
+		}
+		for _, parent := range parents {
+			parentsByID[parent.ID] = parent
+		}
+	}
+
+	var result []domain.ReleaseNotesEntry
+	groups := make(map[string]*domain.ReleaseNotesEntry)
+	for _, leaf := range leaves {
+		system := systemsByID[leaf.SystemID]
+		if system.ParentID == nil {
+			result = append(result, leaf)
+			continue
+		}
+		parent, found := parentsByID[*system.ParentID]
+		if !found {
+			result = append(result, leaf)
+			continue
+		}
+
+		group, found := groups[parent.ID]
+		if !found {
+			group = &domain.ReleaseNotesEntry{
+				SystemID:   parent.ID,
+				SystemName: parent.Name,
+				SystemType: parent.Type,
+			}
+			groups[parent.ID] = group
+		}
+		group.Subsystems = append(group.Subsystems, leaf)
+	}
+
+	for _, group := range groups {
+		sortReleaseNotesEntries(group.Subsystems)
+		group.Change = releaseNotesGroupChange(group.Subsystems)
+		result = append(result, *group)
+	}
+
+	sortReleaseNotesEntries(result)
+	return result, nil
+}
+
+// Helper function to find the build of a system in the latest release before release, ignoring cancelled releases.
+// Nil is returned if no earlier release contains the system.
+func previousReleaseBuild(ctx context.Context, store repository.Store, release *domain.Release, systemID string) (*domain.Build, error) {
+	builds, err := store.Builds().List(ctx, repository.BuildFilter{SystemID: systemID})
+	if err != nil {
+		return nil, err
+	}
+
+	var previous *domain.Build
+	for i := range builds {
+		candidate := builds[i].Release
+		if candidate == nil || candidate.ID == release.ID || candidate.Status == domain.StatusCancelled {
+			continue
+		}
+		if !releaseBefore(candidate, release) {
+			continue
+		}
+		if previous == nil || releaseBefore(previous.Release, candidate) {
+			previous = &builds[i]
+		}
+	}
+	return previous, nil
+}
+
+// Helper function to order releases by release date, then by creation for releases on the same date
+func releaseBefore(a, b *domain.Release) bool {
+	if !a.ReleaseDate.Equal(b.ReleaseDate) {
+		return a.ReleaseDate.Before(b.ReleaseDate)
+	}
+	return a.CreatedAt.Before(b.CreatedAt)
+}
+
+// Helper function to derive the change of a parent system from the subsystems built in the release
+func releaseNotesGroupChange(subsystems []domain.ReleaseNotesEntry) domain.SystemChange {
+	comparisons := make([]domain.SystemComparison, len(subsystems))
+	for i, sub := range subsystems {
+		comparisons[i] = domain.SystemComparison{Change: sub.Change}
+	}
+	return rollupSystemChange(comparisons)
+}
+
+// Helper function to sort release notes entries by system name
+func sortReleaseNotesEntries(entries []domain.ReleaseNotesEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].SystemName < entries[j].SystemName
+	})
+}
@@ -0,0 +1,349 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"release-management/internal/models/api"
+	"release-management/internal/models/domain"
+	"release-management/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RollbackEnvironmentSystem restores a system in an environment to a previous deployment
+func (h *EnvironmentHandler) RollbackEnvironmentSystem(c *gin.Context) {
+	envID := c.Param("id")
+	systemID := c.Param("systemId")
+
+	// An empty body rolls back to the previous version
+	var req api.EnvironmentSystemRollbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil && err != io.EOF {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	envSystem, err := h.store.Environments().GetSystem(ctx, envID, systemID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Environment system not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch environment system"})
+		return
+	}
+	if !h.allowDirectChange(c, envID) {
+		return
+	}
+
+	var targetVersion string
+	switch {
+	case req.DeploymentID != "":
+		// Restore the version that a specific deployment put in place
+		deployment, err := h.store.Deployments().Get(ctx, req.DeploymentID)
+		if err == nil && (deployment.EnvironmentID != envID || deployment.SystemID != systemID) {
+			err = repository.ErrNotFound
+		}
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Deployment not found for this environment system"})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch deployment"})
+			return
+		}
+		targetVersion = deployment.NewVersion
+
+	case req.Version != "":
+		// Only versions that were previously deployed here can be rolled back to
+		deployments, err := h.store.Deployments().List(ctx, repository.DeploymentFilter{
+			EnvironmentID: envID,
+			SystemID:      systemID,
+			Version:       req.Version,
+			Limit:         1,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch deployment history"})
+			return
+		}
+		if len(deployments) == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Version %s was never deployed to this environment", req.Version)})
+			return
+		}
+		targetVersion = req.Version
+
+	default:
+		// Restore the version that ran before the most recent deployment
+		deployments, err := h.store.Deployments().List(ctx, repository.DeploymentFilter{
+			EnvironmentID: envID,
+			SystemID:      systemID,
+			Limit:         1,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch deployment history"})
+			return
+		}
+		if len(deployments) == 0 {
+			c.JSON(http.StatusConflict, gin.H{"error": "System has no deployment history to roll back to"})
+			return
+		}
+		targetVersion = deployments[0].OldVersion
+	}
+
+	if targetVersion == "" {
+		c.JSON(http.StatusConflict, gin.H{"error": "System had no version before this deployment. Remove it from the environment instead"})
+		return
+	}
+
+	if targetVersion == envSystem.Version {
+		c.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("System is already running version %s", targetVersion)})
+		return
+	}
+
+	// The build behind the target version must still exist
+	isValid, err := isValidVersionForSystem(ctx, h.store, systemID, targetVersion)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate version"})
+		return
+	}
+	if !isValid {
+		c.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("Cannot roll back to version %s because its build no longer exists", targetVersion)})
+		return
+	}
+
+	before := *envSystem
+	envSystem.Version = targetVersion
+
+	if err := h.store.Transaction(ctx, func(tx repository.Store) error {
+		return rollbackEnvironmentSystem(tx, c, &before, envSystem)
+	}); err != nil {
+		respondWithError(c, err, "Failed to update environment system")
+		return
+	}
+
+	c.JSON(http.StatusOK, api.RollbackChange{
+		SystemID:    envSystem.SystemID,
+		SystemName:  systemName(envSystem.System),
+		Action:      "rollback",
+		FromVersion: before.Version,
+		ToVersion:   targetVersion,
+	})
+}
+
+// RollbackEnvironment reverts every system in an environment to the version it ran at a given time
+func (h *EnvironmentHandler) RollbackEnvironment(c *gin.Context) {
+	var req api.EnvironmentRollbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	// Check if environment exists
+	environment, err := h.store.Environments().Get(ctx, c.Param("id"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Environment not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch environment"})
+		return
+	}
+	if !h.allowDirectChange(c, environment.ID) {
+		return
+	}
+
+	envSystems, err := h.store.Environments().ListSystems(ctx, environment.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch environment systems"})
+		return
+	}
+
+	deployments, err := h.store.Deployments().List(ctx, repository.DeploymentFilter{EnvironmentID: environment.ID, OldestFirst: true})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch deployment history"})
+		return
+	}
+
+	versionsAt := getVersionsAt(deployments, req.At)
+
+	// Work out the changes and make sure every target build still exists before touching anything
+	var changes []api.RollbackChange
+	var toUpdate []domain.EnvironmentSystem
+	var missingBuilds []string
+	for _, envSystem := range envSystems {
+		targetVersion, known := versionsAt[envSystem.SystemID]
+		if !known || targetVersion == envSystem.Version {
+			continue
+		}
+
+		if targetVersion == "" {
+			changes = append(changes, api.RollbackChange{
+				SystemID:    envSystem.SystemID,
+				SystemName:  systemName(envSystem.System),
+				Action:      "skipped",
+				FromVersion: envSystem.Version,
+			})
+			continue
+		}
+
+		isValid, err := isValidVersionForSystem(ctx, h.store, envSystem.SystemID, targetVersion)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate version"})
+			return
+		}
+		if !isValid {
+			missingBuilds = append(missingBuilds, fmt.Sprintf("%s %s", systemName(envSystem.System), targetVersion))
+			continue
+		}
+
+		changes = append(changes, api.RollbackChange{
+			SystemID:    envSystem.SystemID,
+			SystemName:  systemName(envSystem.System),
+			Action:      "rollback",
+			FromVersion: envSystem.Version,
+			ToVersion:   targetVersion,
+		})
+		toUpdate = append(toUpdate, envSystem)
+	}
+
+	// Systems that ran at the time and were removed since are added back, unless they were deleted altogether
+	var toRestore []domain.EnvironmentSystem
+	for _, systemID := range removedSystemsAt(deployments, envSystems, versionsAt) {
+		targetVersion := versionsAt[systemID]
+		system, err := h.store.Systems().Get(ctx, systemID)
+		if errors.Is(err, repository.ErrNotFound) {
+			changes = append(changes, api.RollbackChange{
+				SystemID:  systemID,
+				Action:    "skipped",
+				ToVersion: targetVersion,
+			})
+			continue
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch system"})
+			return
+		}
+
+		isValid, err := isValidVersionForSystem(ctx, h.store, systemID, targetVersion)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate version"})
+			return
+		}
+		if !isValid {
+			missingBuilds = append(missingBuilds, fmt.Sprintf("%s %s", system.Name, targetVersion))
+			continue
+		}
+
+		changes = append(changes, api.RollbackChange{
+			SystemID:   systemID,
+			SystemName: system.Name,
+			Action:     "restore",
+			ToVersion:  targetVersion,
+		})
+		toRestore = append(toRestore, domain.EnvironmentSystem{EnvironmentID: environment.ID, SystemID: systemID, Version: targetVersion})
+	}
+
+	if len(missingBuilds) > 0 {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":          "Cannot roll back environment because some builds no longer exist",
+			"missing_builds": missingBuilds,
+		})
+		return
+	}
+
+	if err := h.store.Transaction(ctx, func(tx repository.Store) error {
+		for i := range toUpdate {
+			before := toUpdate[i]
+			toUpdate[i].Version = versionsAt[before.SystemID]
+			if err := rollbackEnvironmentSystem(tx, c, &before, &toUpdate[i]); err != nil {
+				return err
+			}
+		}
+		for i := range toRestore {
+			if err := restoreEnvironmentSystem(tx, c, &toRestore[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		respondWithError(c, err, "Failed to update environment system")
+		return
+	}
+
+	if changes == nil {
+		changes = []api.RollbackChange{}
+	}
+
+	c.JSON(http.StatusOK, api.EnvironmentRollbackResponse{
+		EnvironmentID: environment.ID,
+		At:            req.At,
+		Changes:       changes,
+	})
+}
+
+// Helper function to store a rolled back version with its deployment and audit entry
+func rollbackEnvironmentSystem(tx repository.Store, c *gin.Context, before, after *domain.EnvironmentSystem) error {
+	ctx := c.Request.Context()
+	if err := tx.Environments().UpdateSystem(ctx, after); err != nil {
+		return err
+	}
+	if err := recordDeployment(c, tx, after, before.Version, domain.DeploymentSourceRollback); err != nil {
+		return err
+	}
+	return auditEnvironmentSystem(tx, c, before, after)
+}
+
+// Helper function to add a system that was removed from an environment back with its deployment and audit entry
+func restoreEnvironmentSystem(tx repository.Store, c *gin.Context, envSystem *domain.EnvironmentSystem) error {
+	if err := tx.Environments().AddSystem(c.Request.Context(), envSystem); err != nil {
+		return err
+	}
+	if err := recordDeployment(c, tx, envSystem, "", domain.DeploymentSourceRollback); err != nil {
+		return err
+	}
+	return auditEnvironmentSystem(tx, c, nil, envSystem)
+}
+
+// Helper function to list the systems that ran in an environment at a point in time but are no longer linked to it,
+// in the order they were first deployed
+func removedSystemsAt(deployments []domain.Deployment, linked []domain.EnvironmentSystem, versionsAt map[string]string) []string {
+	seen := make(map[string]bool)
+	for _, envSystem := range linked {
+		seen[envSystem.SystemID] = true
+	}
+
+	var removed []string
+	for _, deployment := range deployments {
+		if seen[deployment.SystemID] {
+			continue
+		}
+		seen[deployment.SystemID] = true
+		if versionsAt[deployment.SystemID] != "" {
+			removed = append(removed, deployment.SystemID)
+		}
+	}
+	return removed
+}
+
+// Helper function to reconstruct the version of each system at a point in time from its deployments.
+// Deployments must be ordered oldest first.
+func getVersionsAt(deployments []domain.Deployment, at time.Time) map[string]string {
+	versions := make(map[string]string)
+	for _, deployment := range deployments {
+		if !deployment.CreatedAt.After(at) {
+			// The latest deployment at or before the timestamp wins
+			versions[deployment.SystemID] = deployment.NewVersion
+		} else if _, known := versions[deployment.SystemID]; !known {
+			// Otherwise the first deployment afterwards tells us what was running before it
+			versions[deployment.SystemID] = deployment.OldVersion
+		}
+	}
+	return versions
+}
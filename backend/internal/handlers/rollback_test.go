@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	"release-management/internal/models/domain"
+)
+
+func TestGetVersionsAt(t *testing.T) {
+	at := time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)
+	deployed := func(systemID, oldVersion, newVersion string, offset time.Duration) domain.Deployment {
+		return domain.Deployment{SystemID: systemID, OldVersion: oldVersion, NewVersion: newVersion, CreatedAt: at.Add(offset)}
+	}
+
+	tests := []struct {
+		name        string
+		deployments []domain.Deployment
+		want        map[string]string
+	}{
+		{"no history", nil, map[string]string{}},
+		{"latest deployment before wins", []domain.Deployment{
+			deployed("api", "", "1.0.0", -2*time.Hour),
+			deployed("api", "1.0.0", "1.1.0", -time.Hour),
+		}, map[string]string{"api": "1.1.0"}},
+		{"deployment at the time counts", []domain.Deployment{
+			deployed("api", "1.0.0", "1.1.0", 0),
+		}, map[string]string{"api": "1.1.0"}},
+		{"first deployment after tells the version before", []domain.Deployment{
+			deployed("api", "1.0.0", "1.1.0", time.Hour),
+			deployed("api", "1.1.0", "1.2.0", 2*time.Hour),
+		}, map[string]string{"api": "1.0.0"}},
+		{"added after the time had no version", []domain.Deployment{
+			deployed("web", "", "2.0.0", time.Hour),
+		}, map[string]string{"web": ""}},
+		{"removed before the time has no version", []domain.Deployment{
+			deployed("web", "", "2.0.0", -2*time.Hour),
+			deployed("web", "2.0.0", "", -time.Hour),
+		}, map[string]string{"web": ""}},
+		{"removed after the time", []domain.Deployment{
+			deployed("web", "", "2.0.0", -time.Hour),
+			deployed("web", "2.0.0", "", time.Hour),
+		}, map[string]string{"web": "2.0.0"}},
+		{"systems are independent", []domain.Deployment{
+			deployed("api", "", "1.0.0", -time.Hour),
+			deployed("web", "", "2.0.0", time.Hour),
+			deployed("api", "1.0.0", "1.1.0", 2*time.Hour),
+		}, map[string]string{"api": "1.0.0", "web": ""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getVersionsAt(tt.deployments, at); !maps.Equal(got, tt.want) {
+				t.Errorf("getVersionsAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRemovedSystemsAt(t *testing.T) {
+	deployments := []domain.Deployment{{SystemID: "web"}, {SystemID: "api"}, {SystemID: "worker"}, {SystemID: "web"}, {SystemID: "cron"}}
+	linked := []domain.EnvironmentSystem{{SystemID: "api"}}
+	versionsAt := map[string]string{"api": "1.0.0", "web": "2.0.0", "worker": "", "cron": "0.1.0"}
+
+	if got, want := removedSystemsAt(deployments, linked, versionsAt), []string{"web", "cron"}; !slices.Equal(got, want) {
+		t.Errorf("removedSystemsAt() = %v, want %v", got, want)
+	}
+}
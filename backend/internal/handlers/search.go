@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"release-management/internal/middleware"
+	"release-management/internal/models/domain"
+	"release-management/internal/models/mapper"
+	"release-management/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
+// searchResources maps each search result type to the resource the caller needs read access to
+var searchResources = map[domain.SearchResultType]string{
+	domain.SearchResultRelease:     domain.ResourceReleases,
+	domain.SearchResultSystem:      domain.ResourceSystems,
+	domain.SearchResultBuild:       domain.ResourceBuilds,
+	domain.SearchResultEnvironment: domain.ResourceEnvironments,
+}
+
+type SearchHandler struct {
+	store repository.Store
+}
+
+func NewSearchHandler(store repository.Store) *SearchHandler {
+	return &SearchHandler{store: store}
+}
+
+// GET /search
+func (h *SearchHandler) Search(c *gin.Context) {
+	terms := strings.Fields(c.Query("q"))
+	if len(terms) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing 'q' parameter"})
+		return
+	}
+
+	query := repository.SearchQuery{Terms: terms, Limit: defaultSearchLimit}
+
+	if limitParam := c.Query("limit"); limitParam != "" {
+		limit, err := strconv.Atoi(limitParam)
+		if err != nil || limit < 1 || limit > maxSearchLimit {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'limit' parameter. Use a number between 1 and " + strconv.Itoa(maxSearchLimit)})
+			return
+		}
+		query.Limit = limit
+	}
+
+	requested := domain.SearchResultTypes
+	if typeParam := c.Query("type"); typeParam != "" {
+		requested = nil
+		for _, value := range strings.Split(typeParam, ",") {
+			resultType := domain.SearchResultType(strings.TrimSpace(value))
+			if !resultType.IsValid() {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'type' parameter. Valid types are: release, system, build, environment"})
+				return
+			}
+			requested = append(requested, resultType)
+		}
+	}
+
+	// Only search the types the caller could list through their own endpoints
+	for _, resultType := range requested {
+		allowed, err := canRead(c, h.store, searchResources[resultType])
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check permissions"})
+			return
+		}
+		if allowed {
+			query.Types = append(query.Types, resultType)
+		}
+	}
+	if len(query.Types) == 0 {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to search these types"})
+		return
+	}
+
+	results, err := h.store.Search().Search(c.Request.Context(), query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to search"})
+		return
+	}
+
+	respondWithList(c, results, mapper.SearchResultDomainToAPI)
+}
+
+// Helper function to check that the caller may read a resource, including the scopes of an API token
+func canRead(c *gin.Context, store repository.Store, resource string) (bool, error) {
+	if c.GetString("authMethod") == middleware.AuthMethodAPIToken {
+		token, ok := c.MustGet("apiToken").(*domain.APIToken)
+		if !ok || !token.HasScope(domain.TokenScope(resource+":read")) {
+			return false, nil
+		}
+	}
+	return middleware.Authorize(c, store, resource, domain.ActionRead, "")
+}
@@ -3,54 +3,66 @@ package handlers
 import (
 	"net/http"
 
-	"release-management/internal/database"
+	"release-management/internal/audit"
 	"release-management/internal/models/api"
-	"release-management/internal/models/db"
 	"release-management/internal/models/domain"
 	"release-management/internal/models/mapper"
+	"release-management/internal/repository"
+	"release-management/internal/service"
+	"release-management/internal/version"
 
 	"github.com/gin-gonic/gin"
 )
 
-type SystemHandler struct{}
+type SystemHandler struct {
+	store repository.Store
+}
 
-func NewSystemHandler() *SystemHandler {
-	return &SystemHandler{}
+func NewSystemHandler(store repository.Store) *SystemHandler {
+	return &SystemHandler{store: store}
 }
 
 // GET /systems
 func (h *SystemHandler) GetSystems(c *gin.Context) {
-	var dbSystems []db.System
-	if err := database.DB.Find(&dbSystems).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch systems"})
+	filter := repository.SystemFilter{ParentID: c.Query("parent_id"), Name: c.Query("name")}
+	if systemType := c.Query("type"); systemType != "" {
+		if !domain.SystemType(systemType).IsValid() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'type' parameter. Valid values are: parent_systems, systems, subsystems"})
+			return
+		}
+		filter.Type = domain.SystemType(systemType)
+	}
+	if status := c.Query("status"); status != "" {
+		if !domain.SystemStatus(status).IsValid() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'status' parameter. Valid values are: active, deprecated"})
+			return
+		}
+		filter.Status = domain.SystemStatus(status)
+	}
+
+	page, ok := pageRequest(c, repository.SystemSortFields, repository.Sort{Field: "created_at"})
+	if !ok {
 		return
 	}
 
-	// Convert to API responses
-	apiSystems := make([]api.SystemResponse, len(dbSystems))
-	for i, dbSys := range dbSystems {
-		domainSys := mapper.SystemDBToDomain(&dbSys)
-		apiSys := mapper.SystemDomainToAPI(domainSys)
-		apiSystems[i] = *apiSys
+	systems, err := h.store.Systems().ListPage(c.Request.Context(), filter, page)
+	if err != nil {
+		respondWithListError(c, err, "Failed to fetch systems")
+		return
 	}
 
-	c.JSON(http.StatusOK, apiSystems)
+	respondWithPage(c, systems, mapper.SystemDomainToAPI)
 }
 
 // GET /systems/:id
 func (h *SystemHandler) GetSystem(c *gin.Context) {
-	id := c.Param("id")
-	var dbSys db.System
-
-	if err := database.DB.Preload("Parent").First(&dbSys, "id = ?", id).Error; err != nil {
+	system, err := h.store.Systems().Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "System not found"})
 		return
 	}
 
-	domainSys := mapper.SystemDBToDomain(&dbSys)
-	apiSys := mapper.SystemDomainToAPI(domainSys)
-
-	c.JSON(http.StatusOK, apiSys)
+	c.JSON(http.StatusOK, mapper.SystemDomainToAPI(system))
 }
 
 // POST /systems
@@ -67,66 +79,29 @@ func (h *SystemHandler) CreateSystem(c *gin.Context) {
 		return
 	}
 
-	// Validate type value
-	sysType := domain.SystemType(req.Type)
-	if !sysType.IsValid() {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid type. Must be one of: parent_systems, systems, subsystems"})
-		return
-	}
-
-	if req.Status == "" {
-		req.Status = string(domain.StatusActive)
-	}
+	ctx := c.Request.Context()
+	system := mapper.SystemAPIToDomain(&req)
 
-	// Validate type consistency with parent_id
-	if req.ParentID != nil && *req.ParentID != "" {
-		// If has parent, must be subsystem
-		if req.Type != string(domain.SystemTypeSubsystem) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "System with parent must be of type 'subsystems'"})
-			return
-		}
-
-		var parent db.System
-		if err := database.DB.First(&parent, "id = ?", *req.ParentID).Error; err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Parent system not found"})
-			return
+	if err := h.store.Transaction(ctx, func(tx repository.Store) error {
+		if err := service.NewSystemService(tx).Create(ctx, system); err != nil {
+			return err
 		}
-
-		// Parent must be parent_systems type
-		if parent.Type != string(domain.SystemTypeParent) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Parent system must be of type 'parent_systems'"})
-			return
-		}
-	} else {
-		// If no parent, cannot be subsystem
-		if req.Type == string(domain.SystemTypeSubsystem) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Subsystem must have a parent system"})
-			return
-		}
-	}
-
-	// Convert to domain and then to DB
-	domainSys := mapper.SystemAPIToDomain(&req)
-	dbSys := mapper.SystemDomainToDB(domainSys)
-
-	if err := database.DB.Create(&dbSys).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create system"})
+		return audit.Record(tx, c, domain.AuditEntitySystem, system.ID, nil, mapper.SystemDomainToAPI(system))
+	}); err != nil {
+		respondWithError(c, err, "Failed to create system")
 		return
 	}
 
-	// Convert back for response
-	savedDomain := mapper.SystemDBToDomain(dbSys)
-	response := mapper.SystemDomainToAPI(savedDomain)
-
-	c.JSON(http.StatusCreated, response)
+	c.JSON(http.StatusCreated, mapper.SystemDomainToAPI(system))
 }
 
 // PUT /systems/:id
 func (h *SystemHandler) UpdateSystem(c *gin.Context) {
+	ctx := c.Request.Context()
 	id := c.Param("id")
-	var dbSys db.System
 
-	if err := database.DB.First(&dbSys, "id = ?", id).Error; err != nil {
+	system, err := h.store.Systems().Get(ctx, id)
+	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "System not found"})
 		return
 	}
@@ -137,155 +112,69 @@ func (h *SystemHandler) UpdateSystem(c *gin.Context) {
 		return
 	}
 
-	// Validate type if provided
-	if updateReq.Type != "" {
-		sysType := domain.SystemType(updateReq.Type)
-		if !sysType.IsValid() {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid type. Must be one of: parent_systems, systems, subsystems"})
-			return
-		}
-	}
-
-	// Validate status if provided
-	if updateReq.Status != "" {
-		status := updateReq.Status
-		if status != string(domain.StatusActive) && status != string(domain.StatusDeprecated) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status. Must be 'active' or 'deprecated'"})
-			return
-		}
-	}
-
-	// Check if type can be changed
-	if updateReq.Type != "" && dbSys.Type != updateReq.Type {
-		// Cannot change type if parent_systems has subsystems
-		if dbSys.Type == string(domain.SystemTypeParent) {
-			var subsystemCount int64
-			database.DB.Model(&db.System{}).Where("parent_id = ?", id).Count(&subsystemCount)
-			if subsystemCount > 0 {
-				c.JSON(http.StatusBadRequest, gin.H{"error": "Cannot change type of parent_systems that has subsystems"})
-				return
-			}
-		}
-
-		// Cannot change type if systems has builds
-		if dbSys.Type == string(domain.SystemTypeSystem) {
-			var buildCount int64
-			database.DB.Model(&db.Build{}).Where("system_id = ?", id).Count(&buildCount)
-			if buildCount > 0 {
-				c.JSON(http.StatusBadRequest, gin.H{"error": "Cannot change type of systems that has builds associated with it"})
-				return
-			}
-		}
-	}
-
-	// Modifying parent systems status - update subsystems to match
-	if updateReq.Status != "" && dbSys.Status != updateReq.Status && dbSys.Type == string(domain.SystemTypeParent) {
-		var subsystems []db.System
-		if err := database.DB.Where("parent_id = ?", id).Find(&subsystems).Error; err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch subsystems for status update"})
-			return
-		}
-
-		for _, subsystem := range subsystems {
-			if subsystem.Status != updateReq.Status {
-				subsystem.Status = updateReq.Status
-				if err := database.DB.Save(&subsystem).Error; err != nil {
-					c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update subsystem status: " + err.Error()})
-					return
-				}
-			}
-		}
-	}
-
-	// Validate type consistency with parent_id
-	if updateReq.ParentID != nil && *updateReq.ParentID != "" {
-		// If has parent, must be subsystem
-		newType := dbSys.Type
-		if updateReq.Type != "" {
-			newType = updateReq.Type
-		}
-
-		if newType != string(domain.SystemTypeSubsystem) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "System with parent must be of type 'subsystems'"})
-			return
-		}
-
-		var parent db.System
-		if err := database.DB.First(&parent, "id = ?", *updateReq.ParentID).Error; err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Parent system not found"})
-			return
-		}
-
-		// Parent must be parent_systems type
-		if parent.Type != string(domain.SystemTypeParent) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Parent system must be of type 'parent_systems'"})
-			return
-		}
-	} else if (updateReq.ParentID == nil || (updateReq.ParentID != nil && *updateReq.ParentID == "")) && updateReq.Type != "" {
-		// If no parent, cannot be subsystem
-		if updateReq.Type == string(domain.SystemTypeSubsystem) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Subsystem must have a parent system"})
-			return
-		}
-	}
-
-	// Check if this system has subsystems and is being moved under a parent
-	if updateReq.ParentID != nil && *updateReq.ParentID != "" {
-		var subsystemCount int64
-		database.DB.Model(&db.System{}).Where("parent_id = ?", id).Count(&subsystemCount)
-		if subsystemCount > 0 {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Cannot move a system with subsystems under another system"})
-			return
-		}
-	}
+	// Leave out the parent, which may change below
+	system.Parent = nil
+	before := mapper.SystemDomainToAPI(system)
 
 	// Apply updates
 	if updateReq.Name != "" {
-		dbSys.Name = updateReq.Name
+		system.Name = updateReq.Name
 	}
 	if updateReq.Type != "" {
-		dbSys.Type = updateReq.Type
+		system.Type = domain.SystemType(updateReq.Type)
 	}
 	if updateReq.Status != "" {
-		dbSys.Status = updateReq.Status
+		system.Status = domain.SystemStatus(updateReq.Status)
 	}
 	if updateReq.ParentID != nil {
-		dbSys.ParentID = updateReq.ParentID
+		system.ParentID = updateReq.ParentID
 	}
 	if updateReq.Description != nil {
-		dbSys.Description = updateReq.Description
+		system.Description = updateReq.Description
+	}
+	if updateReq.StrictSemver != nil {
+		system.StrictSemver = *updateReq.StrictSemver
+	}
+	if updateReq.StrictDigests != nil {
+		system.StrictDigests = *updateReq.StrictDigests
 	}
 
-	if err := database.DB.Save(&dbSys).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update system"})
+	if err := h.store.Transaction(ctx, func(tx repository.Store) error {
+		subsystemChanges, err := service.NewSystemService(tx).Update(ctx, system)
+		if err != nil {
+			return err
+		}
+		if err := audit.Record(tx, c, domain.AuditEntitySystem, system.ID, before, mapper.SystemDomainToAPI(system)); err != nil {
+			return err
+		}
+		for _, change := range subsystemChanges {
+			if err := audit.Record(tx, c, domain.AuditEntitySystem, change.After.ID, mapper.SystemDomainToAPI(&change.Before), mapper.SystemDomainToAPI(&change.After)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		respondWithError(c, err, "Failed to update system")
 		return
 	}
 
-	// Convert to response
-	domainSys := mapper.SystemDBToDomain(&dbSys)
-	response := mapper.SystemDomainToAPI(domainSys)
-
-	c.JSON(http.StatusOK, response)
+	c.JSON(http.StatusOK, mapper.SystemDomainToAPI(system))
 }
 
 // DELETE /systems/:id
 func (h *SystemHandler) DeleteSystem(c *gin.Context) {
+	ctx := c.Request.Context()
 	id := c.Param("id")
 
-	// Check if the system has subsystems
-	var subsystemCount int64
-	if err := database.DB.Model(&db.System{}).Where("parent_id = ?", id).Count(&subsystemCount).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check for subsystems"})
-		return
-	}
-
-	if subsystemCount > 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Cannot delete system with subsystems. Please delete subsystems first"})
-		return
-	}
-
-	if err := database.DB.Delete(&db.System{}, "id = ?", id).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete system"})
+	if err := h.store.Transaction(ctx, func(tx repository.Store) error {
+		system, err := service.NewSystemService(tx).Delete(ctx, id)
+		if err != nil {
+			return err
+		}
+		system.Parent = nil
+		return audit.Record(tx, c, domain.AuditEntitySystem, id, mapper.SystemDomainToAPI(system), nil)
+	}); err != nil {
+		respondWithError(c, err, "Failed to delete system")
 		return
 	}
 
@@ -294,42 +183,65 @@ func (h *SystemHandler) DeleteSystem(c *gin.Context) {
 
 // GET /systems/:id/subsystems
 func (h *SystemHandler) GetSubsystems(c *gin.Context) {
-	id := c.Param("id")
-	var dbSubsystems []db.System
-
-	if err := database.DB.Where("parent_id = ?", id).Find(&dbSubsystems).Error; err != nil {
+	subsystems, err := h.store.Systems().List(c.Request.Context(), repository.SystemFilter{ParentID: c.Param("id")})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch subsystems"})
 		return
 	}
 
-	// Convert to API responses
-	apiSubsystems := make([]api.SystemResponse, len(dbSubsystems))
-	for i, dbSub := range dbSubsystems {
-		domainSub := mapper.SystemDBToDomain(&dbSub)
-		apiSub := mapper.SystemDomainToAPI(domainSub)
-		apiSubsystems[i] = *apiSub
-	}
-
-	c.JSON(http.StatusOK, apiSubsystems)
+	respondWithList(c, subsystems, mapper.SystemDomainToAPI)
 }
 
 // GET /systems/:id/builds
 func (h *SystemHandler) GetSystemBuilds(c *gin.Context) {
-	id := c.Param("id")
-	var dbBuilds []db.Build
+	page, ok := pageRequest(c, buildVersionSortFields, repository.Sort{Field: "version", Desc: true})
+	if !ok {
+		return
+	}
 
-	if err := database.DB.Where("system_id = ?", id).Preload("System").Find(&dbBuilds).Error; err != nil {
+	builds, err := h.store.Builds().List(c.Request.Context(), repository.BuildFilter{SystemID: c.Param("id")})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch release builds"})
 		return
 	}
 
-	// Convert to API responses
-	apiBuilds := make([]api.BuildResponse, len(dbBuilds))
-	for i, dbBuild := range dbBuilds {
-		domainBuild := mapper.BuildDBToDomain(&dbBuild)
-		apiBuild := mapper.BuildDomainToAPI(domainBuild)
-		apiBuilds[i] = *apiBuild
+	// Filter by version range, e.g. ?range=>=1.2.0 <2.0.0
+	if rangeParam := c.Query("range"); rangeParam != "" {
+		versionRange, err := version.ParseRange(rangeParam)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid version range: " + err.Error()})
+			return
+		}
+
+		var filtered []domain.Build
+		for _, build := range builds {
+			if versionRange.Contains(build.Version) {
+				filtered = append(filtered, build)
+			}
+		}
+		builds = filtered
+	}
+
+	// Only keep the latest version, preferring releases over pre-releases
+	if c.Query("latest") == "true" && len(builds) > 0 {
+		versions := make([]string, len(builds))
+		for i, build := range builds {
+			versions[i] = build.Version
+		}
+		latest, _ := version.Latest(versions)
+		for _, build := range builds {
+			if build.Version == latest {
+				builds = []domain.Build{build}
+				break
+			}
+		}
+	}
+
+	result, err := pageOfBuildsByVersion(builds, page)
+	if err != nil {
+		respondWithListError(c, err, "Failed to fetch system builds")
+		return
 	}
 
-	c.JSON(http.StatusOK, apiBuilds)
+	respondWithPage(c, result, mapper.BuildDomainToAPI)
 }
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"release-management/internal/models/api"
+	"release-management/internal/models/domain"
+	"release-management/internal/models/mapper"
+	"release-management/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UserHandler struct {
+	store repository.Store
+}
+
+func NewUserHandler(store repository.Store) *UserHandler {
+	return &UserHandler{store: store}
+}
+
+// GET /users
+func (h *UserHandler) GetUsers(c *gin.Context) {
+	users, err := h.store.Users().List(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch users"})
+		return
+	}
+
+	respondWithList(c, users, mapper.UserDomainToAPI)
+}
+
+// PUT /users/:id/role
+func (h *UserHandler) UpdateUserRole(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+	user, err := h.store.Users().Get(ctx, uint(userID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+
+	var req api.RoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	role := domain.Role(req.Role)
+	if !role.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role. Valid values are: viewer, engineer, release-manager, admin"})
+		return
+	}
+
+	// Prevent admins from locking themselves out
+	if user.ID == c.GetUint("userID") && role != domain.RoleAdmin {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "You cannot remove your own admin role"})
+		return
+	}
+
+	user.Role = role
+	user.IsAdmin = role == domain.RoleAdmin
+
+	if err := h.store.Users().Update(ctx, user); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update user role"})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.UserDomainToAPI(user))
+}
@@ -0,0 +1,249 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"release-management/internal/audit"
+	"release-management/internal/models/api"
+	"release-management/internal/models/domain"
+	"release-management/internal/models/mapper"
+	"release-management/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// webhookSecretPrefix marks webhook signing secrets so they are recognizable in configuration files
+const webhookSecretPrefix = "whsec_"
+
+type WebhookSubscriptionHandler struct {
+	store repository.Store
+}
+
+func NewWebhookSubscriptionHandler(store repository.Store) *WebhookSubscriptionHandler {
+	return &WebhookSubscriptionHandler{store: store}
+}
+
+// GET /webhooks
+func (h *WebhookSubscriptionHandler) GetWebhookSubscriptions(c *gin.Context) {
+	subscriptions, err := h.store.WebhookSubscriptions().List(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch webhook subscriptions"})
+		return
+	}
+
+	respondWithList(c, subscriptions, mapper.WebhookSubscriptionDomainToAPI)
+}
+
+// GET /webhooks/:id
+func (h *WebhookSubscriptionHandler) GetWebhookSubscription(c *gin.Context) {
+	subscription, err := h.store.WebhookSubscriptions().Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Webhook subscription not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.WebhookSubscriptionDomainToAPI(subscription))
+}
+
+// POST /webhooks
+func (h *WebhookSubscriptionHandler) CreateWebhookSubscription(c *gin.Context) {
+	var req api.WebhookSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	subscription := mapper.WebhookSubscriptionAPIToDomain(&req)
+	if !validateWebhookSubscription(c, subscription) {
+		return
+	}
+
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate webhook secret"})
+		return
+	}
+	subscription.Secret = secret
+
+	ctx := c.Request.Context()
+	if err := h.store.Transaction(ctx, func(tx repository.Store) error {
+		if err := tx.WebhookSubscriptions().Create(ctx, subscription); err != nil {
+			return err
+		}
+		return audit.Record(tx, c, domain.AuditEntityWebhook, subscription.ID, nil, mapper.WebhookSubscriptionDomainToAPI(subscription))
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create webhook subscription"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, api.WebhookSubscriptionCreatedResponse{
+		WebhookSubscriptionResponse: *mapper.WebhookSubscriptionDomainToAPI(subscription),
+		Secret:                      secret,
+	})
+}
+
+// PUT /webhooks/:id
+func (h *WebhookSubscriptionHandler) UpdateWebhookSubscription(c *gin.Context) {
+	ctx := c.Request.Context()
+	subscription, err := h.store.WebhookSubscriptions().Get(ctx, c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Webhook subscription not found"})
+		return
+	}
+
+	var req api.WebhookSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	before := mapper.WebhookSubscriptionDomainToAPI(subscription)
+	updated := mapper.WebhookSubscriptionAPIToDomain(&req)
+	if !validateWebhookSubscription(c, updated) {
+		return
+	}
+	subscription.Name = updated.Name
+	subscription.URL = updated.URL
+	subscription.EventTypes = updated.EventTypes
+	if req.Active != nil {
+		subscription.Active = *req.Active
+	}
+
+	if err := h.store.Transaction(ctx, func(tx repository.Store) error {
+		if err := tx.WebhookSubscriptions().Update(ctx, subscription); err != nil {
+			return err
+		}
+		return audit.Record(tx, c, domain.AuditEntityWebhook, subscription.ID, before, mapper.WebhookSubscriptionDomainToAPI(subscription))
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update webhook subscription"})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.WebhookSubscriptionDomainToAPI(subscription))
+}
+
+// DELETE /webhooks/:id
+func (h *WebhookSubscriptionHandler) DeleteWebhookSubscription(c *gin.Context) {
+	ctx := c.Request.Context()
+	subscription, err := h.store.WebhookSubscriptions().Get(ctx, c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Webhook subscription not found"})
+		return
+	}
+
+	if err := h.store.Transaction(ctx, func(tx repository.Store) error {
+		if err := tx.WebhookSubscriptions().Delete(ctx, subscription.ID); err != nil {
+			return err
+		}
+		return audit.Record(tx, c, domain.AuditEntityWebhook, subscription.ID, mapper.WebhookSubscriptionDomainToAPI(subscription), nil)
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete webhook subscription"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Webhook subscription deleted successfully"})
+}
+
+// GET /webhooks/:id/deliveries
+func (h *WebhookSubscriptionHandler) GetEventDeliveries(c *gin.Context) {
+	ctx := c.Request.Context()
+	if _, err := h.store.WebhookSubscriptions().Get(ctx, c.Param("id")); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Webhook subscription not found"})
+		return
+	}
+
+	filter := repository.EventDeliveryFilter{SubscriptionID: c.Param("id")}
+	if status := c.Query("status"); status != "" {
+		if !domain.EventDeliveryStatus(status).IsValid() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'status' parameter. Valid values are: pending, retrying, delivered, dead"})
+			return
+		}
+		filter.Status = domain.EventDeliveryStatus(status)
+	}
+	if eventType := c.Query("event_type"); eventType != "" {
+		if !domain.EventType(eventType).IsValid() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'event_type' parameter. Valid values are: " + eventTypeNames()})
+			return
+		}
+		filter.EventType = domain.EventType(eventType)
+	}
+
+	// Newest first unless sorted otherwise
+	page, ok := pageRequest(c, repository.EventDeliverySortFields, repository.Sort{Field: "created_at", Desc: true})
+	if !ok {
+		return
+	}
+
+	deliveries, err := h.store.WebhookSubscriptions().ListDeliveries(ctx, filter, page)
+	if err != nil {
+		respondWithListError(c, err, "Failed to fetch event deliveries")
+		return
+	}
+
+	respondWithPage(c, deliveries, mapper.EventDeliveryDomainToAPI)
+}
+
+// POST /webhooks/:id/deliveries/:deliveryId/redeliver
+func (h *WebhookSubscriptionHandler) RedeliverEvent(c *gin.Context) {
+	ctx := c.Request.Context()
+	delivery, err := h.store.WebhookSubscriptions().GetDelivery(ctx, c.Param("deliveryId"))
+	if err != nil || delivery.SubscriptionID != c.Param("id") {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Event delivery not found"})
+		return
+	}
+
+	if delivery.Status == domain.EventDeliveryPending || delivery.Status == domain.EventDeliveryRetrying {
+		c.JSON(http.StatusConflict, gin.H{"error": "Event delivery is already queued"})
+		return
+	}
+
+	// Requeue with a fresh set of attempts, the outcome of the last attempt stays in the log until the next one
+	now := time.Now()
+	delivery.Status = domain.EventDeliveryPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = &now
+	delivery.DeliveredAt = nil
+	if err := h.store.WebhookSubscriptions().UpdateDelivery(ctx, delivery); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to queue event delivery"})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, mapper.EventDeliveryDomainToAPI(delivery))
+}
+
+// Helper function to validate the URL and event types of a subscription, responding with 400 if they are invalid
+func validateWebhookSubscription(c *gin.Context, subscription *domain.WebhookSubscription) bool {
+	if !domain.IsValidWebhookURL(subscription.URL) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid URL, must be an absolute http or https URL"})
+		return false
+	}
+	for _, eventType := range subscription.EventTypes {
+		if !eventType.IsValid() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid event type '" + string(eventType) + "'. Valid values are: " + eventTypeNames()})
+			return false
+		}
+	}
+	return true
+}
+
+// Helper function to list the valid event types for error messages
+func eventTypeNames() string {
+	names := make([]string, len(domain.EventTypes))
+	for i, eventType := range domain.EventTypes {
+		names[i] = string(eventType)
+	}
+	return strings.Join(names, ", ")
+}
+
+// Helper function to generate a random signing secret for a subscription
+func generateWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return webhookSecretPrefix + hex.EncodeToString(secret), nil
+}
@@ -0,0 +1,157 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"release-management/internal/models/domain"
+	"release-management/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GroupResolver returns the ID of the environment group a request acts on, or an empty string if there is none
+type GroupResolver func(c *gin.Context) (string, error)
+
+// RequirePermission checks the caller's role against the permission matrix for a resource.
+// GET requests need read access, DELETE requests need delete access and every other method needs write access.
+// When groupOf is set, role grants and restrictions of the environment group it resolves also apply.
+func RequirePermission(store repository.Store, resource string, groupOf GroupResolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		groupID := ""
+		if groupOf != nil {
+			var err error
+			if groupID, err = groupOf(c); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check permissions"})
+				c.Abort()
+				return
+			}
+		}
+
+		allowed, err := Authorize(c, store, resource, actionFromMethod(c.Request.Method), groupID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check permissions"})
+			c.Abort()
+			return
+		}
+		if !allowed {
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to perform this action"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequireRole rejects callers whose global role is less privileged than role
+func RequireRole(store repository.Store, role domain.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userRole, err := UserRole(c, store)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check permissions"})
+			c.Abort()
+			return
+		}
+		if !userRole.AtLeast(role) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "This action requires the " + string(role) + " role"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// Authorize checks if the caller may perform an action on a resource, optionally within an environment group.
+// A role grant for the group replaces the caller's global role, and changes need at least the group's
+// required role. Admins are never restricted.
+func Authorize(c *gin.Context, store repository.Store, resource string, action domain.Action, groupID string) (bool, error) {
+	role, err := UserRole(c, store)
+	if err != nil {
+		return false, err
+	}
+	if role == domain.RoleAdmin || groupID == "" {
+		return role.Can(resource, action), nil
+	}
+
+	ctx := c.Request.Context()
+	grant, err := store.EnvironmentGroups().GetRoleGrant(ctx, groupID, c.GetUint("userID"))
+	if err == nil {
+		role = grant.Role
+	} else if !errors.Is(err, repository.ErrNotFound) {
+		return false, err
+	}
+
+	if action != domain.ActionRead {
+		group, err := store.EnvironmentGroups().Get(ctx, groupID)
+		if err == nil {
+			if group.RequiredRole != "" && !role.AtLeast(group.RequiredRole) {
+				return false, nil
+			}
+		} else if !errors.Is(err, repository.ErrNotFound) {
+			return false, err
+		}
+	}
+
+	return role.Can(resource, action), nil
+}
+
+// UserRole returns the global role of the authenticated user
+func UserRole(c *gin.Context, store repository.Store) (domain.Role, error) {
+	if role, ok := c.Get("userRole"); ok {
+		return role.(domain.Role), nil
+	}
+
+	user, err := store.Users().Get(c.Request.Context(), c.GetUint("userID"))
+	if err != nil {
+		return "", err
+	}
+
+	role := user.Role
+	if !role.IsValid() {
+		role = domain.RoleViewer
+	}
+	c.Set("userRole", role)
+	return role, nil
+}
+
+// EnvironmentGroupFromParam resolves the environment group from the :id route parameter
+func EnvironmentGroupFromParam(c *gin.Context) (string, error) {
+	return c.Param("id"), nil
+}
+
+// EnvironmentGroupOfEnvironment resolves the environment group of the environment in the :id route parameter
+func EnvironmentGroupOfEnvironment(store repository.Store) GroupResolver {
+	return func(c *gin.Context) (string, error) {
+		envID := c.Param("id")
+		if envID == "" {
+			return "", nil
+		}
+
+		env, err := store.Environments().Get(c.Request.Context(), envID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				// Let the handler report the missing environment
+				return "", nil
+			}
+			return "", err
+		}
+
+		if env.EnvironmentGroupID == nil {
+			return "", nil
+		}
+		return *env.EnvironmentGroupID, nil
+	}
+}
+
+// Helper function to map an HTTP method to the action it performs
+func actionFromMethod(method string) domain.Action {
+	switch method {
+	case http.MethodGet, http.MethodHead:
+		return domain.ActionRead
+	case http.MethodDelete:
+		return domain.ActionDelete
+	}
+	return domain.ActionWrite
+}
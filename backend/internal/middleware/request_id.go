@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"release-management/internal/audit"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader carries the ID that ties a request to its audit entries
+const RequestIDHeader = "X-Request-ID"
+
+// RequestIDMiddleware reuses the caller's request ID or assigns a new one, and echoes it in the response
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if requestID == "" || len(requestID) > 64 {
+			requestID = uuid.New().String()
+		}
+
+		c.Set(audit.RequestIDKey, requestID)
+		c.Header(RequestIDHeader, requestID)
+		c.Next()
+	}
+}
@@ -0,0 +1,32 @@
+package api
+
+import "time"
+
+// APITokenRequest represents the request payload for creating an API token
+type APITokenRequest struct {
+	Name      string     `json:"name" binding:"required"`
+	Kind      string     `json:"kind,omitempty"`
+	Scopes    []string   `json:"scopes" binding:"required,min=1"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// APITokenResponse represents the API token data returned in HTTP responses
+type APITokenResponse struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Kind       string     `json:"kind"`
+	UserID     uint       `json:"user_id"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// APITokenCreatedResponse represents a newly created API token, the only response that includes the token itself
+type APITokenCreatedResponse struct {
+	APITokenResponse
+	Token string `json:"token"`
+}
@@ -0,0 +1,25 @@
+package api
+
+import "time"
+
+// AuditChangeResponse holds the old and new value of a changed field
+type AuditChangeResponse struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// AuditEntryResponse represents an audit log entry returned in HTTP responses and NDJSON exports
+type AuditEntryResponse struct {
+	ID         string                         `json:"id"`
+	Action     string                         `json:"action"`
+	EntityType string                         `json:"entity_type"`
+	EntityID   string                         `json:"entity_id"`
+	ActorID    *uint                          `json:"actor_id,omitempty"`
+	Actor      string                         `json:"actor"`
+	Before     map[string]interface{}         `json:"before,omitempty"`
+	After      map[string]interface{}         `json:"after,omitempty"`
+	Changes    map[string]AuditChangeResponse `json:"changes,omitempty"`
+	RequestID  string                         `json:"request_id"`
+	SourceIP   string                         `json:"source_ip"`
+	CreatedAt  time.Time                      `json:"created_at"`
+}
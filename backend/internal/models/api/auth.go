@@ -19,6 +19,7 @@ type UserResponse struct {
 	ID        uint      `json:"id"`
 	Email     string    `json:"email"`
 	IsAdmin   bool      `json:"is_admin"`
+	Role      string    `json:"role"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
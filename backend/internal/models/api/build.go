@@ -8,6 +8,8 @@ type BuildRequest struct {
 	ReleaseID *string   `json:"release_id,omitempty"`
 	Version   string    `json:"version" binding:"required"`
 	BuildDate time.Time `json:"build_date" binding:"required"`
+	Changelog string    `json:"changelog,omitempty"`
+	BuildProvenance
 }
 
 // BuildResponse represents the build data returned in HTTP responses
@@ -19,8 +21,10 @@ type BuildResponse struct {
 	ReleaseName string    `json:"release_name,omitempty"`
 	Version     string    `json:"version"`
 	BuildDate   time.Time `json:"build_date"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	Changelog   string    `json:"changelog,omitempty"`
+	BuildProvenance
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 // BuildUpdateRequest represents the request payload for updating a build
@@ -29,4 +33,22 @@ type BuildUpdateRequest struct {
 	ReleaseID *string   `json:"release_id,omitempty"`
 	Version   string    `json:"version,omitempty"`
 	BuildDate time.Time `json:"build_date,omitempty"`
+	Changelog *string   `json:"changelog,omitempty"`
+	// Provenance fields that are present replace the stored ones, an empty string clears them
+	RepositoryURL   *string `json:"repository_url,omitempty"`
+	CommitSHA       *string `json:"commit_sha,omitempty"`
+	Branch          *string `json:"branch,omitempty"`
+	PipelineURL     *string `json:"pipeline_url,omitempty"`
+	ImageDigest     *string `json:"image_digest,omitempty"`
+	PackageChecksum *string `json:"package_checksum,omitempty"`
+}
+
+// BuildProvenance describes where a build comes from and the artifacts it produced
+type BuildProvenance struct {
+	RepositoryURL   string `json:"repository_url,omitempty"`
+	CommitSHA       string `json:"commit_sha,omitempty"`
+	Branch          string `json:"branch,omitempty"`
+	PipelineURL     string `json:"pipeline_url,omitempty"`
+	ImageDigest     string `json:"image_digest,omitempty"`
+	PackageChecksum string `json:"package_checksum,omitempty"`
 }
@@ -0,0 +1,69 @@
+package api
+
+import "time"
+
+// GitHubWorkflowRunEvent represents the parts of a GitHub Actions workflow_run payload used to register builds
+type GitHubWorkflowRunEvent struct {
+	Action      string `json:"action"`
+	WorkflowRun struct {
+		ID         int64     `json:"id"`
+		Name       string    `json:"name"`
+		HeadBranch string    `json:"head_branch"`
+		HeadSHA    string    `json:"head_sha"`
+		RunNumber  int64     `json:"run_number"`
+		Status     string    `json:"status"`
+		Conclusion string    `json:"conclusion"`
+		HTMLURL    string    `json:"html_url"`
+		UpdatedAt  time.Time `json:"updated_at"`
+	} `json:"workflow_run"`
+	Repository struct {
+		Name     string `json:"name"`
+		FullName string `json:"full_name"`
+		HTMLURL  string `json:"html_url"`
+	} `json:"repository"`
+}
+
+// GitLabPipelineEvent represents the parts of a GitLab pipeline event payload used to register builds
+type GitLabPipelineEvent struct {
+	ObjectKind       string `json:"object_kind"`
+	ObjectAttributes struct {
+		ID         int64  `json:"id"`
+		IID        int64  `json:"iid"`
+		Ref        string `json:"ref"`
+		Tag        bool   `json:"tag"`
+		SHA        string `json:"sha"`
+		Status     string `json:"status"`
+		FinishedAt string `json:"finished_at"`
+		URL        string `json:"url"`
+	} `json:"object_attributes"`
+	Project struct {
+		Name              string `json:"name"`
+		PathWithNamespace string `json:"path_with_namespace"`
+		WebURL            string `json:"web_url"`
+	} `json:"project"`
+}
+
+// JenkinsNotification represents the parts of a Jenkins notification plugin payload used to register builds
+type JenkinsNotification struct {
+	Name  string `json:"name"`
+	Build struct {
+		Number     int64             `json:"number"`
+		Phase      string            `json:"phase"`
+		Status     string            `json:"status"`
+		FullURL    string            `json:"full_url"`
+		Parameters map[string]string `json:"parameters"`
+		SCM        struct {
+			Commit string `json:"commit"`
+			Branch string `json:"branch"`
+		} `json:"scm"`
+	} `json:"build"`
+}
+
+// BuildWebhookResponse represents the result of processing a CI webhook delivery
+type BuildWebhookResponse struct {
+	DeliveryID string         `json:"delivery_id"`
+	Status     string         `json:"status"`
+	Message    string         `json:"message,omitempty"`
+	Duplicate  bool           `json:"duplicate"`
+	Build      *BuildResponse `json:"build,omitempty"`
+}
@@ -0,0 +1,71 @@
+package api
+
+import "time"
+
+// ChangeRequestRequest represents the request payload for proposing version changes to an environment
+type ChangeRequestRequest struct {
+	Title         string                     `json:"title" binding:"required"`
+	Description   string                     `json:"description,omitempty"`
+	EnvironmentID string                     `json:"environment_id" binding:"required"`
+	Changes       []ChangeRequestChangeInput `json:"changes" binding:"required"`
+}
+
+// ChangeRequestChangeInput is a system of the environment and the version it should run
+type ChangeRequestChangeInput struct {
+	SystemID string `json:"system_id"`
+	Version  string `json:"version"`
+}
+
+// ChangeRequestRejectRequest represents the request payload for rejecting a change request
+type ChangeRequestRejectRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// ChangeRequestCommentRequest represents the request payload for commenting on a change request
+type ChangeRequestCommentRequest struct {
+	Body string `json:"body" binding:"required"`
+}
+
+// ChangeRequestResponse represents the change request data returned in HTTP responses
+type ChangeRequestResponse struct {
+	ID                string                          `json:"id"`
+	Title             string                          `json:"title"`
+	Description       string                          `json:"description,omitempty"`
+	EnvironmentID     string                          `json:"environment_id"`
+	Status            string                          `json:"status"`
+	RequiredApprovals int                             `json:"required_approvals"`
+	ApproverRole      string                          `json:"approver_role"`
+	AuthorID          uint                            `json:"author_id"`
+	ExpiresAt         time.Time                       `json:"expires_at"`
+	Changes           []ChangeRequestChangeResponse   `json:"changes"`
+	Approvals         []ChangeRequestApprovalResponse `json:"approvals"`
+	Comments          []ChangeRequestCommentResponse  `json:"comments"`
+	RejectedBy        *uint                           `json:"rejected_by,omitempty"`
+	RejectionReason   string                          `json:"rejection_reason,omitempty"`
+	AppliedBy         *uint                           `json:"applied_by,omitempty"`
+	AppliedAt         *time.Time                      `json:"applied_at,omitempty"`
+	CreatedAt         time.Time                       `json:"created_at"`
+	UpdatedAt         time.Time                       `json:"updated_at"`
+}
+
+// ChangeRequestChangeResponse is a version change proposed by a change request.
+// FromVersion is empty if the system is not in the environment yet.
+type ChangeRequestChangeResponse struct {
+	SystemID    string `json:"system_id"`
+	FromVersion string `json:"from_version"`
+	ToVersion   string `json:"to_version"`
+}
+
+// ChangeRequestApprovalResponse is a user's sign-off on a change request
+type ChangeRequestApprovalResponse struct {
+	UserID    uint      `json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ChangeRequestCommentResponse is a note left on a change request
+type ChangeRequestCommentResponse struct {
+	ID        string    `json:"id"`
+	UserID    uint      `json:"user_id"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
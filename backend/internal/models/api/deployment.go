@@ -0,0 +1,46 @@
+package api
+
+import "time"
+
+// DeploymentResponse represents a recorded version change returned in HTTP responses
+type DeploymentResponse struct {
+	ID            string    `json:"id"`
+	EnvironmentID string    `json:"environment_id"`
+	SystemID      string    `json:"system_id"`
+	SystemName    string    `json:"system_name,omitempty"`
+	OldVersion    string    `json:"old_version"`
+	NewVersion    string    `json:"new_version"`
+	BuildID       *string   `json:"build_id,omitempty"`
+	UserID        *uint     `json:"user_id,omitempty"`
+	UserEmail     string    `json:"user_email,omitempty"`
+	Source        string    `json:"source"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// EnvironmentSystemRollbackRequest represents the request payload for rolling back a system in an environment.
+// When neither field is set the system is restored to the version it ran before the last deployment.
+type EnvironmentSystemRollbackRequest struct {
+	Version      string `json:"version,omitempty"`
+	DeploymentID string `json:"deployment_id,omitempty"`
+}
+
+// EnvironmentRollbackRequest represents the request payload for rolling back a whole environment
+type EnvironmentRollbackRequest struct {
+	At time.Time `json:"at" binding:"required"`
+}
+
+// RollbackChange represents a single system change made by a rollback
+type RollbackChange struct {
+	SystemID    string `json:"system_id"`
+	SystemName  string `json:"system_name"`
+	Action      string `json:"action"`
+	FromVersion string `json:"from_version"`
+	ToVersion   string `json:"to_version"`
+}
+
+// EnvironmentRollbackResponse represents the result of rolling back a whole environment
+type EnvironmentRollbackResponse struct {
+	EnvironmentID string           `json:"environment_id"`
+	At            time.Time        `json:"at"`
+	Changes       []RollbackChange `json:"changes"`
+}
@@ -0,0 +1,41 @@
+package api
+
+// DriftItem represents a single system that differs from the environment's release
+type DriftItem struct {
+	SystemID        string `json:"system_id"`
+	SystemName      string `json:"system_name"`
+	DeployedVersion string `json:"deployed_version,omitempty"`
+	ReleaseVersion  string `json:"release_version,omitempty"`
+	BuildID         string `json:"build_id,omitempty"`
+}
+
+// EnvironmentDriftReport represents the differences between an environment and its release manifest
+type EnvironmentDriftReport struct {
+	EnvironmentID     string      `json:"environment_id"`
+	EnvironmentName   string      `json:"environment_name"`
+	EnvironmentType   string      `json:"environment_type"`
+	ReleaseID         string      `json:"release_id"`
+	ReleaseName       string      `json:"release_name"`
+	InSync            bool        `json:"in_sync"`
+	MissingSystems    []DriftItem `json:"missing_systems"`
+	ExtraSystems      []DriftItem `json:"extra_systems"`
+	VersionMismatches []DriftItem `json:"version_mismatches"`
+	UnreleasedBuilds  []DriftItem `json:"unreleased_builds"`
+}
+
+// SystemVersionMatrixEntry represents the versions of one system across the environments of a group
+type SystemVersionMatrixEntry struct {
+	SystemID   string            `json:"system_id"`
+	SystemName string            `json:"system_name"`
+	Versions   map[string]string `json:"versions"`
+	Consistent bool              `json:"consistent"`
+}
+
+// EnvironmentGroupDriftReport represents the drift of every environment in an environment group
+type EnvironmentGroupDriftReport struct {
+	EnvironmentGroupID   string                     `json:"environment_group_id"`
+	EnvironmentGroupName string                     `json:"environment_group_name"`
+	InSync               bool                       `json:"in_sync"`
+	Environments         []EnvironmentDriftReport   `json:"environments"`
+	Systems              []SystemVersionMatrixEntry `json:"systems"`
+}
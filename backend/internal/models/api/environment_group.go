@@ -4,8 +4,10 @@ import "time"
 
 // EnvironmentGroupRequest represents the request payload for creating an environment group
 type EnvironmentGroupRequest struct {
-	Name        string  `json:"name" binding:"required"`
-	Description *string `json:"description,omitempty"`
+	Name          string   `json:"name" binding:"required"`
+	Description   *string  `json:"description,omitempty"`
+	PromotionPath []string `json:"promotion_path,omitempty"`
+	RequiredRole  string   `json:"required_role,omitempty"`
 }
 
 // SimplifiedEnvironmentInfo represents minimal environment data for listings
@@ -19,16 +21,20 @@ type SimplifiedEnvironmentInfo struct {
 
 // EnvironmentGroup and Environment data for API responses
 type EnvironmentGroupResponse struct {
-	ID           string                      `json:"id"`
-	Name         string                      `json:"name"`
-	Description  *string                     `json:"description,omitempty"`
-	CreatedAt    time.Time                   `json:"created_at"`
-	UpdatedAt    time.Time                   `json:"updated_at"`
-	Environments []SimplifiedEnvironmentInfo `json:"environments,omitempty"`
+	ID            string                      `json:"id"`
+	Name          string                      `json:"name"`
+	Description   *string                     `json:"description,omitempty"`
+	PromotionPath []string                    `json:"promotion_path"`
+	RequiredRole  string                      `json:"required_role,omitempty"`
+	CreatedAt     time.Time                   `json:"created_at"`
+	UpdatedAt     time.Time                   `json:"updated_at"`
+	Environments  []SimplifiedEnvironmentInfo `json:"environments,omitempty"`
 }
 
 // EnvironmentGroupUpdateRequest represents the request payload for updating an environment group
 type EnvironmentGroupUpdateRequest struct {
-	Name        string  `json:"name,omitempty"`
-	Description *string `json:"description,omitempty"`
+	Name          string   `json:"name,omitempty"`
+	Description   *string  `json:"description,omitempty"`
+	PromotionPath []string `json:"promotion_path,omitempty"`
+	RequiredRole  *string  `json:"required_role,omitempty"`
 }
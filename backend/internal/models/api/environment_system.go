@@ -42,3 +42,21 @@ type EnvironmentSystemsResponse struct {
 	EnvironmentName string             `json:"environment_name"`
 	Systems         []SimpleSystemInfo `json:"systems"`
 }
+
+// EnvironmentSystemDetailResponse represents a system in an environment with the versions it can be moved to
+type EnvironmentSystemDetailResponse struct {
+	System            SimpleSystemInfo `json:"system"`
+	AvailableVersions []string         `json:"available_versions"`
+}
+
+// EnvironmentSystemsAddedResponse represents the systems added to an environment
+type EnvironmentSystemsAddedResponse struct {
+	Message string             `json:"message"`
+	Systems []SimpleSystemInfo `json:"systems"`
+}
+
+// EnvironmentSystemsSyncResponse represents the result of syncing system versions with the environment's release
+type EnvironmentSystemsSyncResponse struct {
+	Message      string `json:"message"`
+	UpdatedCount int    `json:"updated_count"`
+}
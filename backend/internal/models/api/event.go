@@ -0,0 +1,51 @@
+package api
+
+import "time"
+
+// EventPayload represents an event as it is sent to webhook subscriptions
+type EventPayload struct {
+	ID        string                 `json:"id"`
+	Type      string                 `json:"type"`
+	CreatedAt time.Time              `json:"created_at"`
+	Data      map[string]interface{} `json:"data"`
+}
+
+// ReleaseEvent is the data of a release.created, release.updated or release.deleted event,
+// with the release as it is after the change or, for a deletion, before it
+type ReleaseEvent struct {
+	Release ReleaseResponse `json:"release"`
+}
+
+// ReleaseTransitionedEvent is the data of a release.transitioned event
+type ReleaseTransitionedEvent struct {
+	Release ReleaseResponse `json:"release"`
+	From    string          `json:"from"`
+	To      string          `json:"to"`
+	Comment *string         `json:"comment,omitempty"`
+}
+
+// BuildEvent is the data of a build.registered, build.updated or build.deleted event
+type BuildEvent struct {
+	Build BuildResponse `json:"build"`
+}
+
+// EnvironmentEvent is the data of an environment.created, environment.updated or environment.deleted event
+type EnvironmentEvent struct {
+	Environment EnvironmentResponse `json:"environment"`
+}
+
+// EnvironmentSystemChangedEvent is the data of an environment_system.changed event.
+// OldVersion is empty when a system is added to an environment and NewVersion is empty when it is removed.
+type EnvironmentSystemChangedEvent struct {
+	EnvironmentID string  `json:"environment_id"`
+	SystemID      string  `json:"system_id"`
+	OldVersion    string  `json:"old_version"`
+	NewVersion    string  `json:"new_version"`
+	BuildID       *string `json:"build_id,omitempty"`
+	UserID        *uint   `json:"user_id,omitempty"`
+	// ReleaseID and EnvironmentGroupID are those of the environment, so that subscribers can filter without a lookup
+	ReleaseID          string  `json:"release_id,omitempty"`
+	EnvironmentGroupID *string `json:"environment_group_id,omitempty"`
+	// Source is the deployment source of the change, or import for changes made by a manifest import
+	Source string `json:"source"`
+}
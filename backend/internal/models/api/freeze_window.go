@@ -0,0 +1,50 @@
+package api
+
+import "time"
+
+// FreezeWindowRequest represents the request payload for creating or updating a freeze window
+type FreezeWindowRequest struct {
+	Name   string `json:"name" binding:"required"`
+	Reason string `json:"reason,omitempty"`
+	// Scope is one of global, environment_group or environment_type
+	Scope string `json:"scope" binding:"required"`
+	// EnvironmentGroupID is required for the environment_group scope
+	EnvironmentGroupID *string `json:"environment_group_id,omitempty"`
+	// EnvironmentType is required for the environment_type scope
+	EnvironmentType      *string   `json:"environment_type,omitempty"`
+	StartsAt             time.Time `json:"starts_at" binding:"required"`
+	EndsAt               time.Time `json:"ends_at" binding:"required"`
+	ExemptSystemIDs      []string  `json:"exempt_system_ids,omitempty"`
+	ExemptEnvironmentIDs []string  `json:"exempt_environment_ids,omitempty"`
+}
+
+// FreezeWindowResponse represents the freeze window data returned in HTTP responses
+type FreezeWindowResponse struct {
+	ID                   string    `json:"id"`
+	Name                 string    `json:"name"`
+	Reason               string    `json:"reason,omitempty"`
+	Scope                string    `json:"scope"`
+	EnvironmentGroupID   *string   `json:"environment_group_id,omitempty"`
+	EnvironmentType      *string   `json:"environment_type,omitempty"`
+	StartsAt             time.Time `json:"starts_at"`
+	EndsAt               time.Time `json:"ends_at"`
+	ExemptSystemIDs      []string  `json:"exempt_system_ids"`
+	ExemptEnvironmentIDs []string  `json:"exempt_environment_ids"`
+	CreatedBy            *uint     `json:"created_by,omitempty"`
+	CreatedAt            time.Time `json:"created_at"`
+	UpdatedAt            time.Time `json:"updated_at"`
+}
+
+// FreezeOverrideResponse represents an entry of the log of changes let through freeze windows
+type FreezeOverrideResponse struct {
+	ID               string    `json:"id"`
+	FreezeWindowID   string    `json:"freeze_window_id"`
+	FreezeWindowName string    `json:"freeze_window_name"`
+	EnvironmentID    string    `json:"environment_id"`
+	SystemID         string    `json:"system_id"`
+	OldVersion       string    `json:"old_version"`
+	NewVersion       string    `json:"new_version"`
+	Justification    string    `json:"justification"`
+	UserID           *uint     `json:"user_id,omitempty"`
+	CreatedAt        time.Time `json:"created_at"`
+}
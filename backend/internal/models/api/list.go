@@ -0,0 +1,9 @@
+package api
+
+// ListResponse is the envelope of every list endpoint.
+// The total number of matching items is sent in the X-Total-Count header.
+type ListResponse[T any] struct {
+	Data []T `json:"data"`
+	// NextCursor fetches the next page when passed as the cursor parameter, omitted on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+}
@@ -0,0 +1,88 @@
+package api
+
+import "time"
+
+// Manifest is the declarative description of the catalog returned by GET /export and accepted by POST /import.
+// Entities refer to each other by name. A section that is left out of an import is not changed,
+// while a section that is present lists every entity of its kind and the others are deleted.
+type Manifest struct {
+	Systems           []ManifestSystem           `json:"systems"`
+	Releases          []ManifestRelease          `json:"releases"`
+	EnvironmentGroups []ManifestEnvironmentGroup `json:"environment_groups"`
+	Environments      []ManifestEnvironment      `json:"environments"`
+}
+
+// ManifestSystem represents a system of a manifest with its subsystems and builds.
+// Builds are left alone unless the system lists them.
+type ManifestSystem struct {
+	Name          string           `json:"name"`
+	Description   *string          `json:"description,omitempty"`
+	Type          string           `json:"type,omitempty"`
+	Status        string           `json:"status,omitempty"`
+	StrictSemver  bool             `json:"strict_semver,omitempty"`
+	StrictDigests bool             `json:"strict_digests,omitempty"`
+	Subsystems    []ManifestSystem `json:"subsystems,omitempty"`
+	Builds        []ManifestBuild  `json:"builds,omitempty"`
+}
+
+// ManifestBuild represents a build of a manifest system, identified by its version
+type ManifestBuild struct {
+	Version   string    `json:"version"`
+	Release   string    `json:"release,omitempty"`
+	BuildDate time.Time `json:"build_date"`
+}
+
+// ManifestRelease represents a release of a manifest, identified by its name
+type ManifestRelease struct {
+	Name        string    `json:"name"`
+	Description *string   `json:"description,omitempty"`
+	ReleaseDate time.Time `json:"release_date"`
+	Status      string    `json:"status,omitempty"`
+	Type        string    `json:"type"`
+}
+
+// ManifestEnvironmentGroup represents an environment group of a manifest, identified by its name
+type ManifestEnvironmentGroup struct {
+	Name          string   `json:"name"`
+	Description   *string  `json:"description,omitempty"`
+	PromotionPath []string `json:"promotion_path,omitempty"`
+	RequiredRole  string   `json:"required_role,omitempty"`
+}
+
+// ManifestEnvironment represents an environment of a manifest, identified by its name.
+// Deployed systems are left alone unless the environment lists them.
+type ManifestEnvironment struct {
+	Name        string                      `json:"name"`
+	Type        string                      `json:"type"`
+	Status      string                      `json:"status,omitempty"`
+	URL         *string                     `json:"url,omitempty"`
+	Description *string                     `json:"description,omitempty"`
+	Release     string                      `json:"release"`
+	Group       string                      `json:"group,omitempty"`
+	Systems     []ManifestEnvironmentSystem `json:"systems,omitempty"`
+}
+
+// ManifestEnvironmentSystem represents a system deployed to a manifest environment
+type ManifestEnvironmentSystem struct {
+	System  string `json:"system"`
+	Version string `json:"version"`
+	Status  string `json:"status,omitempty"`
+}
+
+// ManifestChange represents a single change planned or made by an import
+type ManifestChange struct {
+	Action     string   `json:"action"`
+	EntityType string   `json:"entity_type"`
+	Name       string   `json:"name"`
+	EntityID   string   `json:"entity_id,omitempty"`
+	Fields     []string `json:"fields,omitempty"`
+}
+
+// ImportResponse represents the changes applied (or, in dry-run mode, to be applied) by an import
+type ImportResponse struct {
+	DryRun  bool             `json:"dry_run"`
+	Creates int              `json:"creates"`
+	Updates int              `json:"updates"`
+	Deletes int              `json:"deletes"`
+	Changes []ManifestChange `json:"changes"`
+}
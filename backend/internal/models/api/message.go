@@ -0,0 +1,11 @@
+package api
+
+// MessageResponse represents the confirmation returned by endpoints without a resource to return, e.g. deletes
+type MessageResponse struct {
+	Message string `json:"message"`
+}
+
+// ErrorResponse represents a failed request. Rule violations add their details next to the message.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
@@ -0,0 +1,26 @@
+package api
+
+// PromotionRequest represents the request payload for promoting an environment to the next stage
+type PromotionRequest struct {
+	TargetEnvironmentID string `json:"target_environment_id,omitempty"`
+	DryRun              bool   `json:"dry_run,omitempty"`
+}
+
+// PromotionChange represents a single system change made by a promotion
+type PromotionChange struct {
+	SystemID    string `json:"system_id"`
+	SystemName  string `json:"system_name"`
+	Action      string `json:"action"`
+	FromVersion string `json:"from_version"`
+	ToVersion   string `json:"to_version"`
+}
+
+// PromotionResponse represents the diff applied (or, in dry-run mode, to be applied) by a promotion
+type PromotionResponse struct {
+	SourceEnvironmentID   string            `json:"source_environment_id"`
+	SourceEnvironmentName string            `json:"source_environment_name"`
+	TargetEnvironmentID   string            `json:"target_environment_id"`
+	TargetEnvironmentName string            `json:"target_environment_name"`
+	DryRun                bool              `json:"dry_run"`
+	Changes               []PromotionChange `json:"changes"`
+}
@@ -32,3 +32,31 @@ type ReleaseUpdateRequest struct {
 	Status      string    `json:"status,omitempty"`
 	Type        string    `json:"type,omitempty"`
 }
+
+// ReleaseTransitionRequest represents the request payload for moving a release to another status
+type ReleaseTransitionRequest struct {
+	To      string  `json:"to" binding:"required"`
+	Comment *string `json:"comment,omitempty"`
+}
+
+// ReleaseTransitionResponse represents a recorded release transition returned in HTTP responses
+type ReleaseTransitionResponse struct {
+	ID         string    `json:"id"`
+	ReleaseID  string    `json:"release_id"`
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	UserID     uint      `json:"user_id"`
+	UserEmail  string    `json:"user_email,omitempty"`
+	Comment    *string   `json:"comment,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ReleaseTransitionError represents a rejected release transition
+type ReleaseTransitionError struct {
+	Error              string   `json:"error"`
+	Code               string   `json:"code"`
+	From               string   `json:"from"`
+	To                 string   `json:"to"`
+	AllowedTransitions []string `json:"allowed_transitions"`
+	FailedGuards       []string `json:"failed_guards,omitempty"`
+}
@@ -0,0 +1,27 @@
+package api
+
+// ReleaseSummary represents the minimal release data used in comparisons
+type ReleaseSummary struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+// SystemComparisonResponse represents one system lined up between two releases
+type SystemComparisonResponse struct {
+	SystemID      string                     `json:"system_id"`
+	SystemName    string                     `json:"system_name"`
+	SystemType    string                     `json:"system_type"`
+	Change        string                     `json:"change"`
+	BaseVersion   string                     `json:"base_version,omitempty"`
+	TargetVersion string                     `json:"target_version,omitempty"`
+	Subsystems    []SystemComparisonResponse `json:"subsystems,omitempty"`
+}
+
+// ReleaseComparisonResponse represents the differences between two releases
+type ReleaseComparisonResponse struct {
+	Base    ReleaseSummary             `json:"base"`
+	Target  ReleaseSummary             `json:"target"`
+	Summary map[string]int             `json:"summary"`
+	Systems []SystemComparisonResponse `json:"systems"`
+}
@@ -0,0 +1,35 @@
+package api
+
+import "time"
+
+// ReleaseNotesRelease represents the release that release notes are about
+type ReleaseNotesRelease struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	Type        string    `json:"type"`
+	Status      string    `json:"status"`
+	ReleaseDate time.Time `json:"release_date"`
+}
+
+// ReleaseNotesSystemResponse represents a system in release notes.
+// Parent systems have no version of their own and list the subsystems built in the release.
+type ReleaseNotesSystemResponse struct {
+	SystemID        string                       `json:"system_id"`
+	SystemName      string                       `json:"system_name"`
+	SystemType      string                       `json:"system_type"`
+	Change          string                       `json:"change"`
+	Version         string                       `json:"version,omitempty"`
+	BuildDate       *time.Time                   `json:"build_date,omitempty"`
+	Changelog       string                       `json:"changelog,omitempty"`
+	PreviousVersion string                       `json:"previous_version,omitempty"`
+	PreviousRelease *ReleaseSummary              `json:"previous_release,omitempty"`
+	Subsystems      []ReleaseNotesSystemResponse `json:"subsystems,omitempty"`
+}
+
+// ReleaseNotesResponse represents the release notes of a release, also the data that release notes templates render
+type ReleaseNotesResponse struct {
+	Release ReleaseNotesRelease          `json:"release"`
+	Summary map[string]int               `json:"summary"`
+	Systems []ReleaseNotesSystemResponse `json:"systems"`
+}
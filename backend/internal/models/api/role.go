@@ -0,0 +1,19 @@
+package api
+
+import "time"
+
+// RoleRequest represents the request payload for assigning a role
+type RoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+// EnvironmentGroupRoleGrantResponse represents a role granted within an environment group
+type EnvironmentGroupRoleGrantResponse struct {
+	ID                 string        `json:"id"`
+	EnvironmentGroupID string        `json:"environment_group_id"`
+	UserID             uint          `json:"user_id"`
+	Role               string        `json:"role"`
+	CreatedAt          time.Time     `json:"created_at"`
+	UpdatedAt          time.Time     `json:"updated_at"`
+	User               *UserResponse `json:"user,omitempty"`
+}
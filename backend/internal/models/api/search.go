@@ -0,0 +1,12 @@
+package api
+
+// SearchResultResponse represents an entity matching a search
+type SearchResultResponse struct {
+	Type     string  `json:"type"`
+	ID       string  `json:"id"`
+	Title    string  `json:"title"`
+	Subtitle string  `json:"subtitle,omitempty"`
+	Rank     float64 `json:"rank"`
+	// Highlights holds the matching fields with their matches wrapped in <mark> tags
+	Highlights map[string]string `json:"highlights"`
+}
@@ -0,0 +1,47 @@
+package api
+
+import "time"
+
+// WebhookSubscriptionRequest represents the request payload for creating or updating a webhook subscription
+type WebhookSubscriptionRequest struct {
+	Name string `json:"name" binding:"required"`
+	URL  string `json:"url" binding:"required"`
+	// EventTypes selects the events that are sent, empty for every event
+	EventTypes []string `json:"event_types,omitempty"`
+	// Active defaults to true
+	Active *bool `json:"active,omitempty"`
+}
+
+// WebhookSubscriptionResponse represents the webhook subscription data returned in HTTP responses
+type WebhookSubscriptionResponse struct {
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	Active     bool      `json:"active"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// WebhookSubscriptionCreatedResponse represents a newly created webhook subscription, the only response that includes its secret
+type WebhookSubscriptionCreatedResponse struct {
+	WebhookSubscriptionResponse
+	Secret string `json:"secret"`
+}
+
+// EventDeliveryResponse represents an entry of the delivery log of a webhook subscription
+type EventDeliveryResponse struct {
+	ID             string     `json:"id"`
+	SubscriptionID string     `json:"subscription_id"`
+	EventID        string     `json:"event_id"`
+	EventType      string     `json:"event_type"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty"`
+	LastAttemptAt  *time.Time `json:"last_attempt_at,omitempty"`
+	ResponseStatus *int       `json:"response_status,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,53 @@
+package db
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// APIToken represents the api_tokens table in the database.
+// Only a SHA-256 hash of the token is stored, the token itself is shown once at creation.
+type APIToken struct {
+	ID         string `gorm:"primaryKey;type:varchar(36)"`
+	Name       string `gorm:"not null"`
+	Kind       string `gorm:"type:varchar(20);not null"`
+	UserID     uint   `gorm:"not null;index"`
+	Prefix     string `gorm:"type:varchar(16);not null"`
+	TokenHash  string `gorm:"type:varchar(64);not null;uniqueIndex"`
+	Scopes     string `gorm:"type:text"`
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+
+	// Relationships for GORM
+	User User `gorm:"foreignKey:UserID"`
+}
+
+// TableName specifies the table name for GORM
+func (APIToken) TableName() string {
+	return "api_tokens"
+}
+
+// BeforeCreate hook for GORM
+func (t *APIToken) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == "" {
+		t.ID = uuid.New().String()
+	}
+	if t.CreatedAt.IsZero() {
+		t.CreatedAt = time.Now()
+	}
+	if t.UpdatedAt.IsZero() {
+		t.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+// BeforeUpdate hook for GORM
+func (t *APIToken) BeforeUpdate(tx *gorm.DB) error {
+	t.UpdatedAt = time.Now()
+	return nil
+}
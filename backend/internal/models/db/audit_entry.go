@@ -0,0 +1,53 @@
+package db
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AuditEntry represents the append-only audit_entries table in the database.
+// Before, After and Changes hold JSON documents.
+type AuditEntry struct {
+	ID         string    `gorm:"primaryKey;type:varchar(36)"`
+	Action     string    `gorm:"type:varchar(20);not null"`
+	EntityType string    `gorm:"type:varchar(30);not null;index:idx_audit_entity"`
+	EntityID   string    `gorm:"type:varchar(36);not null;index:idx_audit_entity"`
+	ActorID    *uint     `gorm:"index"`
+	Actor      string    `gorm:"type:varchar(255)"`
+	Before     *string   `gorm:"type:jsonb"`
+	After      *string   `gorm:"type:jsonb"`
+	Changes    *string   `gorm:"type:jsonb"`
+	RequestID  string    `gorm:"type:varchar(64);index"`
+	SourceIP   string    `gorm:"type:varchar(64)"`
+	CreatedAt  time.Time `gorm:"index"`
+}
+
+// TableName specifies the table name for GORM
+func (AuditEntry) TableName() string {
+	return "audit_entries"
+}
+
+// BeforeCreate hook for GORM
+func (a *AuditEntry) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == "" {
+		a.ID = uuid.New().String()
+	}
+	if a.CreatedAt.IsZero() {
+		a.CreatedAt = time.Now()
+	}
+	return nil
+}
+
+// BeforeUpdate hook for GORM
+func (a *AuditEntry) BeforeUpdate(tx *gorm.DB) error {
+	// Audit entries are a compliance record and must never be modified
+	return gorm.ErrInvalidData
+}
+
+// BeforeDelete hook for GORM
+func (a *AuditEntry) BeforeDelete(tx *gorm.DB) error {
+	// Audit entries are a compliance record and must never be removed
+	return gorm.ErrInvalidData
+}
@@ -14,8 +14,16 @@ type Build struct {
 	ReleaseID *string `gorm:"type:varchar(36)"`
 	Version   string  `gorm:"not null"`
 	BuildDate time.Time
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	Changelog string `gorm:"type:text"`
+	// Provenance of the build
+	RepositoryURL   string `gorm:"type:text"`
+	CommitSHA       string `gorm:"type:varchar(64);index"`
+	Branch          string
+	PipelineURL     string `gorm:"type:text"`
+	ImageDigest     string `gorm:"index"`
+	PackageChecksum string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
 
 	// Relationships for GORM
 	System  System   `gorm:"foreignKey:SystemID"`
@@ -0,0 +1,130 @@
+package db
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ChangeRequest represents the change_requests table in the database
+type ChangeRequest struct {
+	ID                string    `gorm:"primaryKey;type:varchar(36)"`
+	Title             string    `gorm:"not null"`
+	Description       string    `gorm:"type:text"`
+	EnvironmentID     string    `gorm:"type:varchar(36);not null;index"`
+	Status            string    `gorm:"type:varchar(20);not null;index:idx_change_requests_status_expires_at"`
+	RequiredApprovals int       `gorm:"not null"`
+	ApproverRole      string    `gorm:"type:varchar(20);not null"`
+	AuthorID          uint      `gorm:"not null"`
+	ExpiresAt         time.Time `gorm:"not null;index:idx_change_requests_status_expires_at"`
+	RejectedBy        *uint
+	RejectionReason   string `gorm:"type:text"`
+	AppliedBy         *uint
+	AppliedAt         *time.Time
+	CreatedAt         time.Time `gorm:"index"`
+	UpdatedAt         time.Time
+
+	// Relationships for GORM
+	Changes   []ChangeRequestChange   `gorm:"foreignKey:ChangeRequestID"`
+	Approvals []ChangeRequestApproval `gorm:"foreignKey:ChangeRequestID"`
+	Comments  []ChangeRequestComment  `gorm:"foreignKey:ChangeRequestID"`
+}
+
+// TableName specifies the table name for GORM
+func (ChangeRequest) TableName() string {
+	return "change_requests"
+}
+
+// BeforeCreate hook for GORM
+func (r *ChangeRequest) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == "" {
+		r.ID = uuid.New().String()
+	}
+	if r.CreatedAt.IsZero() {
+		r.CreatedAt = time.Now()
+	}
+	if r.UpdatedAt.IsZero() {
+		r.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+// BeforeUpdate hook for GORM
+func (r *ChangeRequest) BeforeUpdate(tx *gorm.DB) error {
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+// ChangeRequestChange represents the change_request_changes table in the database.
+// Position keeps the changes in the order they were requested.
+type ChangeRequestChange struct {
+	ID              string `gorm:"primaryKey;type:varchar(36)"`
+	ChangeRequestID string `gorm:"type:varchar(36);not null;index"`
+	Position        int    `gorm:"not null"`
+	SystemID        string `gorm:"type:varchar(36);not null"`
+	FromVersion     string `gorm:"type:varchar(50)"`
+	ToVersion       string `gorm:"type:varchar(50);not null"`
+}
+
+// TableName specifies the table name for GORM
+func (ChangeRequestChange) TableName() string {
+	return "change_request_changes"
+}
+
+// BeforeCreate hook for GORM
+func (c *ChangeRequestChange) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == "" {
+		c.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// ChangeRequestApproval represents the change_request_approvals table in the database
+type ChangeRequestApproval struct {
+	ID              string `gorm:"primaryKey;type:varchar(36)"`
+	ChangeRequestID string `gorm:"type:varchar(36);not null;uniqueIndex:idx_change_request_approvals_user"`
+	UserID          uint   `gorm:"not null;uniqueIndex:idx_change_request_approvals_user"`
+	CreatedAt       time.Time
+}
+
+// TableName specifies the table name for GORM
+func (ChangeRequestApproval) TableName() string {
+	return "change_request_approvals"
+}
+
+// BeforeCreate hook for GORM
+func (a *ChangeRequestApproval) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == "" {
+		a.ID = uuid.New().String()
+	}
+	if a.CreatedAt.IsZero() {
+		a.CreatedAt = time.Now()
+	}
+	return nil
+}
+
+// ChangeRequestComment represents the change_request_comments table in the database
+type ChangeRequestComment struct {
+	ID              string `gorm:"primaryKey;type:varchar(36)"`
+	ChangeRequestID string `gorm:"type:varchar(36);not null;index"`
+	UserID          uint   `gorm:"not null"`
+	Body            string `gorm:"type:text;not null"`
+	CreatedAt       time.Time
+}
+
+// TableName specifies the table name for GORM
+func (ChangeRequestComment) TableName() string {
+	return "change_request_comments"
+}
+
+// BeforeCreate hook for GORM
+func (c *ChangeRequestComment) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == "" {
+		c.ID = uuid.New().String()
+	}
+	if c.CreatedAt.IsZero() {
+		c.CreatedAt = time.Now()
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package db
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Deployment represents the append-only deployments table in the database
+type Deployment struct {
+	ID            string  `gorm:"primaryKey;type:varchar(36)"`
+	EnvironmentID string  `gorm:"type:varchar(36);not null;index"`
+	SystemID      string  `gorm:"type:varchar(36);not null;index"`
+	OldVersion    string  `gorm:"type:varchar(50)"`
+	NewVersion    string  `gorm:"type:varchar(50)"`
+	BuildID       *string `gorm:"type:varchar(36)"`
+	UserID        *uint
+	Source        string    `gorm:"type:varchar(20);not null"`
+	CreatedAt     time.Time `gorm:"index"`
+
+	// Relationships for GORM. The system is missing once it was deleted, as history outlives it.
+	System *System `gorm:"foreignKey:SystemID"`
+	User   *User   `gorm:"foreignKey:UserID"`
+}
+
+// TableName specifies the table name for GORM
+func (Deployment) TableName() string {
+	return "deployments"
+}
+
+// BeforeCreate hook for GORM
+func (d *Deployment) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == "" {
+		d.ID = uuid.New().String()
+	}
+	if d.CreatedAt.IsZero() {
+		d.CreatedAt = time.Now()
+	}
+	return nil
+}
+
+// BeforeUpdate hook for GORM
+func (d *Deployment) BeforeUpdate(tx *gorm.DB) error {
+	// Deployments are an audit trail and must never be modified
+	return gorm.ErrInvalidData
+}
+
+// BeforeDelete hook for GORM
+func (d *Deployment) BeforeDelete(tx *gorm.DB) error {
+	// Deployments are an audit trail and must never be removed
+	return gorm.ErrInvalidData
+}
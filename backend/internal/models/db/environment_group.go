@@ -12,8 +12,11 @@ type EnvironmentGroup struct {
 	ID          string `gorm:"primaryKey;type:varchar(36)"`
 	Name        string `gorm:"not null"`
 	Description *string
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	// PromotionPath holds the ordered environment types separated by commas, e.g. "dev,staging,prod"
+	PromotionPath string `gorm:"type:text"`
+	RequiredRole  string `gorm:"type:varchar(20)"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
 
 	// Relationships for GORM
 	Environments []Environment `gorm:"foreignKey:EnvironmentGroupID"`
@@ -0,0 +1,47 @@
+package db
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// EnvironmentGroupRoleGrant represents the environment_group_role_grants table in the database
+type EnvironmentGroupRoleGrant struct {
+	ID                 string `gorm:"primaryKey;type:varchar(36)"`
+	EnvironmentGroupID string `gorm:"type:varchar(36);not null;uniqueIndex:idx_group_role_grant"`
+	UserID             uint   `gorm:"not null;uniqueIndex:idx_group_role_grant"`
+	Role               string `gorm:"type:varchar(20);not null"`
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+
+	// Relationships for GORM
+	EnvironmentGroup EnvironmentGroup `gorm:"foreignKey:EnvironmentGroupID"`
+	User             User             `gorm:"foreignKey:UserID"`
+}
+
+// TableName specifies the table name for GORM
+func (EnvironmentGroupRoleGrant) TableName() string {
+	return "environment_group_role_grants"
+}
+
+// BeforeCreate hook for GORM
+func (g *EnvironmentGroupRoleGrant) BeforeCreate(tx *gorm.DB) error {
+	if g.ID == "" {
+		g.ID = uuid.New().String()
+	}
+	if g.CreatedAt.IsZero() {
+		g.CreatedAt = time.Now()
+	}
+	if g.UpdatedAt.IsZero() {
+		g.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+// BeforeUpdate hook for GORM
+func (g *EnvironmentGroupRoleGrant) BeforeUpdate(tx *gorm.DB) error {
+	g.UpdatedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,33 @@
+package db
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Event represents the events table in the database. Data holds a JSON document.
+type Event struct {
+	ID        string    `gorm:"primaryKey;type:varchar(36)"`
+	Sequence  int64     `gorm:"autoIncrement;uniqueIndex"`
+	Type      string    `gorm:"type:varchar(50);not null"`
+	Data      *string   `gorm:"type:jsonb"`
+	CreatedAt time.Time `gorm:"index"`
+}
+
+// TableName specifies the table name for GORM
+func (Event) TableName() string {
+	return "events"
+}
+
+// BeforeCreate hook for GORM
+func (e *Event) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == "" {
+		e.ID = uuid.New().String()
+	}
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = time.Now()
+	}
+	return nil
+}
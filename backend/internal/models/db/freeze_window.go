@@ -0,0 +1,82 @@
+package db
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// FreezeWindow represents the freeze_windows table in the database
+type FreezeWindow struct {
+	ID                 string    `gorm:"primaryKey;type:varchar(36)"`
+	Name               string    `gorm:"not null"`
+	Reason             string    `gorm:"type:text"`
+	Scope              string    `gorm:"type:varchar(30);not null"`
+	EnvironmentGroupID *string   `gorm:"type:varchar(36)"`
+	EnvironmentType    *string   `gorm:"type:varchar(50)"`
+	StartsAt           time.Time `gorm:"not null;index:idx_freeze_windows_period"`
+	EndsAt             time.Time `gorm:"not null;index:idx_freeze_windows_period"`
+	// ExemptSystemIDs and ExemptEnvironmentIDs hold the exempt IDs separated by commas
+	ExemptSystemIDs      string `gorm:"type:text"`
+	ExemptEnvironmentIDs string `gorm:"type:text"`
+	CreatedBy            *uint
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+}
+
+// TableName specifies the table name for GORM
+func (FreezeWindow) TableName() string {
+	return "freeze_windows"
+}
+
+// BeforeCreate hook for GORM
+func (w *FreezeWindow) BeforeCreate(tx *gorm.DB) error {
+	if w.ID == "" {
+		w.ID = uuid.New().String()
+	}
+	if w.CreatedAt.IsZero() {
+		w.CreatedAt = time.Now()
+	}
+	if w.UpdatedAt.IsZero() {
+		w.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+// BeforeUpdate hook for GORM
+func (w *FreezeWindow) BeforeUpdate(tx *gorm.DB) error {
+	w.UpdatedAt = time.Now()
+	return nil
+}
+
+// FreezeOverride represents the freeze_overrides table in the database, the log of the changes let through freeze windows.
+// It has no foreign keys so that it outlives the windows, environments and systems it is about.
+type FreezeOverride struct {
+	ID               string `gorm:"primaryKey;type:varchar(36)"`
+	FreezeWindowID   string `gorm:"type:varchar(36);not null;index"`
+	FreezeWindowName string `gorm:"not null"`
+	EnvironmentID    string `gorm:"type:varchar(36);not null;index"`
+	SystemID         string `gorm:"type:varchar(36);not null"`
+	OldVersion       string `gorm:"type:varchar(50)"`
+	NewVersion       string `gorm:"type:varchar(50)"`
+	Justification    string `gorm:"type:text;not null"`
+	UserID           *uint
+	CreatedAt        time.Time `gorm:"index"`
+}
+
+// TableName specifies the table name for GORM
+func (FreezeOverride) TableName() string {
+	return "freeze_overrides"
+}
+
+// BeforeCreate hook for GORM
+func (o *FreezeOverride) BeforeCreate(tx *gorm.DB) error {
+	if o.ID == "" {
+		o.ID = uuid.New().String()
+	}
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = time.Now()
+	}
+	return nil
+}
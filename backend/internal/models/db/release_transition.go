@@ -0,0 +1,38 @@
+package db
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ReleaseTransition represents the release_transitions table in the database
+type ReleaseTransition struct {
+	ID         string `gorm:"primaryKey;type:varchar(36)"`
+	ReleaseID  string `gorm:"type:varchar(36);not null;index"`
+	FromStatus string `gorm:"type:varchar(20);not null"`
+	ToStatus   string `gorm:"type:varchar(20);not null"`
+	UserID     uint   `gorm:"not null"`
+	Comment    *string
+	CreatedAt  time.Time
+
+	// Relationships for GORM
+	User User `gorm:"foreignKey:UserID"`
+}
+
+// TableName specifies the table name for GORM
+func (ReleaseTransition) TableName() string {
+	return "release_transitions"
+}
+
+// BeforeCreate hook for GORM
+func (t *ReleaseTransition) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == "" {
+		t.ID = uuid.New().String()
+	}
+	if t.CreatedAt.IsZero() {
+		t.CreatedAt = time.Now()
+	}
+	return nil
+}
@@ -15,8 +15,12 @@ type System struct {
 	ParentID    *string `gorm:"type:varchar(36)"`
 	Type        string  `gorm:"type:varchar(20)"`
 	Status      string  `gorm:"type:varchar(20);default:'active'"`
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	// StrictSemver rejects builds whose version is not a valid SemVer 2.0 version
+	StrictSemver bool `gorm:"default:false"`
+	// StrictDigests rejects builds that share a version with another build but not its digests
+	StrictDigests bool `gorm:"default:false"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
 
 	// Relationships for GORM
 	Parent     *System  `gorm:"foreignKey:ParentID"`
@@ -38,105 +42,11 @@ func (s *System) BeforeCreate(tx *gorm.DB) error {
 		s.CreatedAt = time.Now()
 	}
 	s.UpdatedAt = time.Now()
-
-	// Validate type
-	if !isValidSystemType(s.Type) {
-		return gorm.ErrInvalidValue
-	}
-
-	// Validate type consistency with parent_id
-	if s.ParentID != nil && *s.ParentID != "" {
-		// If has parent, must be subsystem
-		if s.Type != "subsystems" {
-			return gorm.ErrInvalidValue
-		}
-
-		var parent System
-		if err := tx.First(&parent, "id = ?", *s.ParentID).Error; err != nil {
-			return err
-		}
-
-		// Parent must be parent_systems type
-		if parent.Type != "parent_systems" {
-			return gorm.ErrInvalidValue
-		}
-	} else {
-		// If no parent, cannot be subsystem
-		if s.Type == "subsystems" {
-			return gorm.ErrInvalidValue
-		}
-	}
-
 	return nil
 }
 
 // BeforeUpdate hook for GORM
 func (s *System) BeforeUpdate(tx *gorm.DB) error {
 	s.UpdatedAt = time.Now()
-
-	// Get current system state
-	var current System
-	if err := tx.First(&current, "id = ?", s.ID).Error; err != nil {
-		return err
-	}
-
-	// Validate type
-	if !isValidSystemType(s.Type) {
-		return gorm.ErrInvalidValue
-	}
-
-	// Check if type can be changed
-	if current.Type != s.Type {
-		// Cannot change type if parent_systems has subsystems
-		if current.Type == "parent_systems" {
-			var subsystemCount int64
-			tx.Model(&System{}).Where("parent_id = ?", s.ID).Count(&subsystemCount)
-			if subsystemCount > 0 {
-				return gorm.ErrInvalidValue
-			}
-		}
-
-		// Cannot change type if systems has builds
-		if current.Type == "systems" {
-			var buildCount int64
-			tx.Model(&Build{}).Where("system_id = ?", s.ID).Count(&buildCount)
-			if buildCount > 0 {
-				return gorm.ErrInvalidValue
-			}
-		}
-	}
-
-	// Validate type consistency with parent_id
-	if s.ParentID != nil && *s.ParentID != "" {
-		// If has parent, must be subsystem
-		if s.Type != "subsystems" {
-			return gorm.ErrInvalidValue
-		}
-
-		var parent System
-		if err := tx.First(&parent, "id = ?", *s.ParentID).Error; err != nil {
-			return err
-		}
-
-		// Parent must be parent_systems type
-		if parent.Type != "parent_systems" {
-			return gorm.ErrInvalidValue
-		}
-	} else {
-		// If no parent, cannot be subsystem
-		if s.Type == "subsystems" {
-			return gorm.ErrInvalidValue
-		}
-	}
-
 	return nil
 }
-
-// Helper function to validate system type
-func isValidSystemType(t string) bool {
-	switch t {
-	case "parent_systems", "systems", "subsystems":
-		return true
-	}
-	return false
-}
@@ -12,6 +12,7 @@ type User struct {
 	Email     string `gorm:"uniqueIndex;not null"`
 	Password  string `gorm:"not null"`
 	IsAdmin   bool   `gorm:"default:false"`
+	Role      string `gorm:"type:varchar(20);default:'viewer'"`
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
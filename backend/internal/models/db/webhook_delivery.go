@@ -0,0 +1,36 @@
+package db
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WebhookDelivery represents the webhook_deliveries table in the database.
+// Each provider delivery is stored once so that redeliveries are idempotent.
+type WebhookDelivery struct {
+	ID         string  `gorm:"primaryKey;type:varchar(36)"`
+	Provider   string  `gorm:"type:varchar(20);not null;uniqueIndex:idx_webhook_deliveries_provider_delivery"`
+	DeliveryID string  `gorm:"type:varchar(255);not null;uniqueIndex:idx_webhook_deliveries_provider_delivery"`
+	Status     string  `gorm:"type:varchar(20);not null"`
+	BuildID    *string `gorm:"type:varchar(36)"`
+	Message    string
+	CreatedAt  time.Time
+}
+
+// TableName specifies the table name for GORM
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// BeforeCreate hook for GORM
+func (w *WebhookDelivery) BeforeCreate(tx *gorm.DB) error {
+	if w.ID == "" {
+		w.ID = uuid.New().String()
+	}
+	if w.CreatedAt.IsZero() {
+		w.CreatedAt = time.Now()
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package db
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WebhookSubscription represents the webhook_subscriptions table in the database.
+// The secret is stored as is because every payload is signed with it.
+type WebhookSubscription struct {
+	ID     string `gorm:"primaryKey;type:varchar(36)"`
+	Name   string `gorm:"not null"`
+	URL    string `gorm:"not null"`
+	Secret string `gorm:"type:varchar(255);not null"`
+	// EventTypes holds the subscribed event types separated by commas, empty for every event
+	EventTypes string `gorm:"type:text"`
+	Active     bool   `gorm:"not null;default:true"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// TableName specifies the table name for GORM
+func (WebhookSubscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+// BeforeCreate hook for GORM
+func (s *WebhookSubscription) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == "" {
+		s.ID = uuid.New().String()
+	}
+	if s.CreatedAt.IsZero() {
+		s.CreatedAt = time.Now()
+	}
+	if s.UpdatedAt.IsZero() {
+		s.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+// BeforeUpdate hook for GORM
+func (s *WebhookSubscription) BeforeUpdate(tx *gorm.DB) error {
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+// EventDelivery represents the event_deliveries table in the database,
+// the queue and log of the events sent to webhook subscriptions
+type EventDelivery struct {
+	ID             string `gorm:"primaryKey;type:varchar(36)"`
+	SubscriptionID string `gorm:"type:varchar(36);not null;index:idx_event_deliveries_subscription"`
+	EventID        string `gorm:"type:varchar(36);not null"`
+	EventType      string `gorm:"type:varchar(50);not null"`
+	Status         string `gorm:"type:varchar(20);not null"`
+	Attempts       int    `gorm:"not null;default:0"`
+	NextAttemptAt  *time.Time
+	LastAttemptAt  *time.Time
+	ResponseStatus *int
+	LastError      string
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time `gorm:"index:idx_event_deliveries_subscription"`
+	UpdatedAt      time.Time
+
+	// Relationships for GORM
+	Event *Event `gorm:"foreignKey:EventID"`
+}
+
+// TableName specifies the table name for GORM
+func (EventDelivery) TableName() string {
+	return "event_deliveries"
+}
+
+// BeforeCreate hook for GORM
+func (d *EventDelivery) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == "" {
+		d.ID = uuid.New().String()
+	}
+	if d.CreatedAt.IsZero() {
+		d.CreatedAt = time.Now()
+	}
+	if d.UpdatedAt.IsZero() {
+		d.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+// BeforeUpdate hook for GORM
+func (d *EventDelivery) BeforeUpdate(tx *gorm.DB) error {
+	d.UpdatedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// APITokenKind represents who an API token acts for
+type APITokenKind string
+
+const (
+	TokenKindPersonal APITokenKind = "personal"
+	TokenKindService  APITokenKind = "service"
+)
+
+// IsValid checks if the token kind is valid
+func (k APITokenKind) IsValid() bool {
+	switch k {
+	case TokenKindPersonal, TokenKindService:
+		return true
+	}
+	return false
+}
+
+// TokenScope represents a permission granted to an API token, in the form resource:action
+type TokenScope string
+
+// Resources that API token scopes can be granted for
+var TokenScopeResources = []string{"releases", "builds", "systems", "environments", "environment-groups", "audit", "webhooks", "freeze-windows", "change-requests"}
+
+// IsValid checks if the scope names a known resource with a read or write action
+func (s TokenScope) IsValid() bool {
+	for _, resource := range TokenScopeResources {
+		if s == TokenScope(resource+":read") || s == TokenScope(resource+":write") {
+			return true
+		}
+	}
+	return false
+}
+
+// APIToken represents a long-lived credential for automation clients
+type APIToken struct {
+	ID         string
+	Name       string
+	Kind       APITokenKind
+	UserID     uint
+	Prefix     string
+	TokenHash  string
+	Scopes     []TokenScope
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// HasScope checks if the token grants a scope. Write access implies read access.
+func (t *APIToken) HasScope(scope TokenScope) bool {
+	for _, granted := range t.Scopes {
+		if granted == scope {
+			return true
+		}
+		if resource, action, found := strings.Cut(string(scope), ":"); found && action == "read" && granted == TokenScope(resource+":write") {
+			return true
+		}
+	}
+	return false
+}
+
+// IsActive checks if the token is neither revoked nor expired at the given time
+func (t *APIToken) IsActive(now time.Time) bool {
+	if t.RevokedAt != nil {
+		return false
+	}
+	return t.ExpiresAt == nil || now.Before(*t.ExpiresAt)
+}
@@ -0,0 +1,68 @@
+package domain
+
+import "time"
+
+// AuditAction represents the kind of mutation recorded in the audit log
+type AuditAction string
+
+const (
+	AuditActionCreate AuditAction = "create"
+	AuditActionUpdate AuditAction = "update"
+	AuditActionDelete AuditAction = "delete"
+)
+
+// IsValid checks if the audit action is valid
+func (a AuditAction) IsValid() bool {
+	switch a {
+	case AuditActionCreate, AuditActionUpdate, AuditActionDelete:
+		return true
+	}
+	return false
+}
+
+// AuditEntityType represents the kind of entity an audit entry is about
+type AuditEntityType string
+
+const (
+	AuditEntityRelease           AuditEntityType = "release"
+	AuditEntityBuild             AuditEntityType = "build"
+	AuditEntitySystem            AuditEntityType = "system"
+	AuditEntityEnvironment       AuditEntityType = "environment"
+	AuditEntityEnvironmentGroup  AuditEntityType = "environment_group"
+	AuditEntityEnvironmentSystem AuditEntityType = "environment_system"
+	AuditEntityWebhook           AuditEntityType = "webhook"
+	AuditEntityFreezeWindow      AuditEntityType = "freeze_window"
+	AuditEntityChangeRequest     AuditEntityType = "change_request"
+)
+
+// IsValid checks if the audit entity type is valid
+func (t AuditEntityType) IsValid() bool {
+	switch t {
+	case AuditEntityRelease, AuditEntityBuild, AuditEntitySystem, AuditEntityEnvironment, AuditEntityEnvironmentGroup, AuditEntityEnvironmentSystem,
+		AuditEntityWebhook, AuditEntityFreezeWindow, AuditEntityChangeRequest:
+		return true
+	}
+	return false
+}
+
+// AuditChange holds the old and new value of a single changed field
+type AuditChange struct {
+	Before interface{}
+	After  interface{}
+}
+
+// AuditEntry records a single mutation of an entity, who made it and from where
+type AuditEntry struct {
+	ID         string
+	Action     AuditAction
+	EntityType AuditEntityType
+	EntityID   string
+	ActorID    *uint
+	Actor      string
+	Before     map[string]interface{}
+	After      map[string]interface{}
+	Changes    map[string]AuditChange
+	RequestID  string
+	SourceIP   string
+	CreatedAt  time.Time
+}
@@ -1,6 +1,32 @@
 package domain
 
-import "time"
+import (
+	"net/url"
+	"regexp"
+	"time"
+)
+
+// Errors for the rules builds must follow
+var (
+	ErrBuildNotFound           = newError(ErrorKindNotFound, "build_not_found", "Build not found")
+	ErrBuildSystemNotFound     = newError(ErrorKindInvalid, "build_system_not_found", "System not found")
+	ErrBuildReleaseNotFound    = newError(ErrorKindInvalid, "build_release_not_found", "Release not found")
+	ErrBuildForParentSystem    = newError(ErrorKindInvalid, "build_for_parent_system", "Cannot create builds for parent_systems. Only systems and subsystems can have builds")
+	ErrBuildSystemImmutable    = newError(ErrorKindInvalid, "build_system_immutable", "Cannot change system_id of existing build. System ID is immutable after creation")
+	ErrBuildVersionNotSemVer   = newError(ErrorKindInvalid, "build_version_not_semver", "System requires semantic versions")
+	ErrDuplicateBuildInRelease = newError(ErrorKindInvalid, "duplicate_build_in_release", "A build for this system already exists in this release. Each release can only have one build per system")
+	ErrBuildInvalidCommitSHA   = newError(ErrorKindInvalid, "build_invalid_commit_sha", "Invalid commit_sha. Must be 7 to 64 hexadecimal characters")
+	ErrBuildInvalidDigest      = newError(ErrorKindInvalid, "build_invalid_digest", "Invalid digest. Must be an algorithm and a hexadecimal digest, e.g. sha256:<64 hex characters>")
+	ErrBuildInvalidURL         = newError(ErrorKindInvalid, "build_invalid_url", "Invalid URL. Must be an absolute http or https URL")
+	ErrBuildDigestConflict     = newError(ErrorKindConflict, "build_digest_conflict", "Another build of this system has the same version with a different digest. The system requires one artifact per version")
+)
+
+var (
+	// commitSHAPattern matches abbreviated and full SHA-1 and SHA-256 commit hashes
+	commitSHAPattern = regexp.MustCompile(`^[0-9a-f]{7,64}$`)
+	// digestPattern matches OCI content digests, which package checksums are written as too
+	digestPattern = regexp.MustCompile(`^[a-z0-9]+(?:[.+_-][a-z0-9]+)*:[0-9a-f]{32,}$`)
+)
 
 // Build represents a build in the business domain
 type Build struct {
@@ -9,8 +35,73 @@ type Build struct {
 	ReleaseID *string
 	Version   string
 	BuildDate time.Time
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	System    *System
-	Release   *Release
+	// Changelog describes the changes in the build, shown in release notes
+	Changelog string
+	// Provenance of the build, all optional
+	RepositoryURL string
+	CommitSHA     string
+	Branch        string
+	PipelineURL   string
+	// ImageDigest and PackageChecksum reference the artifacts of the build, e.g. sha256:<hex>
+	ImageDigest     string
+	PackageChecksum string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	System          *System
+	Release         *Release
+}
+
+// ValidateProvenance checks the format of the commit, URLs and artifact digests of a build
+func (b *Build) ValidateProvenance() error {
+	if b.CommitSHA != "" && !commitSHAPattern.MatchString(b.CommitSHA) {
+		return ErrBuildInvalidCommitSHA
+	}
+	if b.RepositoryURL != "" && !isWebURL(b.RepositoryURL) {
+		return ErrBuildInvalidURL.WithDetail("field", "repository_url")
+	}
+	if b.PipelineURL != "" && !isWebURL(b.PipelineURL) {
+		return ErrBuildInvalidURL.WithDetail("field", "pipeline_url")
+	}
+	if b.ImageDigest != "" && !digestPattern.MatchString(b.ImageDigest) {
+		return ErrBuildInvalidDigest.WithDetail("field", "image_digest")
+	}
+	if b.PackageChecksum != "" && !digestPattern.MatchString(b.PackageChecksum) {
+		return ErrBuildInvalidDigest.WithDetail("field", "package_checksum")
+	}
+	return nil
+}
+
+// ConflictsWith reports whether other has the same version but a different image digest or package checksum.
+// Digests that are missing on either build do not conflict.
+func (b *Build) ConflictsWith(other *Build) bool {
+	if b.Version != other.Version {
+		return false
+	}
+	return differentDigest(b.ImageDigest, other.ImageDigest) || differentDigest(b.PackageChecksum, other.PackageChecksum)
+}
+
+// ConflictingVersions returns the versions that builds share with different digests
+func ConflictingVersions(builds []Build) []string {
+	var versions []string
+	seen := make(map[string]bool)
+	for i := range builds {
+		for j := i + 1; j < len(builds); j++ {
+			if version := builds[i].Version; !seen[version] && builds[i].ConflictsWith(&builds[j]) {
+				seen[version] = true
+				versions = append(versions, version)
+			}
+		}
+	}
+	return versions
+}
+
+// Helper function to compare two digests that are both known
+func differentDigest(a, b string) bool {
+	return a != "" && b != "" && a != b
+}
+
+// Helper function to check that a URL is an absolute http or https URL
+func isWebURL(value string) bool {
+	u, err := url.Parse(value)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
 }
@@ -0,0 +1,191 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// Errors for the rules change requests must follow
+var (
+	ErrChangeRequestNoChanges           = newError(ErrorKindInvalid, "change_request_no_changes", "A change request needs at least one change")
+	ErrChangeRequestDuplicateSystem     = newError(ErrorKindInvalid, "change_request_duplicate_system", "A change request can change each system only once")
+	ErrChangeRequestVersionRequired     = newError(ErrorKindInvalid, "change_request_version_required", "Every change needs a system_id and a version")
+	ErrChangeRequestNoOp                = newError(ErrorKindInvalid, "change_request_no_op", "System is already running this version")
+	ErrChangeRequestClosed              = newError(ErrorKindConflict, "change_request_closed", "Change request is no longer open")
+	ErrChangeRequestExpired             = newError(ErrorKindConflict, "change_request_expired", "Change request has expired")
+	ErrChangeRequestAlreadyApproved     = newError(ErrorKindConflict, "change_request_already_approved", "You already approved this change request")
+	ErrChangeRequestNotApproved         = newError(ErrorKindConflict, "change_request_not_approved", "Change request does not have the approvals it needs yet")
+	ErrChangeRequestStale               = newError(ErrorKindConflict, "change_request_stale", "Environment changed since the change request was made")
+	ErrChangeRequestRequired            = newError(ErrorKindConflict, "change_request_required", "Versions in this environment only change through approved change requests")
+	ErrChangeRequestMismatch            = newError(ErrorKindConflict, "change_request_mismatch", "Change request does not match the version changes made to its environment")
+	ErrChangeRequestNotFound            = newError(ErrorKindNotFound, "change_request_not_found", "Change request not found")
+	ErrChangeRequestSelfApproval        = newError(ErrorKindForbidden, "change_request_self_approval", "You cannot approve your own change request")
+	ErrChangeRequestApproverRole        = newError(ErrorKindForbidden, "change_request_approver_role", "Your role may not approve or reject this change request")
+	ErrChangeRequestApprovalByToken     = newError(ErrorKindForbidden, "change_request_approval_by_token", "Change requests cannot be approved or rejected with an API token")
+	ErrChangeRequestSystemNotFound      = newError(ErrorKindInvalid, "change_request_system_not_found", "System not found")
+	ErrChangeRequestVersionNotFound     = newError(ErrorKindInvalid, "change_request_version_not_found", "Version not found for system")
+	ErrChangeRequestNotPermitted        = newError(ErrorKindForbidden, "change_request_not_permitted", "You do not have permission to change this environment")
+	ErrChangeRequestEnvironmentNotFound = newError(ErrorKindInvalid, "change_request_environment_not_found", "Environment not found")
+)
+
+// ChangeRequestStatus represents where a change request is in its review
+type ChangeRequestStatus string
+
+const (
+	ChangeRequestStatusPending  ChangeRequestStatus = "pending"
+	ChangeRequestStatusApproved ChangeRequestStatus = "approved"
+	ChangeRequestStatusRejected ChangeRequestStatus = "rejected"
+	ChangeRequestStatusApplied  ChangeRequestStatus = "applied"
+	ChangeRequestStatusExpired  ChangeRequestStatus = "expired"
+)
+
+// IsValid checks if the change request status is valid
+func (s ChangeRequestStatus) IsValid() bool {
+	switch s {
+	case ChangeRequestStatusPending, ChangeRequestStatusApproved, ChangeRequestStatusRejected, ChangeRequestStatusApplied, ChangeRequestStatusExpired:
+		return true
+	}
+	return false
+}
+
+// ChangeRequest proposes version changes to the systems of an environment. It needs RequiredApprovals approvals
+// from users with at least ApproverRole, other than its author, before it can be applied, and expires at ExpiresAt.
+type ChangeRequest struct {
+	ID                string
+	Title             string
+	Description       string
+	EnvironmentID     string
+	Status            ChangeRequestStatus
+	RequiredApprovals int
+	ApproverRole      Role
+	AuthorID          uint
+	ExpiresAt         time.Time
+	RejectedBy        *uint
+	RejectionReason   string
+	AppliedBy         *uint
+	AppliedAt         *time.Time
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	Changes           []ChangeRequestChange
+	Approvals         []ChangeRequestApproval
+	Comments          []ChangeRequestComment
+}
+
+// ChangeRequestChange moves a system of the environment from FromVersion to ToVersion.
+// FromVersion is empty if the system is not in the environment yet.
+type ChangeRequestChange struct {
+	SystemID    string
+	FromVersion string
+	ToVersion   string
+}
+
+// ChangeRequestApproval records a user's sign-off on a change request
+type ChangeRequestApproval struct {
+	ID              string
+	ChangeRequestID string
+	UserID          uint
+	CreatedAt       time.Time
+}
+
+// ChangeRequestComment is a note left on a change request during its review
+type ChangeRequestComment struct {
+	ID              string
+	ChangeRequestID string
+	UserID          uint
+	Body            string
+	CreatedAt       time.Time
+}
+
+// Validate checks that the request changes at least one system, each only once and to a different version
+func (r *ChangeRequest) Validate() error {
+	if len(r.Changes) == 0 {
+		return ErrChangeRequestNoChanges
+	}
+	seen := make(map[string]bool, len(r.Changes))
+	for _, change := range r.Changes {
+		if change.SystemID == "" || change.ToVersion == "" {
+			return ErrChangeRequestVersionRequired
+		}
+		if seen[change.SystemID] {
+			return ErrChangeRequestDuplicateSystem.WithDetail("system_id", change.SystemID)
+		}
+		seen[change.SystemID] = true
+		if change.FromVersion == change.ToVersion {
+			return ErrChangeRequestNoOp.WithDetail("system_id", change.SystemID)
+		}
+	}
+	return nil
+}
+
+// IsOpen checks if the request can still be reviewed, i.e. it is pending or approved
+func (r *ChangeRequest) IsOpen() bool {
+	return r.Status == ChangeRequestStatusPending || r.Status == ChangeRequestStatusApproved
+}
+
+// IsExpired checks if the request expired at a point in time, whether or not its status says so yet
+func (r *ChangeRequest) IsExpired(at time.Time) bool {
+	return r.Status == ChangeRequestStatusExpired || (r.IsOpen() && !at.Before(r.ExpiresAt))
+}
+
+// ApprovedBy checks if a user approved the request
+func (r *ChangeRequest) ApprovedBy(userID uint) bool {
+	return slices.ContainsFunc(r.Approvals, func(a ChangeRequestApproval) bool { return a.UserID == userID })
+}
+
+// Approve adds the approval of a user, approving the request once it has as many as it needs
+func (r *ChangeRequest) Approve(userID uint, at time.Time) (*ChangeRequestApproval, error) {
+	if err := r.checkOpen(at); err != nil {
+		return nil, err
+	}
+	if userID == r.AuthorID {
+		return nil, ErrChangeRequestSelfApproval
+	}
+	if r.ApprovedBy(userID) {
+		return nil, ErrChangeRequestAlreadyApproved
+	}
+
+	approval := ChangeRequestApproval{ChangeRequestID: r.ID, UserID: userID, CreatedAt: at}
+	r.Approvals = append(r.Approvals, approval)
+	if len(r.Approvals) >= r.RequiredApprovals {
+		r.Status = ChangeRequestStatusApproved
+	}
+	return &approval, nil
+}
+
+// Reject closes the request without applying it
+func (r *ChangeRequest) Reject(userID uint, reason string, at time.Time) error {
+	if err := r.checkOpen(at); err != nil {
+		return err
+	}
+	r.Status = ChangeRequestStatusRejected
+	r.RejectedBy = &userID
+	r.RejectionReason = reason
+	return nil
+}
+
+// Apply marks an approved request as applied
+func (r *ChangeRequest) Apply(userID uint, at time.Time) error {
+	if err := r.checkOpen(at); err != nil {
+		return err
+	}
+	if r.Status != ChangeRequestStatusApproved {
+		return ErrChangeRequestNotApproved.
+			WithDetail("approvals", len(r.Approvals)).
+			WithDetail("required_approvals", r.RequiredApprovals)
+	}
+	r.Status = ChangeRequestStatusApplied
+	r.AppliedBy = &userID
+	r.AppliedAt = &at
+	return nil
+}
+
+// Helper function to check that the request can still be reviewed and applied
+func (r *ChangeRequest) checkOpen(at time.Time) error {
+	if r.IsExpired(at) {
+		return ErrChangeRequestExpired.WithDetail("expires_at", r.ExpiresAt)
+	}
+	if !r.IsOpen() {
+		return ErrChangeRequestClosed.WithDetail("status", r.Status)
+	}
+	return nil
+}
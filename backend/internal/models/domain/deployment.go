@@ -0,0 +1,41 @@
+package domain
+
+import "time"
+
+// DeploymentSource represents what triggered a version change in an environment
+type DeploymentSource string
+
+const (
+	DeploymentSourceManual        DeploymentSource = "manual"
+	DeploymentSourceSync          DeploymentSource = "sync"
+	DeploymentSourceAPI           DeploymentSource = "api"
+	DeploymentSourceRollback      DeploymentSource = "rollback"
+	DeploymentSourcePromotion     DeploymentSource = "promotion"
+	DeploymentSourceChangeRequest DeploymentSource = "change_request"
+	DeploymentSourceImport        DeploymentSource = "import"
+)
+
+// IsValid checks if the deployment source is valid
+func (ds DeploymentSource) IsValid() bool {
+	switch ds {
+	case DeploymentSourceManual, DeploymentSourceSync, DeploymentSourceAPI, DeploymentSourceRollback, DeploymentSourcePromotion,
+		DeploymentSourceChangeRequest, DeploymentSourceImport:
+		return true
+	}
+	return false
+}
+
+// Deployment represents a single version change of a system in an environment
+type Deployment struct {
+	ID            string
+	EnvironmentID string
+	SystemID      string
+	OldVersion    string
+	NewVersion    string
+	BuildID       *string
+	UserID        *uint
+	Source        DeploymentSource
+	CreatedAt     time.Time
+	System        *System
+	User          *User
+}
@@ -21,6 +21,15 @@ const (
 	EnvStatusPending        EnvironmentStatus = "pending"
 )
 
+// IsValid checks if the environment status is valid
+func (es EnvironmentStatus) IsValid() bool {
+	switch es {
+	case EnvStatusActive, EnvStatusDecommissioned, EnvStatusMaintenance, EnvStatusPending:
+		return true
+	}
+	return false
+}
+
 // Environment represents an environment in the business domain
 type Environment struct {
 	ID                 string
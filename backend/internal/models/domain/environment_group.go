@@ -1,13 +1,48 @@
 package domain
 
-import "time"
+import (
+	"fmt"
+	"strings"
+	"time"
+)
 
 // EnvironmentGroup represents a group of environments in the business domain
 type EnvironmentGroup struct {
-	ID           string
-	Name         string
-	Description  *string
+	ID            string
+	Name          string
+	Description   *string
+	PromotionPath []EnvironmentType
+	// RequiredRole is the minimum role needed to change the group or its environments, empty for no restriction
+	RequiredRole Role
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
 	Environments []Environment
 }
+
+// NextStage returns the environment type that follows envType in the promotion path
+func (g *EnvironmentGroup) NextStage(envType EnvironmentType) (EnvironmentType, bool) {
+	for i, stage := range g.PromotionPath {
+		if stage == envType && i+1 < len(g.PromotionPath) {
+			return g.PromotionPath[i+1], true
+		}
+	}
+	return "", false
+}
+
+// ValidatePromotionPath checks that the stages of a promotion path are non-empty, distinct and free of commas
+func ValidatePromotionPath(path []EnvironmentType) error {
+	seen := make(map[EnvironmentType]bool)
+	for _, stage := range path {
+		if stage == "" {
+			return fmt.Errorf("Promotion path stages cannot be empty")
+		}
+		if strings.Contains(string(stage), ",") {
+			return fmt.Errorf("Promotion path stage '%s' cannot contain commas", stage)
+		}
+		if seen[stage] {
+			return fmt.Errorf("Promotion path stage '%s' appears more than once", stage)
+		}
+		seen[stage] = true
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package domain
+
+// ErrorKind classifies a domain error so callers can react to it without matching messages
+type ErrorKind string
+
+const (
+	// ErrorKindInvalid means the requested change breaks a business rule
+	ErrorKindInvalid ErrorKind = "invalid"
+	// ErrorKindNotFound means the entity the change is about does not exist
+	ErrorKindNotFound ErrorKind = "not_found"
+	// ErrorKindConflict means the current state of other entities prevents the change
+	ErrorKindConflict ErrorKind = "conflict"
+	// ErrorKindForbidden means the caller may not make the change
+	ErrorKindForbidden ErrorKind = "forbidden"
+)
+
+// Error is a business rule violation. Errors with the same code match each other with errors.Is,
+// so a copy carrying a cause or details still matches the rule it was created from.
+type Error struct {
+	Code    string
+	Kind    ErrorKind
+	Message string
+	// Cause explains the violation further, e.g. why a version could not be parsed
+	Cause error
+	// Details carries extra fields worth returning to the client, e.g. the offending versions
+	Details map[string]interface{}
+}
+
+func newError(kind ErrorKind, code, message string) *Error {
+	return &Error{Code: code, Kind: kind, Message: message}
+}
+
+// Error returns the message followed by the cause, if any
+func (e *Error) Error() string {
+	if e.Cause != nil {
+		return e.Message + ": " + e.Cause.Error()
+	}
+	return e.Message
+}
+
+// Unwrap returns the cause of the violation
+func (e *Error) Unwrap() error {
+	return e.Cause
+}
+
+// Is reports whether target is a domain error with the same code
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// WithCause returns a copy of the error that explains the violation with cause
+func (e *Error) WithCause(cause error) *Error {
+	copied := *e
+	copied.Cause = cause
+	return &copied
+}
+
+// WithDetail returns a copy of the error that carries an extra field for the client
+func (e *Error) WithDetail(key string, value interface{}) *Error {
+	copied := *e
+	copied.Details = make(map[string]interface{}, len(e.Details)+1)
+	for k, v := range e.Details {
+		copied.Details[k] = v
+	}
+	copied.Details[key] = value
+	return &copied
+}
@@ -0,0 +1,59 @@
+package domain
+
+import "time"
+
+// EventType names something that happened in the catalog that other tools can subscribe to
+type EventType string
+
+const (
+	EventReleaseCreated           EventType = "release.created"
+	EventReleaseUpdated           EventType = "release.updated"
+	EventReleaseDeleted           EventType = "release.deleted"
+	EventReleaseTransitioned      EventType = "release.transitioned"
+	EventBuildRegistered          EventType = "build.registered"
+	EventBuildUpdated             EventType = "build.updated"
+	EventBuildDeleted             EventType = "build.deleted"
+	EventEnvironmentCreated       EventType = "environment.created"
+	EventEnvironmentUpdated       EventType = "environment.updated"
+	EventEnvironmentDeleted       EventType = "environment.deleted"
+	EventEnvironmentSystemChanged EventType = "environment_system.changed"
+)
+
+// EventTypes lists every event type in the order they are documented
+var EventTypes = []EventType{
+	EventReleaseCreated, EventReleaseUpdated, EventReleaseDeleted, EventReleaseTransitioned,
+	EventBuildRegistered, EventBuildUpdated, EventBuildDeleted,
+	EventEnvironmentCreated, EventEnvironmentUpdated, EventEnvironmentDeleted,
+	EventEnvironmentSystemChanged,
+}
+
+// IsValid checks if the event type is valid
+func (t EventType) IsValid() bool {
+	for _, eventType := range EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Resource returns the resource a caller needs read access to in order to see events of the type
+func (t EventType) Resource() string {
+	switch t {
+	case EventReleaseCreated, EventReleaseUpdated, EventReleaseDeleted, EventReleaseTransitioned:
+		return ResourceReleases
+	case EventBuildRegistered, EventBuildUpdated, EventBuildDeleted:
+		return ResourceBuilds
+	}
+	return ResourceEnvironments
+}
+
+// Event records something that happened in the catalog, with the data sent to subscribers.
+// Sequence orders events by when they were stored and identifies them in the event stream.
+type Event struct {
+	ID        string
+	Sequence  int64
+	Type      EventType
+	Data      map[string]interface{}
+	CreatedAt time.Time
+}
@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// Errors for the rules freeze windows must follow
+var (
+	ErrFreezeWindowPeriod         = newError(ErrorKindInvalid, "freeze_window_period", "A freeze window must end after it starts")
+	ErrFreezeWindowScope          = newError(ErrorKindInvalid, "freeze_window_scope", "Invalid scope. Valid values are: global, environment_group, environment_type")
+	ErrFreezeWindowGroupRequired  = newError(ErrorKindInvalid, "freeze_window_group_required", "A freeze window scoped to an environment group needs environment_group_id")
+	ErrFreezeWindowTypeRequired   = newError(ErrorKindInvalid, "freeze_window_type_required", "A freeze window scoped to an environment type needs environment_type")
+	ErrFreezeWindowGroupNotFound  = newError(ErrorKindInvalid, "freeze_window_group_not_found", "Environment Group not found")
+	ErrFreezeWindowExemptNotFound = newError(ErrorKindInvalid, "freeze_window_exempt_not_found", "Exempt system or environment not found")
+	ErrEnvironmentFrozen          = newError(ErrorKindConflict, "environment_frozen", "Environment is frozen. Users who may override the freeze can retry with a justification in the X-Freeze-Override header")
+	ErrFreezeOverrideNotPermitted = newError(ErrorKindForbidden, "freeze_override_not_permitted", "You do not have permission to override this freeze")
+)
+
+// FreezeScope selects the environments a freeze window applies to
+type FreezeScope string
+
+const (
+	FreezeScopeGlobal           FreezeScope = "global"
+	FreezeScopeEnvironmentGroup FreezeScope = "environment_group"
+	FreezeScopeEnvironmentType  FreezeScope = "environment_type"
+)
+
+// IsValid checks if the freeze scope is valid
+func (s FreezeScope) IsValid() bool {
+	switch s {
+	case FreezeScopeGlobal, FreezeScopeEnvironmentGroup, FreezeScopeEnvironmentType:
+		return true
+	}
+	return false
+}
+
+// FreezeWindow blocks version changes in its environments from StartsAt until EndsAt, such as a code freeze
+// before a major release or a blackout over the holidays. Exempt systems and environments can still change.
+type FreezeWindow struct {
+	ID                   string
+	Name                 string
+	Reason               string
+	Scope                FreezeScope
+	EnvironmentGroupID   *string
+	EnvironmentType      *EnvironmentType
+	StartsAt             time.Time
+	EndsAt               time.Time
+	ExemptSystemIDs      []string
+	ExemptEnvironmentIDs []string
+	CreatedBy            *uint
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+}
+
+// Validate checks the period and scope of the window
+func (w *FreezeWindow) Validate() error {
+	if !w.EndsAt.After(w.StartsAt) {
+		return ErrFreezeWindowPeriod
+	}
+	switch w.Scope {
+	case FreezeScopeGlobal:
+	case FreezeScopeEnvironmentGroup:
+		if w.EnvironmentGroupID == nil || *w.EnvironmentGroupID == "" {
+			return ErrFreezeWindowGroupRequired
+		}
+	case FreezeScopeEnvironmentType:
+		if w.EnvironmentType == nil || *w.EnvironmentType == "" {
+			return ErrFreezeWindowTypeRequired
+		}
+	default:
+		return ErrFreezeWindowScope
+	}
+	return nil
+}
+
+// IsActive checks if the window is in force at a point in time
+func (w *FreezeWindow) IsActive(at time.Time) bool {
+	return !at.Before(w.StartsAt) && at.Before(w.EndsAt)
+}
+
+// Covers checks if the window applies to an environment, regardless of exempt systems
+func (w *FreezeWindow) Covers(env *Environment) bool {
+	if slices.Contains(w.ExemptEnvironmentIDs, env.ID) {
+		return false
+	}
+	switch w.Scope {
+	case FreezeScopeGlobal:
+		return true
+	case FreezeScopeEnvironmentGroup:
+		return w.EnvironmentGroupID != nil && env.EnvironmentGroupID != nil && *w.EnvironmentGroupID == *env.EnvironmentGroupID
+	case FreezeScopeEnvironmentType:
+		return w.EnvironmentType != nil && *w.EnvironmentType == env.Type
+	}
+	return false
+}
+
+// Blocks checks if the window blocks a version change of a system in an environment
+func (w *FreezeWindow) Blocks(env *Environment, systemID string) bool {
+	return w.Covers(env) && !slices.Contains(w.ExemptSystemIDs, systemID)
+}
+
+// FreezeOverride records a version change that was let through an active freeze window with a justification.
+// The name of the window is kept so that the record outlives the window.
+type FreezeOverride struct {
+	ID               string
+	FreezeWindowID   string
+	FreezeWindowName string
+	EnvironmentID    string
+	SystemID         string
+	OldVersion       string
+	NewVersion       string
+	Justification    string
+	UserID           *uint
+	CreatedAt        time.Time
+}
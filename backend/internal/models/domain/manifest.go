@@ -0,0 +1,104 @@
+package domain
+
+import "time"
+
+// ErrInvalidManifest is returned when a manifest cannot be applied, with every problem found in its details
+var ErrInvalidManifest = newError(ErrorKindInvalid, "invalid_manifest", "Invalid manifest")
+
+// Manifest describes the catalog declaratively. Entities refer to each other by name instead of ID.
+// A nil section is left alone on import, while a present section lists every entity of its kind.
+type Manifest struct {
+	Systems           []ManifestSystem
+	Releases          []ManifestRelease
+	EnvironmentGroups []ManifestEnvironmentGroup
+	Environments      []ManifestEnvironment
+}
+
+// ManifestSystem is a parent system or system with its subsystems, or a subsystem
+type ManifestSystem struct {
+	Name          string
+	Description   *string
+	Type          SystemType
+	Status        SystemStatus
+	StrictSemver  bool
+	StrictDigests bool
+	Subsystems    []ManifestSystem
+	// Builds lists every build of the system, nil leaves the builds alone
+	Builds []ManifestBuild
+}
+
+// ManifestBuild is a build of a system, identified by its version
+type ManifestBuild struct {
+	Version   string
+	Release   string
+	BuildDate time.Time
+}
+
+// ManifestRelease is a release, identified by its name
+type ManifestRelease struct {
+	Name        string
+	Description *string
+	ReleaseDate time.Time
+	Status      ReleaseStatus
+	Type        ReleaseType
+}
+
+// ManifestEnvironmentGroup is an environment group, identified by its name
+type ManifestEnvironmentGroup struct {
+	Name          string
+	Description   *string
+	PromotionPath []EnvironmentType
+	RequiredRole  Role
+}
+
+// ManifestEnvironment is an environment, identified by its name
+type ManifestEnvironment struct {
+	Name        string
+	Type        EnvironmentType
+	Status      EnvironmentStatus
+	URL         *string
+	Description *string
+	Release     string
+	Group       string
+	// Systems lists every system deployed to the environment, nil leaves the deployed systems alone
+	Systems []ManifestEnvironmentSystem
+}
+
+// ManifestEnvironmentSystem is a system deployed to an environment in a version
+type ManifestEnvironmentSystem struct {
+	System  string
+	Version string
+	Status  string
+}
+
+// ManifestChange is a single create, update or delete needed to bring the catalog in line with a manifest
+type ManifestChange struct {
+	Action     AuditAction
+	EntityType AuditEntityType
+	// Name is the natural key of the entity, e.g. "payments@1.2.0" for a build or "staging/payments" for a deployed system
+	Name string
+	// EntityID is empty for entities that are not created yet
+	EntityID string
+	// Fields lists the fields an update changes
+	Fields []string
+	// Before and After hold the entity before and after an applied change, nil for a creation or deletion
+	Before interface{}
+	After  interface{}
+}
+
+// ManifestPlan lists the changes an import makes, in the order they are applied
+type ManifestPlan struct {
+	Changes []ManifestChange
+	Applied bool
+}
+
+// Count returns the number of changes with an action
+func (p *ManifestPlan) Count(action AuditAction) int {
+	count := 0
+	for _, change := range p.Changes {
+		if change.Action == action {
+			count++
+		}
+	}
+	return count
+}
@@ -8,9 +8,46 @@ type ReleaseStatus string
 const (
 	StatusPlanned    ReleaseStatus = "planned"
 	StatusInProgress ReleaseStatus = "in-progress"
-	StatusCompleted  ReleaseStatus = "completed"
+	StatusFrozen     ReleaseStatus = "frozen"
+	StatusReleased   ReleaseStatus = "released"
+	StatusCancelled  ReleaseStatus = "cancelled"
 )
 
+// releaseTransitions lists the statuses a release may move to from each status
+var releaseTransitions = map[ReleaseStatus][]ReleaseStatus{
+	StatusPlanned:    {StatusInProgress, StatusCancelled},
+	StatusInProgress: {StatusPlanned, StatusFrozen, StatusCancelled},
+	StatusFrozen:     {StatusInProgress, StatusReleased, StatusCancelled},
+	StatusReleased:   {},
+	StatusCancelled:  {},
+}
+
+// IsValid checks if the release status is part of the lifecycle
+func (rs ReleaseStatus) IsValid() bool {
+	_, ok := releaseTransitions[rs]
+	return ok
+}
+
+// AllowedTransitions returns the statuses a release may move to from rs
+func (rs ReleaseStatus) AllowedTransitions() []ReleaseStatus {
+	return append([]ReleaseStatus(nil), releaseTransitions[rs]...)
+}
+
+// CanTransitionTo checks if a release may move from rs to target
+func (rs ReleaseStatus) CanTransitionTo(target ReleaseStatus) bool {
+	for _, allowed := range releaseTransitions[rs] {
+		if allowed == target {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTerminal checks if no further transitions are possible from rs
+func (rs ReleaseStatus) IsTerminal() bool {
+	return rs.IsValid() && len(releaseTransitions[rs]) == 0
+}
+
 // ReleaseType represents the type of release
 type ReleaseType string
 
@@ -32,3 +69,15 @@ type Release struct {
 	UpdatedAt   time.Time
 	Builds      []Build
 }
+
+// ReleaseTransition records a single move of a release between two statuses
+type ReleaseTransition struct {
+	ID         string
+	ReleaseID  string
+	FromStatus ReleaseStatus
+	ToStatus   ReleaseStatus
+	UserID     uint
+	Comment    *string
+	CreatedAt  time.Time
+	User       *User
+}
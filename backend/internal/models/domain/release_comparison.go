@@ -0,0 +1,33 @@
+package domain
+
+// SystemChange represents how a system differs between two releases
+type SystemChange string
+
+const (
+	ChangeAdded      SystemChange = "added"
+	ChangeRemoved    SystemChange = "removed"
+	ChangeUpgraded   SystemChange = "upgraded"
+	ChangeDowngraded SystemChange = "downgraded"
+	ChangeUnchanged  SystemChange = "unchanged"
+
+	// ChangeModified is only used for parent systems whose subsystems changed in different ways
+	ChangeModified SystemChange = "modified"
+)
+
+// SystemComparison represents one system lined up between a base and a target release
+type SystemComparison struct {
+	SystemID      string
+	SystemName    string
+	SystemType    SystemType
+	Change        SystemChange
+	BaseVersion   string
+	TargetVersion string
+	Subsystems    []SystemComparison
+}
+
+// ReleaseComparison represents the differences between a base and a target release
+type ReleaseComparison struct {
+	Base    *Release
+	Target  *Release
+	Systems []SystemComparison
+}
@@ -0,0 +1,50 @@
+package domain
+
+import "time"
+
+// ReleaseNotesFormat represents the format release notes are rendered in
+type ReleaseNotesFormat string
+
+const (
+	ReleaseNotesJSON     ReleaseNotesFormat = "json"
+	ReleaseNotesMarkdown ReleaseNotesFormat = "markdown"
+	ReleaseNotesHTML     ReleaseNotesFormat = "html"
+)
+
+// IsValid checks if the release notes format is supported
+func (f ReleaseNotesFormat) IsValid() bool {
+	switch f {
+	case ReleaseNotesJSON, ReleaseNotesMarkdown, ReleaseNotesHTML:
+		return true
+	}
+	return false
+}
+
+// Errors for the rules of release notes
+var (
+	ErrReleaseNotesInvalidFormat    = newError(ErrorKindInvalid, "release_notes_invalid_format", "Invalid format. Must be 'markdown', 'html' or 'json'")
+	ErrReleaseNotesInvalidTeam      = newError(ErrorKindInvalid, "release_notes_invalid_team", "Invalid team. Team names may only contain letters, digits, '-' and '_'")
+	ErrReleaseNotesTemplateNotFound = newError(ErrorKindInvalid, "release_notes_template_not_found", "No release notes template for this team and format")
+	ErrReleaseNotesTemplateInvalid  = newError(ErrorKindInvalid, "release_notes_template_invalid", "Release notes template could not be rendered")
+)
+
+// ReleaseNotesEntry represents a system in release notes. Systems with builds carry the version of the release
+// next to the version of the latest earlier release containing the system. Parent systems only group their subsystems.
+type ReleaseNotesEntry struct {
+	SystemID        string
+	SystemName      string
+	SystemType      SystemType
+	Change          SystemChange
+	Version         string
+	BuildDate       time.Time
+	Changelog       string
+	PreviousVersion string
+	PreviousRelease *Release
+	Subsystems      []ReleaseNotesEntry
+}
+
+// ReleaseNotes represents the builds of a release grouped by parent system
+type ReleaseNotes struct {
+	Release *Release
+	Systems []ReleaseNotesEntry
+}
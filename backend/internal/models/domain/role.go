@@ -0,0 +1,121 @@
+package domain
+
+import "time"
+
+// Role represents a user's level of access
+type Role string
+
+const (
+	RoleViewer         Role = "viewer"
+	RoleEngineer       Role = "engineer"
+	RoleReleaseManager Role = "release-manager"
+	RoleAdmin          Role = "admin"
+)
+
+// Action represents what a request does to a resource
+type Action string
+
+const (
+	ActionRead   Action = "read"
+	ActionWrite  Action = "write"
+	ActionDelete Action = "delete"
+	// ActionOverride lets changes through a freeze window
+	ActionOverride Action = "override"
+)
+
+// Resources protected by the permission matrix
+const (
+	ResourceReleases          = "releases"
+	ResourceBuilds            = "builds"
+	ResourceSystems           = "systems"
+	ResourceEnvironments      = "environments"
+	ResourceEnvironmentGroups = "environment-groups"
+	ResourceAudit             = "audit"
+	ResourceWebhooks          = "webhooks"
+	ResourceFreezeWindows     = "freeze-windows"
+	ResourceChangeRequests    = "change-requests"
+)
+
+// roleRanks orders roles from least to most privileged
+var roleRanks = map[Role]int{
+	RoleViewer:         1,
+	RoleEngineer:       2,
+	RoleReleaseManager: 3,
+	RoleAdmin:          4,
+}
+
+// rolePermissions is the permission matrix: the actions each role may perform per resource
+var rolePermissions = map[Role]map[string][]Action{
+	RoleViewer: {
+		ResourceReleases:          {ActionRead},
+		ResourceBuilds:            {ActionRead},
+		ResourceSystems:           {ActionRead},
+		ResourceEnvironments:      {ActionRead},
+		ResourceEnvironmentGroups: {ActionRead},
+		ResourceFreezeWindows:     {ActionRead},
+		ResourceChangeRequests:    {ActionRead},
+	},
+	RoleEngineer: {
+		ResourceReleases:          {ActionRead},
+		ResourceBuilds:            {ActionRead, ActionWrite},
+		ResourceSystems:           {ActionRead, ActionWrite},
+		ResourceEnvironments:      {ActionRead, ActionWrite},
+		ResourceEnvironmentGroups: {ActionRead},
+		ResourceFreezeWindows:     {ActionRead},
+		ResourceChangeRequests:    {ActionRead, ActionWrite},
+	},
+	RoleReleaseManager: {
+		ResourceReleases:          {ActionRead, ActionWrite, ActionDelete},
+		ResourceBuilds:            {ActionRead, ActionWrite, ActionDelete},
+		ResourceSystems:           {ActionRead, ActionWrite},
+		ResourceEnvironments:      {ActionRead, ActionWrite},
+		ResourceEnvironmentGroups: {ActionRead, ActionWrite},
+		ResourceAudit:             {ActionRead},
+		ResourceFreezeWindows:     {ActionRead, ActionWrite, ActionDelete, ActionOverride},
+		ResourceChangeRequests:    {ActionRead, ActionWrite},
+	},
+	RoleAdmin: {
+		ResourceReleases:          {ActionRead, ActionWrite, ActionDelete},
+		ResourceBuilds:            {ActionRead, ActionWrite, ActionDelete},
+		ResourceSystems:           {ActionRead, ActionWrite, ActionDelete},
+		ResourceEnvironments:      {ActionRead, ActionWrite, ActionDelete},
+		ResourceEnvironmentGroups: {ActionRead, ActionWrite, ActionDelete},
+		ResourceAudit:             {ActionRead},
+		ResourceWebhooks:          {ActionRead, ActionWrite, ActionDelete},
+		ResourceFreezeWindows:     {ActionRead, ActionWrite, ActionDelete, ActionOverride},
+		ResourceChangeRequests:    {ActionRead, ActionWrite},
+	},
+}
+
+// IsValid checks if the role is valid
+func (r Role) IsValid() bool {
+	_, ok := roleRanks[r]
+	return ok
+}
+
+// AtLeast checks if the role is as privileged as other or more
+func (r Role) AtLeast(other Role) bool {
+	return roleRanks[r] >= roleRanks[other]
+}
+
+// Can checks if the role may perform an action on a resource
+func (r Role) Can(resource string, action Action) bool {
+	for _, allowed := range rolePermissions[r][resource] {
+		if allowed == action {
+			return true
+		}
+	}
+	return false
+}
+
+// EnvironmentGroupRoleGrant gives a user a role within a single environment group,
+// replacing their global role for the group's environments
+type EnvironmentGroupRoleGrant struct {
+	ID                 string
+	EnvironmentGroupID string
+	UserID             uint
+	Role               Role
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+	User               *User
+}
@@ -0,0 +1,43 @@
+package domain
+
+// SearchResultType is the kind of entity a search result points to
+type SearchResultType string
+
+const (
+	SearchResultRelease     SearchResultType = "release"
+	SearchResultSystem      SearchResultType = "system"
+	SearchResultBuild       SearchResultType = "build"
+	SearchResultEnvironment SearchResultType = "environment"
+)
+
+// SearchResultTypes lists every searchable type
+var SearchResultTypes = []SearchResultType{SearchResultRelease, SearchResultSystem, SearchResultBuild, SearchResultEnvironment}
+
+// IsValid checks if the search result type is valid
+func (t SearchResultType) IsValid() bool {
+	switch t {
+	case SearchResultRelease, SearchResultSystem, SearchResultBuild, SearchResultEnvironment:
+		return true
+	}
+	return false
+}
+
+// Search highlights wrap the matching words of a field in these markers
+const (
+	HighlightStart = "<mark>"
+	HighlightStop  = "</mark>"
+)
+
+// SearchResult is an entity matching a full-text search
+type SearchResult struct {
+	Type SearchResultType
+	ID   string
+	// Title names the entity, e.g. the system and version of a build
+	Title string
+	// Subtitle adds context, e.g. the release a build belongs to
+	Subtitle string
+	// Rank orders results by relevance, higher first
+	Rank float64
+	// Highlights holds the matching fields with their matches wrapped in HighlightStart and HighlightStop
+	Highlights map[string]string
+}
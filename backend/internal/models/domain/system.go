@@ -28,17 +28,46 @@ const (
 	StatusDeprecated SystemStatus = "deprecated"
 )
 
+// Errors for the rules of the system hierarchy
+var (
+	ErrSystemNotFound                 = newError(ErrorKindNotFound, "system_not_found", "System not found")
+	ErrInvalidSystemType              = newError(ErrorKindInvalid, "invalid_system_type", "Invalid type. Must be one of: parent_systems, systems, subsystems")
+	ErrInvalidSystemStatus            = newError(ErrorKindInvalid, "invalid_system_status", "Invalid status. Must be 'active' or 'deprecated'")
+	ErrParentSystemNotFound           = newError(ErrorKindInvalid, "parent_system_not_found", "Parent system not found")
+	ErrParentMustBeParentSystem       = newError(ErrorKindInvalid, "parent_must_be_parent_system", "Parent system must be of type 'parent_systems'")
+	ErrChildMustBeSubsystem           = newError(ErrorKindInvalid, "child_must_be_subsystem", "System with parent must be of type 'subsystems'")
+	ErrSubsystemRequiresParent        = newError(ErrorKindInvalid, "subsystem_requires_parent", "Subsystem must have a parent system")
+	ErrTypeChangeWithSubsystems       = newError(ErrorKindInvalid, "type_change_with_subsystems", "Cannot change type of parent_systems that has subsystems")
+	ErrTypeChangeWithBuilds           = newError(ErrorKindInvalid, "type_change_with_builds", "Cannot change type of systems that has builds associated with it")
+	ErrCannotMoveSystemWithChildren   = newError(ErrorKindInvalid, "cannot_move_system_with_subsystems", "Cannot move a system with subsystems under another system")
+	ErrCannotDeleteSystemWithChildren = newError(ErrorKindInvalid, "cannot_delete_system_with_subsystems", "Cannot delete system with subsystems. Please delete subsystems first")
+	ErrBuildsNotSemVer                = newError(ErrorKindInvalid, "builds_not_semver", "Cannot enable strict semver because some builds do not use semantic versions")
+	ErrBuildsDigestConflict           = newError(ErrorKindInvalid, "builds_digest_conflict", "Cannot enable strict digests because some versions have builds with different digests")
+)
+
+// IsValid checks if the system status is valid
+func (ss SystemStatus) IsValid() bool {
+	switch ss {
+	case StatusActive, StatusDeprecated:
+		return true
+	}
+	return false
+}
+
 // System represents a system entity in the business domain
 type System struct {
-	ID          string
-	Name        string
-	Description *string
-	ParentID    *string
-	Type        SystemType
-	Status      SystemStatus
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	Parent      *System
-	Subsystems  []System
-	Builds      []Build
+	ID           string
+	Name         string
+	Description  *string
+	ParentID     *string
+	Type         SystemType
+	Status       SystemStatus
+	StrictSemver bool
+	// StrictDigests rejects builds that share a version with another build of the system but not its digests
+	StrictDigests bool
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	Parent        *System
+	Subsystems    []System
+	Builds        []Build
 }
@@ -8,6 +8,7 @@ type User struct {
 	Email     string
 	Password  string
 	IsAdmin   bool
+	Role      Role
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
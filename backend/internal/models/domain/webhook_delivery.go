@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+// WebhookDeliveryStatus represents what happened to a CI webhook delivery
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryProcessed WebhookDeliveryStatus = "processed"
+	WebhookDeliveryIgnored   WebhookDeliveryStatus = "ignored"
+)
+
+// WebhookDelivery records a processed CI webhook delivery so redeliveries are idempotent
+type WebhookDelivery struct {
+	ID         string
+	Provider   string
+	DeliveryID string
+	Status     WebhookDeliveryStatus
+	BuildID    *string
+	Message    string
+	CreatedAt  time.Time
+}
@@ -0,0 +1,80 @@
+package domain
+
+import "time"
+
+// WebhookSubscription sends the events of the selected types to a URL, signed with a shared secret
+type WebhookSubscription struct {
+	ID   string
+	Name string
+	URL  string
+	// Secret signs every payload, it is shown once when the subscription is created
+	Secret string
+	// EventTypes selects the events that are sent, empty for every event
+	EventTypes []EventType
+	Active     bool
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// Accepts checks if the subscription wants events of a type
+func (s *WebhookSubscription) Accepts(eventType EventType) bool {
+	if !s.Active {
+		return false
+	}
+	if len(s.EventTypes) == 0 {
+		return true
+	}
+	for _, accepted := range s.EventTypes {
+		if accepted == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidWebhookURL checks if a URL is an absolute http or https URL that events can be sent to
+func IsValidWebhookURL(rawURL string) bool {
+	return isWebURL(rawURL)
+}
+
+// EventDeliveryStatus represents where an event is in its delivery to a webhook subscription
+type EventDeliveryStatus string
+
+const (
+	// EventDeliveryPending waits for its first attempt
+	EventDeliveryPending EventDeliveryStatus = "pending"
+	// EventDeliveryRetrying failed at least once and waits for its next attempt
+	EventDeliveryRetrying  EventDeliveryStatus = "retrying"
+	EventDeliveryDelivered EventDeliveryStatus = "delivered"
+	// EventDeliveryDead ran out of attempts and stays in the dead-letter queue until it is redelivered
+	EventDeliveryDead EventDeliveryStatus = "dead"
+)
+
+// IsValid checks if the delivery status is valid
+func (s EventDeliveryStatus) IsValid() bool {
+	switch s {
+	case EventDeliveryPending, EventDeliveryRetrying, EventDeliveryDelivered, EventDeliveryDead:
+		return true
+	}
+	return false
+}
+
+// EventDelivery sends one event to one webhook subscription and logs the outcome of the last attempt
+type EventDelivery struct {
+	ID             string
+	SubscriptionID string
+	EventID        string
+	EventType      EventType
+	Status         EventDeliveryStatus
+	Attempts       int
+	// NextAttemptAt is when the delivery is due, nil once it is delivered or dead
+	NextAttemptAt *time.Time
+	LastAttemptAt *time.Time
+	// ResponseStatus is the HTTP status of the last attempt, nil if it got no response
+	ResponseStatus *int
+	LastError      string
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	Event          *Event
+}
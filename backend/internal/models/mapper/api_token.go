@@ -0,0 +1,111 @@
+package mapper
+
+import (
+	"strings"
+
+	"release-management/internal/models/api"
+	"release-management/internal/models/db"
+	"release-management/internal/models/domain"
+)
+
+// APITokenDBToDomain converts db.APIToken to domain.APIToken
+func APITokenDBToDomain(dbToken *db.APIToken) *domain.APIToken {
+	if dbToken == nil {
+		return nil
+	}
+
+	domainToken := &domain.APIToken{
+		ID:         dbToken.ID,
+		Name:       dbToken.Name,
+		Kind:       domain.APITokenKind(dbToken.Kind),
+		UserID:     dbToken.UserID,
+		Prefix:     dbToken.Prefix,
+		TokenHash:  dbToken.TokenHash,
+		ExpiresAt:  dbToken.ExpiresAt,
+		LastUsedAt: dbToken.LastUsedAt,
+		RevokedAt:  dbToken.RevokedAt,
+		CreatedAt:  dbToken.CreatedAt,
+		UpdatedAt:  dbToken.UpdatedAt,
+	}
+
+	if dbToken.Scopes != "" {
+		for _, scope := range strings.Split(dbToken.Scopes, ",") {
+			domainToken.Scopes = append(domainToken.Scopes, domain.TokenScope(scope))
+		}
+	}
+
+	return domainToken
+}
+
+// APITokenDomainToDB converts domain.APIToken to db.APIToken
+func APITokenDomainToDB(domainToken *domain.APIToken) *db.APIToken {
+	if domainToken == nil {
+		return nil
+	}
+
+	scopes := make([]string, len(domainToken.Scopes))
+	for i, scope := range domainToken.Scopes {
+		scopes[i] = string(scope)
+	}
+
+	return &db.APIToken{
+		ID:         domainToken.ID,
+		Name:       domainToken.Name,
+		Kind:       string(domainToken.Kind),
+		UserID:     domainToken.UserID,
+		Prefix:     domainToken.Prefix,
+		TokenHash:  domainToken.TokenHash,
+		Scopes:     strings.Join(scopes, ","),
+		ExpiresAt:  domainToken.ExpiresAt,
+		LastUsedAt: domainToken.LastUsedAt,
+		RevokedAt:  domainToken.RevokedAt,
+		CreatedAt:  domainToken.CreatedAt,
+		UpdatedAt:  domainToken.UpdatedAt,
+	}
+}
+
+// APITokenDomainToAPI converts domain.APIToken to api.APITokenResponse
+func APITokenDomainToAPI(domainToken *domain.APIToken) *api.APITokenResponse {
+	if domainToken == nil {
+		return nil
+	}
+
+	apiToken := &api.APITokenResponse{
+		ID:         domainToken.ID,
+		Name:       domainToken.Name,
+		Kind:       string(domainToken.Kind),
+		UserID:     domainToken.UserID,
+		Prefix:     domainToken.Prefix,
+		Scopes:     make([]string, len(domainToken.Scopes)),
+		ExpiresAt:  domainToken.ExpiresAt,
+		LastUsedAt: domainToken.LastUsedAt,
+		RevokedAt:  domainToken.RevokedAt,
+		CreatedAt:  domainToken.CreatedAt,
+		UpdatedAt:  domainToken.UpdatedAt,
+	}
+
+	for i, scope := range domainToken.Scopes {
+		apiToken.Scopes[i] = string(scope)
+	}
+
+	return apiToken
+}
+
+// APITokenAPIToDomain converts api.APITokenRequest to domain.APIToken
+func APITokenAPIToDomain(apiReq *api.APITokenRequest) *domain.APIToken {
+	if apiReq == nil {
+		return nil
+	}
+
+	domainToken := &domain.APIToken{
+		Name:      apiReq.Name,
+		Kind:      domain.APITokenKind(apiReq.Kind),
+		ExpiresAt: apiReq.ExpiresAt,
+	}
+
+	for _, scope := range apiReq.Scopes {
+		domainToken.Scopes = append(domainToken.Scopes, domain.TokenScope(strings.TrimSpace(scope)))
+	}
+
+	return domainToken
+}
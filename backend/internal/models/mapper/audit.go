@@ -0,0 +1,121 @@
+package mapper
+
+import (
+	"encoding/json"
+
+	"release-management/internal/models/api"
+	"release-management/internal/models/db"
+	"release-management/internal/models/domain"
+)
+
+// AuditEntryDBToDomain converts db.AuditEntry to domain.AuditEntry
+func AuditEntryDBToDomain(dbEntry *db.AuditEntry) *domain.AuditEntry {
+	if dbEntry == nil {
+		return nil
+	}
+
+	domainEntry := &domain.AuditEntry{
+		ID:         dbEntry.ID,
+		Action:     domain.AuditAction(dbEntry.Action),
+		EntityType: domain.AuditEntityType(dbEntry.EntityType),
+		EntityID:   dbEntry.EntityID,
+		ActorID:    dbEntry.ActorID,
+		Actor:      dbEntry.Actor,
+		RequestID:  dbEntry.RequestID,
+		SourceIP:   dbEntry.SourceIP,
+		CreatedAt:  dbEntry.CreatedAt,
+	}
+
+	if dbEntry.Before != nil {
+		json.Unmarshal([]byte(*dbEntry.Before), &domainEntry.Before)
+	}
+	if dbEntry.After != nil {
+		json.Unmarshal([]byte(*dbEntry.After), &domainEntry.After)
+	}
+	if dbEntry.Changes != nil {
+		var changes map[string]api.AuditChangeResponse
+		if err := json.Unmarshal([]byte(*dbEntry.Changes), &changes); err == nil {
+			domainEntry.Changes = make(map[string]domain.AuditChange, len(changes))
+			for field, change := range changes {
+				domainEntry.Changes[field] = domain.AuditChange{Before: change.Before, After: change.After}
+			}
+		}
+	}
+
+	return domainEntry
+}
+
+// AuditEntryDomainToDB converts domain.AuditEntry to db.AuditEntry
+func AuditEntryDomainToDB(domainEntry *domain.AuditEntry) *db.AuditEntry {
+	if domainEntry == nil {
+		return nil
+	}
+
+	dbEntry := &db.AuditEntry{
+		ID:         domainEntry.ID,
+		Action:     string(domainEntry.Action),
+		EntityType: string(domainEntry.EntityType),
+		EntityID:   domainEntry.EntityID,
+		ActorID:    domainEntry.ActorID,
+		Actor:      domainEntry.Actor,
+		RequestID:  domainEntry.RequestID,
+		SourceIP:   domainEntry.SourceIP,
+		CreatedAt:  domainEntry.CreatedAt,
+	}
+
+	if domainEntry.Before != nil {
+		dbEntry.Before = marshalJSONDocument(domainEntry.Before)
+	}
+	if domainEntry.After != nil {
+		dbEntry.After = marshalJSONDocument(domainEntry.After)
+	}
+	if domainEntry.Changes != nil {
+		changes := make(map[string]api.AuditChangeResponse, len(domainEntry.Changes))
+		for field, change := range domainEntry.Changes {
+			changes[field] = api.AuditChangeResponse{Before: change.Before, After: change.After}
+		}
+		dbEntry.Changes = marshalJSONDocument(changes)
+	}
+
+	return dbEntry
+}
+
+// AuditEntryDomainToAPI converts domain.AuditEntry to api.AuditEntryResponse
+func AuditEntryDomainToAPI(domainEntry *domain.AuditEntry) *api.AuditEntryResponse {
+	if domainEntry == nil {
+		return nil
+	}
+
+	apiEntry := &api.AuditEntryResponse{
+		ID:         domainEntry.ID,
+		Action:     string(domainEntry.Action),
+		EntityType: string(domainEntry.EntityType),
+		EntityID:   domainEntry.EntityID,
+		ActorID:    domainEntry.ActorID,
+		Actor:      domainEntry.Actor,
+		Before:     domainEntry.Before,
+		After:      domainEntry.After,
+		RequestID:  domainEntry.RequestID,
+		SourceIP:   domainEntry.SourceIP,
+		CreatedAt:  domainEntry.CreatedAt,
+	}
+
+	if len(domainEntry.Changes) > 0 {
+		apiEntry.Changes = make(map[string]api.AuditChangeResponse, len(domainEntry.Changes))
+		for field, change := range domainEntry.Changes {
+			apiEntry.Changes[field] = api.AuditChangeResponse{Before: change.Before, After: change.After}
+		}
+	}
+
+	return apiEntry
+}
+
+// Helper function to encode a document for a JSON column
+func marshalJSONDocument(v interface{}) *string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	s := string(data)
+	return &s
+}
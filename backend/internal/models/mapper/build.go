@@ -1,6 +1,8 @@
 package mapper
 
 import (
+	"strings"
+
 	"release-management/internal/models/api"
 	"release-management/internal/models/db"
 	"release-management/internal/models/domain"
@@ -13,13 +15,20 @@ func BuildDBToDomain(dbBuild *db.Build) *domain.Build {
 	}
 
 	domainBuild := &domain.Build{
-		ID:        dbBuild.ID,
-		SystemID:  dbBuild.SystemID,
-		ReleaseID: dbBuild.ReleaseID,
-		Version:   dbBuild.Version,
-		BuildDate: dbBuild.BuildDate,
-		CreatedAt: dbBuild.CreatedAt,
-		UpdatedAt: dbBuild.UpdatedAt,
+		ID:              dbBuild.ID,
+		SystemID:        dbBuild.SystemID,
+		ReleaseID:       dbBuild.ReleaseID,
+		Version:         dbBuild.Version,
+		BuildDate:       dbBuild.BuildDate,
+		Changelog:       dbBuild.Changelog,
+		RepositoryURL:   dbBuild.RepositoryURL,
+		CommitSHA:       dbBuild.CommitSHA,
+		Branch:          dbBuild.Branch,
+		PipelineURL:     dbBuild.PipelineURL,
+		ImageDigest:     dbBuild.ImageDigest,
+		PackageChecksum: dbBuild.PackageChecksum,
+		CreatedAt:       dbBuild.CreatedAt,
+		UpdatedAt:       dbBuild.UpdatedAt,
 	}
 
 	// Convert relationships
@@ -40,13 +49,20 @@ func BuildDomainToDB(domainBuild *domain.Build) *db.Build {
 		return nil
 	}
 	return &db.Build{
-		ID:        domainBuild.ID,
-		SystemID:  domainBuild.SystemID,
-		ReleaseID: domainBuild.ReleaseID,
-		Version:   domainBuild.Version,
-		BuildDate: domainBuild.BuildDate,
-		CreatedAt: domainBuild.CreatedAt,
-		UpdatedAt: domainBuild.UpdatedAt,
+		ID:              domainBuild.ID,
+		SystemID:        domainBuild.SystemID,
+		ReleaseID:       domainBuild.ReleaseID,
+		Version:         domainBuild.Version,
+		BuildDate:       domainBuild.BuildDate,
+		Changelog:       domainBuild.Changelog,
+		RepositoryURL:   domainBuild.RepositoryURL,
+		CommitSHA:       domainBuild.CommitSHA,
+		Branch:          domainBuild.Branch,
+		PipelineURL:     domainBuild.PipelineURL,
+		ImageDigest:     domainBuild.ImageDigest,
+		PackageChecksum: domainBuild.PackageChecksum,
+		CreatedAt:       domainBuild.CreatedAt,
+		UpdatedAt:       domainBuild.UpdatedAt,
 	}
 }
 
@@ -62,6 +78,15 @@ func BuildDomainToAPI(domainBuild *domain.Build) *api.BuildResponse {
 		ReleaseID: domainBuild.ReleaseID,
 		Version:   domainBuild.Version,
 		BuildDate: domainBuild.BuildDate,
+		Changelog: domainBuild.Changelog,
+		BuildProvenance: api.BuildProvenance{
+			RepositoryURL:   domainBuild.RepositoryURL,
+			CommitSHA:       domainBuild.CommitSHA,
+			Branch:          domainBuild.Branch,
+			PipelineURL:     domainBuild.PipelineURL,
+			ImageDigest:     domainBuild.ImageDigest,
+			PackageChecksum: domainBuild.PackageChecksum,
+		},
 		CreatedAt: domainBuild.CreatedAt,
 		UpdatedAt: domainBuild.UpdatedAt,
 	}
@@ -85,9 +110,21 @@ func BuildAPIToDomain(apiReq *api.BuildRequest) *domain.Build {
 		return nil
 	}
 	return &domain.Build{
-		SystemID:  apiReq.SystemID,
-		ReleaseID: apiReq.ReleaseID,
-		Version:   apiReq.Version,
-		BuildDate: apiReq.BuildDate,
+		SystemID:        apiReq.SystemID,
+		ReleaseID:       apiReq.ReleaseID,
+		Version:         apiReq.Version,
+		BuildDate:       apiReq.BuildDate,
+		Changelog:       strings.TrimSpace(apiReq.Changelog),
+		RepositoryURL:   strings.TrimSpace(apiReq.RepositoryURL),
+		CommitSHA:       NormalizeDigest(apiReq.CommitSHA),
+		Branch:          strings.TrimSpace(apiReq.Branch),
+		PipelineURL:     strings.TrimSpace(apiReq.PipelineURL),
+		ImageDigest:     NormalizeDigest(apiReq.ImageDigest),
+		PackageChecksum: NormalizeDigest(apiReq.PackageChecksum),
 	}
 }
+
+// NormalizeDigest trims a commit SHA or artifact digest and lowercases it, since hexadecimal digits are compared as text
+func NormalizeDigest(digest string) string {
+	return strings.ToLower(strings.TrimSpace(digest))
+}
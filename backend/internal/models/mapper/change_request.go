@@ -0,0 +1,206 @@
+package mapper
+
+import (
+	"strings"
+
+	"release-management/internal/models/api"
+	"release-management/internal/models/db"
+	"release-management/internal/models/domain"
+)
+
+// ChangeRequestDBToDomain converts db.ChangeRequest to domain.ChangeRequest, including its loaded relationships
+func ChangeRequestDBToDomain(dbRequest *db.ChangeRequest) *domain.ChangeRequest {
+	if dbRequest == nil {
+		return nil
+	}
+
+	domainRequest := &domain.ChangeRequest{
+		ID:                dbRequest.ID,
+		Title:             dbRequest.Title,
+		Description:       dbRequest.Description,
+		EnvironmentID:     dbRequest.EnvironmentID,
+		Status:            domain.ChangeRequestStatus(dbRequest.Status),
+		RequiredApprovals: dbRequest.RequiredApprovals,
+		ApproverRole:      domain.Role(dbRequest.ApproverRole),
+		AuthorID:          dbRequest.AuthorID,
+		ExpiresAt:         dbRequest.ExpiresAt,
+		RejectedBy:        dbRequest.RejectedBy,
+		RejectionReason:   dbRequest.RejectionReason,
+		AppliedBy:         dbRequest.AppliedBy,
+		AppliedAt:         dbRequest.AppliedAt,
+		CreatedAt:         dbRequest.CreatedAt,
+		UpdatedAt:         dbRequest.UpdatedAt,
+	}
+	for _, change := range dbRequest.Changes {
+		domainRequest.Changes = append(domainRequest.Changes, domain.ChangeRequestChange{
+			SystemID:    change.SystemID,
+			FromVersion: change.FromVersion,
+			ToVersion:   change.ToVersion,
+		})
+	}
+	for i := range dbRequest.Approvals {
+		domainRequest.Approvals = append(domainRequest.Approvals, *ChangeRequestApprovalDBToDomain(&dbRequest.Approvals[i]))
+	}
+	for i := range dbRequest.Comments {
+		domainRequest.Comments = append(domainRequest.Comments, *ChangeRequestCommentDBToDomain(&dbRequest.Comments[i]))
+	}
+	return domainRequest
+}
+
+// ChangeRequestDomainToDB converts domain.ChangeRequest to db.ChangeRequest with its changes.
+// Approvals and comments are stored on their own.
+func ChangeRequestDomainToDB(domainRequest *domain.ChangeRequest) *db.ChangeRequest {
+	if domainRequest == nil {
+		return nil
+	}
+
+	dbRequest := &db.ChangeRequest{
+		ID:                domainRequest.ID,
+		Title:             domainRequest.Title,
+		Description:       domainRequest.Description,
+		EnvironmentID:     domainRequest.EnvironmentID,
+		Status:            string(domainRequest.Status),
+		RequiredApprovals: domainRequest.RequiredApprovals,
+		ApproverRole:      string(domainRequest.ApproverRole),
+		AuthorID:          domainRequest.AuthorID,
+		ExpiresAt:         domainRequest.ExpiresAt,
+		RejectedBy:        domainRequest.RejectedBy,
+		RejectionReason:   domainRequest.RejectionReason,
+		AppliedBy:         domainRequest.AppliedBy,
+		AppliedAt:         domainRequest.AppliedAt,
+		CreatedAt:         domainRequest.CreatedAt,
+		UpdatedAt:         domainRequest.UpdatedAt,
+	}
+	for i, change := range domainRequest.Changes {
+		dbRequest.Changes = append(dbRequest.Changes, db.ChangeRequestChange{
+			ChangeRequestID: domainRequest.ID,
+			Position:        i,
+			SystemID:        change.SystemID,
+			FromVersion:     change.FromVersion,
+			ToVersion:       change.ToVersion,
+		})
+	}
+	return dbRequest
+}
+
+// ChangeRequestDomainToAPI converts domain.ChangeRequest to api.ChangeRequestResponse
+func ChangeRequestDomainToAPI(domainRequest *domain.ChangeRequest) *api.ChangeRequestResponse {
+	if domainRequest == nil {
+		return nil
+	}
+
+	apiRequest := &api.ChangeRequestResponse{
+		ID:                domainRequest.ID,
+		Title:             domainRequest.Title,
+		Description:       domainRequest.Description,
+		EnvironmentID:     domainRequest.EnvironmentID,
+		Status:            string(domainRequest.Status),
+		RequiredApprovals: domainRequest.RequiredApprovals,
+		ApproverRole:      string(domainRequest.ApproverRole),
+		AuthorID:          domainRequest.AuthorID,
+		ExpiresAt:         domainRequest.ExpiresAt,
+		Changes:           make([]api.ChangeRequestChangeResponse, len(domainRequest.Changes)),
+		Approvals:         make([]api.ChangeRequestApprovalResponse, len(domainRequest.Approvals)),
+		Comments:          make([]api.ChangeRequestCommentResponse, len(domainRequest.Comments)),
+		RejectedBy:        domainRequest.RejectedBy,
+		RejectionReason:   domainRequest.RejectionReason,
+		AppliedBy:         domainRequest.AppliedBy,
+		AppliedAt:         domainRequest.AppliedAt,
+		CreatedAt:         domainRequest.CreatedAt,
+		UpdatedAt:         domainRequest.UpdatedAt,
+	}
+	for i, change := range domainRequest.Changes {
+		apiRequest.Changes[i] = api.ChangeRequestChangeResponse{
+			SystemID:    change.SystemID,
+			FromVersion: change.FromVersion,
+			ToVersion:   change.ToVersion,
+		}
+	}
+	for i, approval := range domainRequest.Approvals {
+		apiRequest.Approvals[i] = api.ChangeRequestApprovalResponse{UserID: approval.UserID, CreatedAt: approval.CreatedAt}
+	}
+	for i, comment := range domainRequest.Comments {
+		apiRequest.Comments[i] = api.ChangeRequestCommentResponse{
+			ID:        comment.ID,
+			UserID:    comment.UserID,
+			Body:      comment.Body,
+			CreatedAt: comment.CreatedAt,
+		}
+	}
+	return apiRequest
+}
+
+// ChangeRequestAPIToDomain converts api.ChangeRequestRequest to domain.ChangeRequest.
+// The versions the systems run now are filled in by the caller.
+func ChangeRequestAPIToDomain(apiReq *api.ChangeRequestRequest) *domain.ChangeRequest {
+	if apiReq == nil {
+		return nil
+	}
+
+	domainRequest := &domain.ChangeRequest{
+		Title:         strings.TrimSpace(apiReq.Title),
+		Description:   strings.TrimSpace(apiReq.Description),
+		EnvironmentID: apiReq.EnvironmentID,
+	}
+	for _, change := range apiReq.Changes {
+		domainRequest.Changes = append(domainRequest.Changes, domain.ChangeRequestChange{
+			SystemID:  strings.TrimSpace(change.SystemID),
+			ToVersion: strings.TrimSpace(change.Version),
+		})
+	}
+	return domainRequest
+}
+
+// ChangeRequestApprovalDBToDomain converts db.ChangeRequestApproval to domain.ChangeRequestApproval
+func ChangeRequestApprovalDBToDomain(dbApproval *db.ChangeRequestApproval) *domain.ChangeRequestApproval {
+	if dbApproval == nil {
+		return nil
+	}
+	return &domain.ChangeRequestApproval{
+		ID:              dbApproval.ID,
+		ChangeRequestID: dbApproval.ChangeRequestID,
+		UserID:          dbApproval.UserID,
+		CreatedAt:       dbApproval.CreatedAt,
+	}
+}
+
+// ChangeRequestApprovalDomainToDB converts domain.ChangeRequestApproval to db.ChangeRequestApproval
+func ChangeRequestApprovalDomainToDB(domainApproval *domain.ChangeRequestApproval) *db.ChangeRequestApproval {
+	if domainApproval == nil {
+		return nil
+	}
+	return &db.ChangeRequestApproval{
+		ID:              domainApproval.ID,
+		ChangeRequestID: domainApproval.ChangeRequestID,
+		UserID:          domainApproval.UserID,
+		CreatedAt:       domainApproval.CreatedAt,
+	}
+}
+
+// ChangeRequestCommentDBToDomain converts db.ChangeRequestComment to domain.ChangeRequestComment
+func ChangeRequestCommentDBToDomain(dbComment *db.ChangeRequestComment) *domain.ChangeRequestComment {
+	if dbComment == nil {
+		return nil
+	}
+	return &domain.ChangeRequestComment{
+		ID:              dbComment.ID,
+		ChangeRequestID: dbComment.ChangeRequestID,
+		UserID:          dbComment.UserID,
+		Body:            dbComment.Body,
+		CreatedAt:       dbComment.CreatedAt,
+	}
+}
+
+// ChangeRequestCommentDomainToDB converts domain.ChangeRequestComment to db.ChangeRequestComment
+func ChangeRequestCommentDomainToDB(domainComment *domain.ChangeRequestComment) *db.ChangeRequestComment {
+	if domainComment == nil {
+		return nil
+	}
+	return &db.ChangeRequestComment{
+		ID:              domainComment.ID,
+		ChangeRequestID: domainComment.ChangeRequestID,
+		UserID:          domainComment.UserID,
+		Body:            domainComment.Body,
+		CreatedAt:       domainComment.CreatedAt,
+	}
+}
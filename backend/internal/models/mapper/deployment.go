@@ -0,0 +1,86 @@
+package mapper
+
+import (
+	"release-management/internal/models/api"
+	"release-management/internal/models/db"
+	"release-management/internal/models/domain"
+)
+
+// DeploymentDBToDomain converts db.Deployment to domain.Deployment
+func DeploymentDBToDomain(dbDeployment *db.Deployment) *domain.Deployment {
+	if dbDeployment == nil {
+		return nil
+	}
+
+	domainDeployment := &domain.Deployment{
+		ID:            dbDeployment.ID,
+		EnvironmentID: dbDeployment.EnvironmentID,
+		SystemID:      dbDeployment.SystemID,
+		OldVersion:    dbDeployment.OldVersion,
+		NewVersion:    dbDeployment.NewVersion,
+		BuildID:       dbDeployment.BuildID,
+		UserID:        dbDeployment.UserID,
+		Source:        domain.DeploymentSource(dbDeployment.Source),
+		CreatedAt:     dbDeployment.CreatedAt,
+	}
+
+	// Convert relationships
+	if dbDeployment.System != nil {
+		domainDeployment.System = SystemDBToDomain(dbDeployment.System)
+	}
+
+	if dbDeployment.User != nil {
+		domainDeployment.User = UserDBToDomain(dbDeployment.User)
+	}
+
+	return domainDeployment
+}
+
+// DeploymentDomainToDB converts domain.Deployment to db.Deployment
+func DeploymentDomainToDB(domainDeployment *domain.Deployment) *db.Deployment {
+	if domainDeployment == nil {
+		return nil
+	}
+	return &db.Deployment{
+		ID:            domainDeployment.ID,
+		EnvironmentID: domainDeployment.EnvironmentID,
+		SystemID:      domainDeployment.SystemID,
+		OldVersion:    domainDeployment.OldVersion,
+		NewVersion:    domainDeployment.NewVersion,
+		BuildID:       domainDeployment.BuildID,
+		UserID:        domainDeployment.UserID,
+		Source:        string(domainDeployment.Source),
+		CreatedAt:     domainDeployment.CreatedAt,
+	}
+}
+
+// DeploymentDomainToAPI converts domain.Deployment to api.DeploymentResponse
+func DeploymentDomainToAPI(domainDeployment *domain.Deployment) *api.DeploymentResponse {
+	if domainDeployment == nil {
+		return nil
+	}
+
+	apiDeployment := &api.DeploymentResponse{
+		ID:            domainDeployment.ID,
+		EnvironmentID: domainDeployment.EnvironmentID,
+		SystemID:      domainDeployment.SystemID,
+		OldVersion:    domainDeployment.OldVersion,
+		NewVersion:    domainDeployment.NewVersion,
+		BuildID:       domainDeployment.BuildID,
+		UserID:        domainDeployment.UserID,
+		Source:        string(domainDeployment.Source),
+		CreatedAt:     domainDeployment.CreatedAt,
+	}
+
+	// Extract just the system name from the System relationship
+	if domainDeployment.System != nil {
+		apiDeployment.SystemName = domainDeployment.System.Name
+	}
+
+	// Extract just the email from the User relationship
+	if domainDeployment.User != nil {
+		apiDeployment.UserEmail = domainDeployment.User.Email
+	}
+
+	return apiDeployment
+}
@@ -1,6 +1,8 @@
 package mapper
 
 import (
+	"strings"
+
 	"release-management/internal/models/api"
 	"release-management/internal/models/db"
 	"release-management/internal/models/domain"
@@ -13,11 +15,13 @@ func EnvironmentGroupDBToDomain(dbGroup *db.EnvironmentGroup) *domain.Environmen
 	}
 
 	domainGroup := &domain.EnvironmentGroup{
-		ID:          dbGroup.ID,
-		Name:        dbGroup.Name,
-		Description: dbGroup.Description,
-		CreatedAt:   dbGroup.CreatedAt,
-		UpdatedAt:   dbGroup.UpdatedAt,
+		ID:            dbGroup.ID,
+		Name:          dbGroup.Name,
+		Description:   dbGroup.Description,
+		PromotionPath: PromotionPathFromDB(dbGroup.PromotionPath),
+		RequiredRole:  domain.Role(dbGroup.RequiredRole),
+		CreatedAt:     dbGroup.CreatedAt,
+		UpdatedAt:     dbGroup.UpdatedAt,
 	}
 
 	// Convert relationships
@@ -39,11 +43,13 @@ func EnvironmentGroupDomainToDB(domainGroup *domain.EnvironmentGroup) *db.Enviro
 		return nil
 	}
 	return &db.EnvironmentGroup{
-		ID:          domainGroup.ID,
-		Name:        domainGroup.Name,
-		Description: domainGroup.Description,
-		CreatedAt:   domainGroup.CreatedAt,
-		UpdatedAt:   domainGroup.UpdatedAt,
+		ID:            domainGroup.ID,
+		Name:          domainGroup.Name,
+		Description:   domainGroup.Description,
+		PromotionPath: PromotionPathToDB(domainGroup.PromotionPath),
+		RequiredRole:  string(domainGroup.RequiredRole),
+		CreatedAt:     domainGroup.CreatedAt,
+		UpdatedAt:     domainGroup.UpdatedAt,
 	}
 }
 
@@ -54,11 +60,17 @@ func EnvironmentGroupDomainToAPI(domainGroup *domain.EnvironmentGroup) *api.Envi
 	}
 
 	apiGroup := &api.EnvironmentGroupResponse{
-		ID:          domainGroup.ID,
-		Name:        domainGroup.Name,
-		Description: domainGroup.Description,
-		CreatedAt:   domainGroup.CreatedAt,
-		UpdatedAt:   domainGroup.UpdatedAt,
+		ID:            domainGroup.ID,
+		Name:          domainGroup.Name,
+		Description:   domainGroup.Description,
+		PromotionPath: make([]string, len(domainGroup.PromotionPath)),
+		RequiredRole:  string(domainGroup.RequiredRole),
+		CreatedAt:     domainGroup.CreatedAt,
+		UpdatedAt:     domainGroup.UpdatedAt,
+	}
+
+	for i, stage := range domainGroup.PromotionPath {
+		apiGroup.PromotionPath[i] = string(stage)
 	}
 
 	if len(domainGroup.Environments) > 0 {
@@ -83,7 +95,35 @@ func EnvironmentGroupAPIToDomain(apiReq *api.EnvironmentGroupRequest) *domain.En
 		return nil
 	}
 	return &domain.EnvironmentGroup{
-		Name:        apiReq.Name,
-		Description: apiReq.Description,
+		Name:          apiReq.Name,
+		Description:   apiReq.Description,
+		PromotionPath: PromotionPathAPIToDomain(apiReq.PromotionPath),
+		RequiredRole:  domain.Role(apiReq.RequiredRole),
+	}
+}
+
+// PromotionPathAPIToDomain converts the promotion path of an API request to domain environment types
+func PromotionPathAPIToDomain(path []string) []domain.EnvironmentType {
+	stages := make([]domain.EnvironmentType, len(path))
+	for i, stage := range path {
+		stages[i] = domain.EnvironmentType(strings.TrimSpace(stage))
+	}
+	return stages
+}
+
+// PromotionPathFromDB converts the stored comma separated promotion path to domain environment types
+func PromotionPathFromDB(path string) []domain.EnvironmentType {
+	if path == "" {
+		return nil
+	}
+	return PromotionPathAPIToDomain(strings.Split(path, ","))
+}
+
+// PromotionPathToDB converts domain environment types to the stored comma separated promotion path
+func PromotionPathToDB(path []domain.EnvironmentType) string {
+	stages := make([]string, len(path))
+	for i, stage := range path {
+		stages[i] = string(stage)
 	}
+	return strings.Join(stages, ",")
 }
@@ -0,0 +1,58 @@
+package mapper
+
+import (
+	"encoding/json"
+
+	"release-management/internal/models/api"
+	"release-management/internal/models/db"
+	"release-management/internal/models/domain"
+)
+
+// EventDBToDomain converts db.Event to domain.Event
+func EventDBToDomain(dbEvent *db.Event) *domain.Event {
+	if dbEvent == nil {
+		return nil
+	}
+
+	domainEvent := &domain.Event{
+		ID:        dbEvent.ID,
+		Sequence:  dbEvent.Sequence,
+		Type:      domain.EventType(dbEvent.Type),
+		CreatedAt: dbEvent.CreatedAt,
+	}
+	if dbEvent.Data != nil {
+		json.Unmarshal([]byte(*dbEvent.Data), &domainEvent.Data)
+	}
+	return domainEvent
+}
+
+// EventDomainToDB converts domain.Event to db.Event
+func EventDomainToDB(domainEvent *domain.Event) *db.Event {
+	if domainEvent == nil {
+		return nil
+	}
+
+	dbEvent := &db.Event{
+		ID:        domainEvent.ID,
+		Sequence:  domainEvent.Sequence,
+		Type:      string(domainEvent.Type),
+		CreatedAt: domainEvent.CreatedAt,
+	}
+	if domainEvent.Data != nil {
+		dbEvent.Data = marshalJSONDocument(domainEvent.Data)
+	}
+	return dbEvent
+}
+
+// EventDomainToAPI converts domain.Event to api.EventPayload
+func EventDomainToAPI(domainEvent *domain.Event) *api.EventPayload {
+	if domainEvent == nil {
+		return nil
+	}
+	return &api.EventPayload{
+		ID:        domainEvent.ID,
+		Type:      string(domainEvent.Type),
+		CreatedAt: domainEvent.CreatedAt,
+		Data:      domainEvent.Data,
+	}
+}
@@ -0,0 +1,196 @@
+package mapper
+
+import (
+	"slices"
+	"strings"
+
+	"release-management/internal/models/api"
+	"release-management/internal/models/db"
+	"release-management/internal/models/domain"
+)
+
+// FreezeWindowDBToDomain converts db.FreezeWindow to domain.FreezeWindow
+func FreezeWindowDBToDomain(dbWindow *db.FreezeWindow) *domain.FreezeWindow {
+	if dbWindow == nil {
+		return nil
+	}
+
+	domainWindow := &domain.FreezeWindow{
+		ID:                   dbWindow.ID,
+		Name:                 dbWindow.Name,
+		Reason:               dbWindow.Reason,
+		Scope:                domain.FreezeScope(dbWindow.Scope),
+		EnvironmentGroupID:   dbWindow.EnvironmentGroupID,
+		StartsAt:             dbWindow.StartsAt,
+		EndsAt:               dbWindow.EndsAt,
+		ExemptSystemIDs:      splitIDs(dbWindow.ExemptSystemIDs),
+		ExemptEnvironmentIDs: splitIDs(dbWindow.ExemptEnvironmentIDs),
+		CreatedBy:            dbWindow.CreatedBy,
+		CreatedAt:            dbWindow.CreatedAt,
+		UpdatedAt:            dbWindow.UpdatedAt,
+	}
+	if dbWindow.EnvironmentType != nil {
+		envType := domain.EnvironmentType(*dbWindow.EnvironmentType)
+		domainWindow.EnvironmentType = &envType
+	}
+	return domainWindow
+}
+
+// FreezeWindowDomainToDB converts domain.FreezeWindow to db.FreezeWindow
+func FreezeWindowDomainToDB(domainWindow *domain.FreezeWindow) *db.FreezeWindow {
+	if domainWindow == nil {
+		return nil
+	}
+
+	dbWindow := &db.FreezeWindow{
+		ID:                   domainWindow.ID,
+		Name:                 domainWindow.Name,
+		Reason:               domainWindow.Reason,
+		Scope:                string(domainWindow.Scope),
+		EnvironmentGroupID:   domainWindow.EnvironmentGroupID,
+		StartsAt:             domainWindow.StartsAt,
+		EndsAt:               domainWindow.EndsAt,
+		ExemptSystemIDs:      strings.Join(domainWindow.ExemptSystemIDs, ","),
+		ExemptEnvironmentIDs: strings.Join(domainWindow.ExemptEnvironmentIDs, ","),
+		CreatedBy:            domainWindow.CreatedBy,
+		CreatedAt:            domainWindow.CreatedAt,
+		UpdatedAt:            domainWindow.UpdatedAt,
+	}
+	if domainWindow.EnvironmentType != nil {
+		envType := string(*domainWindow.EnvironmentType)
+		dbWindow.EnvironmentType = &envType
+	}
+	return dbWindow
+}
+
+// FreezeWindowDomainToAPI converts domain.FreezeWindow to api.FreezeWindowResponse
+func FreezeWindowDomainToAPI(domainWindow *domain.FreezeWindow) *api.FreezeWindowResponse {
+	if domainWindow == nil {
+		return nil
+	}
+
+	apiWindow := &api.FreezeWindowResponse{
+		ID:                   domainWindow.ID,
+		Name:                 domainWindow.Name,
+		Reason:               domainWindow.Reason,
+		Scope:                string(domainWindow.Scope),
+		EnvironmentGroupID:   domainWindow.EnvironmentGroupID,
+		StartsAt:             domainWindow.StartsAt,
+		EndsAt:               domainWindow.EndsAt,
+		ExemptSystemIDs:      append([]string{}, domainWindow.ExemptSystemIDs...),
+		ExemptEnvironmentIDs: append([]string{}, domainWindow.ExemptEnvironmentIDs...),
+		CreatedBy:            domainWindow.CreatedBy,
+		CreatedAt:            domainWindow.CreatedAt,
+		UpdatedAt:            domainWindow.UpdatedAt,
+	}
+	if domainWindow.EnvironmentType != nil {
+		envType := string(*domainWindow.EnvironmentType)
+		apiWindow.EnvironmentType = &envType
+	}
+	return apiWindow
+}
+
+// FreezeWindowAPIToDomain converts api.FreezeWindowRequest to domain.FreezeWindow.
+// Only the fields of the scope are kept, so that switching scopes does not leave stale ones behind.
+func FreezeWindowAPIToDomain(apiReq *api.FreezeWindowRequest) *domain.FreezeWindow {
+	if apiReq == nil {
+		return nil
+	}
+
+	domainWindow := &domain.FreezeWindow{
+		Name:                 strings.TrimSpace(apiReq.Name),
+		Reason:               strings.TrimSpace(apiReq.Reason),
+		Scope:                domain.FreezeScope(strings.TrimSpace(apiReq.Scope)),
+		StartsAt:             apiReq.StartsAt,
+		EndsAt:               apiReq.EndsAt,
+		ExemptSystemIDs:      cleanIDs(apiReq.ExemptSystemIDs),
+		ExemptEnvironmentIDs: cleanIDs(apiReq.ExemptEnvironmentIDs),
+	}
+	switch domainWindow.Scope {
+	case domain.FreezeScopeEnvironmentGroup:
+		domainWindow.EnvironmentGroupID = apiReq.EnvironmentGroupID
+	case domain.FreezeScopeEnvironmentType:
+		if apiReq.EnvironmentType != nil {
+			envType := domain.EnvironmentType(strings.TrimSpace(*apiReq.EnvironmentType))
+			domainWindow.EnvironmentType = &envType
+		}
+	}
+	return domainWindow
+}
+
+// FreezeOverrideDBToDomain converts db.FreezeOverride to domain.FreezeOverride
+func FreezeOverrideDBToDomain(dbOverride *db.FreezeOverride) *domain.FreezeOverride {
+	if dbOverride == nil {
+		return nil
+	}
+	return &domain.FreezeOverride{
+		ID:               dbOverride.ID,
+		FreezeWindowID:   dbOverride.FreezeWindowID,
+		FreezeWindowName: dbOverride.FreezeWindowName,
+		EnvironmentID:    dbOverride.EnvironmentID,
+		SystemID:         dbOverride.SystemID,
+		OldVersion:       dbOverride.OldVersion,
+		NewVersion:       dbOverride.NewVersion,
+		Justification:    dbOverride.Justification,
+		UserID:           dbOverride.UserID,
+		CreatedAt:        dbOverride.CreatedAt,
+	}
+}
+
+// FreezeOverrideDomainToDB converts domain.FreezeOverride to db.FreezeOverride
+func FreezeOverrideDomainToDB(domainOverride *domain.FreezeOverride) *db.FreezeOverride {
+	if domainOverride == nil {
+		return nil
+	}
+	return &db.FreezeOverride{
+		ID:               domainOverride.ID,
+		FreezeWindowID:   domainOverride.FreezeWindowID,
+		FreezeWindowName: domainOverride.FreezeWindowName,
+		EnvironmentID:    domainOverride.EnvironmentID,
+		SystemID:         domainOverride.SystemID,
+		OldVersion:       domainOverride.OldVersion,
+		NewVersion:       domainOverride.NewVersion,
+		Justification:    domainOverride.Justification,
+		UserID:           domainOverride.UserID,
+		CreatedAt:        domainOverride.CreatedAt,
+	}
+}
+
+// FreezeOverrideDomainToAPI converts domain.FreezeOverride to api.FreezeOverrideResponse
+func FreezeOverrideDomainToAPI(domainOverride *domain.FreezeOverride) *api.FreezeOverrideResponse {
+	if domainOverride == nil {
+		return nil
+	}
+	return &api.FreezeOverrideResponse{
+		ID:               domainOverride.ID,
+		FreezeWindowID:   domainOverride.FreezeWindowID,
+		FreezeWindowName: domainOverride.FreezeWindowName,
+		EnvironmentID:    domainOverride.EnvironmentID,
+		SystemID:         domainOverride.SystemID,
+		OldVersion:       domainOverride.OldVersion,
+		NewVersion:       domainOverride.NewVersion,
+		Justification:    domainOverride.Justification,
+		UserID:           domainOverride.UserID,
+		CreatedAt:        domainOverride.CreatedAt,
+	}
+}
+
+// Helper function to split IDs stored separated by commas
+func splitIDs(joined string) []string {
+	if joined == "" {
+		return nil
+	}
+	return strings.Split(joined, ",")
+}
+
+// Helper function to trim IDs and drop empty and repeated ones
+func cleanIDs(ids []string) []string {
+	var cleaned []string
+	for _, id := range ids {
+		id = strings.TrimSpace(id)
+		if id != "" && !slices.Contains(cleaned, id) {
+			cleaned = append(cleaned, id)
+		}
+	}
+	return cleaned
+}
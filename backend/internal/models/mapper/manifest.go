@@ -0,0 +1,191 @@
+package mapper
+
+import (
+	"release-management/internal/models/api"
+	"release-management/internal/models/domain"
+)
+
+// ManifestDomainToAPI converts domain.Manifest to api.Manifest
+func ManifestDomainToAPI(domainManifest *domain.Manifest) *api.Manifest {
+	if domainManifest == nil {
+		return nil
+	}
+
+	apiManifest := &api.Manifest{
+		Systems:           manifestSystemsDomainToAPI(domainManifest.Systems),
+		Releases:          make([]api.ManifestRelease, len(domainManifest.Releases)),
+		EnvironmentGroups: make([]api.ManifestEnvironmentGroup, len(domainManifest.EnvironmentGroups)),
+		Environments:      make([]api.ManifestEnvironment, len(domainManifest.Environments)),
+	}
+
+	for i, release := range domainManifest.Releases {
+		apiManifest.Releases[i] = api.ManifestRelease{
+			Name:        release.Name,
+			Description: release.Description,
+			ReleaseDate: release.ReleaseDate,
+			Status:      string(release.Status),
+			Type:        string(release.Type),
+		}
+	}
+
+	for i, group := range domainManifest.EnvironmentGroups {
+		apiManifest.EnvironmentGroups[i] = api.ManifestEnvironmentGroup{
+			Name:         group.Name,
+			Description:  group.Description,
+			RequiredRole: string(group.RequiredRole),
+		}
+		for _, stage := range group.PromotionPath {
+			apiManifest.EnvironmentGroups[i].PromotionPath = append(apiManifest.EnvironmentGroups[i].PromotionPath, string(stage))
+		}
+	}
+
+	for i, env := range domainManifest.Environments {
+		apiManifest.Environments[i] = api.ManifestEnvironment{
+			Name:        env.Name,
+			Type:        string(env.Type),
+			Status:      string(env.Status),
+			URL:         env.URL,
+			Description: env.Description,
+			Release:     env.Release,
+			Group:       env.Group,
+		}
+		for _, envSystem := range env.Systems {
+			apiManifest.Environments[i].Systems = append(apiManifest.Environments[i].Systems, api.ManifestEnvironmentSystem(envSystem))
+		}
+	}
+
+	return apiManifest
+}
+
+// ManifestAPIToDomain converts api.Manifest to domain.Manifest, keeping missing lists nil so that they are left alone
+func ManifestAPIToDomain(apiManifest *api.Manifest) *domain.Manifest {
+	if apiManifest == nil {
+		return nil
+	}
+
+	domainManifest := &domain.Manifest{Systems: manifestSystemsAPIToDomain(apiManifest.Systems)}
+
+	if apiManifest.Releases != nil {
+		domainManifest.Releases = make([]domain.ManifestRelease, len(apiManifest.Releases))
+		for i, release := range apiManifest.Releases {
+			domainManifest.Releases[i] = domain.ManifestRelease{
+				Name:        release.Name,
+				Description: release.Description,
+				ReleaseDate: release.ReleaseDate,
+				Status:      domain.ReleaseStatus(release.Status),
+				Type:        domain.ReleaseType(release.Type),
+			}
+		}
+	}
+
+	if apiManifest.EnvironmentGroups != nil {
+		domainManifest.EnvironmentGroups = make([]domain.ManifestEnvironmentGroup, len(apiManifest.EnvironmentGroups))
+		for i, group := range apiManifest.EnvironmentGroups {
+			domainManifest.EnvironmentGroups[i] = domain.ManifestEnvironmentGroup{
+				Name:         group.Name,
+				Description:  group.Description,
+				RequiredRole: domain.Role(group.RequiredRole),
+			}
+			if len(group.PromotionPath) > 0 {
+				domainManifest.EnvironmentGroups[i].PromotionPath = PromotionPathAPIToDomain(group.PromotionPath)
+			}
+		}
+	}
+
+	if apiManifest.Environments != nil {
+		domainManifest.Environments = make([]domain.ManifestEnvironment, len(apiManifest.Environments))
+		for i, env := range apiManifest.Environments {
+			domainManifest.Environments[i] = domain.ManifestEnvironment{
+				Name:        env.Name,
+				Type:        domain.EnvironmentType(env.Type),
+				Status:      domain.EnvironmentStatus(env.Status),
+				URL:         env.URL,
+				Description: env.Description,
+				Release:     env.Release,
+				Group:       env.Group,
+			}
+			if env.Systems != nil {
+				domainManifest.Environments[i].Systems = make([]domain.ManifestEnvironmentSystem, len(env.Systems))
+				for j, envSystem := range env.Systems {
+					domainManifest.Environments[i].Systems[j] = domain.ManifestEnvironmentSystem(envSystem)
+				}
+			}
+		}
+	}
+
+	return domainManifest
+}
+
+// ManifestPlanDomainToAPI converts domain.ManifestPlan to api.ImportResponse
+func ManifestPlanDomainToAPI(domainPlan *domain.ManifestPlan) *api.ImportResponse {
+	if domainPlan == nil {
+		return nil
+	}
+
+	apiResponse := &api.ImportResponse{
+		DryRun:  !domainPlan.Applied,
+		Creates: domainPlan.Count(domain.AuditActionCreate),
+		Updates: domainPlan.Count(domain.AuditActionUpdate),
+		Deletes: domainPlan.Count(domain.AuditActionDelete),
+		Changes: make([]api.ManifestChange, len(domainPlan.Changes)),
+	}
+	for i, change := range domainPlan.Changes {
+		apiResponse.Changes[i] = api.ManifestChange{
+			Action:     string(change.Action),
+			EntityType: string(change.EntityType),
+			Name:       change.Name,
+			EntityID:   change.EntityID,
+			Fields:     change.Fields,
+		}
+	}
+	return apiResponse
+}
+
+// Helper function to convert domain manifest systems and their subsystems to API manifest systems
+func manifestSystemsDomainToAPI(systems []domain.ManifestSystem) []api.ManifestSystem {
+	apiSystems := make([]api.ManifestSystem, len(systems))
+	for i, system := range systems {
+		apiSystems[i] = api.ManifestSystem{
+			Name:          system.Name,
+			Description:   system.Description,
+			Type:          string(system.Type),
+			Status:        string(system.Status),
+			StrictSemver:  system.StrictSemver,
+			StrictDigests: system.StrictDigests,
+		}
+		if len(system.Subsystems) > 0 {
+			apiSystems[i].Subsystems = manifestSystemsDomainToAPI(system.Subsystems)
+		}
+		for _, build := range system.Builds {
+			apiSystems[i].Builds = append(apiSystems[i].Builds, api.ManifestBuild(build))
+		}
+	}
+	return apiSystems
+}
+
+// Helper function to convert API manifest systems and their subsystems to domain manifest systems
+func manifestSystemsAPIToDomain(systems []api.ManifestSystem) []domain.ManifestSystem {
+	if systems == nil {
+		return nil
+	}
+
+	domainSystems := make([]domain.ManifestSystem, len(systems))
+	for i, system := range systems {
+		domainSystems[i] = domain.ManifestSystem{
+			Name:          system.Name,
+			Description:   system.Description,
+			Type:          domain.SystemType(system.Type),
+			Status:        domain.SystemStatus(system.Status),
+			StrictSemver:  system.StrictSemver,
+			StrictDigests: system.StrictDigests,
+			Subsystems:    manifestSystemsAPIToDomain(system.Subsystems),
+		}
+		if system.Builds != nil {
+			domainSystems[i].Builds = make([]domain.ManifestBuild, len(system.Builds))
+			for j, build := range system.Builds {
+				domainSystems[i].Builds[j] = domain.ManifestBuild(build)
+			}
+		}
+	}
+	return domainSystems
+}
@@ -96,3 +96,67 @@ func ReleaseAPIToDomain(apiReq *api.ReleaseRequest) *domain.Release {
 		Type:        domain.ReleaseType(apiReq.Type),
 	}
 }
+
+// ReleaseTransitionDBToDomain converts db.ReleaseTransition to domain.ReleaseTransition
+func ReleaseTransitionDBToDomain(dbTransition *db.ReleaseTransition) *domain.ReleaseTransition {
+	if dbTransition == nil {
+		return nil
+	}
+
+	domainTransition := &domain.ReleaseTransition{
+		ID:         dbTransition.ID,
+		ReleaseID:  dbTransition.ReleaseID,
+		FromStatus: domain.ReleaseStatus(dbTransition.FromStatus),
+		ToStatus:   domain.ReleaseStatus(dbTransition.ToStatus),
+		UserID:     dbTransition.UserID,
+		Comment:    dbTransition.Comment,
+		CreatedAt:  dbTransition.CreatedAt,
+	}
+
+	// Convert relationships
+	if dbTransition.User.ID != 0 {
+		domainTransition.User = UserDBToDomain(&dbTransition.User)
+	}
+
+	return domainTransition
+}
+
+// ReleaseTransitionDomainToDB converts domain.ReleaseTransition to db.ReleaseTransition
+func ReleaseTransitionDomainToDB(domainTransition *domain.ReleaseTransition) *db.ReleaseTransition {
+	if domainTransition == nil {
+		return nil
+	}
+	return &db.ReleaseTransition{
+		ID:         domainTransition.ID,
+		ReleaseID:  domainTransition.ReleaseID,
+		FromStatus: string(domainTransition.FromStatus),
+		ToStatus:   string(domainTransition.ToStatus),
+		UserID:     domainTransition.UserID,
+		Comment:    domainTransition.Comment,
+		CreatedAt:  domainTransition.CreatedAt,
+	}
+}
+
+// ReleaseTransitionDomainToAPI converts domain.ReleaseTransition to api.ReleaseTransitionResponse
+func ReleaseTransitionDomainToAPI(domainTransition *domain.ReleaseTransition) *api.ReleaseTransitionResponse {
+	if domainTransition == nil {
+		return nil
+	}
+
+	apiTransition := &api.ReleaseTransitionResponse{
+		ID:         domainTransition.ID,
+		ReleaseID:  domainTransition.ReleaseID,
+		FromStatus: string(domainTransition.FromStatus),
+		ToStatus:   string(domainTransition.ToStatus),
+		UserID:     domainTransition.UserID,
+		Comment:    domainTransition.Comment,
+		CreatedAt:  domainTransition.CreatedAt,
+	}
+
+	// Extract just the email from the User relationship
+	if domainTransition.User != nil {
+		apiTransition.UserEmail = domainTransition.User.Email
+	}
+
+	return apiTransition
+}
@@ -0,0 +1,75 @@
+package mapper
+
+import (
+	"release-management/internal/models/api"
+	"release-management/internal/models/domain"
+)
+
+// ReleaseComparisonDomainToAPI converts domain.ReleaseComparison to api.ReleaseComparisonResponse
+func ReleaseComparisonDomainToAPI(domainComparison *domain.ReleaseComparison) *api.ReleaseComparisonResponse {
+	if domainComparison == nil {
+		return nil
+	}
+
+	apiComparison := &api.ReleaseComparisonResponse{
+		Summary: map[string]int{
+			string(domain.ChangeAdded):      0,
+			string(domain.ChangeRemoved):    0,
+			string(domain.ChangeUpgraded):   0,
+			string(domain.ChangeDowngraded): 0,
+			string(domain.ChangeUnchanged):  0,
+		},
+		Systems: make([]api.SystemComparisonResponse, len(domainComparison.Systems)),
+	}
+
+	if domainComparison.Base != nil {
+		apiComparison.Base = releaseSummaryDomainToAPI(domainComparison.Base)
+	}
+	if domainComparison.Target != nil {
+		apiComparison.Target = releaseSummaryDomainToAPI(domainComparison.Target)
+	}
+
+	for i, system := range domainComparison.Systems {
+		apiComparison.Systems[i] = systemComparisonDomainToAPI(system)
+
+		// Only systems with builds are counted, parent systems are roll-ups
+		if len(system.Subsystems) == 0 {
+			apiComparison.Summary[string(system.Change)]++
+		}
+		for _, sub := range system.Subsystems {
+			apiComparison.Summary[string(sub.Change)]++
+		}
+	}
+
+	return apiComparison
+}
+
+// Helper function to convert domain.Release to api.ReleaseSummary
+func releaseSummaryDomainToAPI(domainRel *domain.Release) api.ReleaseSummary {
+	return api.ReleaseSummary{
+		ID:     domainRel.ID,
+		Name:   domainRel.Name,
+		Status: string(domainRel.Status),
+	}
+}
+
+// Helper function to convert domain.SystemComparison to api.SystemComparisonResponse
+func systemComparisonDomainToAPI(system domain.SystemComparison) api.SystemComparisonResponse {
+	apiSystem := api.SystemComparisonResponse{
+		SystemID:      system.SystemID,
+		SystemName:    system.SystemName,
+		SystemType:    string(system.SystemType),
+		Change:        string(system.Change),
+		BaseVersion:   system.BaseVersion,
+		TargetVersion: system.TargetVersion,
+	}
+
+	if len(system.Subsystems) > 0 {
+		apiSystem.Subsystems = make([]api.SystemComparisonResponse, len(system.Subsystems))
+		for i, sub := range system.Subsystems {
+			apiSystem.Subsystems[i] = systemComparisonDomainToAPI(sub)
+		}
+	}
+
+	return apiSystem
+}
@@ -0,0 +1,80 @@
+package mapper
+
+import (
+	"release-management/internal/models/api"
+	"release-management/internal/models/domain"
+)
+
+// ReleaseNotesDomainToAPI converts domain.ReleaseNotes to api.ReleaseNotesResponse
+func ReleaseNotesDomainToAPI(domainNotes *domain.ReleaseNotes) *api.ReleaseNotesResponse {
+	if domainNotes == nil {
+		return nil
+	}
+
+	apiNotes := &api.ReleaseNotesResponse{
+		Summary: map[string]int{
+			string(domain.ChangeAdded):      0,
+			string(domain.ChangeUpgraded):   0,
+			string(domain.ChangeDowngraded): 0,
+			string(domain.ChangeUnchanged):  0,
+		},
+		Systems: make([]api.ReleaseNotesSystemResponse, len(domainNotes.Systems)),
+	}
+
+	if release := domainNotes.Release; release != nil {
+		apiNotes.Release = api.ReleaseNotesRelease{
+			ID:          release.ID,
+			Name:        release.Name,
+			Type:        string(release.Type),
+			Status:      string(release.Status),
+			ReleaseDate: release.ReleaseDate,
+		}
+		if release.Description != nil {
+			apiNotes.Release.Description = *release.Description
+		}
+	}
+
+	for i, system := range domainNotes.Systems {
+		apiNotes.Systems[i] = releaseNotesEntryDomainToAPI(system)
+
+		// Only systems with builds are counted, parent systems group their subsystems
+		if len(system.Subsystems) == 0 {
+			apiNotes.Summary[string(system.Change)]++
+		}
+		for _, sub := range system.Subsystems {
+			apiNotes.Summary[string(sub.Change)]++
+		}
+	}
+
+	return apiNotes
+}
+
+// Helper function to convert domain.ReleaseNotesEntry to api.ReleaseNotesSystemResponse
+func releaseNotesEntryDomainToAPI(entry domain.ReleaseNotesEntry) api.ReleaseNotesSystemResponse {
+	apiEntry := api.ReleaseNotesSystemResponse{
+		SystemID:        entry.SystemID,
+		SystemName:      entry.SystemName,
+		SystemType:      string(entry.SystemType),
+		Change:          string(entry.Change),
+		Version:         entry.Version,
+		Changelog:       entry.Changelog,
+		PreviousVersion: entry.PreviousVersion,
+	}
+	if !entry.BuildDate.IsZero() {
+		buildDate := entry.BuildDate
+		apiEntry.BuildDate = &buildDate
+	}
+	if entry.PreviousRelease != nil {
+		previous := releaseSummaryDomainToAPI(entry.PreviousRelease)
+		apiEntry.PreviousRelease = &previous
+	}
+
+	if len(entry.Subsystems) > 0 {
+		apiEntry.Subsystems = make([]api.ReleaseNotesSystemResponse, len(entry.Subsystems))
+		for i, sub := range entry.Subsystems {
+			apiEntry.Subsystems[i] = releaseNotesEntryDomainToAPI(sub)
+		}
+	}
+
+	return apiEntry
+}
@@ -0,0 +1,60 @@
+package mapper
+
+import (
+	"release-management/internal/models/api"
+	"release-management/internal/models/db"
+	"release-management/internal/models/domain"
+)
+
+// EnvironmentGroupRoleGrantDBToDomain converts db.EnvironmentGroupRoleGrant to domain.EnvironmentGroupRoleGrant
+func EnvironmentGroupRoleGrantDBToDomain(dbGrant *db.EnvironmentGroupRoleGrant) *domain.EnvironmentGroupRoleGrant {
+	if dbGrant == nil {
+		return nil
+	}
+
+	domainGrant := &domain.EnvironmentGroupRoleGrant{
+		ID:                 dbGrant.ID,
+		EnvironmentGroupID: dbGrant.EnvironmentGroupID,
+		UserID:             dbGrant.UserID,
+		Role:               domain.Role(dbGrant.Role),
+		CreatedAt:          dbGrant.CreatedAt,
+		UpdatedAt:          dbGrant.UpdatedAt,
+	}
+
+	if dbGrant.User.ID != 0 {
+		domainGrant.User = UserDBToDomain(&dbGrant.User)
+	}
+
+	return domainGrant
+}
+
+// EnvironmentGroupRoleGrantDomainToDB converts domain.EnvironmentGroupRoleGrant to db.EnvironmentGroupRoleGrant
+func EnvironmentGroupRoleGrantDomainToDB(domainGrant *domain.EnvironmentGroupRoleGrant) *db.EnvironmentGroupRoleGrant {
+	if domainGrant == nil {
+		return nil
+	}
+	return &db.EnvironmentGroupRoleGrant{
+		ID:                 domainGrant.ID,
+		EnvironmentGroupID: domainGrant.EnvironmentGroupID,
+		UserID:             domainGrant.UserID,
+		Role:               string(domainGrant.Role),
+		CreatedAt:          domainGrant.CreatedAt,
+		UpdatedAt:          domainGrant.UpdatedAt,
+	}
+}
+
+// EnvironmentGroupRoleGrantDomainToAPI converts domain.EnvironmentGroupRoleGrant to api.EnvironmentGroupRoleGrantResponse
+func EnvironmentGroupRoleGrantDomainToAPI(domainGrant *domain.EnvironmentGroupRoleGrant) *api.EnvironmentGroupRoleGrantResponse {
+	if domainGrant == nil {
+		return nil
+	}
+	return &api.EnvironmentGroupRoleGrantResponse{
+		ID:                 domainGrant.ID,
+		EnvironmentGroupID: domainGrant.EnvironmentGroupID,
+		UserID:             domainGrant.UserID,
+		Role:               string(domainGrant.Role),
+		CreatedAt:          domainGrant.CreatedAt,
+		UpdatedAt:          domainGrant.UpdatedAt,
+		User:               UserDomainToAPI(domainGrant.User),
+	}
+}
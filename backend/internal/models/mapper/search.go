@@ -0,0 +1,27 @@
+package mapper
+
+import (
+	"release-management/internal/models/api"
+	"release-management/internal/models/domain"
+)
+
+// SearchResultDomainToAPI converts domain.SearchResult to api.SearchResultResponse
+func SearchResultDomainToAPI(domainResult *domain.SearchResult) *api.SearchResultResponse {
+	if domainResult == nil {
+		return nil
+	}
+
+	highlights := domainResult.Highlights
+	if highlights == nil {
+		highlights = map[string]string{}
+	}
+
+	return &api.SearchResultResponse{
+		Type:       string(domainResult.Type),
+		ID:         domainResult.ID,
+		Title:      domainResult.Title,
+		Subtitle:   domainResult.Subtitle,
+		Rank:       domainResult.Rank,
+		Highlights: highlights,
+	}
+}
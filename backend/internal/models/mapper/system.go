@@ -13,14 +13,16 @@ func SystemDBToDomain(dbSys *db.System) *domain.System {
 	}
 
 	domainSys := &domain.System{
-		ID:          dbSys.ID,
-		Name:        dbSys.Name,
-		Description: dbSys.Description,
-		ParentID:    dbSys.ParentID,
-		Type:        domain.SystemType(dbSys.Type),
-		Status:      domain.SystemStatus(dbSys.Status),
-		CreatedAt:   dbSys.CreatedAt,
-		UpdatedAt:   dbSys.UpdatedAt,
+		ID:            dbSys.ID,
+		Name:          dbSys.Name,
+		Description:   dbSys.Description,
+		ParentID:      dbSys.ParentID,
+		Type:          domain.SystemType(dbSys.Type),
+		Status:        domain.SystemStatus(dbSys.Status),
+		StrictSemver:  dbSys.StrictSemver,
+		StrictDigests: dbSys.StrictDigests,
+		CreatedAt:     dbSys.CreatedAt,
+		UpdatedAt:     dbSys.UpdatedAt,
 	}
 
 	// Convert relationships
@@ -55,14 +57,16 @@ func SystemDomainToDB(domainSys *domain.System) *db.System {
 		return nil
 	}
 	return &db.System{
-		ID:          domainSys.ID,
-		Name:        domainSys.Name,
-		Description: domainSys.Description,
-		ParentID:    domainSys.ParentID,
-		Type:        string(domainSys.Type),
-		Status:      string(domainSys.Status),
-		CreatedAt:   domainSys.CreatedAt,
-		UpdatedAt:   domainSys.UpdatedAt,
+		ID:            domainSys.ID,
+		Name:          domainSys.Name,
+		Description:   domainSys.Description,
+		ParentID:      domainSys.ParentID,
+		Type:          string(domainSys.Type),
+		Status:        string(domainSys.Status),
+		StrictSemver:  domainSys.StrictSemver,
+		StrictDigests: domainSys.StrictDigests,
+		CreatedAt:     domainSys.CreatedAt,
+		UpdatedAt:     domainSys.UpdatedAt,
 	}
 }
 
@@ -73,14 +77,16 @@ func SystemDomainToAPI(domainSys *domain.System) *api.SystemResponse {
 	}
 
 	apiSys := &api.SystemResponse{
-		ID:          domainSys.ID,
-		Name:        domainSys.Name,
-		Description: domainSys.Description,
-		ParentID:    domainSys.ParentID,
-		Type:        string(domainSys.Type),
-		Status:      string(domainSys.Status),
-		CreatedAt:   domainSys.CreatedAt,
-		UpdatedAt:   domainSys.UpdatedAt,
+		ID:            domainSys.ID,
+		Name:          domainSys.Name,
+		Description:   domainSys.Description,
+		ParentID:      domainSys.ParentID,
+		Type:          string(domainSys.Type),
+		Status:        string(domainSys.Status),
+		StrictSemver:  domainSys.StrictSemver,
+		StrictDigests: domainSys.StrictDigests,
+		CreatedAt:     domainSys.CreatedAt,
+		UpdatedAt:     domainSys.UpdatedAt,
 	}
 
 	// Convert relationships
@@ -115,10 +121,12 @@ func SystemAPIToDomain(apiReq *api.SystemRequest) *domain.System {
 		return nil
 	}
 	return &domain.System{
-		Name:        apiReq.Name,
-		Description: apiReq.Description,
-		ParentID:    apiReq.ParentID,
-		Type:        domain.SystemType(apiReq.Type),
-		Status:      domain.SystemStatus(apiReq.Status),
+		Name:          apiReq.Name,
+		Description:   apiReq.Description,
+		ParentID:      apiReq.ParentID,
+		Type:          domain.SystemType(apiReq.Type),
+		Status:        domain.SystemStatus(apiReq.Status),
+		StrictSemver:  apiReq.StrictSemver,
+		StrictDigests: apiReq.StrictDigests,
 	}
 }
@@ -16,6 +16,7 @@ func UserDBToDomain(dbUser *db.User) *domain.User {
 		Email:     dbUser.Email,
 		Password:  dbUser.Password,
 		IsAdmin:   dbUser.IsAdmin,
+		Role:      domain.Role(dbUser.Role),
 		CreatedAt: dbUser.CreatedAt,
 		UpdatedAt: dbUser.UpdatedAt,
 	}
@@ -31,6 +32,7 @@ func UserDomainToDB(domainUser *domain.User) *db.User {
 		Email:     domainUser.Email,
 		Password:  domainUser.Password,
 		IsAdmin:   domainUser.IsAdmin,
+		Role:      string(domainUser.Role),
 		CreatedAt: domainUser.CreatedAt,
 		UpdatedAt: domainUser.UpdatedAt,
 	}
@@ -45,6 +47,7 @@ func UserDomainToAPI(domainUser *domain.User) *api.UserResponse {
 		ID:        domainUser.ID,
 		Email:     domainUser.Email,
 		IsAdmin:   domainUser.IsAdmin,
+		Role:      string(domainUser.Role),
 		CreatedAt: domainUser.CreatedAt,
 		UpdatedAt: domainUser.UpdatedAt,
 	}
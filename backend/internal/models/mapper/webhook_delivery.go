@@ -0,0 +1,38 @@
+package mapper
+
+import (
+	"release-management/internal/models/db"
+	"release-management/internal/models/domain"
+)
+
+// WebhookDeliveryDBToDomain converts db.WebhookDelivery to domain.WebhookDelivery
+func WebhookDeliveryDBToDomain(dbDelivery *db.WebhookDelivery) *domain.WebhookDelivery {
+	if dbDelivery == nil {
+		return nil
+	}
+	return &domain.WebhookDelivery{
+		ID:         dbDelivery.ID,
+		Provider:   dbDelivery.Provider,
+		DeliveryID: dbDelivery.DeliveryID,
+		Status:     domain.WebhookDeliveryStatus(dbDelivery.Status),
+		BuildID:    dbDelivery.BuildID,
+		Message:    dbDelivery.Message,
+		CreatedAt:  dbDelivery.CreatedAt,
+	}
+}
+
+// WebhookDeliveryDomainToDB converts domain.WebhookDelivery to db.WebhookDelivery
+func WebhookDeliveryDomainToDB(domainDelivery *domain.WebhookDelivery) *db.WebhookDelivery {
+	if domainDelivery == nil {
+		return nil
+	}
+	return &db.WebhookDelivery{
+		ID:         domainDelivery.ID,
+		Provider:   domainDelivery.Provider,
+		DeliveryID: domainDelivery.DeliveryID,
+		Status:     string(domainDelivery.Status),
+		BuildID:    domainDelivery.BuildID,
+		Message:    domainDelivery.Message,
+		CreatedAt:  domainDelivery.CreatedAt,
+	}
+}
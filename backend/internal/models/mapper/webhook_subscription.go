@@ -0,0 +1,160 @@
+package mapper
+
+import (
+	"strings"
+
+	"release-management/internal/models/api"
+	"release-management/internal/models/db"
+	"release-management/internal/models/domain"
+)
+
+// WebhookSubscriptionDBToDomain converts db.WebhookSubscription to domain.WebhookSubscription
+func WebhookSubscriptionDBToDomain(dbSubscription *db.WebhookSubscription) *domain.WebhookSubscription {
+	if dbSubscription == nil {
+		return nil
+	}
+
+	domainSubscription := &domain.WebhookSubscription{
+		ID:        dbSubscription.ID,
+		Name:      dbSubscription.Name,
+		URL:       dbSubscription.URL,
+		Secret:    dbSubscription.Secret,
+		Active:    dbSubscription.Active,
+		CreatedAt: dbSubscription.CreatedAt,
+		UpdatedAt: dbSubscription.UpdatedAt,
+	}
+	if dbSubscription.EventTypes != "" {
+		for _, eventType := range strings.Split(dbSubscription.EventTypes, ",") {
+			domainSubscription.EventTypes = append(domainSubscription.EventTypes, domain.EventType(eventType))
+		}
+	}
+	return domainSubscription
+}
+
+// WebhookSubscriptionDomainToDB converts domain.WebhookSubscription to db.WebhookSubscription
+func WebhookSubscriptionDomainToDB(domainSubscription *domain.WebhookSubscription) *db.WebhookSubscription {
+	if domainSubscription == nil {
+		return nil
+	}
+
+	eventTypes := make([]string, len(domainSubscription.EventTypes))
+	for i, eventType := range domainSubscription.EventTypes {
+		eventTypes[i] = string(eventType)
+	}
+
+	return &db.WebhookSubscription{
+		ID:         domainSubscription.ID,
+		Name:       domainSubscription.Name,
+		URL:        domainSubscription.URL,
+		Secret:     domainSubscription.Secret,
+		EventTypes: strings.Join(eventTypes, ","),
+		Active:     domainSubscription.Active,
+		CreatedAt:  domainSubscription.CreatedAt,
+		UpdatedAt:  domainSubscription.UpdatedAt,
+	}
+}
+
+// WebhookSubscriptionDomainToAPI converts domain.WebhookSubscription to api.WebhookSubscriptionResponse, leaving out the secret
+func WebhookSubscriptionDomainToAPI(domainSubscription *domain.WebhookSubscription) *api.WebhookSubscriptionResponse {
+	if domainSubscription == nil {
+		return nil
+	}
+
+	apiSubscription := &api.WebhookSubscriptionResponse{
+		ID:         domainSubscription.ID,
+		Name:       domainSubscription.Name,
+		URL:        domainSubscription.URL,
+		EventTypes: make([]string, len(domainSubscription.EventTypes)),
+		Active:     domainSubscription.Active,
+		CreatedAt:  domainSubscription.CreatedAt,
+		UpdatedAt:  domainSubscription.UpdatedAt,
+	}
+	for i, eventType := range domainSubscription.EventTypes {
+		apiSubscription.EventTypes[i] = string(eventType)
+	}
+	return apiSubscription
+}
+
+// WebhookSubscriptionAPIToDomain converts api.WebhookSubscriptionRequest to domain.WebhookSubscription
+func WebhookSubscriptionAPIToDomain(apiReq *api.WebhookSubscriptionRequest) *domain.WebhookSubscription {
+	if apiReq == nil {
+		return nil
+	}
+
+	domainSubscription := &domain.WebhookSubscription{
+		Name:   strings.TrimSpace(apiReq.Name),
+		URL:    strings.TrimSpace(apiReq.URL),
+		Active: apiReq.Active == nil || *apiReq.Active,
+	}
+	for _, eventType := range apiReq.EventTypes {
+		domainSubscription.EventTypes = append(domainSubscription.EventTypes, domain.EventType(strings.TrimSpace(eventType)))
+	}
+	return domainSubscription
+}
+
+// EventDeliveryDBToDomain converts db.EventDelivery to domain.EventDelivery
+func EventDeliveryDBToDomain(dbDelivery *db.EventDelivery) *domain.EventDelivery {
+	if dbDelivery == nil {
+		return nil
+	}
+	return &domain.EventDelivery{
+		ID:             dbDelivery.ID,
+		SubscriptionID: dbDelivery.SubscriptionID,
+		EventID:        dbDelivery.EventID,
+		EventType:      domain.EventType(dbDelivery.EventType),
+		Status:         domain.EventDeliveryStatus(dbDelivery.Status),
+		Attempts:       dbDelivery.Attempts,
+		NextAttemptAt:  dbDelivery.NextAttemptAt,
+		LastAttemptAt:  dbDelivery.LastAttemptAt,
+		ResponseStatus: dbDelivery.ResponseStatus,
+		LastError:      dbDelivery.LastError,
+		DeliveredAt:    dbDelivery.DeliveredAt,
+		CreatedAt:      dbDelivery.CreatedAt,
+		UpdatedAt:      dbDelivery.UpdatedAt,
+		Event:          EventDBToDomain(dbDelivery.Event),
+	}
+}
+
+// EventDeliveryDomainToDB converts domain.EventDelivery to db.EventDelivery
+func EventDeliveryDomainToDB(domainDelivery *domain.EventDelivery) *db.EventDelivery {
+	if domainDelivery == nil {
+		return nil
+	}
+	return &db.EventDelivery{
+		ID:             domainDelivery.ID,
+		SubscriptionID: domainDelivery.SubscriptionID,
+		EventID:        domainDelivery.EventID,
+		EventType:      string(domainDelivery.EventType),
+		Status:         string(domainDelivery.Status),
+		Attempts:       domainDelivery.Attempts,
+		NextAttemptAt:  domainDelivery.NextAttemptAt,
+		LastAttemptAt:  domainDelivery.LastAttemptAt,
+		ResponseStatus: domainDelivery.ResponseStatus,
+		LastError:      domainDelivery.LastError,
+		DeliveredAt:    domainDelivery.DeliveredAt,
+		CreatedAt:      domainDelivery.CreatedAt,
+		UpdatedAt:      domainDelivery.UpdatedAt,
+	}
+}
+
+// EventDeliveryDomainToAPI converts domain.EventDelivery to api.EventDeliveryResponse
+func EventDeliveryDomainToAPI(domainDelivery *domain.EventDelivery) *api.EventDeliveryResponse {
+	if domainDelivery == nil {
+		return nil
+	}
+	return &api.EventDeliveryResponse{
+		ID:             domainDelivery.ID,
+		SubscriptionID: domainDelivery.SubscriptionID,
+		EventID:        domainDelivery.EventID,
+		EventType:      string(domainDelivery.EventType),
+		Status:         string(domainDelivery.Status),
+		Attempts:       domainDelivery.Attempts,
+		NextAttemptAt:  domainDelivery.NextAttemptAt,
+		LastAttemptAt:  domainDelivery.LastAttemptAt,
+		ResponseStatus: domainDelivery.ResponseStatus,
+		LastError:      domainDelivery.LastError,
+		DeliveredAt:    domainDelivery.DeliveredAt,
+		CreatedAt:      domainDelivery.CreatedAt,
+		UpdatedAt:      domainDelivery.UpdatedAt,
+	}
+}
@@ -0,0 +1,308 @@
+// Package openapi builds the OpenAPI 3.1 document of the HTTP API from route descriptions and the api models.
+//
+// Schemas are derived from the structs in internal/models/api: json tags name the properties and binding tags
+// add the validation rules, so the document follows the contracts the handlers actually bind and return.
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"release-management/internal/models/api"
+)
+
+// Version is the OpenAPI version of generated documents
+const Version = "3.1.0"
+
+// Document is an OpenAPI document, limited to the parts the API uses
+type Document struct {
+	OpenAPI    string                `json:"openapi"`
+	Info       Info                  `json:"info"`
+	Paths      map[string]PathItem   `json:"paths"`
+	Components Components            `json:"components"`
+	Security   []SecurityRequirement `json:"security,omitempty"`
+}
+
+// Info describes the API
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// PathItem holds the operations of one path by lowercase HTTP method
+type PathItem map[string]*Operation
+
+// Operation is one method of a path
+type Operation struct {
+	OperationID string                 `json:"operationId"`
+	Summary     string                 `json:"summary,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Tags        []string               `json:"tags,omitempty"`
+	Parameters  []Parameter            `json:"parameters,omitempty"`
+	RequestBody *RequestBody           `json:"requestBody,omitempty"`
+	Responses   map[string]Response    `json:"responses"`
+	Security    *[]SecurityRequirement `json:"security,omitempty"`
+}
+
+// Parameter is a path, query or header parameter
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody describes the body of a request by media type
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response describes a response by media type
+type Response struct {
+	Description string                    `json:"description"`
+	Headers     map[string]ResponseHeader `json:"headers,omitempty"`
+	Content     map[string]MediaType      `json:"content,omitempty"`
+}
+
+// ResponseHeader describes a response header
+type ResponseHeader struct {
+	Description string  `json:"description,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// MediaType holds the schema of a body
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Components holds the reusable schemas and security schemes
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme describes how requests authenticate
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+	Description  string `json:"description,omitempty"`
+}
+
+// SecurityRequirement lists the schemes a request must satisfy, by name
+type SecurityRequirement map[string][]string
+
+// bearerAuth is the name of the security scheme of protected routes
+const bearerAuth = "bearerAuth"
+
+// Route describes one route of the router for the document
+type Route struct {
+	// Method and Path as registered with gin, e.g. GET and /api/releases/:id
+	Method string
+	Path   string
+	// ID is the unique operationId that generated clients name their methods after
+	ID          string
+	Summary     string
+	Description string
+	Tag         string
+	// Public routes do not need a bearer token
+	Public bool
+	// Parameters are the query and header parameters. Path parameters are derived from Path.
+	Parameters []Parameter
+	// Paginated adds the limit, cursor and sort parameters of list endpoints with Sort as the sort fields
+	Paginated bool
+	Sort      []string
+	// Request is a value of the JSON body type, or OneOf for alternatives. Nil for routes without body.
+	Request interface{}
+	// OptionalRequest marks a body that may be omitted
+	OptionalRequest bool
+	// Status is the success status, 200 when not set
+	Status int
+	// Response is a value of the success body type, nil for an empty body
+	Response interface{}
+	// ContentType of the success body when it is not JSON, e.g. application/x-ndjson
+	ContentType string
+	// TextContentTypes are additional plain text representations of the success body, e.g. text/markdown
+	TextContentTypes []string
+	// YAML adds application/yaml with the same schema to the request and success bodies
+	YAML bool
+}
+
+// OneOf lists alternative body types, e.g. the payloads of different CI providers
+type OneOf []interface{}
+
+// New builds the document of routes
+func New(info Info, routes []Route) *Document {
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   make(map[string]PathItem),
+		Components: Components{
+			SecuritySchemes: map[string]SecurityScheme{
+				bearerAuth: {
+					Type:        "http",
+					Scheme:      "bearer",
+					Description: "A JWT from /api/auth/login or an API token starting with rm_",
+				},
+			},
+		},
+		Security: []SecurityRequirement{{bearerAuth: {}}},
+	}
+	schemas := newGenerator()
+
+	for _, route := range routes {
+		path, pathParams := openAPIPath(route.Path)
+		item, ok := doc.Paths[path]
+		if !ok {
+			item = make(PathItem)
+			doc.Paths[path] = item
+		}
+		item[strings.ToLower(route.Method)] = route.operation(schemas, pathParams)
+	}
+
+	doc.Components.Schemas = schemas.schemas
+	return doc
+}
+
+// HasOperation reports whether the document describes a route registered with gin
+func (d *Document) HasOperation(method, ginPath string) bool {
+	path, _ := openAPIPath(ginPath)
+	_, ok := d.Paths[path][strings.ToLower(method)]
+	return ok
+}
+
+// Helper function to build the operation of a route
+func (r Route) operation(schemas *generator, pathParams []string) *Operation {
+	op := &Operation{
+		OperationID: r.ID,
+		Summary:     r.Summary,
+		Description: r.Description,
+		Responses:   make(map[string]Response),
+	}
+	if r.Tag != "" {
+		op.Tags = []string{r.Tag}
+	}
+	if r.Public {
+		op.Security = &[]SecurityRequirement{}
+	}
+
+	for _, name := range pathParams {
+		op.Parameters = append(op.Parameters, Parameter{Name: name, In: "path", Required: true, Schema: &Schema{Type: "string"}})
+	}
+	op.Parameters = append(op.Parameters, r.Parameters...)
+	if r.Paginated {
+		op.Parameters = append(op.Parameters, pageParameters(r.Sort)...)
+	}
+
+	if r.Request != nil {
+		op.RequestBody = &RequestBody{
+			Required: !r.OptionalRequest,
+			Content:  map[string]MediaType{"application/json": {Schema: schemas.bodySchema(r.Request)}},
+		}
+		if r.YAML {
+			op.RequestBody.Content["application/yaml"] = op.RequestBody.Content["application/json"]
+		}
+	}
+
+	status := r.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	response := Response{Description: http.StatusText(status)}
+	if r.Response != nil {
+		contentType := r.ContentType
+		if contentType == "" {
+			contentType = "application/json"
+		}
+		response.Content = map[string]MediaType{contentType: {Schema: schemas.bodySchema(r.Response)}}
+		for _, textContentType := range r.TextContentTypes {
+			response.Content[textContentType] = MediaType{Schema: &Schema{Type: "string"}}
+		}
+		if r.YAML {
+			response.Content["application/yaml"] = response.Content[contentType]
+		}
+	}
+	if isListResponse(r.Response) {
+		response.Headers = map[string]ResponseHeader{
+			"X-Total-Count": {Description: "Number of items matching the filters across all pages", Schema: &Schema{Type: "integer"}},
+			"Link":          {Description: `Link to the next page with rel="next", omitted on the last page`, Schema: &Schema{Type: "string"}},
+		}
+	}
+	op.Responses[strconv.Itoa(status)] = response
+
+	op.Responses["default"] = Response{
+		Description: "Error",
+		Content:     map[string]MediaType{"application/json": {Schema: schemas.Schema(reflect.TypeOf(api.ErrorResponse{}))}},
+	}
+	return op
+}
+
+// Helper function to return the limit, cursor and sort parameters of a paginated list
+func pageParameters(sortFields []string) []Parameter {
+	sortValues := make([]string, 0, 2*len(sortFields))
+	for _, field := range sortFields {
+		sortValues = append(sortValues, field, "-"+field)
+	}
+
+	return []Parameter{
+		{Name: "limit", In: "query", Description: "Maximum number of items on the page", Schema: &Schema{Type: "integer", Minimum: ptr(1.0), Maximum: ptr(500.0)}},
+		{Name: "cursor", In: "query", Description: "The next_cursor of the previous page", Schema: &Schema{Type: "string"}},
+		{Name: "sort", In: "query", Description: "Field to sort by, prefixed with - for descending order", Schema: &Schema{Type: "string", Enum: sortValues}},
+	}
+}
+
+// Helper function to convert a gin path to an OpenAPI path, returning the names of its parameters
+func openAPIPath(ginPath string) (string, []string) {
+	segments := strings.Split(ginPath, "/")
+	var params []string
+	for i, segment := range segments {
+		if name, ok := strings.CutPrefix(segment, ":"); ok {
+			segments[i] = "{" + name + "}"
+			params = append(params, name)
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
+
+// Helper function to check whether a body is the list envelope
+func isListResponse(body interface{}) bool {
+	if body == nil {
+		return false
+	}
+	return strings.HasPrefix(reflect.TypeOf(body).Name(), "ListResponse[")
+}
+
+// Helper function to return a pointer to a value
+func ptr[T any](value T) *T {
+	return &value
+}
+
+// Query returns a string query parameter
+func Query(name, description string) Parameter {
+	return Parameter{Name: name, In: "query", Description: description, Schema: &Schema{Type: "string"}}
+}
+
+// QueryEnum returns a query parameter that takes one of values
+func QueryEnum(name, description string, values ...string) Parameter {
+	return Parameter{Name: name, In: "query", Description: description, Schema: &Schema{Type: "string", Enum: values}}
+}
+
+// QueryTime returns an RFC3339 time query parameter
+func QueryTime(name, description string) Parameter {
+	return Parameter{Name: name, In: "query", Description: description, Schema: &Schema{Type: "string", Format: "date-time"}}
+}
+
+// QueryInt returns an integer query parameter between min and max
+func QueryInt(name, description string, min, max int) Parameter {
+	return Parameter{Name: name, In: "query", Description: description, Schema: &Schema{Type: "integer", Minimum: ptr(float64(min)), Maximum: ptr(float64(max))}}
+}
+
+// Header returns a string header parameter
+func Header(name, description string) Parameter {
+	return Parameter{Name: name, In: "header", Description: description, Schema: &Schema{Type: "string"}}
+}
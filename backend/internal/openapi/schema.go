@@ -0,0 +1,222 @@
+package openapi
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schema is a JSON Schema as used by OpenAPI 3.1, limited to the keywords the api models need
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// generator derives schemas from Go types. Named structs become components that are referenced by name.
+type generator struct {
+	schemas map[string]*Schema
+}
+
+func newGenerator() *generator {
+	return &generator{schemas: make(map[string]*Schema)}
+}
+
+// Helper function to return the schema of a request or response body value
+func (g *generator) bodySchema(body interface{}) *Schema {
+	if alternatives, ok := body.(OneOf); ok {
+		schema := &Schema{}
+		for _, alternative := range alternatives {
+			schema.OneOf = append(schema.OneOf, g.Schema(reflect.TypeOf(alternative)))
+		}
+		return schema
+	}
+	return g.Schema(reflect.TypeOf(body))
+}
+
+// Schema returns the schema of t, registering the components it refers to
+func (g *generator) Schema(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &Schema{Type: "integer", Format: integerFormat(t)}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer", Format: integerFormat(t), Minimum: ptr(0.0)}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: g.Schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.Schema(t.Elem())}
+	case reflect.Interface:
+		// Any JSON value
+		return &Schema{}
+	case reflect.Struct:
+		if t == timeType {
+			return &Schema{Type: "string", Format: "date-time"}
+		}
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+
+		name := componentName(t)
+		if _, ok := g.schemas[name]; !ok {
+			// Register before generating the properties so that recursive types refer to themselves
+			g.schemas[name] = &Schema{}
+			*g.schemas[name] = *g.structSchema(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + name}
+	}
+
+	return &Schema{}
+}
+
+// Helper function to build the object schema of a struct, following the encoding/json rules for field names
+func (g *generator) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	g.addFields(schema, t)
+	return schema
+}
+
+// Helper function to add the fields of a struct to an object schema, flattening embedded structs
+func (g *generator) addFields(schema *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			g.addFields(schema, field.Type)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property := g.Schema(field.Type)
+		rules := strings.Split(field.Tag.Get("binding"), ",")
+		applyBindingRules(property, field.Type, rules)
+		schema.Properties[name] = property
+
+		// Fields that are always sent are required, as are fields the binding rules require
+		omitted := strings.Contains(options, "omitempty") || field.Type.Kind() == reflect.Pointer
+		if !omitted || hasRule(rules, "required") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
+
+// Helper function to translate the validator rules of a binding tag into schema keywords
+func applyBindingRules(schema *Schema, t reflect.Type, rules []string) {
+	for _, rule := range rules {
+		key, value, _ := strings.Cut(rule, "=")
+		switch key {
+		case "email":
+			schema.Format = "email"
+		case "url", "uri":
+			schema.Format = "uri"
+		case "uuid":
+			schema.Format = "uuid"
+		case "oneof":
+			schema.Enum = strings.Fields(value)
+		case "min", "gte":
+			setBound(schema, t, value, true)
+		case "max", "lte":
+			setBound(schema, t, value, false)
+		}
+	}
+}
+
+// Helper function to set a min or max rule, which limits the length of strings and lists and the value of numbers
+func setBound(schema *Schema, t reflect.Type, value string, lower bool) {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return
+	}
+
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.String:
+		if lower {
+			schema.MinLength = &n
+		} else {
+			schema.MaxLength = &n
+		}
+	case reflect.Slice, reflect.Array, reflect.Map:
+		if lower {
+			schema.MinItems = &n
+		} else {
+			schema.MaxItems = &n
+		}
+	default:
+		if lower {
+			schema.Minimum = ptr(float64(n))
+		} else {
+			schema.Maximum = ptr(float64(n))
+		}
+	}
+}
+
+// Helper function to check whether a binding tag contains a rule
+func hasRule(rules []string, rule string) bool {
+	for _, r := range rules {
+		if r == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// Helper function to name the component of a struct type.
+// Instances of generic types are named after their type argument, e.g. ListResponse[ReleaseResponse] becomes ReleaseResponseList.
+func componentName(t reflect.Type) string {
+	name := t.Name()
+	generic, args, ok := strings.Cut(name, "[")
+	if !ok {
+		return name
+	}
+
+	arg := strings.TrimSuffix(args, "]")
+	arg = arg[strings.LastIndex(arg, ".")+1:]
+	return arg + strings.TrimSuffix(generic, "Response")
+}
+
+// Helper function to return the format of an integer type
+func integerFormat(t reflect.Type) string {
+	if t.Bits() == 64 {
+		return "int64"
+	}
+	if t.Bits() == 32 {
+		return "int32"
+	}
+	return ""
+}
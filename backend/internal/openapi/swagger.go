@@ -0,0 +1,9 @@
+package openapi
+
+import _ "embed"
+
+// SwaggerUI is a page that renders the document served next to it at ./openapi.json.
+// The Swagger UI assets are loaded from a pinned swagger-ui-dist release on unpkg.
+//
+//go:embed swagger.html
+var SwaggerUI []byte
@@ -0,0 +1,128 @@
+// Package releasenotes renders release notes with Go templates.
+//
+// Markdown notes are rendered with text/template and HTML notes with html/template, which uses the same syntax
+// but escapes the rendered values. Both execute on an api.ReleaseNotesResponse. The built-in templates live in
+// the templates directory. A template directory on disk can hold default.md.tmpl and default.html.tmpl to
+// replace them for everyone, and <team>.md.tmpl and <team>.html.tmpl for teams that want their own layout.
+// Templates are read on every render, so they can be changed without restarting the server.
+package releasenotes
+
+import (
+	"embed"
+	"errors"
+	htmltemplate "html/template"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	texttemplate "text/template"
+
+	"release-management/internal/models/domain"
+)
+
+// DefaultTeam is the team whose templates are used when no team is asked for
+const DefaultTeam = "default"
+
+//go:embed templates/*.tmpl
+var builtin embed.FS
+
+// teamPattern keeps team names to plain file names
+var teamPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+// funcs are the functions available to templates besides the built-in ones
+var funcs = map[string]interface{}{
+	// indent prefixes every line but the first with n spaces, e.g. to nest a changelog under a list item
+	"indent": func(n int, s string) string {
+		return strings.ReplaceAll(s, "\n", "\n"+strings.Repeat(" ", n))
+	},
+}
+
+// Renderer renders release notes with the templates of a directory, falling back to the built-in templates
+type Renderer struct {
+	dir string
+}
+
+// NewRenderer returns a renderer for the templates in dir. An empty dir only uses the built-in templates.
+func NewRenderer(dir string) *Renderer {
+	return &Renderer{dir: dir}
+}
+
+// ContentType returns the content type of release notes rendered in format
+func ContentType(format domain.ReleaseNotesFormat) string {
+	if format == domain.ReleaseNotesHTML {
+		return "text/html; charset=utf-8"
+	}
+	return "text/markdown; charset=utf-8"
+}
+
+// Render writes the release notes in data to w with the markdown or html template of team
+func (r *Renderer) Render(w io.Writer, format domain.ReleaseNotesFormat, team string, data interface{}) error {
+	if team == "" {
+		team = DefaultTeam
+	}
+	if !teamPattern.MatchString(team) {
+		return domain.ErrReleaseNotesInvalidTeam.WithDetail("team", team)
+	}
+
+	var extension string
+	switch format {
+	case domain.ReleaseNotesMarkdown:
+		extension = ".md.tmpl"
+	case domain.ReleaseNotesHTML:
+		extension = ".html.tmpl"
+	default:
+		return domain.ErrReleaseNotesInvalidFormat
+	}
+
+	name := team + extension
+	source, err := r.load(name)
+	if err != nil {
+		return err
+	}
+
+	if format == domain.ReleaseNotesHTML {
+		tmpl, err := htmltemplate.New(name).Funcs(funcs).Parse(source)
+		if err != nil {
+			return invalidTemplate(name, err)
+		}
+		if err := tmpl.Execute(w, data); err != nil {
+			return invalidTemplate(name, err)
+		}
+		return nil
+	}
+
+	tmpl, err := texttemplate.New(name).Funcs(funcs).Parse(source)
+	if err != nil {
+		return invalidTemplate(name, err)
+	}
+	if err := tmpl.Execute(w, data); err != nil {
+		return invalidTemplate(name, err)
+	}
+	return nil
+}
+
+// Helper function to read a template from the template directory, or the built-in templates for the default team
+func (r *Renderer) load(name string) (string, error) {
+	if r.dir != "" {
+		source, err := os.ReadFile(filepath.Join(r.dir, name))
+		if err == nil {
+			return string(source), nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+	}
+
+	source, err := builtin.ReadFile("templates/" + name)
+	if err != nil {
+		return "", domain.ErrReleaseNotesTemplateNotFound.WithDetail("template", name)
+	}
+	return string(source), nil
+}
+
+// Helper function to report a template that does not parse or execute
+func invalidTemplate(name string, err error) error {
+	return domain.ErrReleaseNotesTemplateInvalid.WithCause(err).WithDetail("template", name)
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"release-management/internal/models/domain"
+)
+
+// APITokenRepository stores the hashed API tokens of automation clients
+type APITokenRepository interface {
+	// ListByUser returns the tokens of a user, newest first
+	ListByUser(ctx context.Context, userID uint) ([]domain.APIToken, error)
+
+	// Get returns a token or ErrNotFound
+	Get(ctx context.Context, id string) (*domain.APIToken, error)
+
+	// GetByHash returns the token stored under a hash or ErrNotFound
+	GetByHash(ctx context.Context, tokenHash string) (*domain.APIToken, error)
+
+	// Create stores a new token and fills in its ID and timestamps
+	Create(ctx context.Context, token *domain.APIToken) error
+
+	// Update stores every field of an existing token or returns ErrNotFound
+	Update(ctx context.Context, token *domain.APIToken) error
+
+	// MarkUsed records when a token was last used without changing its update time
+	MarkUsed(ctx context.Context, id string, at time.Time) error
+}
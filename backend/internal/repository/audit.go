@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"release-management/internal/models/domain"
+)
+
+// AuditFilter narrows down the entries returned by an AuditRepository. Empty fields match every entry.
+type AuditFilter struct {
+	EntityType domain.AuditEntityType
+	EntityID   string
+	Action     domain.AuditAction
+	ActorID    *uint
+	Actor      string
+	Since      *time.Time
+	Until      *time.Time
+}
+
+// AuditSortFields lists the fields audit entries can be sorted by
+var AuditSortFields = SortFields[domain.AuditEntry]{
+	ID: func(e *domain.AuditEntry) string { return e.ID },
+	Keys: map[string]func(*domain.AuditEntry) string{
+		"created_at": func(e *domain.AuditEntry) string { return TimeKey(e.CreatedAt) },
+	},
+}
+
+// AuditRepository stores the append-only audit log
+type AuditRepository interface {
+	// Create records a new entry and fills in its ID and creation time
+	Create(ctx context.Context, entry *domain.AuditEntry) error
+
+	// ListPage returns a page of the entries matching filter, sorted by one of AuditSortFields
+	ListPage(ctx context.Context, filter AuditFilter, page PageRequest) (*Page[domain.AuditEntry], error)
+
+	// Each calls fn for every entry matching filter, oldest first, without loading all of them at once.
+	// Iteration stops at the first error returned by fn.
+	Each(ctx context.Context, filter AuditFilter, fn func(entry *domain.AuditEntry) error) error
+}
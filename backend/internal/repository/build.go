@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"release-management/internal/models/domain"
+)
+
+// BuildFilter narrows down the builds returned by a BuildRepository. Empty fields match every build.
+type BuildFilter struct {
+	SystemID  string
+	ReleaseID string
+	// CommitSHA matches the builds whose commit starts with it, so abbreviated commits find their builds
+	CommitSHA   string
+	ImageDigest string
+	// BuiltAfter and BuiltBefore bound the build date, both inclusive
+	BuiltAfter  *time.Time
+	BuiltBefore *time.Time
+}
+
+// BuildSortFields lists the fields builds can be sorted by
+var BuildSortFields = SortFields[domain.Build]{
+	ID: func(b *domain.Build) string { return b.ID },
+	Keys: map[string]func(*domain.Build) string{
+		"created_at": func(b *domain.Build) string { return TimeKey(b.CreatedAt) },
+		"build_date": func(b *domain.Build) string { return TimeKey(b.BuildDate) },
+	},
+}
+
+// BuildRepository stores builds
+type BuildRepository interface {
+	// List returns the builds matching filter with their system and release, oldest first
+	List(ctx context.Context, filter BuildFilter) ([]domain.Build, error)
+
+	// ListPage returns a page of the builds matching filter with their system and release, sorted by one of BuildSortFields
+	ListPage(ctx context.Context, filter BuildFilter, page PageRequest) (*Page[domain.Build], error)
+
+	// Count returns the number of builds matching filter
+	Count(ctx context.Context, filter BuildFilter) (int64, error)
+
+	// Get returns a build with its system and release, or ErrNotFound
+	Get(ctx context.Context, id string) (*domain.Build, error)
+
+	// FindByVersion returns the build of a system with the given version, or ErrNotFound
+	FindByVersion(ctx context.Context, systemID, version string) (*domain.Build, error)
+
+	// Create stores a new build and fills in its ID and timestamps
+	Create(ctx context.Context, build *domain.Build) error
+
+	// Update stores every field of an existing build or returns ErrNotFound
+	Update(ctx context.Context, build *domain.Build) error
+
+	// Delete removes a build or returns ErrNotFound
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"release-management/internal/models/domain"
+)
+
+// ChangeRequestFilter narrows down the requests returned by a ChangeRequestRepository. Empty fields match every request.
+type ChangeRequestFilter struct {
+	Status        domain.ChangeRequestStatus
+	EnvironmentID string
+	AuthorID      uint
+}
+
+// ChangeRequestSortFields lists the fields change requests can be sorted by
+var ChangeRequestSortFields = SortFields[domain.ChangeRequest]{
+	ID: func(r *domain.ChangeRequest) string { return r.ID },
+	Keys: map[string]func(*domain.ChangeRequest) string{
+		"created_at": func(r *domain.ChangeRequest) string { return TimeKey(r.CreatedAt) },
+		"expires_at": func(r *domain.ChangeRequest) string { return TimeKey(r.ExpiresAt) },
+	},
+}
+
+// ChangeRequestRepository stores change requests with their changes, approvals and comments
+type ChangeRequestRepository interface {
+	// List returns a page of the requests matching filter, sorted by one of ChangeRequestSortFields
+	List(ctx context.Context, filter ChangeRequestFilter, page PageRequest) (*Page[domain.ChangeRequest], error)
+
+	// Get returns a request by ID, or ErrNotFound
+	Get(ctx context.Context, id string) (*domain.ChangeRequest, error)
+
+	// Lock keeps other transactions from locking or changing a request until the current transaction ends,
+	// or returns ErrNotFound. Outside a transaction the lock is released right away.
+	Lock(ctx context.Context, id string) error
+
+	// Create stores a new request with its changes and fills in its ID and timestamps
+	Create(ctx context.Context, request *domain.ChangeRequest) error
+
+	// Update writes the status and decision fields of an existing request, returning ErrNotFound if there is none.
+	// Its changes, approvals and comments are left as they are.
+	Update(ctx context.Context, request *domain.ChangeRequest) error
+
+	// Expire marks the pending and approved requests whose expiry passed at a point in time as expired
+	Expire(ctx context.Context, at time.Time) error
+
+	// AddApproval stores an approval and fills in its ID, returning ErrDuplicate if the user already approved the request
+	AddApproval(ctx context.Context, approval *domain.ChangeRequestApproval) error
+
+	// AddComment stores a comment and fills in its ID and timestamp
+	AddComment(ctx context.Context, comment *domain.ChangeRequestComment) error
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"release-management/internal/models/domain"
+)
+
+// DeploymentFilter narrows down the deployments returned by a DeploymentRepository. Empty fields match every deployment.
+type DeploymentFilter struct {
+	EnvironmentID string
+	SystemID      string
+	// Version matches deployments that put the version in place or replaced it
+	Version string
+	Since   *time.Time
+	Until   *time.Time
+	// OldestFirst orders deployments by creation time ascending instead of newest first
+	OldestFirst bool
+	// Limit caps the number of deployments returned, 0 for no limit
+	Limit int
+}
+
+// DeploymentSortFields lists the fields deployments can be sorted by
+var DeploymentSortFields = SortFields[domain.Deployment]{
+	ID: func(d *domain.Deployment) string { return d.ID },
+	Keys: map[string]func(*domain.Deployment) string{
+		"created_at": func(d *domain.Deployment) string { return TimeKey(d.CreatedAt) },
+	},
+}
+
+// DeploymentRepository stores the append-only history of version changes in environments
+type DeploymentRepository interface {
+	// List returns the deployments matching filter with their system and user
+	List(ctx context.Context, filter DeploymentFilter) ([]domain.Deployment, error)
+
+	// ListPage returns a page of the deployments matching filter with their system and user, sorted by one of DeploymentSortFields.
+	// The OldestFirst and Limit fields of filter are ignored in favour of page.
+	ListPage(ctx context.Context, filter DeploymentFilter, page PageRequest) (*Page[domain.Deployment], error)
+
+	// Get returns a deployment or ErrNotFound
+	Get(ctx context.Context, id string) (*domain.Deployment, error)
+
+	// Create records a new deployment and fills in its ID and creation time
+	Create(ctx context.Context, deployment *domain.Deployment) error
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+
+	"release-management/internal/models/domain"
+)
+
+// EnvironmentFilter narrows down the environments returned by an EnvironmentRepository. Empty fields match every environment.
+type EnvironmentFilter struct {
+	ReleaseID          string
+	EnvironmentGroupID string
+	Type               domain.EnvironmentType
+	Status             domain.EnvironmentStatus
+}
+
+// EnvironmentSortFields lists the fields environments can be sorted by
+var EnvironmentSortFields = SortFields[domain.Environment]{
+	ID: func(e *domain.Environment) string { return e.ID },
+	Keys: map[string]func(*domain.Environment) string{
+		"created_at": func(e *domain.Environment) string { return TimeKey(e.CreatedAt) },
+		"name":       func(e *domain.Environment) string { return e.Name },
+	},
+}
+
+// EnvironmentRepository stores environments and the systems deployed to them
+type EnvironmentRepository interface {
+	// List returns the environments matching filter, oldest first
+	List(ctx context.Context, filter EnvironmentFilter) ([]domain.Environment, error)
+
+	// ListPage returns a page of the environments matching filter, sorted by one of EnvironmentSortFields
+	ListPage(ctx context.Context, filter EnvironmentFilter, page PageRequest) (*Page[domain.Environment], error)
+
+	// Count returns the number of environments matching filter
+	Count(ctx context.Context, filter EnvironmentFilter) (int64, error)
+
+	// Get returns an environment or ErrNotFound
+	Get(ctx context.Context, id string) (*domain.Environment, error)
+
+	// Create stores a new environment and fills in its ID, default status and timestamps
+	Create(ctx context.Context, environment *domain.Environment) error
+
+	// Update stores every field of an existing environment or returns ErrNotFound
+	Update(ctx context.Context, environment *domain.Environment) error
+
+	// Delete removes an environment or returns ErrNotFound
+	Delete(ctx context.Context, id string) error
+
+	// ListSystems returns the systems deployed to an environment with their system, oldest first
+	ListSystems(ctx context.Context, environmentID string) ([]domain.EnvironmentSystem, error)
+
+	// GetSystem returns a system deployed to an environment with its system, or ErrNotFound
+	GetSystem(ctx context.Context, environmentID, systemID string) (*domain.EnvironmentSystem, error)
+
+	// AddSystem deploys a system to an environment and fills in its ID, default status and timestamps
+	AddSystem(ctx context.Context, envSystem *domain.EnvironmentSystem) error
+
+	// UpdateSystem stores the version and status of a deployed system or returns ErrNotFound
+	UpdateSystem(ctx context.Context, envSystem *domain.EnvironmentSystem) error
+
+	// RemoveSystem removes a system from an environment or returns ErrNotFound
+	RemoveSystem(ctx context.Context, environmentID, systemID string) error
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+
+	"release-management/internal/models/domain"
+)
+
+// EnvironmentGroupSortFields lists the fields environment groups can be sorted by
+var EnvironmentGroupSortFields = SortFields[domain.EnvironmentGroup]{
+	ID: func(g *domain.EnvironmentGroup) string { return g.ID },
+	Keys: map[string]func(*domain.EnvironmentGroup) string{
+		"created_at": func(g *domain.EnvironmentGroup) string { return TimeKey(g.CreatedAt) },
+		"name":       func(g *domain.EnvironmentGroup) string { return g.Name },
+	},
+}
+
+// EnvironmentGroupRepository stores environment groups and the role grants within them
+type EnvironmentGroupRepository interface {
+	// List returns every environment group with its environments, oldest first
+	List(ctx context.Context) ([]domain.EnvironmentGroup, error)
+
+	// ListPage returns a page of the environment groups with their environments, sorted by one of EnvironmentGroupSortFields
+	ListPage(ctx context.Context, page PageRequest) (*Page[domain.EnvironmentGroup], error)
+
+	// Get returns an environment group with its environments, or ErrNotFound
+	Get(ctx context.Context, id string) (*domain.EnvironmentGroup, error)
+
+	// Create stores a new environment group and fills in its ID and timestamps
+	Create(ctx context.Context, group *domain.EnvironmentGroup) error
+
+	// Update stores every field of an existing environment group or returns ErrNotFound
+	Update(ctx context.Context, group *domain.EnvironmentGroup) error
+
+	// Delete removes an environment group or returns ErrNotFound
+	Delete(ctx context.Context, id string) error
+
+	// ListRoleGrants returns the role grants of a group with their users, oldest first
+	ListRoleGrants(ctx context.Context, groupID string) ([]domain.EnvironmentGroupRoleGrant, error)
+
+	// GetRoleGrant returns the role grant of a user in a group, or ErrNotFound
+	GetRoleGrant(ctx context.Context, groupID string, userID uint) (*domain.EnvironmentGroupRoleGrant, error)
+
+	// SaveRoleGrant creates the role grant of a user in a group or replaces the role of an existing one
+	SaveRoleGrant(ctx context.Context, grant *domain.EnvironmentGroupRoleGrant) error
+
+	// DeleteRoleGrant removes the role grant of a user in a group or returns ErrNotFound
+	DeleteRoleGrant(ctx context.Context, groupID string, userID uint) error
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"release-management/internal/models/domain"
+)
+
+// EventRepository stores the events published to webhook subscriptions and the event stream
+type EventRepository interface {
+	// Create stores a new event and fills in its ID, sequence and creation time
+	Create(ctx context.Context, event *domain.Event) error
+
+	// Get returns an event by ID, or ErrNotFound
+	Get(ctx context.Context, id string) (*domain.Event, error)
+
+	// ListAfter returns up to limit events with a sequence greater than after, in sequence order
+	ListAfter(ctx context.Context, after int64, limit int) ([]domain.Event, error)
+
+	// ListLatest returns the last limit events, in sequence order
+	ListLatest(ctx context.Context, limit int) ([]domain.Event, error)
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"release-management/internal/models/domain"
+)
+
+// FreezeWindowFilter narrows down the windows returned by a FreezeWindowRepository. Empty fields match every window.
+type FreezeWindowFilter struct {
+	// Since and Until match the windows that overlap the period between them
+	Since *time.Time
+	Until *time.Time
+}
+
+// FreezeOverrideFilter narrows down the overrides returned by a FreezeWindowRepository. Empty fields match every override.
+type FreezeOverrideFilter struct {
+	FreezeWindowID string
+	EnvironmentID  string
+}
+
+// FreezeOverrideSortFields lists the fields freeze overrides can be sorted by
+var FreezeOverrideSortFields = SortFields[domain.FreezeOverride]{
+	ID: func(o *domain.FreezeOverride) string { return o.ID },
+	Keys: map[string]func(*domain.FreezeOverride) string{
+		"created_at": func(o *domain.FreezeOverride) string { return TimeKey(o.CreatedAt) },
+	},
+}
+
+// FreezeWindowRepository stores freeze windows with the log of the changes let through them
+type FreezeWindowRepository interface {
+	// List returns the windows matching filter, sorted by start
+	List(ctx context.Context, filter FreezeWindowFilter) ([]domain.FreezeWindow, error)
+
+	// Get returns a window by ID, or ErrNotFound
+	Get(ctx context.Context, id string) (*domain.FreezeWindow, error)
+
+	// Create stores a new window and fills in its ID and timestamps
+	Create(ctx context.Context, window *domain.FreezeWindow) error
+
+	// Update writes every field of an existing window, returning ErrNotFound if there is none
+	Update(ctx context.Context, window *domain.FreezeWindow) error
+
+	// Delete removes a window, returning ErrNotFound if there is none. Its overrides are kept.
+	Delete(ctx context.Context, id string) error
+
+	// CreateOverride logs a change let through a window and fills in its ID and timestamp
+	CreateOverride(ctx context.Context, override *domain.FreezeOverride) error
+
+	// ListOverrides returns a page of the overrides matching filter, sorted by one of FreezeOverrideSortFields
+	ListOverrides(ctx context.Context, filter FreezeOverrideFilter, page PageRequest) (*Page[domain.FreezeOverride], error)
+}
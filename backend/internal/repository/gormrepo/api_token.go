@@ -0,0 +1,80 @@
+package gormrepo
+
+import (
+	"context"
+	"time"
+
+	"release-management/internal/models/db"
+	"release-management/internal/models/domain"
+	"release-management/internal/models/mapper"
+	"release-management/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+type apiTokenRepository struct {
+	db *gorm.DB
+}
+
+func (r *apiTokenRepository) ListByUser(ctx context.Context, userID uint) ([]domain.APIToken, error) {
+	var rows []db.APIToken
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at DESC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	tokens := make([]domain.APIToken, len(rows))
+	for i := range rows {
+		tokens[i] = *mapper.APITokenDBToDomain(&rows[i])
+	}
+	return tokens, nil
+}
+
+func (r *apiTokenRepository) Get(ctx context.Context, id string) (*domain.APIToken, error) {
+	var row db.APIToken
+	if err := r.db.WithContext(ctx).First(&row, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return mapper.APITokenDBToDomain(&row), nil
+}
+
+func (r *apiTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*domain.APIToken, error) {
+	var row db.APIToken
+	if err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&row).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return mapper.APITokenDBToDomain(&row), nil
+}
+
+func (r *apiTokenRepository) Create(ctx context.Context, token *domain.APIToken) error {
+	row := mapper.APITokenDomainToDB(token)
+	if err := createRow(r.db.WithContext(ctx), row); err != nil {
+		return err
+	}
+
+	token.ID = row.ID
+	token.CreatedAt = row.CreatedAt
+	token.UpdatedAt = row.UpdatedAt
+	return nil
+}
+
+func (r *apiTokenRepository) Update(ctx context.Context, token *domain.APIToken) error {
+	row := mapper.APITokenDomainToDB(token)
+	if err := updateRow(r.db.WithContext(ctx), row); err != nil {
+		return err
+	}
+
+	token.UpdatedAt = row.UpdatedAt
+	return nil
+}
+
+func (r *apiTokenRepository) MarkUsed(ctx context.Context, id string, at time.Time) error {
+	// UpdateColumn skips the hooks so updated_at keeps reflecting changes to the token itself
+	result := r.db.WithContext(ctx).Model(&db.APIToken{}).Where("id = ?", id).UpdateColumn("last_used_at", at)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
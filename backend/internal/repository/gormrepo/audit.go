@@ -0,0 +1,82 @@
+package gormrepo
+
+import (
+	"context"
+
+	"release-management/internal/models/db"
+	"release-management/internal/models/domain"
+	"release-management/internal/models/mapper"
+	"release-management/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+type auditRepository struct {
+	db *gorm.DB
+}
+
+var auditSortColumns = map[string]sortColumn{
+	"created_at": {name: "created_at", time: true},
+}
+
+func (r *auditRepository) Create(ctx context.Context, entry *domain.AuditEntry) error {
+	row := mapper.AuditEntryDomainToDB(entry)
+	if err := createRow(r.db.WithContext(ctx), row); err != nil {
+		return err
+	}
+
+	entry.ID = row.ID
+	entry.CreatedAt = row.CreatedAt
+	return nil
+}
+
+func (r *auditRepository) ListPage(ctx context.Context, filter repository.AuditFilter, page repository.PageRequest) (*repository.Page[domain.AuditEntry], error) {
+	return listPage(r.query(ctx, filter), r.query(ctx, filter), auditSortColumns, repository.AuditSortFields, page, mapper.AuditEntryDBToDomain)
+}
+
+func (r *auditRepository) Each(ctx context.Context, filter repository.AuditFilter, fn func(entry *domain.AuditEntry) error) error {
+	// Scan row by row so large exports do not have to fit in memory
+	rows, err := r.query(ctx, filter).Order("created_at ASC, id ASC").Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row db.AuditEntry
+		if err := r.db.ScanRows(rows, &row); err != nil {
+			return err
+		}
+		if err := fn(mapper.AuditEntryDBToDomain(&row)); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// Helper function to build the query for a filter
+func (r *auditRepository) query(ctx context.Context, filter repository.AuditFilter) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&db.AuditEntry{})
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != "" {
+		query = query.Where("entity_id = ?", filter.EntityID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.ActorID != nil {
+		query = query.Where("actor_id = ?", *filter.ActorID)
+	}
+	if filter.Actor != "" {
+		query = query.Where("actor = ?", filter.Actor)
+	}
+	if filter.Since != nil {
+		query = query.Where("created_at >= ?", *filter.Since)
+	}
+	if filter.Until != nil {
+		query = query.Where("created_at <= ?", *filter.Until)
+	}
+	return query
+}
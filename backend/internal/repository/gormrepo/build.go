@@ -0,0 +1,112 @@
+package gormrepo
+
+import (
+	"context"
+
+	"release-management/internal/models/db"
+	"release-management/internal/models/domain"
+	"release-management/internal/models/mapper"
+	"release-management/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+type buildRepository struct {
+	db *gorm.DB
+}
+
+var buildSortColumns = map[string]sortColumn{
+	"created_at": {name: "created_at", time: true},
+	"build_date": {name: "build_date", time: true},
+}
+
+func (r *buildRepository) List(ctx context.Context, filter repository.BuildFilter) ([]domain.Build, error) {
+	var rows []db.Build
+	if err := r.query(ctx, filter).Preload("System").Preload("Release").Order("created_at ASC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	builds := make([]domain.Build, len(rows))
+	for i := range rows {
+		builds[i] = *mapper.BuildDBToDomain(&rows[i])
+	}
+	return builds, nil
+}
+
+func (r *buildRepository) ListPage(ctx context.Context, filter repository.BuildFilter, page repository.PageRequest) (*repository.Page[domain.Build], error) {
+	list := r.query(ctx, filter).Preload("System").Preload("Release")
+	return listPage(r.query(ctx, filter), list, buildSortColumns, repository.BuildSortFields, page, mapper.BuildDBToDomain)
+}
+
+func (r *buildRepository) Count(ctx context.Context, filter repository.BuildFilter) (int64, error) {
+	var count int64
+	err := r.query(ctx, filter).Count(&count).Error
+	return count, err
+}
+
+func (r *buildRepository) Get(ctx context.Context, id string) (*domain.Build, error) {
+	var row db.Build
+	if err := r.db.WithContext(ctx).Preload("System").Preload("Release").First(&row, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return mapper.BuildDBToDomain(&row), nil
+}
+
+func (r *buildRepository) FindByVersion(ctx context.Context, systemID, version string) (*domain.Build, error) {
+	var row db.Build
+	if err := r.db.WithContext(ctx).Preload("System").Preload("Release").
+		Where("system_id = ? AND version = ?", systemID, version).First(&row).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return mapper.BuildDBToDomain(&row), nil
+}
+
+func (r *buildRepository) Create(ctx context.Context, build *domain.Build) error {
+	row := mapper.BuildDomainToDB(build)
+	if err := createRow(r.db.WithContext(ctx), row); err != nil {
+		return err
+	}
+
+	build.ID = row.ID
+	build.CreatedAt = row.CreatedAt
+	build.UpdatedAt = row.UpdatedAt
+	return nil
+}
+
+func (r *buildRepository) Update(ctx context.Context, build *domain.Build) error {
+	row := mapper.BuildDomainToDB(build)
+	if err := updateRow(r.db.WithContext(ctx), row); err != nil {
+		return err
+	}
+
+	build.UpdatedAt = row.UpdatedAt
+	return nil
+}
+
+func (r *buildRepository) Delete(ctx context.Context, id string) error {
+	return deleteRows(r.db.WithContext(ctx), &db.Build{}, "id = ?", id)
+}
+
+// Helper function to build the query for a filter
+func (r *buildRepository) query(ctx context.Context, filter repository.BuildFilter) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&db.Build{})
+	if filter.SystemID != "" {
+		query = query.Where("system_id = ?", filter.SystemID)
+	}
+	if filter.ReleaseID != "" {
+		query = query.Where("release_id = ?", filter.ReleaseID)
+	}
+	if filter.CommitSHA != "" {
+		query = query.Where("commit_sha LIKE ?", filter.CommitSHA+"%")
+	}
+	if filter.ImageDigest != "" {
+		query = query.Where("image_digest = ?", filter.ImageDigest)
+	}
+	if filter.BuiltAfter != nil {
+		query = query.Where("build_date >= ?", *filter.BuiltAfter)
+	}
+	if filter.BuiltBefore != nil {
+		query = query.Where("build_date <= ?", *filter.BuiltBefore)
+	}
+	return query
+}
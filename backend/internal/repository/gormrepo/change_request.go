@@ -0,0 +1,123 @@
+package gormrepo
+
+import (
+	"context"
+	"time"
+
+	"release-management/internal/models/db"
+	"release-management/internal/models/domain"
+	"release-management/internal/models/mapper"
+	"release-management/internal/repository"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type changeRequestRepository struct {
+	db *gorm.DB
+}
+
+var changeRequestSortColumns = map[string]sortColumn{
+	"created_at": {name: "created_at", time: true},
+	"expires_at": {name: "expires_at", time: true},
+}
+
+func (r *changeRequestRepository) List(ctx context.Context, filter repository.ChangeRequestFilter, page repository.PageRequest) (*repository.Page[domain.ChangeRequest], error) {
+	list := withChangeRequestRelations(r.query(ctx, filter))
+	return listPage(r.query(ctx, filter), list, changeRequestSortColumns, repository.ChangeRequestSortFields, page, mapper.ChangeRequestDBToDomain)
+}
+
+func (r *changeRequestRepository) Get(ctx context.Context, id string) (*domain.ChangeRequest, error) {
+	var row db.ChangeRequest
+	if err := withChangeRequestRelations(r.db.WithContext(ctx)).First(&row, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return mapper.ChangeRequestDBToDomain(&row), nil
+}
+
+func (r *changeRequestRepository) Lock(ctx context.Context, id string) error {
+	var row db.ChangeRequest
+	err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&row, "id = ?", id).Error
+	return translateError(err)
+}
+
+func (r *changeRequestRepository) Create(ctx context.Context, request *domain.ChangeRequest) error {
+	row := mapper.ChangeRequestDomainToDB(request)
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := createRow(tx, row); err != nil {
+			return err
+		}
+		for i := range row.Changes {
+			row.Changes[i].ChangeRequestID = row.ID
+			if err := createRow(tx, &row.Changes[i]); err != nil {
+				return err
+			}
+		}
+
+		request.ID = row.ID
+		request.CreatedAt = row.CreatedAt
+		request.UpdatedAt = row.UpdatedAt
+		return nil
+	})
+}
+
+func (r *changeRequestRepository) Update(ctx context.Context, request *domain.ChangeRequest) error {
+	row := mapper.ChangeRequestDomainToDB(request)
+	if err := updateRow(r.db.WithContext(ctx), row); err != nil {
+		return err
+	}
+
+	request.UpdatedAt = row.UpdatedAt
+	return nil
+}
+
+func (r *changeRequestRepository) Expire(ctx context.Context, at time.Time) error {
+	return r.db.WithContext(ctx).Model(&db.ChangeRequest{}).
+		Where("status IN ? AND expires_at <= ?", []string{string(domain.ChangeRequestStatusPending), string(domain.ChangeRequestStatusApproved)}, at).
+		Updates(map[string]interface{}{"status": string(domain.ChangeRequestStatusExpired), "updated_at": time.Now()}).Error
+}
+
+func (r *changeRequestRepository) AddApproval(ctx context.Context, approval *domain.ChangeRequestApproval) error {
+	row := mapper.ChangeRequestApprovalDomainToDB(approval)
+	if err := createRow(r.db.WithContext(ctx), row); err != nil {
+		return err
+	}
+
+	approval.ID = row.ID
+	approval.CreatedAt = row.CreatedAt
+	return nil
+}
+
+func (r *changeRequestRepository) AddComment(ctx context.Context, comment *domain.ChangeRequestComment) error {
+	row := mapper.ChangeRequestCommentDomainToDB(comment)
+	if err := createRow(r.db.WithContext(ctx), row); err != nil {
+		return err
+	}
+
+	comment.ID = row.ID
+	comment.CreatedAt = row.CreatedAt
+	return nil
+}
+
+// Helper function to build the change request query for a filter
+func (r *changeRequestRepository) query(ctx context.Context, filter repository.ChangeRequestFilter) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&db.ChangeRequest{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", string(filter.Status))
+	}
+	if filter.EnvironmentID != "" {
+		query = query.Where("environment_id = ?", filter.EnvironmentID)
+	}
+	if filter.AuthorID != 0 {
+		query = query.Where("author_id = ?", filter.AuthorID)
+	}
+	return query
+}
+
+// Helper function to preload the changes, approvals and comments of change requests in order
+func withChangeRequestRelations(query *gorm.DB) *gorm.DB {
+	return query.
+		Preload("Changes", func(tx *gorm.DB) *gorm.DB { return tx.Order("position ASC") }).
+		Preload("Approvals", orderByCreation).
+		Preload("Comments", orderByCreation)
+}
@@ -0,0 +1,88 @@
+package gormrepo
+
+import (
+	"context"
+
+	"release-management/internal/models/db"
+	"release-management/internal/models/domain"
+	"release-management/internal/models/mapper"
+	"release-management/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+type deploymentRepository struct {
+	db *gorm.DB
+}
+
+var deploymentSortColumns = map[string]sortColumn{
+	"created_at": {name: "created_at", time: true},
+}
+
+func (r *deploymentRepository) List(ctx context.Context, filter repository.DeploymentFilter) ([]domain.Deployment, error) {
+	query := r.query(ctx, filter).Preload("System").Preload("User")
+	if filter.OldestFirst {
+		query = query.Order("created_at ASC")
+	} else {
+		query = query.Order("created_at DESC")
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	var rows []db.Deployment
+	if err := query.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	deployments := make([]domain.Deployment, len(rows))
+	for i := range rows {
+		deployments[i] = *mapper.DeploymentDBToDomain(&rows[i])
+	}
+	return deployments, nil
+}
+
+func (r *deploymentRepository) ListPage(ctx context.Context, filter repository.DeploymentFilter, page repository.PageRequest) (*repository.Page[domain.Deployment], error) {
+	list := r.query(ctx, filter).Preload("System").Preload("User")
+	return listPage(r.query(ctx, filter), list, deploymentSortColumns, repository.DeploymentSortFields, page, mapper.DeploymentDBToDomain)
+}
+
+func (r *deploymentRepository) Get(ctx context.Context, id string) (*domain.Deployment, error) {
+	var row db.Deployment
+	if err := r.db.WithContext(ctx).Preload("System").Preload("User").First(&row, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return mapper.DeploymentDBToDomain(&row), nil
+}
+
+func (r *deploymentRepository) Create(ctx context.Context, deployment *domain.Deployment) error {
+	row := mapper.DeploymentDomainToDB(deployment)
+	if err := createRow(r.db.WithContext(ctx), row); err != nil {
+		return err
+	}
+
+	deployment.ID = row.ID
+	deployment.CreatedAt = row.CreatedAt
+	return nil
+}
+
+// Helper function to build the query for a filter
+func (r *deploymentRepository) query(ctx context.Context, filter repository.DeploymentFilter) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&db.Deployment{})
+	if filter.EnvironmentID != "" {
+		query = query.Where("environment_id = ?", filter.EnvironmentID)
+	}
+	if filter.SystemID != "" {
+		query = query.Where("system_id = ?", filter.SystemID)
+	}
+	if filter.Version != "" {
+		query = query.Where("(new_version = ? OR old_version = ?)", filter.Version, filter.Version)
+	}
+	if filter.Since != nil {
+		query = query.Where("created_at >= ?", *filter.Since)
+	}
+	if filter.Until != nil {
+		query = query.Where("created_at <= ?", *filter.Until)
+	}
+	return query
+}
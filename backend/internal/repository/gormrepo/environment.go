@@ -0,0 +1,150 @@
+package gormrepo
+
+import (
+	"context"
+
+	"release-management/internal/models/db"
+	"release-management/internal/models/domain"
+	"release-management/internal/models/mapper"
+	"release-management/internal/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type environmentRepository struct {
+	db *gorm.DB
+}
+
+var environmentSortColumns = map[string]sortColumn{
+	"created_at": {name: "created_at", time: true},
+	"name":       {name: "name"},
+}
+
+func (r *environmentRepository) List(ctx context.Context, filter repository.EnvironmentFilter) ([]domain.Environment, error) {
+	var rows []db.Environment
+	if err := r.query(ctx, filter).Order("created_at ASC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	environments := make([]domain.Environment, len(rows))
+	for i := range rows {
+		environments[i] = *mapper.EnvironmentDBToDomain(&rows[i])
+	}
+	return environments, nil
+}
+
+func (r *environmentRepository) ListPage(ctx context.Context, filter repository.EnvironmentFilter, page repository.PageRequest) (*repository.Page[domain.Environment], error) {
+	return listPage(r.query(ctx, filter), r.query(ctx, filter), environmentSortColumns, repository.EnvironmentSortFields, page, mapper.EnvironmentDBToDomain)
+}
+
+func (r *environmentRepository) Count(ctx context.Context, filter repository.EnvironmentFilter) (int64, error) {
+	var count int64
+	err := r.query(ctx, filter).Count(&count).Error
+	return count, err
+}
+
+func (r *environmentRepository) Get(ctx context.Context, id string) (*domain.Environment, error) {
+	var row db.Environment
+	if err := r.db.WithContext(ctx).First(&row, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return mapper.EnvironmentDBToDomain(&row), nil
+}
+
+func (r *environmentRepository) Create(ctx context.Context, environment *domain.Environment) error {
+	row := mapper.EnvironmentDomainToDB(environment)
+	if err := createRow(r.db.WithContext(ctx), row); err != nil {
+		return err
+	}
+
+	environment.ID = row.ID
+	environment.Status = domain.EnvironmentStatus(row.Status)
+	environment.CreatedAt = row.CreatedAt
+	environment.UpdatedAt = row.UpdatedAt
+	return nil
+}
+
+func (r *environmentRepository) Update(ctx context.Context, environment *domain.Environment) error {
+	row := mapper.EnvironmentDomainToDB(environment)
+	if err := updateRow(r.db.WithContext(ctx), row); err != nil {
+		return err
+	}
+
+	environment.UpdatedAt = row.UpdatedAt
+	return nil
+}
+
+func (r *environmentRepository) Delete(ctx context.Context, id string) error {
+	return deleteRows(r.db.WithContext(ctx), &db.Environment{}, "id = ?", id)
+}
+
+func (r *environmentRepository) ListSystems(ctx context.Context, environmentID string) ([]domain.EnvironmentSystem, error) {
+	var rows []db.EnvironmentSystem
+	if err := r.db.WithContext(ctx).Preload("System").Where("environment_id = ?", environmentID).Order("created_at ASC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	envSystems := make([]domain.EnvironmentSystem, len(rows))
+	for i := range rows {
+		envSystems[i] = *mapper.EnvironmentSystemDBToDomain(&rows[i])
+	}
+	return envSystems, nil
+}
+
+func (r *environmentRepository) GetSystem(ctx context.Context, environmentID, systemID string) (*domain.EnvironmentSystem, error) {
+	var row db.EnvironmentSystem
+	if err := r.db.WithContext(ctx).Preload("System").
+		Where("environment_id = ? AND system_id = ?", environmentID, systemID).First(&row).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return mapper.EnvironmentSystemDBToDomain(&row), nil
+}
+
+func (r *environmentRepository) AddSystem(ctx context.Context, envSystem *domain.EnvironmentSystem) error {
+	row := mapper.EnvironmentSystemDomainToDB(envSystem)
+	if row.ID == "" {
+		row.ID = uuid.New().String()
+	}
+	if err := createRow(r.db.WithContext(ctx), row); err != nil {
+		return err
+	}
+
+	envSystem.ID = row.ID
+	envSystem.Status = row.Status
+	envSystem.CreatedAt = row.CreatedAt
+	envSystem.UpdatedAt = row.UpdatedAt
+	return nil
+}
+
+func (r *environmentRepository) UpdateSystem(ctx context.Context, envSystem *domain.EnvironmentSystem) error {
+	row := mapper.EnvironmentSystemDomainToDB(envSystem)
+	if err := updateRow(r.db.WithContext(ctx), row); err != nil {
+		return err
+	}
+
+	envSystem.UpdatedAt = row.UpdatedAt
+	return nil
+}
+
+func (r *environmentRepository) RemoveSystem(ctx context.Context, environmentID, systemID string) error {
+	return deleteRows(r.db.WithContext(ctx), &db.EnvironmentSystem{}, "environment_id = ? AND system_id = ?", environmentID, systemID)
+}
+
+// Helper function to build the query for a filter
+func (r *environmentRepository) query(ctx context.Context, filter repository.EnvironmentFilter) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&db.Environment{})
+	if filter.ReleaseID != "" {
+		query = query.Where("release_id = ?", filter.ReleaseID)
+	}
+	if filter.EnvironmentGroupID != "" {
+		query = query.Where("environment_group_id = ?", filter.EnvironmentGroupID)
+	}
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	return query
+}
@@ -0,0 +1,133 @@
+package gormrepo
+
+import (
+	"context"
+	"errors"
+
+	"release-management/internal/models/db"
+	"release-management/internal/models/domain"
+	"release-management/internal/models/mapper"
+	"release-management/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+type environmentGroupRepository struct {
+	db *gorm.DB
+}
+
+var environmentGroupSortColumns = map[string]sortColumn{
+	"created_at": {name: "created_at", time: true},
+	"name":       {name: "name"},
+}
+
+func (r *environmentGroupRepository) List(ctx context.Context) ([]domain.EnvironmentGroup, error) {
+	var rows []db.EnvironmentGroup
+	if err := r.db.WithContext(ctx).Preload("Environments", orderByCreation).Order("created_at ASC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	groups := make([]domain.EnvironmentGroup, len(rows))
+	for i := range rows {
+		groups[i] = *mapper.EnvironmentGroupDBToDomain(&rows[i])
+	}
+	return groups, nil
+}
+
+func (r *environmentGroupRepository) ListPage(ctx context.Context, page repository.PageRequest) (*repository.Page[domain.EnvironmentGroup], error) {
+	count := r.db.WithContext(ctx).Model(&db.EnvironmentGroup{})
+	list := r.db.WithContext(ctx).Model(&db.EnvironmentGroup{}).Preload("Environments", orderByCreation)
+	return listPage(count, list, environmentGroupSortColumns, repository.EnvironmentGroupSortFields, page, mapper.EnvironmentGroupDBToDomain)
+}
+
+func (r *environmentGroupRepository) Get(ctx context.Context, id string) (*domain.EnvironmentGroup, error) {
+	var row db.EnvironmentGroup
+	if err := r.db.WithContext(ctx).Preload("Environments", orderByCreation).First(&row, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return mapper.EnvironmentGroupDBToDomain(&row), nil
+}
+
+func (r *environmentGroupRepository) Create(ctx context.Context, group *domain.EnvironmentGroup) error {
+	row := mapper.EnvironmentGroupDomainToDB(group)
+	if err := createRow(r.db.WithContext(ctx), row); err != nil {
+		return err
+	}
+
+	group.ID = row.ID
+	group.CreatedAt = row.CreatedAt
+	group.UpdatedAt = row.UpdatedAt
+	return nil
+}
+
+func (r *environmentGroupRepository) Update(ctx context.Context, group *domain.EnvironmentGroup) error {
+	row := mapper.EnvironmentGroupDomainToDB(group)
+	if err := updateRow(r.db.WithContext(ctx), row); err != nil {
+		return err
+	}
+
+	group.UpdatedAt = row.UpdatedAt
+	return nil
+}
+
+func (r *environmentGroupRepository) Delete(ctx context.Context, id string) error {
+	return deleteRows(r.db.WithContext(ctx), &db.EnvironmentGroup{}, "id = ?", id)
+}
+
+func (r *environmentGroupRepository) ListRoleGrants(ctx context.Context, groupID string) ([]domain.EnvironmentGroupRoleGrant, error) {
+	var rows []db.EnvironmentGroupRoleGrant
+	if err := r.db.WithContext(ctx).Preload("User").Where("environment_group_id = ?", groupID).Order("created_at ASC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	grants := make([]domain.EnvironmentGroupRoleGrant, len(rows))
+	for i := range rows {
+		grants[i] = *mapper.EnvironmentGroupRoleGrantDBToDomain(&rows[i])
+	}
+	return grants, nil
+}
+
+func (r *environmentGroupRepository) GetRoleGrant(ctx context.Context, groupID string, userID uint) (*domain.EnvironmentGroupRoleGrant, error) {
+	var row db.EnvironmentGroupRoleGrant
+	if err := r.db.WithContext(ctx).Preload("User").
+		Where("environment_group_id = ? AND user_id = ?", groupID, userID).First(&row).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return mapper.EnvironmentGroupRoleGrantDBToDomain(&row), nil
+}
+
+func (r *environmentGroupRepository) SaveRoleGrant(ctx context.Context, grant *domain.EnvironmentGroupRoleGrant) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Replace the role of an existing grant for the user in this group
+		var row db.EnvironmentGroupRoleGrant
+		err := tx.Where("environment_group_id = ? AND user_id = ?", grant.EnvironmentGroupID, grant.UserID).First(&row).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			row = *mapper.EnvironmentGroupRoleGrantDomainToDB(grant)
+			if err := createRow(tx, &row); err != nil {
+				return err
+			}
+		case err != nil:
+			return err
+		default:
+			row.Role = string(grant.Role)
+			if err := updateRow(tx, &row); err != nil {
+				return err
+			}
+		}
+
+		grant.ID = row.ID
+		grant.CreatedAt = row.CreatedAt
+		grant.UpdatedAt = row.UpdatedAt
+		return nil
+	})
+}
+
+func (r *environmentGroupRepository) DeleteRoleGrant(ctx context.Context, groupID string, userID uint) error {
+	return deleteRows(r.db.WithContext(ctx), &db.EnvironmentGroupRoleGrant{}, "environment_group_id = ? AND user_id = ?", groupID, userID)
+}
+
+// Helper function to order preloaded relationships oldest first
+func orderByCreation(tx *gorm.DB) *gorm.DB {
+	return tx.Order("created_at ASC")
+}
@@ -0,0 +1,62 @@
+package gormrepo
+
+import (
+	"context"
+	"slices"
+
+	"release-management/internal/models/db"
+	"release-management/internal/models/domain"
+	"release-management/internal/models/mapper"
+
+	"gorm.io/gorm"
+)
+
+type eventRepository struct {
+	db *gorm.DB
+}
+
+func (r *eventRepository) Create(ctx context.Context, event *domain.Event) error {
+	row := mapper.EventDomainToDB(event)
+	if err := createRow(r.db.WithContext(ctx), row); err != nil {
+		return err
+	}
+
+	event.ID = row.ID
+	event.Sequence = row.Sequence
+	event.CreatedAt = row.CreatedAt
+	return nil
+}
+
+func (r *eventRepository) Get(ctx context.Context, id string) (*domain.Event, error) {
+	var row db.Event
+	if err := r.db.WithContext(ctx).First(&row, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return mapper.EventDBToDomain(&row), nil
+}
+
+func (r *eventRepository) ListAfter(ctx context.Context, after int64, limit int) ([]domain.Event, error) {
+	var rows []db.Event
+	if err := r.db.WithContext(ctx).Where("sequence > ?", after).Order("sequence").Limit(limit).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return eventsDBToDomain(rows), nil
+}
+
+func (r *eventRepository) ListLatest(ctx context.Context, limit int) ([]domain.Event, error) {
+	var rows []db.Event
+	if err := r.db.WithContext(ctx).Order("sequence DESC").Limit(limit).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	slices.Reverse(rows)
+	return eventsDBToDomain(rows), nil
+}
+
+// Helper function to convert event rows to domain events
+func eventsDBToDomain(rows []db.Event) []domain.Event {
+	events := make([]domain.Event, len(rows))
+	for i := range rows {
+		events[i] = *mapper.EventDBToDomain(&rows[i])
+	}
+	return events
+}
@@ -0,0 +1,102 @@
+package gormrepo
+
+import (
+	"context"
+
+	"release-management/internal/models/db"
+	"release-management/internal/models/domain"
+	"release-management/internal/models/mapper"
+	"release-management/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+type freezeWindowRepository struct {
+	db *gorm.DB
+}
+
+var freezeOverrideSortColumns = map[string]sortColumn{
+	"created_at": {name: "created_at", time: true},
+}
+
+func (r *freezeWindowRepository) List(ctx context.Context, filter repository.FreezeWindowFilter) ([]domain.FreezeWindow, error) {
+	query := r.db.WithContext(ctx)
+	if filter.Since != nil {
+		query = query.Where("ends_at > ?", *filter.Since)
+	}
+	if filter.Until != nil {
+		query = query.Where("starts_at < ?", *filter.Until)
+	}
+
+	var rows []db.FreezeWindow
+	if err := query.Order("starts_at ASC, created_at ASC, id ASC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	windows := make([]domain.FreezeWindow, len(rows))
+	for i := range rows {
+		windows[i] = *mapper.FreezeWindowDBToDomain(&rows[i])
+	}
+	return windows, nil
+}
+
+func (r *freezeWindowRepository) Get(ctx context.Context, id string) (*domain.FreezeWindow, error) {
+	var row db.FreezeWindow
+	if err := r.db.WithContext(ctx).First(&row, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return mapper.FreezeWindowDBToDomain(&row), nil
+}
+
+func (r *freezeWindowRepository) Create(ctx context.Context, window *domain.FreezeWindow) error {
+	row := mapper.FreezeWindowDomainToDB(window)
+	if err := createRow(r.db.WithContext(ctx), row); err != nil {
+		return err
+	}
+
+	window.ID = row.ID
+	window.CreatedAt = row.CreatedAt
+	window.UpdatedAt = row.UpdatedAt
+	return nil
+}
+
+func (r *freezeWindowRepository) Update(ctx context.Context, window *domain.FreezeWindow) error {
+	row := mapper.FreezeWindowDomainToDB(window)
+	if err := updateRow(r.db.WithContext(ctx), row); err != nil {
+		return err
+	}
+
+	window.UpdatedAt = row.UpdatedAt
+	return nil
+}
+
+func (r *freezeWindowRepository) Delete(ctx context.Context, id string) error {
+	return deleteRows(r.db.WithContext(ctx), &db.FreezeWindow{}, "id = ?", id)
+}
+
+func (r *freezeWindowRepository) CreateOverride(ctx context.Context, override *domain.FreezeOverride) error {
+	row := mapper.FreezeOverrideDomainToDB(override)
+	if err := createRow(r.db.WithContext(ctx), row); err != nil {
+		return err
+	}
+
+	override.ID = row.ID
+	override.CreatedAt = row.CreatedAt
+	return nil
+}
+
+func (r *freezeWindowRepository) ListOverrides(ctx context.Context, filter repository.FreezeOverrideFilter, page repository.PageRequest) (*repository.Page[domain.FreezeOverride], error) {
+	return listPage(r.overrideQuery(ctx, filter), r.overrideQuery(ctx, filter), freezeOverrideSortColumns, repository.FreezeOverrideSortFields, page, mapper.FreezeOverrideDBToDomain)
+}
+
+// Helper function to build the override query for a filter
+func (r *freezeWindowRepository) overrideQuery(ctx context.Context, filter repository.FreezeOverrideFilter) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&db.FreezeOverride{})
+	if filter.FreezeWindowID != "" {
+		query = query.Where("freeze_window_id = ?", filter.FreezeWindowID)
+	}
+	if filter.EnvironmentID != "" {
+		query = query.Where("environment_id = ?", filter.EnvironmentID)
+	}
+	return query
+}
@@ -0,0 +1,123 @@
+package gormrepo
+
+import (
+	"context"
+
+	"release-management/internal/models/db"
+	"release-management/internal/models/domain"
+	"release-management/internal/models/mapper"
+	"release-management/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+type releaseRepository struct {
+	db *gorm.DB
+}
+
+var releaseSortColumns = map[string]sortColumn{
+	"created_at":   {name: "created_at", time: true},
+	"name":         {name: "name"},
+	"release_date": {name: "release_date", time: true},
+}
+
+func (r *releaseRepository) List(ctx context.Context) ([]domain.Release, error) {
+	var rows []db.Release
+	if err := r.db.WithContext(ctx).Order("created_at ASC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	releases := make([]domain.Release, len(rows))
+	for i := range rows {
+		releases[i] = *mapper.ReleaseDBToDomain(&rows[i])
+	}
+	return releases, nil
+}
+
+func (r *releaseRepository) ListPage(ctx context.Context, filter repository.ReleaseFilter, page repository.PageRequest) (*repository.Page[domain.Release], error) {
+	return listPage(r.query(ctx, filter), r.query(ctx, filter), releaseSortColumns, repository.ReleaseSortFields, page, mapper.ReleaseDBToDomain)
+}
+
+func (r *releaseRepository) Get(ctx context.Context, id string) (*domain.Release, error) {
+	var row db.Release
+	if err := r.db.WithContext(ctx).First(&row, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return mapper.ReleaseDBToDomain(&row), nil
+}
+
+func (r *releaseRepository) Create(ctx context.Context, release *domain.Release) error {
+	row := mapper.ReleaseDomainToDB(release)
+	if err := createRow(r.db.WithContext(ctx), row); err != nil {
+		return err
+	}
+
+	release.ID = row.ID
+	release.Status = domain.ReleaseStatus(row.Status)
+	release.CreatedAt = row.CreatedAt
+	release.UpdatedAt = row.UpdatedAt
+	return nil
+}
+
+func (r *releaseRepository) Update(ctx context.Context, release *domain.Release) error {
+	row := mapper.ReleaseDomainToDB(release)
+	if err := updateRow(r.db.WithContext(ctx), row); err != nil {
+		return err
+	}
+
+	release.UpdatedAt = row.UpdatedAt
+	return nil
+}
+
+func (r *releaseRepository) Delete(ctx context.Context, id string) error {
+	return deleteRows(r.db.WithContext(ctx), &db.Release{}, "id = ?", id)
+}
+
+func (r *releaseRepository) Transition(ctx context.Context, transition *domain.ReleaseTransition) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Only move the release if nobody else changed its status in the meantime
+		result := tx.Model(&db.Release{}).
+			Where("id = ? AND status = ?", transition.ReleaseID, string(transition.FromStatus)).
+			Update("status", string(transition.ToStatus))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return repository.ErrConflict
+		}
+
+		row := mapper.ReleaseTransitionDomainToDB(transition)
+		if err := createRow(tx, row); err != nil {
+			return err
+		}
+
+		transition.ID = row.ID
+		transition.CreatedAt = row.CreatedAt
+		return nil
+	})
+}
+
+func (r *releaseRepository) ListTransitions(ctx context.Context, releaseID string) ([]domain.ReleaseTransition, error) {
+	var rows []db.ReleaseTransition
+	if err := r.db.WithContext(ctx).Preload("User").Where("release_id = ?", releaseID).Order("created_at ASC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	transitions := make([]domain.ReleaseTransition, len(rows))
+	for i := range rows {
+		transitions[i] = *mapper.ReleaseTransitionDBToDomain(&rows[i])
+	}
+	return transitions, nil
+}
+
+// Helper function to build the query for a filter
+func (r *releaseRepository) query(ctx context.Context, filter repository.ReleaseFilter) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&db.Release{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+	return query
+}
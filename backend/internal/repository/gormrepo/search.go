@@ -0,0 +1,141 @@
+package gormrepo
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"release-management/internal/models/domain"
+	"release-management/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+type searchRepository struct {
+	db *gorm.DB
+}
+
+// headlineOptions makes ts_headline mark matches like domain.HighlightStart and domain.HighlightStop
+const headlineOptions = "StartSel=" + domain.HighlightStart + ", StopSel=" + domain.HighlightStop + ", MaxFragments=2, MaxWords=20, MinWords=5"
+
+// searchQueries select the matches of each type for the tsquery $1 with the columns of searchRow.
+// Highlighted columns are prefixed with "hl_" followed by the name of the field.
+var searchQueries = map[domain.SearchResultType]string{
+	domain.SearchResultRelease: `
+		SELECT r.id, r.name AS title, r.status AS subtitle, ts_rank(r.search_vector, q) AS rank,
+			ts_headline('simple', r.name, q, @options) AS hl_name,
+			ts_headline('simple', coalesce(r.description, ''), q, @options) AS hl_description
+		FROM releases r, to_tsquery('simple', @query) q
+		WHERE r.search_vector @@ q`,
+	domain.SearchResultSystem: `
+		SELECT s.id, s.name AS title, s.type AS subtitle, ts_rank(s.search_vector, q) AS rank,
+			ts_headline('simple', s.name, q, @options) AS hl_name,
+			ts_headline('simple', coalesce(s.description, ''), q, @options) AS hl_description
+		FROM systems s, to_tsquery('simple', @query) q
+		WHERE s.search_vector @@ q`,
+	// Builds also match on the name of their system and release, so "payments hotfix" finds the payments build of a hotfix
+	domain.SearchResultBuild: `
+		SELECT b.id, s.name || ' ' || b.version AS title, coalesce(rel.name, '') AS subtitle,
+			ts_rank(b.search_vector || setweight(to_tsvector('simple', s.name), 'A') || setweight(to_tsvector('simple', coalesce(rel.name, '')), 'A'), q) AS rank,
+			ts_headline('simple', b.version, q, @options) AS hl_version,
+			ts_headline('simple', s.name, q, @options) AS hl_system,
+			ts_headline('simple', coalesce(rel.name, ''), q, @options) AS hl_release
+		FROM builds b
+		JOIN systems s ON s.id = b.system_id
+		LEFT JOIN releases rel ON rel.id = b.release_id,
+		to_tsquery('simple', @query) q
+		WHERE (b.search_vector || to_tsvector('simple', s.name) || to_tsvector('simple', coalesce(rel.name, ''))) @@ q`,
+	domain.SearchResultEnvironment: `
+		SELECT e.id, e.name AS title, e.type AS subtitle, ts_rank(e.search_vector, q) AS rank,
+			ts_headline('simple', e.name, q, @options) AS hl_name,
+			ts_headline('simple', coalesce(e.url, ''), q, @options) AS hl_url,
+			ts_headline('simple', coalesce(e.description, ''), q, @options) AS hl_description
+		FROM environments e, to_tsquery('simple', @query) q
+		WHERE e.search_vector @@ q`,
+}
+
+// searchRow is a match of one of searchQueries
+type searchRow struct {
+	ID            string
+	Title         string
+	Subtitle      string
+	Rank          float64
+	HlName        string
+	HlDescription string
+	HlVersion     string
+	HlSystem      string
+	HlRelease     string
+	HlURL         string
+}
+
+func (r *searchRepository) Search(ctx context.Context, query repository.SearchQuery) ([]domain.SearchResult, error) {
+	tsQuery := prefixQuery(query.Terms)
+	if tsQuery == "" {
+		return nil, nil
+	}
+
+	var results []domain.SearchResult
+	for _, resultType := range domain.SearchResultTypes {
+		if len(query.Types) > 0 && !slices.Contains(query.Types, resultType) {
+			continue
+		}
+
+		sql := searchQueries[resultType] + " ORDER BY rank DESC, id"
+		if query.Limit > 0 {
+			sql += " LIMIT " + strconv.Itoa(query.Limit)
+		}
+
+		var rows []searchRow
+		if err := r.db.WithContext(ctx).Raw(sql, map[string]interface{}{"query": tsQuery, "options": headlineOptions}).Scan(&rows).Error; err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			results = append(results, searchResult(resultType, &row))
+		}
+	}
+
+	// Each type was limited on its own, so merge them by rank before cutting the combined list
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Rank != results[j].Rank {
+			return results[i].Rank > results[j].Rank
+		}
+		return results[i].ID < results[j].ID
+	})
+	if query.Limit > 0 && len(results) > query.Limit {
+		results = results[:query.Limit]
+	}
+	return results, nil
+}
+
+// Helper function to build a tsquery that matches every term as a word prefix.
+// Terms are quoted so that characters with a meaning in tsquery syntax are searched for literally.
+func prefixQuery(terms []string) string {
+	var parts []string
+	for _, term := range terms {
+		term = strings.NewReplacer(`'`, "", `\`, "").Replace(strings.TrimSpace(term))
+		if term != "" {
+			parts = append(parts, "'"+term+"':*")
+		}
+	}
+	return strings.Join(parts, " & ")
+}
+
+// Helper function to convert a search row, keeping only the highlights of fields that matched
+func searchResult(resultType domain.SearchResultType, row *searchRow) domain.SearchResult {
+	result := domain.SearchResult{Type: resultType, ID: row.ID, Title: row.Title, Subtitle: row.Subtitle, Rank: row.Rank, Highlights: map[string]string{}}
+	for field, value := range map[string]string{
+		"name":        row.HlName,
+		"description": row.HlDescription,
+		"version":     row.HlVersion,
+		"system":      row.HlSystem,
+		"release":     row.HlRelease,
+		"url":         row.HlURL,
+	} {
+		if strings.Contains(value, domain.HighlightStart) {
+			result.Highlights[field] = value
+		}
+	}
+	return result
+}
@@ -0,0 +1,197 @@
+// Package gormrepo implements the repositories on top of GORM and Postgres.
+package gormrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"release-management/internal/repository"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Store implements repository.Store with a GORM connection.
+// The connection must be opened with TranslateError so that unique violations are reported as repository.ErrDuplicate.
+type Store struct {
+	db *gorm.DB
+}
+
+// NewStore creates a store that reads and writes through db
+func NewStore(db *gorm.DB) *Store {
+	return &Store{db: db}
+}
+
+func (s *Store) Releases() repository.ReleaseRepository {
+	return &releaseRepository{db: s.db}
+}
+
+func (s *Store) Builds() repository.BuildRepository {
+	return &buildRepository{db: s.db}
+}
+
+func (s *Store) Systems() repository.SystemRepository {
+	return &systemRepository{db: s.db}
+}
+
+func (s *Store) Environments() repository.EnvironmentRepository {
+	return &environmentRepository{db: s.db}
+}
+
+func (s *Store) EnvironmentGroups() repository.EnvironmentGroupRepository {
+	return &environmentGroupRepository{db: s.db}
+}
+
+func (s *Store) Deployments() repository.DeploymentRepository {
+	return &deploymentRepository{db: s.db}
+}
+
+func (s *Store) Users() repository.UserRepository {
+	return &userRepository{db: s.db}
+}
+
+func (s *Store) APITokens() repository.APITokenRepository {
+	return &apiTokenRepository{db: s.db}
+}
+
+func (s *Store) Audit() repository.AuditRepository {
+	return &auditRepository{db: s.db}
+}
+
+func (s *Store) WebhookDeliveries() repository.WebhookDeliveryRepository {
+	return &webhookDeliveryRepository{db: s.db}
+}
+
+func (s *Store) WebhookSubscriptions() repository.WebhookSubscriptionRepository {
+	return &webhookSubscriptionRepository{db: s.db}
+}
+
+func (s *Store) FreezeWindows() repository.FreezeWindowRepository {
+	return &freezeWindowRepository{db: s.db}
+}
+
+func (s *Store) ChangeRequests() repository.ChangeRequestRepository {
+	return &changeRequestRepository{db: s.db}
+}
+
+func (s *Store) Events() repository.EventRepository {
+	return &eventRepository{db: s.db}
+}
+
+func (s *Store) Search() repository.SearchRepository {
+	return &searchRepository{db: s.db}
+}
+
+// Transaction runs fn in a database transaction. Nested transactions use savepoints.
+func (s *Store) Transaction(ctx context.Context, fn func(tx repository.Store) error) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(NewStore(tx))
+	})
+}
+
+// Helper function to translate GORM errors to repository errors
+func translateError(err error) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return repository.ErrNotFound
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return repository.ErrDuplicate
+	}
+	return err
+}
+
+// Helper function to insert a row without touching its relationships
+func createRow(tx *gorm.DB, row interface{}) error {
+	return translateError(tx.Omit(clause.Associations).Create(row).Error)
+}
+
+// Helper function to write every column of an existing row, returning ErrNotFound if there is none
+func updateRow(tx *gorm.DB, row interface{}) error {
+	result := tx.Model(row).Select("*").Omit("created_at", clause.Associations).Updates(row)
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// Helper function to delete the rows matching a condition, returning ErrNotFound if there are none
+func deleteRows(tx *gorm.DB, model interface{}, query string, args ...interface{}) error {
+	result := tx.Where(query, args...).Delete(model)
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// sortColumn is the column behind a field of repository.SortFields
+type sortColumn struct {
+	name string
+	// time is set for timestamp columns, whose cursor keys are parsed with repository.ParseTimeKey
+	time bool
+}
+
+// Helper function to load the page selected by page, counting the matching rows with count and loading them with list.
+// Both queries must apply the same filter, but only list may preload relationships.
+func listPage[R any, T any](count, list *gorm.DB, columns map[string]sortColumn, fields repository.SortFields[T], page repository.PageRequest, convert func(*R) *T) (*repository.Page[T], error) {
+	column, ok := columns[page.Sort.Field]
+	if !ok {
+		return nil, fmt.Errorf("cannot sort by %q", page.Sort.Field)
+	}
+
+	result := &repository.Page[T]{}
+	if err := count.Count(&result.Total).Error; err != nil {
+		return nil, err
+	}
+
+	direction, after := "ASC", ">"
+	if page.Sort.Desc {
+		direction, after = "DESC", "<"
+	}
+
+	// Continue after the cursor with a keyset condition so that deep pages stay as fast as the first one
+	if page.Cursor != "" {
+		cursor, err := repository.DecodeCursor(page.Cursor, page.Sort)
+		if err != nil {
+			return nil, err
+		}
+		var key interface{} = cursor.Key
+		if column.time {
+			if key, err = repository.ParseTimeKey(cursor.Key); err != nil {
+				return nil, err
+			}
+		}
+		list = list.Where(fmt.Sprintf("(%[1]s %[2]s ? OR (%[1]s = ? AND id %[2]s ?))", column.name, after), key, key, cursor.ID)
+	}
+
+	list = list.Order(fmt.Sprintf("%s %s, id %s", column.name, direction, direction))
+	// Load one extra row to find out whether there is a next page
+	if page.Limit > 0 {
+		list = list.Limit(page.Limit + 1)
+	}
+
+	var rows []R
+	if err := list.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	hasMore := page.Limit > 0 && len(rows) > page.Limit
+	if hasMore {
+		rows = rows[:page.Limit]
+	}
+
+	result.Items = make([]T, len(rows))
+	for i := range rows {
+		result.Items[i] = *convert(&rows[i])
+	}
+	if hasMore {
+		result.NextCursor = fields.CursorAfter(&result.Items[len(result.Items)-1], page.Sort)
+	}
+	return result, nil
+}
@@ -0,0 +1,45 @@
+package gormrepo_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"release-management/internal/database"
+	"release-management/internal/repository"
+	"release-management/internal/repository/gormrepo"
+	"release-management/internal/repository/repositorytest"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// The contract suite needs a disposable Postgres database, e.g.
+// TEST_DATABASE_DSN="host=localhost user=postgres password=postgres dbname=release_test sslmode=disable" go test ./internal/repository/...
+func TestStore(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_DSN is not set")
+	}
+
+	conn, err := gorm.Open(postgres.Open(dsn), &gorm.Config{TranslateError: true, Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("failed to connect to database: %v", err)
+	}
+	database.DB = conn
+	if _, err := database.MigrateUp(context.Background()); err != nil {
+		t.Fatalf("failed to migrate database: %v", err)
+	}
+
+	repositorytest.TestStore(t, func(t *testing.T) repository.Store {
+		// Every subtest starts from empty tables
+		err := conn.Exec(`TRUNCATE change_request_comments, change_request_approvals, change_request_changes, change_requests, freeze_overrides, freeze_windows, event_deliveries, webhook_subscriptions, events, audit_entries, webhook_deliveries, api_tokens, environment_group_role_grants,
+			deployments, release_transitions, environment_systems, environments, environment_groups,
+			builds, systems, releases, users RESTART IDENTITY CASCADE`).Error
+		if err != nil {
+			t.Fatalf("failed to truncate tables: %v", err)
+		}
+		return gormrepo.NewStore(conn)
+	})
+}
@@ -0,0 +1,107 @@
+package gormrepo
+
+import (
+	"context"
+
+	"release-management/internal/models/db"
+	"release-management/internal/models/domain"
+	"release-management/internal/models/mapper"
+	"release-management/internal/repository"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type systemRepository struct {
+	db *gorm.DB
+}
+
+var systemSortColumns = map[string]sortColumn{
+	"created_at": {name: "created_at", time: true},
+	"name":       {name: "name"},
+}
+
+func (r *systemRepository) List(ctx context.Context, filter repository.SystemFilter) ([]domain.System, error) {
+	var rows []db.System
+	if err := r.query(ctx, filter).Order("created_at ASC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	systems := make([]domain.System, len(rows))
+	for i := range rows {
+		systems[i] = *mapper.SystemDBToDomain(&rows[i])
+	}
+	return systems, nil
+}
+
+func (r *systemRepository) ListPage(ctx context.Context, filter repository.SystemFilter, page repository.PageRequest) (*repository.Page[domain.System], error) {
+	return listPage(r.query(ctx, filter), r.query(ctx, filter), systemSortColumns, repository.SystemSortFields, page, mapper.SystemDBToDomain)
+}
+
+func (r *systemRepository) Count(ctx context.Context, filter repository.SystemFilter) (int64, error) {
+	var count int64
+	err := r.query(ctx, filter).Count(&count).Error
+	return count, err
+}
+
+func (r *systemRepository) Get(ctx context.Context, id string) (*domain.System, error) {
+	var row db.System
+	if err := r.db.WithContext(ctx).Preload("Parent").First(&row, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return mapper.SystemDBToDomain(&row), nil
+}
+
+func (r *systemRepository) Lock(ctx context.Context, id string) error {
+	var row db.System
+	err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&row, "id = ?", id).Error
+	return translateError(err)
+}
+
+func (r *systemRepository) Create(ctx context.Context, system *domain.System) error {
+	row := mapper.SystemDomainToDB(system)
+	if err := createRow(r.db.WithContext(ctx), row); err != nil {
+		return err
+	}
+
+	system.ID = row.ID
+	system.Status = domain.SystemStatus(row.Status)
+	system.CreatedAt = row.CreatedAt
+	system.UpdatedAt = row.UpdatedAt
+	return nil
+}
+
+func (r *systemRepository) Update(ctx context.Context, system *domain.System) error {
+	row := mapper.SystemDomainToDB(system)
+	if err := updateRow(r.db.WithContext(ctx), row); err != nil {
+		return err
+	}
+
+	system.UpdatedAt = row.UpdatedAt
+	return nil
+}
+
+func (r *systemRepository) Delete(ctx context.Context, id string) error {
+	return deleteRows(r.db.WithContext(ctx), &db.System{}, "id = ?", id)
+}
+
+// Helper function to build the query for a filter
+func (r *systemRepository) query(ctx context.Context, filter repository.SystemFilter) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&db.System{})
+	if filter.ParentID != "" {
+		query = query.Where("parent_id = ?", filter.ParentID)
+	}
+	if filter.Name != "" {
+		query = query.Where("name = ?", filter.Name)
+	}
+	if len(filter.IDs) > 0 {
+		query = query.Where("id IN ?", filter.IDs)
+	}
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	return query
+}
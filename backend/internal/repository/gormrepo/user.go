@@ -0,0 +1,67 @@
+package gormrepo
+
+import (
+	"context"
+
+	"release-management/internal/models/db"
+	"release-management/internal/models/domain"
+	"release-management/internal/models/mapper"
+
+	"gorm.io/gorm"
+)
+
+type userRepository struct {
+	db *gorm.DB
+}
+
+func (r *userRepository) List(ctx context.Context) ([]domain.User, error) {
+	var rows []db.User
+	if err := r.db.WithContext(ctx).Order("email ASC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	users := make([]domain.User, len(rows))
+	for i := range rows {
+		users[i] = *mapper.UserDBToDomain(&rows[i])
+	}
+	return users, nil
+}
+
+func (r *userRepository) Get(ctx context.Context, id uint) (*domain.User, error) {
+	var row db.User
+	if err := r.db.WithContext(ctx).First(&row, id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return mapper.UserDBToDomain(&row), nil
+}
+
+func (r *userRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	var row db.User
+	if err := r.db.WithContext(ctx).Where("email = ?", email).First(&row).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return mapper.UserDBToDomain(&row), nil
+}
+
+func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
+	row := mapper.UserDomainToDB(user)
+	if err := createRow(r.db.WithContext(ctx), row); err != nil {
+		return err
+	}
+
+	user.ID = row.ID
+	user.Role = domain.Role(row.Role)
+	user.CreatedAt = row.CreatedAt
+	user.UpdatedAt = row.UpdatedAt
+	return nil
+}
+
+func (r *userRepository) Update(ctx context.Context, user *domain.User) error {
+	row := mapper.UserDomainToDB(user)
+	if err := updateRow(r.db.WithContext(ctx), row); err != nil {
+		return err
+	}
+
+	user.UpdatedAt = row.UpdatedAt
+	return nil
+}
@@ -0,0 +1,34 @@
+package gormrepo
+
+import (
+	"context"
+
+	"release-management/internal/models/db"
+	"release-management/internal/models/domain"
+	"release-management/internal/models/mapper"
+
+	"gorm.io/gorm"
+)
+
+type webhookDeliveryRepository struct {
+	db *gorm.DB
+}
+
+func (r *webhookDeliveryRepository) Get(ctx context.Context, provider, deliveryID string) (*domain.WebhookDelivery, error) {
+	var row db.WebhookDelivery
+	if err := r.db.WithContext(ctx).Where("provider = ? AND delivery_id = ?", provider, deliveryID).First(&row).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return mapper.WebhookDeliveryDBToDomain(&row), nil
+}
+
+func (r *webhookDeliveryRepository) Create(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	row := mapper.WebhookDeliveryDomainToDB(delivery)
+	if err := createRow(r.db.WithContext(ctx), row); err != nil {
+		return err
+	}
+
+	delivery.ID = row.ID
+	delivery.CreatedAt = row.CreatedAt
+	return nil
+}
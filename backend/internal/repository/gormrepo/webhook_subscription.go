@@ -0,0 +1,171 @@
+package gormrepo
+
+import (
+	"context"
+	"time"
+
+	"release-management/internal/models/db"
+	"release-management/internal/models/domain"
+	"release-management/internal/models/mapper"
+	"release-management/internal/repository"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type webhookSubscriptionRepository struct {
+	db *gorm.DB
+}
+
+var eventDeliverySortColumns = map[string]sortColumn{
+	"created_at": {name: "created_at", time: true},
+}
+
+func (r *webhookSubscriptionRepository) List(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	var rows []db.WebhookSubscription
+	if err := r.db.WithContext(ctx).Order("created_at ASC, id ASC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	subscriptions := make([]domain.WebhookSubscription, len(rows))
+	for i := range rows {
+		subscriptions[i] = *mapper.WebhookSubscriptionDBToDomain(&rows[i])
+	}
+	return subscriptions, nil
+}
+
+func (r *webhookSubscriptionRepository) Get(ctx context.Context, id string) (*domain.WebhookSubscription, error) {
+	var row db.WebhookSubscription
+	if err := r.db.WithContext(ctx).First(&row, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return mapper.WebhookSubscriptionDBToDomain(&row), nil
+}
+
+func (r *webhookSubscriptionRepository) Create(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	row := mapper.WebhookSubscriptionDomainToDB(subscription)
+	if err := createRow(r.db.WithContext(ctx), row); err != nil {
+		return err
+	}
+
+	subscription.ID = row.ID
+	subscription.CreatedAt = row.CreatedAt
+	subscription.UpdatedAt = row.UpdatedAt
+	return nil
+}
+
+func (r *webhookSubscriptionRepository) Update(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	row := mapper.WebhookSubscriptionDomainToDB(subscription)
+	if err := updateRow(r.db.WithContext(ctx), row); err != nil {
+		return err
+	}
+
+	subscription.UpdatedAt = row.UpdatedAt
+	return nil
+}
+
+func (r *webhookSubscriptionRepository) Delete(ctx context.Context, id string) error {
+	// Deliveries are removed with the subscription by the foreign key
+	return deleteRows(r.db.WithContext(ctx), &db.WebhookSubscription{}, "id = ?", id)
+}
+
+func (r *webhookSubscriptionRepository) CreateDelivery(ctx context.Context, delivery *domain.EventDelivery) error {
+	row := mapper.EventDeliveryDomainToDB(delivery)
+	if err := createRow(r.db.WithContext(ctx), row); err != nil {
+		return err
+	}
+
+	delivery.ID = row.ID
+	delivery.CreatedAt = row.CreatedAt
+	delivery.UpdatedAt = row.UpdatedAt
+	return nil
+}
+
+func (r *webhookSubscriptionRepository) GetDelivery(ctx context.Context, id string) (*domain.EventDelivery, error) {
+	var row db.EventDelivery
+	if err := r.db.WithContext(ctx).Preload("Event").First(&row, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return mapper.EventDeliveryDBToDomain(&row), nil
+}
+
+func (r *webhookSubscriptionRepository) UpdateDelivery(ctx context.Context, delivery *domain.EventDelivery) error {
+	row := mapper.EventDeliveryDomainToDB(delivery)
+	if err := updateRow(r.db.WithContext(ctx), row); err != nil {
+		return err
+	}
+
+	delivery.UpdatedAt = row.UpdatedAt
+	return nil
+}
+
+func (r *webhookSubscriptionRepository) ListDeliveries(ctx context.Context, filter repository.EventDeliveryFilter, page repository.PageRequest) (*repository.Page[domain.EventDelivery], error) {
+	return listPage(r.deliveryQuery(ctx, filter), r.deliveryQuery(ctx, filter), eventDeliverySortColumns, repository.EventDeliverySortFields, page, mapper.EventDeliveryDBToDomain)
+}
+
+func (r *webhookSubscriptionRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.EventDelivery, error) {
+	var rows []db.EventDelivery
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Skip rows another dispatcher is claiming right now instead of waiting for it
+		query := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status IN ? AND next_attempt_at <= ?", []string{string(domain.EventDeliveryPending), string(domain.EventDeliveryRetrying)}, now).
+			Order("next_attempt_at ASC, id ASC")
+		if limit > 0 {
+			query = query.Limit(limit)
+		}
+		if err := query.Find(&rows).Error; err != nil || len(rows) == 0 {
+			return err
+		}
+
+		ids := make([]string, len(rows))
+		eventIDs := make([]string, len(rows))
+		for i := range rows {
+			ids[i] = rows[i].ID
+			eventIDs[i] = rows[i].EventID
+		}
+		leasedUntil := now.Add(lease)
+		// UpdateColumn skips the hooks so updated_at keeps reflecting the attempts themselves
+		if err := tx.Model(&db.EventDelivery{}).Where("id IN ?", ids).UpdateColumn("next_attempt_at", leasedUntil).Error; err != nil {
+			return err
+		}
+
+		// Events are loaded without the lock, several deliveries may send the same event
+		var events []db.Event
+		if err := tx.Find(&events, "id IN ?", eventIDs).Error; err != nil {
+			return err
+		}
+		byID := make(map[string]*db.Event, len(events))
+		for i := range events {
+			byID[events[i].ID] = &events[i]
+		}
+		for i := range rows {
+			rows[i].NextAttemptAt = &leasedUntil
+			rows[i].Event = byID[rows[i].EventID]
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]domain.EventDelivery, len(rows))
+	for i := range rows {
+		deliveries[i] = *mapper.EventDeliveryDBToDomain(&rows[i])
+	}
+	return deliveries, nil
+}
+
+// Helper function to build the delivery query for a filter
+func (r *webhookSubscriptionRepository) deliveryQuery(ctx context.Context, filter repository.EventDeliveryFilter) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&db.EventDelivery{})
+	if filter.SubscriptionID != "" {
+		query = query.Where("subscription_id = ?", filter.SubscriptionID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.EventType != "" {
+		query = query.Where("event_type = ?", filter.EventType)
+	}
+	return query
+}
@@ -0,0 +1,107 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"release-management/internal/models/domain"
+	"release-management/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+type apiTokenRepository struct {
+	s *Store
+}
+
+func (r *apiTokenRepository) ListByUser(ctx context.Context, userID uint) ([]domain.APIToken, error) {
+	defer r.s.lock()()
+	tokens := reversed(ordered(r.s.data.apiTokens, func(t *domain.APIToken) bool {
+		return t.UserID == userID
+	}))
+	for i := range tokens {
+		tokens[i].Scopes = slices.Clone(tokens[i].Scopes)
+	}
+	return tokens, nil
+}
+
+func (r *apiTokenRepository) Get(ctx context.Context, id string) (*domain.APIToken, error) {
+	defer r.s.lock()()
+	row, ok := r.s.data.apiTokens[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	row.value.Scopes = slices.Clone(row.value.Scopes)
+	return &row.value, nil
+}
+
+func (r *apiTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*domain.APIToken, error) {
+	defer r.s.lock()()
+	for _, row := range r.s.data.apiTokens {
+		if row.value.TokenHash == tokenHash {
+			row.value.Scopes = slices.Clone(row.value.Scopes)
+			return &row.value, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *apiTokenRepository) Create(ctx context.Context, token *domain.APIToken) error {
+	defer r.s.lock()()
+	if token.ID == "" {
+		token.ID = uuid.New().String()
+	}
+	if _, ok := r.s.data.apiTokens[token.ID]; ok || r.hashTaken(token.TokenHash, "") {
+		return repository.ErrDuplicate
+	}
+	stamp(&token.CreatedAt, &token.UpdatedAt)
+
+	r.s.data.apiTokens[token.ID] = entry[domain.APIToken]{seq: r.s.nextSeq(), createdAt: token.CreatedAt, value: storedAPIToken(token)}
+	return nil
+}
+
+func (r *apiTokenRepository) Update(ctx context.Context, token *domain.APIToken) error {
+	defer r.s.lock()()
+	row, ok := r.s.data.apiTokens[token.ID]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	if r.hashTaken(token.TokenHash, token.ID) {
+		return repository.ErrDuplicate
+	}
+
+	token.UpdatedAt = time.Now()
+	row.value = storedAPIToken(token)
+	row.value.CreatedAt = row.createdAt
+	r.s.data.apiTokens[token.ID] = row
+	return nil
+}
+
+func (r *apiTokenRepository) MarkUsed(ctx context.Context, id string, at time.Time) error {
+	defer r.s.lock()()
+	row, ok := r.s.data.apiTokens[id]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	row.value.LastUsedAt = &at
+	r.s.data.apiTokens[id] = row
+	return nil
+}
+
+// Helper function to check if another token than exceptID is stored under a hash
+func (r *apiTokenRepository) hashTaken(tokenHash, exceptID string) bool {
+	for id, row := range r.s.data.apiTokens {
+		if id != exceptID && row.value.TokenHash == tokenHash {
+			return true
+		}
+	}
+	return false
+}
+
+// Helper function to copy the scopes of a token before storing it
+func storedAPIToken(token *domain.APIToken) domain.APIToken {
+	stored := *token
+	stored.Scopes = slices.Clone(token.Scopes)
+	return stored
+}
@@ -0,0 +1,101 @@
+package memory
+
+import (
+	"context"
+	"maps"
+
+	"release-management/internal/models/domain"
+	"release-management/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+type auditRepository struct {
+	s *Store
+}
+
+func (r *auditRepository) Create(ctx context.Context, entry *domain.AuditEntry) error {
+	defer r.s.lock()()
+	if entry.ID == "" {
+		entry.ID = uuid.New().String()
+	}
+	if _, ok := r.s.data.auditEntries[entry.ID]; ok {
+		return repository.ErrDuplicate
+	}
+	stamp(&entry.CreatedAt, nil)
+
+	r.s.data.auditEntries[entry.ID] = auditEntry(r.s.nextSeq(), entry)
+	return nil
+}
+
+func (r *auditRepository) ListPage(ctx context.Context, filter repository.AuditFilter, page repository.PageRequest) (*repository.Page[domain.AuditEntry], error) {
+	defer r.s.lock()()
+	result, err := paginate(ordered(r.s.data.auditEntries, auditMatcher(filter)), repository.AuditSortFields, page)
+	if err != nil {
+		return nil, err
+	}
+	for i := range result.Items {
+		result.Items[i] = copyAuditEntry(&result.Items[i])
+	}
+	return result, nil
+}
+
+func (r *auditRepository) Each(ctx context.Context, filter repository.AuditFilter, fn func(entry *domain.AuditEntry) error) error {
+	// Collect the entries first so that fn can use the store without deadlocking
+	unlock := r.s.lock()
+	entries := ordered(r.s.data.auditEntries, auditMatcher(filter))
+	unlock()
+
+	for i := range entries {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		copied := copyAuditEntry(&entries[i])
+		if err := fn(&copied); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Helper function to match audit entries against a filter
+func auditMatcher(filter repository.AuditFilter) func(*domain.AuditEntry) bool {
+	return func(e *domain.AuditEntry) bool {
+		if filter.EntityType != "" && e.EntityType != filter.EntityType {
+			return false
+		}
+		if filter.EntityID != "" && e.EntityID != filter.EntityID {
+			return false
+		}
+		if filter.Action != "" && e.Action != filter.Action {
+			return false
+		}
+		if filter.ActorID != nil && (e.ActorID == nil || *e.ActorID != *filter.ActorID) {
+			return false
+		}
+		if filter.Actor != "" && e.Actor != filter.Actor {
+			return false
+		}
+		if filter.Since != nil && e.CreatedAt.Before(*filter.Since) {
+			return false
+		}
+		if filter.Until != nil && e.CreatedAt.After(*filter.Until) {
+			return false
+		}
+		return true
+	}
+}
+
+// Helper function to wrap a copy of an audit entry for storing it
+func auditEntry(seq int64, e *domain.AuditEntry) entry[domain.AuditEntry] {
+	return entry[domain.AuditEntry]{seq: seq, createdAt: e.CreatedAt, value: copyAuditEntry(e)}
+}
+
+// Helper function to copy the documents of an audit entry so callers cannot change stored entries
+func copyAuditEntry(e *domain.AuditEntry) domain.AuditEntry {
+	copied := *e
+	copied.Before = maps.Clone(e.Before)
+	copied.After = maps.Clone(e.After)
+	copied.Changes = maps.Clone(e.Changes)
+	return copied
+}
@@ -0,0 +1,141 @@
+package memory
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"release-management/internal/models/domain"
+	"release-management/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+type buildRepository struct {
+	s *Store
+}
+
+func (r *buildRepository) List(ctx context.Context, filter repository.BuildFilter) ([]domain.Build, error) {
+	defer r.s.lock()()
+	builds := ordered(r.s.data.builds, buildMatcher(filter))
+	for i := range builds {
+		r.resolve(&builds[i])
+	}
+	return builds, nil
+}
+
+func (r *buildRepository) ListPage(ctx context.Context, filter repository.BuildFilter, page repository.PageRequest) (*repository.Page[domain.Build], error) {
+	defer r.s.lock()()
+	result, err := paginate(ordered(r.s.data.builds, buildMatcher(filter)), repository.BuildSortFields, page)
+	if err != nil {
+		return nil, err
+	}
+	for i := range result.Items {
+		r.resolve(&result.Items[i])
+	}
+	return result, nil
+}
+
+func (r *buildRepository) Count(ctx context.Context, filter repository.BuildFilter) (int64, error) {
+	defer r.s.lock()()
+	return int64(len(ordered(r.s.data.builds, buildMatcher(filter)))), nil
+}
+
+func (r *buildRepository) Get(ctx context.Context, id string) (*domain.Build, error) {
+	defer r.s.lock()()
+	row, ok := r.s.data.builds[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	r.resolve(&row.value)
+	return &row.value, nil
+}
+
+func (r *buildRepository) FindByVersion(ctx context.Context, systemID, version string) (*domain.Build, error) {
+	defer r.s.lock()()
+	builds := ordered(r.s.data.builds, func(b *domain.Build) bool {
+		return b.SystemID == systemID && b.Version == version
+	})
+	if len(builds) == 0 {
+		return nil, repository.ErrNotFound
+	}
+	return &builds[0], nil
+}
+
+func (r *buildRepository) Create(ctx context.Context, build *domain.Build) error {
+	defer r.s.lock()()
+	if build.ID == "" {
+		build.ID = uuid.New().String()
+	}
+	if _, ok := r.s.data.builds[build.ID]; ok {
+		return repository.ErrDuplicate
+	}
+	stamp(&build.CreatedAt, &build.UpdatedAt)
+
+	r.s.data.builds[build.ID] = entry[domain.Build]{seq: r.s.nextSeq(), createdAt: build.CreatedAt, value: storedBuild(build)}
+	return nil
+}
+
+func (r *buildRepository) Update(ctx context.Context, build *domain.Build) error {
+	defer r.s.lock()()
+	row, ok := r.s.data.builds[build.ID]
+	if !ok {
+		return repository.ErrNotFound
+	}
+
+	build.UpdatedAt = time.Now()
+	row.value = storedBuild(build)
+	row.value.CreatedAt = row.createdAt
+	r.s.data.builds[build.ID] = row
+	return nil
+}
+
+func (r *buildRepository) Delete(ctx context.Context, id string) error {
+	defer r.s.lock()()
+	if _, ok := r.s.data.builds[id]; !ok {
+		return repository.ErrNotFound
+	}
+	delete(r.s.data.builds, id)
+	return nil
+}
+
+// Helper function to fill in the system and release of a build
+func (r *buildRepository) resolve(build *domain.Build) {
+	build.System = r.s.system(build.SystemID)
+	if build.ReleaseID != nil {
+		build.Release = r.s.release(*build.ReleaseID)
+	}
+}
+
+// Helper function to match builds against a filter
+func buildMatcher(filter repository.BuildFilter) func(*domain.Build) bool {
+	return func(b *domain.Build) bool {
+		if filter.SystemID != "" && b.SystemID != filter.SystemID {
+			return false
+		}
+		if filter.ReleaseID != "" && (b.ReleaseID == nil || *b.ReleaseID != filter.ReleaseID) {
+			return false
+		}
+		if filter.CommitSHA != "" && !strings.HasPrefix(b.CommitSHA, filter.CommitSHA) {
+			return false
+		}
+		if filter.ImageDigest != "" && b.ImageDigest != filter.ImageDigest {
+			return false
+		}
+		if filter.BuiltAfter != nil && b.BuildDate.Before(*filter.BuiltAfter) {
+			return false
+		}
+		if filter.BuiltBefore != nil && b.BuildDate.After(*filter.BuiltBefore) {
+			return false
+		}
+		return true
+	}
+}
+
+// Helper function to strip the relationships of a build before storing it
+func storedBuild(build *domain.Build) domain.Build {
+	stored := *build
+	stored.System = nil
+	stored.Release = nil
+	return stored
+}
@@ -0,0 +1,146 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"release-management/internal/models/domain"
+	"release-management/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+type changeRequestRepository struct {
+	s *Store
+}
+
+func (r *changeRequestRepository) List(ctx context.Context, filter repository.ChangeRequestFilter, page repository.PageRequest) (*repository.Page[domain.ChangeRequest], error) {
+	defer r.s.lock()()
+	requests := ordered(r.s.data.changeRequests, func(request *domain.ChangeRequest) bool {
+		return (filter.Status == "" || request.Status == filter.Status) &&
+			(filter.EnvironmentID == "" || request.EnvironmentID == filter.EnvironmentID) &&
+			(filter.AuthorID == 0 || request.AuthorID == filter.AuthorID)
+	})
+	for i := range requests {
+		requests[i] = r.withReviews(&requests[i])
+	}
+	return paginate(requests, repository.ChangeRequestSortFields, page)
+}
+
+func (r *changeRequestRepository) Get(ctx context.Context, id string) (*domain.ChangeRequest, error) {
+	defer r.s.lock()()
+	row, ok := r.s.data.changeRequests[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	request := r.withReviews(&row.value)
+	return &request, nil
+}
+
+// Lock only checks that the request exists, as transactions already hold the store lock until they end
+func (r *changeRequestRepository) Lock(ctx context.Context, id string) error {
+	defer r.s.lock()()
+	if _, ok := r.s.data.changeRequests[id]; !ok {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+func (r *changeRequestRepository) Create(ctx context.Context, request *domain.ChangeRequest) error {
+	defer r.s.lock()()
+	if request.ID == "" {
+		request.ID = uuid.New().String()
+	}
+	if _, ok := r.s.data.changeRequests[request.ID]; ok {
+		return repository.ErrDuplicate
+	}
+	stamp(&request.CreatedAt, &request.UpdatedAt)
+
+	r.s.data.changeRequests[request.ID] = entry[domain.ChangeRequest]{
+		seq: r.s.nextSeq(), createdAt: request.CreatedAt, value: storedChangeRequest(request),
+	}
+	return nil
+}
+
+func (r *changeRequestRepository) Update(ctx context.Context, request *domain.ChangeRequest) error {
+	defer r.s.lock()()
+	row, ok := r.s.data.changeRequests[request.ID]
+	if !ok {
+		return repository.ErrNotFound
+	}
+
+	// Keep the stored changes, only the status and decision fields change
+	request.UpdatedAt = time.Now()
+	changes := row.value.Changes
+	row.value = storedChangeRequest(request)
+	row.value.Changes = changes
+	row.value.CreatedAt = row.createdAt
+	r.s.data.changeRequests[request.ID] = row
+	return nil
+}
+
+func (r *changeRequestRepository) Expire(ctx context.Context, at time.Time) error {
+	defer r.s.lock()()
+	for id, row := range r.s.data.changeRequests {
+		if row.value.IsOpen() && !at.Before(row.value.ExpiresAt) {
+			row.value = storedChangeRequest(&row.value)
+			row.value.Status = domain.ChangeRequestStatusExpired
+			row.value.UpdatedAt = time.Now()
+			r.s.data.changeRequests[id] = row
+		}
+	}
+	return nil
+}
+
+func (r *changeRequestRepository) AddApproval(ctx context.Context, approval *domain.ChangeRequestApproval) error {
+	defer r.s.lock()()
+	for _, row := range r.s.data.approvals {
+		if row.value.ChangeRequestID == approval.ChangeRequestID && row.value.UserID == approval.UserID {
+			return repository.ErrDuplicate
+		}
+	}
+	if approval.ID == "" {
+		approval.ID = uuid.New().String()
+	}
+	stamp(&approval.CreatedAt, nil)
+
+	r.s.data.approvals[approval.ID] = entry[domain.ChangeRequestApproval]{seq: r.s.nextSeq(), createdAt: approval.CreatedAt, value: *approval}
+	return nil
+}
+
+func (r *changeRequestRepository) AddComment(ctx context.Context, comment *domain.ChangeRequestComment) error {
+	defer r.s.lock()()
+	if comment.ID == "" {
+		comment.ID = uuid.New().String()
+	}
+	if _, ok := r.s.data.comments[comment.ID]; ok {
+		return repository.ErrDuplicate
+	}
+	stamp(&comment.CreatedAt, nil)
+
+	r.s.data.comments[comment.ID] = entry[domain.ChangeRequestComment]{seq: r.s.nextSeq(), createdAt: comment.CreatedAt, value: *comment}
+	return nil
+}
+
+// Helper function to attach the approvals and comments of a request, oldest first
+func (r *changeRequestRepository) withReviews(request *domain.ChangeRequest) domain.ChangeRequest {
+	loaded := storedChangeRequest(request)
+	loaded.Approvals = ordered(r.s.data.approvals, func(a *domain.ChangeRequestApproval) bool {
+		return a.ChangeRequestID == request.ID
+	})
+	loaded.Comments = ordered(r.s.data.comments, func(c *domain.ChangeRequestComment) bool {
+		return c.ChangeRequestID == request.ID
+	})
+	return loaded
+}
+
+// Helper function to copy the changes of a request so that it does not share them with the stored one.
+// Approvals and comments are stored on their own.
+func storedChangeRequest(request *domain.ChangeRequest) domain.ChangeRequest {
+	stored := *request
+	stored.Changes = slices.Clone(request.Changes)
+	stored.Approvals = nil
+	stored.Comments = nil
+	return stored
+}
@@ -0,0 +1,99 @@
+package memory
+
+import (
+	"context"
+
+	"release-management/internal/models/domain"
+	"release-management/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+type deploymentRepository struct {
+	s *Store
+}
+
+func (r *deploymentRepository) List(ctx context.Context, filter repository.DeploymentFilter) ([]domain.Deployment, error) {
+	defer r.s.lock()()
+	deployments := ordered(r.s.data.deployments, deploymentMatcher(filter))
+	if !filter.OldestFirst {
+		reversed(deployments)
+	}
+	if filter.Limit > 0 && len(deployments) > filter.Limit {
+		deployments = deployments[:filter.Limit]
+	}
+
+	for i := range deployments {
+		r.resolve(&deployments[i])
+	}
+	return deployments, nil
+}
+
+func (r *deploymentRepository) ListPage(ctx context.Context, filter repository.DeploymentFilter, page repository.PageRequest) (*repository.Page[domain.Deployment], error) {
+	defer r.s.lock()()
+	result, err := paginate(ordered(r.s.data.deployments, deploymentMatcher(filter)), repository.DeploymentSortFields, page)
+	if err != nil {
+		return nil, err
+	}
+	for i := range result.Items {
+		r.resolve(&result.Items[i])
+	}
+	return result, nil
+}
+
+func (r *deploymentRepository) Get(ctx context.Context, id string) (*domain.Deployment, error) {
+	defer r.s.lock()()
+	row, ok := r.s.data.deployments[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	r.resolve(&row.value)
+	return &row.value, nil
+}
+
+func (r *deploymentRepository) Create(ctx context.Context, deployment *domain.Deployment) error {
+	defer r.s.lock()()
+	if deployment.ID == "" {
+		deployment.ID = uuid.New().String()
+	}
+	if _, ok := r.s.data.deployments[deployment.ID]; ok {
+		return repository.ErrDuplicate
+	}
+	stamp(&deployment.CreatedAt, nil)
+
+	stored := *deployment
+	stored.System = nil
+	stored.User = nil
+	r.s.data.deployments[deployment.ID] = entry[domain.Deployment]{seq: r.s.nextSeq(), createdAt: deployment.CreatedAt, value: stored}
+	return nil
+}
+
+// Helper function to fill in the system and user of a deployment
+func (r *deploymentRepository) resolve(deployment *domain.Deployment) {
+	deployment.System = r.s.system(deployment.SystemID)
+	if deployment.UserID != nil {
+		deployment.User = r.s.user(*deployment.UserID)
+	}
+}
+
+// Helper function to match deployments against a filter
+func deploymentMatcher(filter repository.DeploymentFilter) func(*domain.Deployment) bool {
+	return func(d *domain.Deployment) bool {
+		if filter.EnvironmentID != "" && d.EnvironmentID != filter.EnvironmentID {
+			return false
+		}
+		if filter.SystemID != "" && d.SystemID != filter.SystemID {
+			return false
+		}
+		if filter.Version != "" && d.NewVersion != filter.Version && d.OldVersion != filter.Version {
+			return false
+		}
+		if filter.Since != nil && d.CreatedAt.Before(*filter.Since) {
+			return false
+		}
+		if filter.Until != nil && d.CreatedAt.After(*filter.Until) {
+			return false
+		}
+		return true
+	}
+}
@@ -0,0 +1,195 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"release-management/internal/models/domain"
+	"release-management/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+type environmentRepository struct {
+	s *Store
+}
+
+func (r *environmentRepository) List(ctx context.Context, filter repository.EnvironmentFilter) ([]domain.Environment, error) {
+	defer r.s.lock()()
+	return ordered(r.s.data.environments, environmentMatcher(filter)), nil
+}
+
+func (r *environmentRepository) ListPage(ctx context.Context, filter repository.EnvironmentFilter, page repository.PageRequest) (*repository.Page[domain.Environment], error) {
+	defer r.s.lock()()
+	return paginate(ordered(r.s.data.environments, environmentMatcher(filter)), repository.EnvironmentSortFields, page)
+}
+
+func (r *environmentRepository) Count(ctx context.Context, filter repository.EnvironmentFilter) (int64, error) {
+	defer r.s.lock()()
+	return int64(len(ordered(r.s.data.environments, environmentMatcher(filter)))), nil
+}
+
+func (r *environmentRepository) Get(ctx context.Context, id string) (*domain.Environment, error) {
+	defer r.s.lock()()
+	row, ok := r.s.data.environments[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &row.value, nil
+}
+
+func (r *environmentRepository) Create(ctx context.Context, environment *domain.Environment) error {
+	defer r.s.lock()()
+	if environment.ID == "" {
+		environment.ID = uuid.New().String()
+	}
+	if _, ok := r.s.data.environments[environment.ID]; ok {
+		return repository.ErrDuplicate
+	}
+	if environment.Status == "" {
+		environment.Status = domain.EnvStatusPending
+	}
+	stamp(&environment.CreatedAt, &environment.UpdatedAt)
+
+	r.s.data.environments[environment.ID] = entry[domain.Environment]{seq: r.s.nextSeq(), createdAt: environment.CreatedAt, value: storedEnvironment(environment)}
+	return nil
+}
+
+func (r *environmentRepository) Update(ctx context.Context, environment *domain.Environment) error {
+	defer r.s.lock()()
+	row, ok := r.s.data.environments[environment.ID]
+	if !ok {
+		return repository.ErrNotFound
+	}
+
+	environment.UpdatedAt = time.Now()
+	row.value = storedEnvironment(environment)
+	row.value.CreatedAt = row.createdAt
+	r.s.data.environments[environment.ID] = row
+	return nil
+}
+
+func (r *environmentRepository) Delete(ctx context.Context, id string) error {
+	defer r.s.lock()()
+	if _, ok := r.s.data.environments[id]; !ok {
+		return repository.ErrNotFound
+	}
+	delete(r.s.data.environments, id)
+	return nil
+}
+
+func (r *environmentRepository) ListSystems(ctx context.Context, environmentID string) ([]domain.EnvironmentSystem, error) {
+	defer r.s.lock()()
+	envSystems := ordered(r.s.data.envSystems, func(es *domain.EnvironmentSystem) bool {
+		return es.EnvironmentID == environmentID
+	})
+	for i := range envSystems {
+		envSystems[i].System = r.s.system(envSystems[i].SystemID)
+	}
+	return envSystems, nil
+}
+
+func (r *environmentRepository) GetSystem(ctx context.Context, environmentID, systemID string) (*domain.EnvironmentSystem, error) {
+	defer r.s.lock()()
+	row, ok := r.findSystem(environmentID, systemID)
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	row.value.System = r.s.system(systemID)
+	return &row.value, nil
+}
+
+func (r *environmentRepository) AddSystem(ctx context.Context, envSystem *domain.EnvironmentSystem) error {
+	defer r.s.lock()()
+	if envSystem.ID == "" {
+		envSystem.ID = uuid.New().String()
+	}
+	if _, ok := r.s.data.envSystems[envSystem.ID]; ok {
+		return repository.ErrDuplicate
+	}
+	if envSystem.Status == "" {
+		envSystem.Status = "active"
+	}
+	stamp(&envSystem.CreatedAt, &envSystem.UpdatedAt)
+
+	r.s.data.envSystems[envSystem.ID] = entry[domain.EnvironmentSystem]{seq: r.s.nextSeq(), createdAt: envSystem.CreatedAt, value: storedEnvironmentSystem(envSystem)}
+	return nil
+}
+
+func (r *environmentRepository) UpdateSystem(ctx context.Context, envSystem *domain.EnvironmentSystem) error {
+	defer r.s.lock()()
+	row, ok := r.s.data.envSystems[envSystem.ID]
+	if !ok {
+		return repository.ErrNotFound
+	}
+
+	envSystem.UpdatedAt = time.Now()
+	row.value = storedEnvironmentSystem(envSystem)
+	row.value.CreatedAt = row.createdAt
+	r.s.data.envSystems[envSystem.ID] = row
+	return nil
+}
+
+func (r *environmentRepository) RemoveSystem(ctx context.Context, environmentID, systemID string) error {
+	defer r.s.lock()()
+	removed := false
+	for id, row := range r.s.data.envSystems {
+		if row.value.EnvironmentID == environmentID && row.value.SystemID == systemID {
+			delete(r.s.data.envSystems, id)
+			removed = true
+		}
+	}
+	if !removed {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// Helper function to find the oldest entry of a system in an environment
+func (r *environmentRepository) findSystem(environmentID, systemID string) (entry[domain.EnvironmentSystem], bool) {
+	var found entry[domain.EnvironmentSystem]
+	ok := false
+	for _, row := range r.s.data.envSystems {
+		if row.value.EnvironmentID != environmentID || row.value.SystemID != systemID {
+			continue
+		}
+		if !ok || row.seq < found.seq {
+			found, ok = row, true
+		}
+	}
+	return found, ok
+}
+
+// Helper function to match environments against a filter
+func environmentMatcher(filter repository.EnvironmentFilter) func(*domain.Environment) bool {
+	return func(e *domain.Environment) bool {
+		if filter.ReleaseID != "" && e.ReleaseID != filter.ReleaseID {
+			return false
+		}
+		if filter.EnvironmentGroupID != "" && (e.EnvironmentGroupID == nil || *e.EnvironmentGroupID != filter.EnvironmentGroupID) {
+			return false
+		}
+		if filter.Type != "" && e.Type != filter.Type {
+			return false
+		}
+		if filter.Status != "" && e.Status != filter.Status {
+			return false
+		}
+		return true
+	}
+}
+
+// Helper function to strip the relationships of an environment before storing it
+func storedEnvironment(environment *domain.Environment) domain.Environment {
+	stored := *environment
+	stored.EnvironmentSystems = nil
+	return stored
+}
+
+// Helper function to strip the relationships of a deployed system before storing it
+func storedEnvironmentSystem(envSystem *domain.EnvironmentSystem) domain.EnvironmentSystem {
+	stored := *envSystem
+	stored.Environment = nil
+	stored.System = nil
+	return stored
+}
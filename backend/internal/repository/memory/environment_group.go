@@ -0,0 +1,172 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"release-management/internal/models/domain"
+	"release-management/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+type environmentGroupRepository struct {
+	s *Store
+}
+
+func (r *environmentGroupRepository) List(ctx context.Context) ([]domain.EnvironmentGroup, error) {
+	defer r.s.lock()()
+	groups := ordered(r.s.data.groups, nil)
+	for i := range groups {
+		r.resolve(&groups[i])
+	}
+	return groups, nil
+}
+
+func (r *environmentGroupRepository) ListPage(ctx context.Context, page repository.PageRequest) (*repository.Page[domain.EnvironmentGroup], error) {
+	defer r.s.lock()()
+	result, err := paginate(ordered(r.s.data.groups, nil), repository.EnvironmentGroupSortFields, page)
+	if err != nil {
+		return nil, err
+	}
+	for i := range result.Items {
+		r.resolve(&result.Items[i])
+	}
+	return result, nil
+}
+
+func (r *environmentGroupRepository) Get(ctx context.Context, id string) (*domain.EnvironmentGroup, error) {
+	defer r.s.lock()()
+	row, ok := r.s.data.groups[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	r.resolve(&row.value)
+	return &row.value, nil
+}
+
+func (r *environmentGroupRepository) Create(ctx context.Context, group *domain.EnvironmentGroup) error {
+	defer r.s.lock()()
+	if group.ID == "" {
+		group.ID = uuid.New().String()
+	}
+	if _, ok := r.s.data.groups[group.ID]; ok {
+		return repository.ErrDuplicate
+	}
+	stamp(&group.CreatedAt, &group.UpdatedAt)
+
+	r.s.data.groups[group.ID] = entry[domain.EnvironmentGroup]{seq: r.s.nextSeq(), createdAt: group.CreatedAt, value: storedEnvironmentGroup(group)}
+	return nil
+}
+
+func (r *environmentGroupRepository) Update(ctx context.Context, group *domain.EnvironmentGroup) error {
+	defer r.s.lock()()
+	row, ok := r.s.data.groups[group.ID]
+	if !ok {
+		return repository.ErrNotFound
+	}
+
+	group.UpdatedAt = time.Now()
+	row.value = storedEnvironmentGroup(group)
+	row.value.CreatedAt = row.createdAt
+	r.s.data.groups[group.ID] = row
+	return nil
+}
+
+func (r *environmentGroupRepository) Delete(ctx context.Context, id string) error {
+	defer r.s.lock()()
+	if _, ok := r.s.data.groups[id]; !ok {
+		return repository.ErrNotFound
+	}
+	delete(r.s.data.groups, id)
+	// Like the foreign key, deleting a group deletes the freeze windows scoped to it
+	for windowID, row := range r.s.data.freezeWindows {
+		if row.value.EnvironmentGroupID != nil && *row.value.EnvironmentGroupID == id {
+			delete(r.s.data.freezeWindows, windowID)
+		}
+	}
+	return nil
+}
+
+func (r *environmentGroupRepository) ListRoleGrants(ctx context.Context, groupID string) ([]domain.EnvironmentGroupRoleGrant, error) {
+	defer r.s.lock()()
+	grants := ordered(r.s.data.roleGrants, func(g *domain.EnvironmentGroupRoleGrant) bool {
+		return g.EnvironmentGroupID == groupID
+	})
+	for i := range grants {
+		grants[i].User = r.s.user(grants[i].UserID)
+	}
+	return grants, nil
+}
+
+func (r *environmentGroupRepository) GetRoleGrant(ctx context.Context, groupID string, userID uint) (*domain.EnvironmentGroupRoleGrant, error) {
+	defer r.s.lock()()
+	row, ok := r.findRoleGrant(groupID, userID)
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	row.value.User = r.s.user(userID)
+	return &row.value, nil
+}
+
+func (r *environmentGroupRepository) SaveRoleGrant(ctx context.Context, grant *domain.EnvironmentGroupRoleGrant) error {
+	defer r.s.lock()()
+
+	// Replace the role of an existing grant for the user in this group
+	if row, ok := r.findRoleGrant(grant.EnvironmentGroupID, grant.UserID); ok {
+		row.value.Role = grant.Role
+		row.value.UpdatedAt = time.Now()
+		r.s.data.roleGrants[row.value.ID] = row
+
+		grant.ID = row.value.ID
+		grant.CreatedAt = row.value.CreatedAt
+		grant.UpdatedAt = row.value.UpdatedAt
+		return nil
+	}
+
+	if grant.ID == "" {
+		grant.ID = uuid.New().String()
+	}
+	stamp(&grant.CreatedAt, &grant.UpdatedAt)
+	stored := *grant
+	stored.User = nil
+	r.s.data.roleGrants[grant.ID] = entry[domain.EnvironmentGroupRoleGrant]{seq: r.s.nextSeq(), createdAt: grant.CreatedAt, value: stored}
+	return nil
+}
+
+func (r *environmentGroupRepository) DeleteRoleGrant(ctx context.Context, groupID string, userID uint) error {
+	defer r.s.lock()()
+	row, ok := r.findRoleGrant(groupID, userID)
+	if !ok {
+		return repository.ErrNotFound
+	}
+	delete(r.s.data.roleGrants, row.value.ID)
+	return nil
+}
+
+// Helper function to find the role grant of a user in a group, which is unique
+func (r *environmentGroupRepository) findRoleGrant(groupID string, userID uint) (entry[domain.EnvironmentGroupRoleGrant], bool) {
+	for _, row := range r.s.data.roleGrants {
+		if row.value.EnvironmentGroupID == groupID && row.value.UserID == userID {
+			return row, true
+		}
+	}
+	return entry[domain.EnvironmentGroupRoleGrant]{}, false
+}
+
+// Helper function to fill in the environments of a group and copy its promotion path
+func (r *environmentGroupRepository) resolve(group *domain.EnvironmentGroup) {
+	group.PromotionPath = slices.Clone(group.PromotionPath)
+	group.Environments = ordered(r.s.data.environments, func(e *domain.Environment) bool {
+		return e.EnvironmentGroupID != nil && *e.EnvironmentGroupID == group.ID
+	})
+}
+
+// Helper function to strip the relationships of a group and copy its promotion path before storing it
+func storedEnvironmentGroup(group *domain.EnvironmentGroup) domain.EnvironmentGroup {
+	stored := *group
+	stored.PromotionPath = slices.Clone(group.PromotionPath)
+	stored.Environments = nil
+	return stored
+}
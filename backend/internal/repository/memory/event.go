@@ -0,0 +1,85 @@
+package memory
+
+import (
+	"context"
+	"maps"
+	"sort"
+
+	"release-management/internal/models/domain"
+	"release-management/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+type eventRepository struct {
+	s *Store
+}
+
+func (r *eventRepository) Create(ctx context.Context, event *domain.Event) error {
+	defer r.s.lock()()
+	if event.ID == "" {
+		event.ID = uuid.New().String()
+	}
+	if _, ok := r.s.data.events[event.ID]; ok {
+		return repository.ErrDuplicate
+	}
+	stamp(&event.CreatedAt, nil)
+	r.s.data.eventSeq++
+	event.Sequence = r.s.data.eventSeq
+
+	r.s.data.events[event.ID] = entry[domain.Event]{seq: r.s.nextSeq(), createdAt: event.CreatedAt, value: copyEvent(event)}
+	return nil
+}
+
+func (r *eventRepository) Get(ctx context.Context, id string) (*domain.Event, error) {
+	defer r.s.lock()()
+	event := r.s.event(id)
+	if event == nil {
+		return nil, repository.ErrNotFound
+	}
+	return event, nil
+}
+
+func (r *eventRepository) ListAfter(ctx context.Context, after int64, limit int) ([]domain.Event, error) {
+	defer r.s.lock()()
+	var events []domain.Event
+	for _, event := range r.s.sortedEvents() {
+		if event.Sequence > after && len(events) < limit {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func (r *eventRepository) ListLatest(ctx context.Context, limit int) ([]domain.Event, error) {
+	defer r.s.lock()()
+	events := r.s.sortedEvents()
+	return events[max(len(events)-limit, 0):], nil
+}
+
+// Helper function to copy every event in sequence order
+func (s *Store) sortedEvents() []domain.Event {
+	events := make([]domain.Event, 0, len(s.data.events))
+	for _, row := range s.data.events {
+		events = append(events, copyEvent(&row.value))
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].Sequence < events[j].Sequence })
+	return events
+}
+
+// Helper function to look up a copy of an event for a relationship, nil if it does not exist
+func (s *Store) event(id string) *domain.Event {
+	row, ok := s.data.events[id]
+	if !ok {
+		return nil
+	}
+	event := copyEvent(&row.value)
+	return &event
+}
+
+// Helper function to copy the data of an event so callers cannot change stored events
+func copyEvent(e *domain.Event) domain.Event {
+	copied := *e
+	copied.Data = maps.Clone(e.Data)
+	return copied
+}
@@ -0,0 +1,111 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	"release-management/internal/models/domain"
+	"release-management/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+type freezeWindowRepository struct {
+	s *Store
+}
+
+func (r *freezeWindowRepository) List(ctx context.Context, filter repository.FreezeWindowFilter) ([]domain.FreezeWindow, error) {
+	defer r.s.lock()()
+	windows := ordered(r.s.data.freezeWindows, func(w *domain.FreezeWindow) bool {
+		return (filter.Since == nil || w.EndsAt.After(*filter.Since)) &&
+			(filter.Until == nil || w.StartsAt.Before(*filter.Until))
+	})
+	sort.SliceStable(windows, func(i, j int) bool {
+		return windows[i].StartsAt.Before(windows[j].StartsAt)
+	})
+	for i := range windows {
+		windows[i] = storedFreezeWindow(&windows[i])
+	}
+	return windows, nil
+}
+
+func (r *freezeWindowRepository) Get(ctx context.Context, id string) (*domain.FreezeWindow, error) {
+	defer r.s.lock()()
+	row, ok := r.s.data.freezeWindows[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	window := storedFreezeWindow(&row.value)
+	return &window, nil
+}
+
+func (r *freezeWindowRepository) Create(ctx context.Context, window *domain.FreezeWindow) error {
+	defer r.s.lock()()
+	if window.ID == "" {
+		window.ID = uuid.New().String()
+	}
+	if _, ok := r.s.data.freezeWindows[window.ID]; ok {
+		return repository.ErrDuplicate
+	}
+	stamp(&window.CreatedAt, &window.UpdatedAt)
+
+	r.s.data.freezeWindows[window.ID] = entry[domain.FreezeWindow]{
+		seq: r.s.nextSeq(), createdAt: window.CreatedAt, value: storedFreezeWindow(window),
+	}
+	return nil
+}
+
+func (r *freezeWindowRepository) Update(ctx context.Context, window *domain.FreezeWindow) error {
+	defer r.s.lock()()
+	row, ok := r.s.data.freezeWindows[window.ID]
+	if !ok {
+		return repository.ErrNotFound
+	}
+
+	window.UpdatedAt = time.Now()
+	row.value = storedFreezeWindow(window)
+	row.value.CreatedAt = row.createdAt
+	r.s.data.freezeWindows[window.ID] = row
+	return nil
+}
+
+func (r *freezeWindowRepository) Delete(ctx context.Context, id string) error {
+	defer r.s.lock()()
+	if _, ok := r.s.data.freezeWindows[id]; !ok {
+		return repository.ErrNotFound
+	}
+	delete(r.s.data.freezeWindows, id)
+	return nil
+}
+
+func (r *freezeWindowRepository) CreateOverride(ctx context.Context, override *domain.FreezeOverride) error {
+	defer r.s.lock()()
+	if override.ID == "" {
+		override.ID = uuid.New().String()
+	}
+	if _, ok := r.s.data.freezeOverrides[override.ID]; ok {
+		return repository.ErrDuplicate
+	}
+	stamp(&override.CreatedAt, nil)
+
+	r.s.data.freezeOverrides[override.ID] = entry[domain.FreezeOverride]{seq: r.s.nextSeq(), createdAt: override.CreatedAt, value: *override}
+	return nil
+}
+
+func (r *freezeWindowRepository) ListOverrides(ctx context.Context, filter repository.FreezeOverrideFilter, page repository.PageRequest) (*repository.Page[domain.FreezeOverride], error) {
+	defer r.s.lock()()
+	return paginate(ordered(r.s.data.freezeOverrides, func(o *domain.FreezeOverride) bool {
+		return (filter.FreezeWindowID == "" || o.FreezeWindowID == filter.FreezeWindowID) &&
+			(filter.EnvironmentID == "" || o.EnvironmentID == filter.EnvironmentID)
+	}), repository.FreezeOverrideSortFields, page)
+}
+
+// Helper function to copy the exemptions of a window so that it does not share them with the stored one
+func storedFreezeWindow(window *domain.FreezeWindow) domain.FreezeWindow {
+	stored := *window
+	stored.ExemptSystemIDs = slices.Clone(window.ExemptSystemIDs)
+	stored.ExemptEnvironmentIDs = slices.Clone(window.ExemptEnvironmentIDs)
+	return stored
+}
@@ -0,0 +1,125 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"release-management/internal/models/domain"
+	"release-management/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+type releaseRepository struct {
+	s *Store
+}
+
+func (r *releaseRepository) List(ctx context.Context) ([]domain.Release, error) {
+	defer r.s.lock()()
+	return ordered(r.s.data.releases, nil), nil
+}
+
+func (r *releaseRepository) ListPage(ctx context.Context, filter repository.ReleaseFilter, page repository.PageRequest) (*repository.Page[domain.Release], error) {
+	defer r.s.lock()()
+	return paginate(ordered(r.s.data.releases, releaseMatcher(filter)), repository.ReleaseSortFields, page)
+}
+
+func (r *releaseRepository) Get(ctx context.Context, id string) (*domain.Release, error) {
+	defer r.s.lock()()
+	row, ok := r.s.data.releases[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &row.value, nil
+}
+
+func (r *releaseRepository) Create(ctx context.Context, release *domain.Release) error {
+	defer r.s.lock()()
+	if release.ID == "" {
+		release.ID = uuid.New().String()
+	}
+	if _, ok := r.s.data.releases[release.ID]; ok {
+		return repository.ErrDuplicate
+	}
+	if release.Status == "" {
+		release.Status = domain.StatusPlanned
+	}
+	stamp(&release.CreatedAt, &release.UpdatedAt)
+
+	r.s.data.releases[release.ID] = entry[domain.Release]{seq: r.s.nextSeq(), createdAt: release.CreatedAt, value: storedRelease(release)}
+	return nil
+}
+
+func (r *releaseRepository) Update(ctx context.Context, release *domain.Release) error {
+	defer r.s.lock()()
+	row, ok := r.s.data.releases[release.ID]
+	if !ok {
+		return repository.ErrNotFound
+	}
+
+	release.UpdatedAt = time.Now()
+	row.value = storedRelease(release)
+	row.value.CreatedAt = row.createdAt
+	r.s.data.releases[release.ID] = row
+	return nil
+}
+
+func (r *releaseRepository) Delete(ctx context.Context, id string) error {
+	defer r.s.lock()()
+	if _, ok := r.s.data.releases[id]; !ok {
+		return repository.ErrNotFound
+	}
+	delete(r.s.data.releases, id)
+	return nil
+}
+
+func (r *releaseRepository) Transition(ctx context.Context, transition *domain.ReleaseTransition) error {
+	defer r.s.lock()()
+	row, ok := r.s.data.releases[transition.ReleaseID]
+	if !ok || row.value.Status != transition.FromStatus {
+		return repository.ErrConflict
+	}
+
+	row.value.Status = transition.ToStatus
+	r.s.data.releases[transition.ReleaseID] = row
+
+	if transition.ID == "" {
+		transition.ID = uuid.New().String()
+	}
+	stamp(&transition.CreatedAt, nil)
+	stored := *transition
+	stored.User = nil
+	r.s.data.transitions[transition.ID] = entry[domain.ReleaseTransition]{seq: r.s.nextSeq(), createdAt: transition.CreatedAt, value: stored}
+	return nil
+}
+
+func (r *releaseRepository) ListTransitions(ctx context.Context, releaseID string) ([]domain.ReleaseTransition, error) {
+	defer r.s.lock()()
+	transitions := ordered(r.s.data.transitions, func(t *domain.ReleaseTransition) bool {
+		return t.ReleaseID == releaseID
+	})
+	for i := range transitions {
+		transitions[i].User = r.s.user(transitions[i].UserID)
+	}
+	return transitions, nil
+}
+
+// Helper function to strip the relationships of a release before storing it
+func storedRelease(release *domain.Release) domain.Release {
+	stored := *release
+	stored.Builds = nil
+	return stored
+}
+
+// Helper function to match releases against a filter
+func releaseMatcher(filter repository.ReleaseFilter) func(*domain.Release) bool {
+	return func(r *domain.Release) bool {
+		if filter.Status != "" && r.Status != filter.Status {
+			return false
+		}
+		if filter.Type != "" && r.Type != filter.Type {
+			return false
+		}
+		return true
+	}
+}
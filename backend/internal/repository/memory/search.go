@@ -0,0 +1,220 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"release-management/internal/models/domain"
+	"release-management/internal/repository"
+)
+
+type searchRepository struct {
+	s *Store
+}
+
+// searchField is a field of an entity with the weight of its matches, like the weights of the Postgres search vectors
+type searchField struct {
+	name   string
+	value  string
+	weight float64
+}
+
+const (
+	weightA = 1.0
+	weightB = 0.4
+	weightC = 0.2
+)
+
+// Search matches every term as a case-insensitive word prefix, similar to the prefix queries of the Postgres implementation
+func (r *searchRepository) Search(ctx context.Context, query repository.SearchQuery) ([]domain.SearchResult, error) {
+	defer r.s.lock()()
+
+	var terms []string
+	for _, term := range query.Terms {
+		if term = strings.ToLower(strings.TrimSpace(term)); term != "" {
+			terms = append(terms, term)
+		}
+	}
+	if len(terms) == 0 {
+		return nil, nil
+	}
+
+	wanted := func(t domain.SearchResultType) bool {
+		return len(query.Types) == 0 || slices.Contains(query.Types, t)
+	}
+
+	var results []domain.SearchResult
+	add := func(result domain.SearchResult, fields []searchField) {
+		if rank, highlights, ok := matchFields(terms, fields); ok {
+			result.Rank = rank
+			result.Highlights = highlights
+			results = append(results, result)
+		}
+	}
+
+	if wanted(domain.SearchResultRelease) {
+		for _, release := range ordered(r.s.data.releases, nil) {
+			add(domain.SearchResult{Type: domain.SearchResultRelease, ID: release.ID, Title: release.Name, Subtitle: string(release.Status)}, []searchField{
+				{"name", release.Name, weightA},
+				{"description", deref(release.Description), weightB},
+			})
+		}
+	}
+	if wanted(domain.SearchResultSystem) {
+		for _, system := range ordered(r.s.data.systems, nil) {
+			add(domain.SearchResult{Type: domain.SearchResultSystem, ID: system.ID, Title: system.Name, Subtitle: string(system.Type)}, []searchField{
+				{"name", system.Name, weightA},
+				{"description", deref(system.Description), weightB},
+			})
+		}
+	}
+	if wanted(domain.SearchResultBuild) {
+		for _, build := range ordered(r.s.data.builds, nil) {
+			// Builds are found by the name of their system and release too, but only their own system is required
+			system := r.s.system(build.SystemID)
+			if system == nil {
+				continue
+			}
+			releaseName := ""
+			if build.ReleaseID != nil {
+				if release := r.s.release(*build.ReleaseID); release != nil {
+					releaseName = release.Name
+				}
+			}
+			add(domain.SearchResult{Type: domain.SearchResultBuild, ID: build.ID, Title: system.Name + " " + build.Version, Subtitle: releaseName}, []searchField{
+				{"version", build.Version, weightA},
+				{"system", system.Name, weightA},
+				{"release", releaseName, weightA},
+			})
+		}
+	}
+	if wanted(domain.SearchResultEnvironment) {
+		for _, environment := range ordered(r.s.data.environments, nil) {
+			add(domain.SearchResult{Type: domain.SearchResultEnvironment, ID: environment.ID, Title: environment.Name, Subtitle: string(environment.Type)}, []searchField{
+				{"name", environment.Name, weightA},
+				{"url", deref(environment.URL), weightB},
+				{"description", deref(environment.Description), weightC},
+			})
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Rank != results[j].Rank {
+			return results[i].Rank > results[j].Rank
+		}
+		return results[i].ID < results[j].ID
+	})
+	if query.Limit > 0 && len(results) > query.Limit {
+		results = results[:query.Limit]
+	}
+	return results, nil
+}
+
+// Helper function to match every term against the fields of an entity.
+// It returns the rank, the highlighted fields and whether every term matched at least one field.
+func matchFields(terms []string, fields []searchField) (float64, map[string]string, bool) {
+	rank := 0.0
+	matched := make([]bool, len(fields))
+	for _, term := range terms {
+		found := false
+		for i, field := range fields {
+			if starts := wordPrefixMatches(field.value, term); len(starts) > 0 {
+				found = true
+				rank += field.weight * float64(len(starts))
+				matched[i] = true
+			}
+		}
+		if !found {
+			return 0, nil, false
+		}
+	}
+
+	highlights := map[string]string{}
+	for i, field := range fields {
+		if matched[i] {
+			highlights[field.name] = highlight(field.value, terms)
+		}
+	}
+	return rank, highlights, true
+}
+
+// Helper function to find where term starts a word of value, ignoring case
+func wordPrefixMatches(value, term string) []int {
+	lower := strings.ToLower(value)
+	var starts []int
+	for offset := 0; offset < len(lower); {
+		i := strings.Index(lower[offset:], term)
+		if i < 0 {
+			break
+		}
+		start := offset + i
+		if isWordStart(lower, start) {
+			starts = append(starts, start)
+		}
+		offset = start + len(term)
+	}
+	return starts
+}
+
+// Helper function to wrap every word that starts with one of the terms in highlight markers
+func highlight(value string, terms []string) string {
+	lower := strings.ToLower(value)
+	var b strings.Builder
+	for i := 0; i < len(value); {
+		if isWordStart(lower, i) {
+			if end := matchedWordEnd(lower, i, terms); end > i {
+				b.WriteString(domain.HighlightStart + value[i:end] + domain.HighlightStop)
+				i = end
+				continue
+			}
+		}
+		_, size := utf8.DecodeRuneInString(value[i:])
+		b.WriteString(value[i : i+size])
+		i += size
+	}
+	return b.String()
+}
+
+// Helper function to return the end of the word at start if it starts with a term, or start if none does
+func matchedWordEnd(lower string, start int, terms []string) int {
+	for _, term := range terms {
+		if strings.HasPrefix(lower[start:], term) {
+			end := start + len(term)
+			for end < len(lower) {
+				r, size := utf8.DecodeRuneInString(lower[end:])
+				if !isWordRune(r) {
+					break
+				}
+				end += size
+			}
+			return end
+		}
+	}
+	return start
+}
+
+// Helper function to check whether a word starts at index i
+func isWordStart(s string, i int) bool {
+	if i == 0 {
+		return true
+	}
+	r, _ := utf8.DecodeLastRuneInString(s[:i])
+	return !isWordRune(r)
+}
+
+// Helper function to check whether a rune belongs to a word rather than separating words
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// Helper function to dereference an optional string
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,328 @@
+// Package memory implements the repositories in memory for tests and local experiments.
+// It behaves like the gormrepo package, see the repositorytest package for the contract both implement.
+package memory
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"release-management/internal/models/domain"
+	"release-management/internal/repository"
+)
+
+// Store implements repository.Store in memory.
+// Relationships are resolved on read, but foreign keys are not enforced.
+type Store struct {
+	mu   *sync.Mutex
+	data *data
+	// inTx is set for the store handed to a transaction, which already holds the lock
+	inTx bool
+}
+
+// entry is a stored value with the order it was inserted in
+type entry[V any] struct {
+	seq       int64
+	createdAt time.Time
+	value     V
+}
+
+type data struct {
+	seq        int64
+	nextUserID uint
+	// eventSeq numbers events without gaps, like a database sequence in a store without concurrent transactions
+	eventSeq int64
+
+	releases          map[string]entry[domain.Release]
+	transitions       map[string]entry[domain.ReleaseTransition]
+	builds            map[string]entry[domain.Build]
+	systems           map[string]entry[domain.System]
+	environments      map[string]entry[domain.Environment]
+	envSystems        map[string]entry[domain.EnvironmentSystem]
+	groups            map[string]entry[domain.EnvironmentGroup]
+	roleGrants        map[string]entry[domain.EnvironmentGroupRoleGrant]
+	deployments       map[string]entry[domain.Deployment]
+	users             map[uint]entry[domain.User]
+	apiTokens         map[string]entry[domain.APIToken]
+	auditEntries      map[string]entry[domain.AuditEntry]
+	webhookDeliveries map[string]entry[domain.WebhookDelivery]
+	subscriptions     map[string]entry[domain.WebhookSubscription]
+	eventDeliveries   map[string]entry[domain.EventDelivery]
+	events            map[string]entry[domain.Event]
+	freezeWindows     map[string]entry[domain.FreezeWindow]
+	freezeOverrides   map[string]entry[domain.FreezeOverride]
+	changeRequests    map[string]entry[domain.ChangeRequest]
+	approvals         map[string]entry[domain.ChangeRequestApproval]
+	comments          map[string]entry[domain.ChangeRequestComment]
+}
+
+// NewStore creates an empty store
+func NewStore() *Store {
+	return &Store{
+		mu: &sync.Mutex{},
+		data: &data{
+			releases:          map[string]entry[domain.Release]{},
+			transitions:       map[string]entry[domain.ReleaseTransition]{},
+			builds:            map[string]entry[domain.Build]{},
+			systems:           map[string]entry[domain.System]{},
+			environments:      map[string]entry[domain.Environment]{},
+			envSystems:        map[string]entry[domain.EnvironmentSystem]{},
+			groups:            map[string]entry[domain.EnvironmentGroup]{},
+			roleGrants:        map[string]entry[domain.EnvironmentGroupRoleGrant]{},
+			deployments:       map[string]entry[domain.Deployment]{},
+			users:             map[uint]entry[domain.User]{},
+			apiTokens:         map[string]entry[domain.APIToken]{},
+			auditEntries:      map[string]entry[domain.AuditEntry]{},
+			webhookDeliveries: map[string]entry[domain.WebhookDelivery]{},
+			subscriptions:     map[string]entry[domain.WebhookSubscription]{},
+			eventDeliveries:   map[string]entry[domain.EventDelivery]{},
+			events:            map[string]entry[domain.Event]{},
+			freezeWindows:     map[string]entry[domain.FreezeWindow]{},
+			freezeOverrides:   map[string]entry[domain.FreezeOverride]{},
+			changeRequests:    map[string]entry[domain.ChangeRequest]{},
+			approvals:         map[string]entry[domain.ChangeRequestApproval]{},
+			comments:          map[string]entry[domain.ChangeRequestComment]{},
+		},
+	}
+}
+
+func (s *Store) Releases() repository.ReleaseRepository {
+	return &releaseRepository{s}
+}
+
+func (s *Store) Builds() repository.BuildRepository {
+	return &buildRepository{s}
+}
+
+func (s *Store) Systems() repository.SystemRepository {
+	return &systemRepository{s}
+}
+
+func (s *Store) Environments() repository.EnvironmentRepository {
+	return &environmentRepository{s}
+}
+
+func (s *Store) EnvironmentGroups() repository.EnvironmentGroupRepository {
+	return &environmentGroupRepository{s}
+}
+
+func (s *Store) Deployments() repository.DeploymentRepository {
+	return &deploymentRepository{s}
+}
+
+func (s *Store) Users() repository.UserRepository {
+	return &userRepository{s}
+}
+
+func (s *Store) APITokens() repository.APITokenRepository {
+	return &apiTokenRepository{s}
+}
+
+func (s *Store) Audit() repository.AuditRepository {
+	return &auditRepository{s}
+}
+
+func (s *Store) WebhookDeliveries() repository.WebhookDeliveryRepository {
+	return &webhookDeliveryRepository{s}
+}
+
+func (s *Store) WebhookSubscriptions() repository.WebhookSubscriptionRepository {
+	return &webhookSubscriptionRepository{s}
+}
+
+func (s *Store) FreezeWindows() repository.FreezeWindowRepository {
+	return &freezeWindowRepository{s}
+}
+
+func (s *Store) ChangeRequests() repository.ChangeRequestRepository {
+	return &changeRequestRepository{s}
+}
+
+func (s *Store) Events() repository.EventRepository {
+	return &eventRepository{s}
+}
+
+func (s *Store) Search() repository.SearchRepository {
+	return &searchRepository{s}
+}
+
+// Transaction runs fn while holding the store lock and restores the previous data if fn fails or panics.
+// Nested transactions restore only what changed within them, like savepoints.
+func (s *Store) Transaction(ctx context.Context, fn func(tx repository.Store) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	defer s.lock()()
+
+	snapshot := s.data.clone()
+	committed := false
+	defer func() {
+		if !committed {
+			*s.data = *snapshot
+		}
+	}()
+
+	if err := fn(&Store{mu: s.mu, data: s.data, inTx: true}); err != nil {
+		return err
+	}
+	committed = true
+	return nil
+}
+
+// Helper function to take the store lock unless it is already held by a transaction
+func (s *Store) lock() func() {
+	if s.inTx {
+		return func() {}
+	}
+	s.mu.Lock()
+	return s.mu.Unlock
+}
+
+// Helper function to hand out the next insertion sequence number
+func (s *Store) nextSeq() int64 {
+	s.data.seq++
+	return s.data.seq
+}
+
+// Helper function to copy the maps of the store so that a failed transaction can be undone.
+// Stored values are never modified in place, so the values themselves can be shared.
+func (d *data) clone() *data {
+	return &data{
+		seq:               d.seq,
+		nextUserID:        d.nextUserID,
+		eventSeq:          d.eventSeq,
+		releases:          maps.Clone(d.releases),
+		transitions:       maps.Clone(d.transitions),
+		builds:            maps.Clone(d.builds),
+		systems:           maps.Clone(d.systems),
+		environments:      maps.Clone(d.environments),
+		envSystems:        maps.Clone(d.envSystems),
+		groups:            maps.Clone(d.groups),
+		roleGrants:        maps.Clone(d.roleGrants),
+		deployments:       maps.Clone(d.deployments),
+		users:             maps.Clone(d.users),
+		apiTokens:         maps.Clone(d.apiTokens),
+		auditEntries:      maps.Clone(d.auditEntries),
+		webhookDeliveries: maps.Clone(d.webhookDeliveries),
+		subscriptions:     maps.Clone(d.subscriptions),
+		eventDeliveries:   maps.Clone(d.eventDeliveries),
+		events:            maps.Clone(d.events),
+		freezeWindows:     maps.Clone(d.freezeWindows),
+		freezeOverrides:   maps.Clone(d.freezeOverrides),
+		changeRequests:    maps.Clone(d.changeRequests),
+		approvals:         maps.Clone(d.approvals),
+		comments:          maps.Clone(d.comments),
+	}
+}
+
+// Helper function to return the values matching keep, oldest first
+func ordered[K comparable, V any](rows map[K]entry[V], keep func(value *V) bool) []V {
+	entries := make([]entry[V], 0, len(rows))
+	for _, row := range rows {
+		if keep == nil || keep(&row.value) {
+			entries = append(entries, row)
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].createdAt.Equal(entries[j].createdAt) {
+			return entries[i].createdAt.Before(entries[j].createdAt)
+		}
+		return entries[i].seq < entries[j].seq
+	})
+
+	values := make([]V, len(entries))
+	for i := range entries {
+		values[i] = entries[i].value
+	}
+	return values
+}
+
+// Helper function to cut the page selected by page out of values, which must already be filtered
+func paginate[V any](values []V, fields repository.SortFields[V], page repository.PageRequest) (*repository.Page[V], error) {
+	key := fields.Keys[page.Sort.Field]
+	if key == nil {
+		return nil, fmt.Errorf("cannot sort by %q", page.Sort.Field)
+	}
+
+	// Compare by key and then ID, reversed when sorting in descending order
+	compare := func(keyA, idA, keyB, idB string) int {
+		c := strings.Compare(keyA, keyB)
+		if c == 0 {
+			c = strings.Compare(idA, idB)
+		}
+		if page.Sort.Desc {
+			return -c
+		}
+		return c
+	}
+	sort.SliceStable(values, func(i, j int) bool {
+		return compare(key(&values[i]), fields.ID(&values[i]), key(&values[j]), fields.ID(&values[j])) < 0
+	})
+
+	result := &repository.Page[V]{Total: int64(len(values))}
+	if page.Cursor != "" {
+		cursor, err := repository.DecodeCursor(page.Cursor, page.Sort)
+		if err != nil {
+			return nil, err
+		}
+		start := sort.Search(len(values), func(i int) bool {
+			return compare(key(&values[i]), fields.ID(&values[i]), cursor.Key, cursor.ID) > 0
+		})
+		values = values[start:]
+	}
+
+	if page.Limit > 0 && len(values) > page.Limit {
+		values = values[:page.Limit]
+		result.NextCursor = fields.CursorAfter(&values[len(values)-1], page.Sort)
+	}
+	result.Items = values
+	return result, nil
+}
+
+// Helper function to reverse values in place
+func reversed[V any](values []V) []V {
+	for i, j := 0, len(values)-1; i < j; i, j = i+1, j-1 {
+		values[i], values[j] = values[j], values[i]
+	}
+	return values
+}
+
+// Helper function to fill in the creation and update time of a new value
+func stamp(createdAt, updatedAt *time.Time) {
+	now := time.Now()
+	if createdAt.IsZero() {
+		*createdAt = now
+	}
+	if updatedAt != nil && updatedAt.IsZero() {
+		*updatedAt = now
+	}
+}
+
+// Helper function to look up a release for a relationship, nil if it does not exist
+func (s *Store) release(id string) *domain.Release {
+	if row, ok := s.data.releases[id]; ok {
+		return &row.value
+	}
+	return nil
+}
+
+// Helper function to look up a system for a relationship, nil if it does not exist
+func (s *Store) system(id string) *domain.System {
+	if row, ok := s.data.systems[id]; ok {
+		return &row.value
+	}
+	return nil
+}
+
+// Helper function to look up a user for a relationship, nil if it does not exist
+func (s *Store) user(id uint) *domain.User {
+	if row, ok := s.data.users[id]; ok {
+		return &row.value
+	}
+	return nil
+}
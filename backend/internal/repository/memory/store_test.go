@@ -0,0 +1,15 @@
+package memory_test
+
+import (
+	"testing"
+
+	"release-management/internal/repository"
+	"release-management/internal/repository/memory"
+	"release-management/internal/repository/repositorytest"
+)
+
+func TestStore(t *testing.T) {
+	repositorytest.TestStore(t, func(t *testing.T) repository.Store {
+		return memory.NewStore()
+	})
+}
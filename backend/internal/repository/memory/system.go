@@ -0,0 +1,123 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"release-management/internal/models/domain"
+	"release-management/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+type systemRepository struct {
+	s *Store
+}
+
+func (r *systemRepository) List(ctx context.Context, filter repository.SystemFilter) ([]domain.System, error) {
+	defer r.s.lock()()
+	return ordered(r.s.data.systems, systemMatcher(filter)), nil
+}
+
+func (r *systemRepository) ListPage(ctx context.Context, filter repository.SystemFilter, page repository.PageRequest) (*repository.Page[domain.System], error) {
+	defer r.s.lock()()
+	return paginate(ordered(r.s.data.systems, systemMatcher(filter)), repository.SystemSortFields, page)
+}
+
+func (r *systemRepository) Count(ctx context.Context, filter repository.SystemFilter) (int64, error) {
+	defer r.s.lock()()
+	return int64(len(ordered(r.s.data.systems, systemMatcher(filter)))), nil
+}
+
+func (r *systemRepository) Get(ctx context.Context, id string) (*domain.System, error) {
+	defer r.s.lock()()
+	row, ok := r.s.data.systems[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	if row.value.ParentID != nil {
+		row.value.Parent = r.s.system(*row.value.ParentID)
+	}
+	return &row.value, nil
+}
+
+// Lock only checks that the system exists, as transactions already hold the store lock until they end
+func (r *systemRepository) Lock(ctx context.Context, id string) error {
+	defer r.s.lock()()
+	if _, ok := r.s.data.systems[id]; !ok {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+func (r *systemRepository) Create(ctx context.Context, system *domain.System) error {
+	defer r.s.lock()()
+	if system.ID == "" {
+		system.ID = uuid.New().String()
+	}
+	if _, ok := r.s.data.systems[system.ID]; ok {
+		return repository.ErrDuplicate
+	}
+	if system.Status == "" {
+		system.Status = domain.StatusActive
+	}
+	stamp(&system.CreatedAt, &system.UpdatedAt)
+
+	r.s.data.systems[system.ID] = entry[domain.System]{seq: r.s.nextSeq(), createdAt: system.CreatedAt, value: storedSystem(system)}
+	return nil
+}
+
+func (r *systemRepository) Update(ctx context.Context, system *domain.System) error {
+	defer r.s.lock()()
+	row, ok := r.s.data.systems[system.ID]
+	if !ok {
+		return repository.ErrNotFound
+	}
+
+	system.UpdatedAt = time.Now()
+	row.value = storedSystem(system)
+	row.value.CreatedAt = row.createdAt
+	r.s.data.systems[system.ID] = row
+	return nil
+}
+
+func (r *systemRepository) Delete(ctx context.Context, id string) error {
+	defer r.s.lock()()
+	if _, ok := r.s.data.systems[id]; !ok {
+		return repository.ErrNotFound
+	}
+	delete(r.s.data.systems, id)
+	return nil
+}
+
+// Helper function to match systems against a filter
+func systemMatcher(filter repository.SystemFilter) func(*domain.System) bool {
+	return func(s *domain.System) bool {
+		if filter.ParentID != "" && (s.ParentID == nil || *s.ParentID != filter.ParentID) {
+			return false
+		}
+		if filter.Name != "" && s.Name != filter.Name {
+			return false
+		}
+		if len(filter.IDs) > 0 && !slices.Contains(filter.IDs, s.ID) {
+			return false
+		}
+		if filter.Type != "" && s.Type != filter.Type {
+			return false
+		}
+		if filter.Status != "" && s.Status != filter.Status {
+			return false
+		}
+		return true
+	}
+}
+
+// Helper function to strip the relationships of a system before storing it
+func storedSystem(system *domain.System) domain.System {
+	stored := *system
+	stored.Parent = nil
+	stored.Subsystems = nil
+	stored.Builds = nil
+	return stored
+}
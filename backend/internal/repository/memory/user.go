@@ -0,0 +1,92 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"release-management/internal/models/domain"
+	"release-management/internal/repository"
+)
+
+type userRepository struct {
+	s *Store
+}
+
+func (r *userRepository) List(ctx context.Context) ([]domain.User, error) {
+	defer r.s.lock()()
+	users := ordered(r.s.data.users, nil)
+	sort.SliceStable(users, func(i, j int) bool {
+		return users[i].Email < users[j].Email
+	})
+	return users, nil
+}
+
+func (r *userRepository) Get(ctx context.Context, id uint) (*domain.User, error) {
+	defer r.s.lock()()
+	row, ok := r.s.data.users[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &row.value, nil
+}
+
+func (r *userRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	defer r.s.lock()()
+	for _, row := range r.s.data.users {
+		if row.value.Email == email {
+			return &row.value, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
+	defer r.s.lock()()
+	if r.emailTaken(user.Email, 0) {
+		return repository.ErrDuplicate
+	}
+	if user.ID == 0 {
+		r.s.data.nextUserID++
+		user.ID = r.s.data.nextUserID
+	} else if _, ok := r.s.data.users[user.ID]; ok {
+		return repository.ErrDuplicate
+	}
+	if user.ID > r.s.data.nextUserID {
+		r.s.data.nextUserID = user.ID
+	}
+	if user.Role == "" {
+		user.Role = domain.RoleViewer
+	}
+	stamp(&user.CreatedAt, &user.UpdatedAt)
+
+	r.s.data.users[user.ID] = entry[domain.User]{seq: r.s.nextSeq(), createdAt: user.CreatedAt, value: *user}
+	return nil
+}
+
+func (r *userRepository) Update(ctx context.Context, user *domain.User) error {
+	defer r.s.lock()()
+	row, ok := r.s.data.users[user.ID]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	if r.emailTaken(user.Email, user.ID) {
+		return repository.ErrDuplicate
+	}
+
+	user.UpdatedAt = time.Now()
+	row.value = *user
+	row.value.CreatedAt = row.createdAt
+	r.s.data.users[user.ID] = row
+	return nil
+}
+
+// Helper function to check if another user than exceptID has an email address
+func (r *userRepository) emailTaken(email string, exceptID uint) bool {
+	for id, row := range r.s.data.users {
+		if id != exceptID && row.value.Email == email {
+			return true
+		}
+	}
+	return false
+}
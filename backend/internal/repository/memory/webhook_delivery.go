@@ -0,0 +1,43 @@
+package memory
+
+import (
+	"context"
+
+	"release-management/internal/models/domain"
+	"release-management/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+type webhookDeliveryRepository struct {
+	s *Store
+}
+
+func (r *webhookDeliveryRepository) Get(ctx context.Context, provider, deliveryID string) (*domain.WebhookDelivery, error) {
+	defer r.s.lock()()
+	for _, row := range r.s.data.webhookDeliveries {
+		if row.value.Provider == provider && row.value.DeliveryID == deliveryID {
+			return &row.value, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *webhookDeliveryRepository) Create(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	defer r.s.lock()()
+	if delivery.ID == "" {
+		delivery.ID = uuid.New().String()
+	}
+	if _, ok := r.s.data.webhookDeliveries[delivery.ID]; ok {
+		return repository.ErrDuplicate
+	}
+	for _, row := range r.s.data.webhookDeliveries {
+		if row.value.Provider == delivery.Provider && row.value.DeliveryID == delivery.DeliveryID {
+			return repository.ErrDuplicate
+		}
+	}
+	stamp(&delivery.CreatedAt, nil)
+
+	r.s.data.webhookDeliveries[delivery.ID] = entry[domain.WebhookDelivery]{seq: r.s.nextSeq(), createdAt: delivery.CreatedAt, value: *delivery}
+	return nil
+}
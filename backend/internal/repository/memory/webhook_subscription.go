@@ -0,0 +1,162 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	"release-management/internal/models/domain"
+	"release-management/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+type webhookSubscriptionRepository struct {
+	s *Store
+}
+
+func (r *webhookSubscriptionRepository) List(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	defer r.s.lock()()
+	subscriptions := ordered(r.s.data.subscriptions, nil)
+	for i := range subscriptions {
+		subscriptions[i].EventTypes = slices.Clone(subscriptions[i].EventTypes)
+	}
+	return subscriptions, nil
+}
+
+func (r *webhookSubscriptionRepository) Get(ctx context.Context, id string) (*domain.WebhookSubscription, error) {
+	defer r.s.lock()()
+	row, ok := r.s.data.subscriptions[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	row.value.EventTypes = slices.Clone(row.value.EventTypes)
+	return &row.value, nil
+}
+
+func (r *webhookSubscriptionRepository) Create(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	defer r.s.lock()()
+	if subscription.ID == "" {
+		subscription.ID = uuid.New().String()
+	}
+	if _, ok := r.s.data.subscriptions[subscription.ID]; ok {
+		return repository.ErrDuplicate
+	}
+	stamp(&subscription.CreatedAt, &subscription.UpdatedAt)
+
+	r.s.data.subscriptions[subscription.ID] = entry[domain.WebhookSubscription]{
+		seq: r.s.nextSeq(), createdAt: subscription.CreatedAt, value: storedWebhookSubscription(subscription),
+	}
+	return nil
+}
+
+func (r *webhookSubscriptionRepository) Update(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	defer r.s.lock()()
+	row, ok := r.s.data.subscriptions[subscription.ID]
+	if !ok {
+		return repository.ErrNotFound
+	}
+
+	subscription.UpdatedAt = time.Now()
+	row.value = storedWebhookSubscription(subscription)
+	row.value.CreatedAt = row.createdAt
+	r.s.data.subscriptions[subscription.ID] = row
+	return nil
+}
+
+func (r *webhookSubscriptionRepository) Delete(ctx context.Context, id string) error {
+	defer r.s.lock()()
+	if _, ok := r.s.data.subscriptions[id]; !ok {
+		return repository.ErrNotFound
+	}
+	delete(r.s.data.subscriptions, id)
+	for deliveryID, row := range r.s.data.eventDeliveries {
+		if row.value.SubscriptionID == id {
+			delete(r.s.data.eventDeliveries, deliveryID)
+		}
+	}
+	return nil
+}
+
+func (r *webhookSubscriptionRepository) CreateDelivery(ctx context.Context, delivery *domain.EventDelivery) error {
+	defer r.s.lock()()
+	if delivery.ID == "" {
+		delivery.ID = uuid.New().String()
+	}
+	if _, ok := r.s.data.eventDeliveries[delivery.ID]; ok {
+		return repository.ErrDuplicate
+	}
+	stamp(&delivery.CreatedAt, &delivery.UpdatedAt)
+
+	stored := *delivery
+	stored.Event = nil
+	r.s.data.eventDeliveries[delivery.ID] = entry[domain.EventDelivery]{seq: r.s.nextSeq(), createdAt: delivery.CreatedAt, value: stored}
+	return nil
+}
+
+func (r *webhookSubscriptionRepository) GetDelivery(ctx context.Context, id string) (*domain.EventDelivery, error) {
+	defer r.s.lock()()
+	row, ok := r.s.data.eventDeliveries[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	row.value.Event = r.s.event(row.value.EventID)
+	return &row.value, nil
+}
+
+func (r *webhookSubscriptionRepository) UpdateDelivery(ctx context.Context, delivery *domain.EventDelivery) error {
+	defer r.s.lock()()
+	row, ok := r.s.data.eventDeliveries[delivery.ID]
+	if !ok {
+		return repository.ErrNotFound
+	}
+
+	delivery.UpdatedAt = time.Now()
+	row.value = *delivery
+	row.value.CreatedAt = row.createdAt
+	row.value.Event = nil
+	r.s.data.eventDeliveries[delivery.ID] = row
+	return nil
+}
+
+func (r *webhookSubscriptionRepository) ListDeliveries(ctx context.Context, filter repository.EventDeliveryFilter, page repository.PageRequest) (*repository.Page[domain.EventDelivery], error) {
+	defer r.s.lock()()
+	return paginate(ordered(r.s.data.eventDeliveries, func(d *domain.EventDelivery) bool {
+		return (filter.SubscriptionID == "" || d.SubscriptionID == filter.SubscriptionID) &&
+			(filter.Status == "" || d.Status == filter.Status) &&
+			(filter.EventType == "" || d.EventType == filter.EventType)
+	}), repository.EventDeliverySortFields, page)
+}
+
+func (r *webhookSubscriptionRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.EventDelivery, error) {
+	defer r.s.lock()()
+	due := ordered(r.s.data.eventDeliveries, func(d *domain.EventDelivery) bool {
+		return (d.Status == domain.EventDeliveryPending || d.Status == domain.EventDeliveryRetrying) &&
+			d.NextAttemptAt != nil && !d.NextAttemptAt.After(now)
+	})
+	sort.SliceStable(due, func(i, j int) bool {
+		return due[i].NextAttemptAt.Before(*due[j].NextAttemptAt)
+	})
+	if limit > 0 && len(due) > limit {
+		due = due[:limit]
+	}
+
+	leasedUntil := now.Add(lease)
+	for i := range due {
+		row := r.s.data.eventDeliveries[due[i].ID]
+		row.value.NextAttemptAt = &leasedUntil
+		r.s.data.eventDeliveries[due[i].ID] = row
+
+		due[i].NextAttemptAt = &leasedUntil
+		due[i].Event = r.s.event(due[i].EventID)
+	}
+	return due, nil
+}
+
+// Helper function to copy the event types of a subscription before storing it
+func storedWebhookSubscription(subscription *domain.WebhookSubscription) domain.WebhookSubscription {
+	stored := *subscription
+	stored.EventTypes = slices.Clone(subscription.EventTypes)
+	return stored
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+)
+
+// ErrInvalidCursor is returned when a cursor is malformed or was issued for another sort order
+var ErrInvalidCursor = errors.New("invalid page cursor")
+
+// timeKeyLayout formats times with a fixed width so that their keys sort like the times themselves
+const timeKeyLayout = "2006-01-02T15:04:05.000000000Z"
+
+// Sort orders a list by one field. Items with the same value are ordered by ID.
+type Sort struct {
+	Field string
+	Desc  bool
+}
+
+// String returns the field, prefixed with "-" when sorting in descending order
+func (s Sort) String() string {
+	if s.Desc {
+		return "-" + s.Field
+	}
+	return s.Field
+}
+
+// PageRequest selects one page of a sorted list
+type PageRequest struct {
+	// Limit caps the number of items on the page, 0 for no limit
+	Limit int
+	Sort  Sort
+	// Cursor continues after the last item of a previous page, empty for the first page
+	Cursor string
+}
+
+// Page is one page of a sorted list
+type Page[T any] struct {
+	Items []T
+	// Total is the number of items matching the filter across all pages
+	Total int64
+	// NextCursor continues with the next page, empty on the last page
+	NextCursor string
+}
+
+// SortFields describes how the items of a list can be sorted.
+// Keys returns, per field name, the key of an item for that field. Keys compare like the field values, see TimeKey.
+type SortFields[T any] struct {
+	ID   func(item *T) string
+	Keys map[string]func(item *T) string
+}
+
+// Names returns the fields the list can be sorted by in alphabetical order
+func (f SortFields[T]) Names() []string {
+	names := make([]string, 0, len(f.Keys))
+	for name := range f.Keys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Has reports whether the list can be sorted by field
+func (f SortFields[T]) Has(field string) bool {
+	_, ok := f.Keys[field]
+	return ok
+}
+
+// CursorAfter returns the cursor that continues a list sorted by s after item
+func (f SortFields[T]) CursorAfter(item *T, s Sort) string {
+	return Cursor{Sort: s.String(), Key: f.Keys[s.Field](item), ID: f.ID(item)}.Encode()
+}
+
+// Cursor is the position of an item in a sorted list
+type Cursor struct {
+	// Sort is the sort order the cursor was issued for
+	Sort string `json:"s"`
+	Key  string `json:"k"`
+	ID   string `json:"id"`
+}
+
+// Encode returns the cursor as an opaque URL safe token
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses a token returned by Cursor.Encode and checks that it was issued for sort s
+func DecodeCursor(token string, s Sort) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.Sort != s.String() {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
+// TimeKey returns the sort key of a time
+func TimeKey(t time.Time) string {
+	return t.UTC().Format(timeKeyLayout)
+}
+
+// ParseTimeKey parses a key returned by TimeKey
+func ParseTimeKey(key string) (time.Time, error) {
+	t, err := time.Parse(timeKeyLayout, key)
+	if err != nil {
+		return time.Time{}, ErrInvalidCursor
+	}
+	return t, nil
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+
+	"release-management/internal/models/domain"
+)
+
+// ReleaseFilter narrows down the releases returned by ReleaseRepository.ListPage. Empty fields match every release.
+type ReleaseFilter struct {
+	Status domain.ReleaseStatus
+	Type   domain.ReleaseType
+}
+
+// ReleaseSortFields lists the fields releases can be sorted by
+var ReleaseSortFields = SortFields[domain.Release]{
+	ID: func(r *domain.Release) string { return r.ID },
+	Keys: map[string]func(*domain.Release) string{
+		"created_at":   func(r *domain.Release) string { return TimeKey(r.CreatedAt) },
+		"name":         func(r *domain.Release) string { return r.Name },
+		"release_date": func(r *domain.Release) string { return TimeKey(r.ReleaseDate) },
+	},
+}
+
+// ReleaseRepository stores releases and the history of their lifecycle transitions
+type ReleaseRepository interface {
+	// List returns every release, oldest first
+	List(ctx context.Context) ([]domain.Release, error)
+
+	// ListPage returns a page of the releases matching filter, sorted by one of ReleaseSortFields
+	ListPage(ctx context.Context, filter ReleaseFilter, page PageRequest) (*Page[domain.Release], error)
+
+	// Get returns a release or ErrNotFound
+	Get(ctx context.Context, id string) (*domain.Release, error)
+
+	// Create stores a new release and fills in its ID, default status and timestamps
+	Create(ctx context.Context, release *domain.Release) error
+
+	// Update stores every field of an existing release or returns ErrNotFound
+	Update(ctx context.Context, release *domain.Release) error
+
+	// Delete removes a release or returns ErrNotFound
+	Delete(ctx context.Context, id string) error
+
+	// Transition moves a release from transition.FromStatus to transition.ToStatus and records the transition.
+	// ErrConflict is returned if the release is no longer in transition.FromStatus.
+	Transition(ctx context.Context, transition *domain.ReleaseTransition) error
+
+	// ListTransitions returns the transitions of a release with the users who made them, oldest first
+	ListTransitions(ctx context.Context, releaseID string) ([]domain.ReleaseTransition, error)
+}
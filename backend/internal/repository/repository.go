@@ -0,0 +1,43 @@
+// Package repository defines how handlers load and store the aggregates of the release manager.
+// Repositories work on domain types so that handlers do not depend on the database,
+// see the gormrepo package for the Postgres implementation and the memory package for tests.
+package repository
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrNotFound is returned when a record does not exist
+	ErrNotFound = errors.New("record not found")
+
+	// ErrDuplicate is returned when a record would break a uniqueness constraint
+	ErrDuplicate = errors.New("record already exists")
+
+	// ErrConflict is returned when a record was changed by another request in the meantime
+	ErrConflict = errors.New("record was changed concurrently")
+)
+
+// Store gives access to the repository of every aggregate
+type Store interface {
+	Releases() ReleaseRepository
+	Builds() BuildRepository
+	Systems() SystemRepository
+	Environments() EnvironmentRepository
+	EnvironmentGroups() EnvironmentGroupRepository
+	Deployments() DeploymentRepository
+	Users() UserRepository
+	APITokens() APITokenRepository
+	Audit() AuditRepository
+	WebhookDeliveries() WebhookDeliveryRepository
+	WebhookSubscriptions() WebhookSubscriptionRepository
+	FreezeWindows() FreezeWindowRepository
+	ChangeRequests() ChangeRequestRepository
+	Events() EventRepository
+	Search() SearchRepository
+
+	// Transaction runs fn with a store whose repositories share a single transaction.
+	// The transaction is rolled back if fn returns an error and committed otherwise.
+	Transaction(ctx context.Context, fn func(tx Store) error) error
+}
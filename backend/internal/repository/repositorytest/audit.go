@@ -0,0 +1,107 @@
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"release-management/internal/models/domain"
+	"release-management/internal/repository"
+)
+
+func testAudit(t *testing.T, s repository.Store) {
+	ctx := context.Background()
+	entryID := func(e *domain.AuditEntry) string { return e.ID }
+
+	user := createUser(t, s, "auditor@example.com")
+	created := &domain.AuditEntry{Action: domain.AuditActionCreate, EntityType: domain.AuditEntityRelease, EntityID: "release-1", ActorID: &user.ID, Actor: user.Email,
+		After: map[string]interface{}{"name": "2024.1"}, RequestID: "req-1", SourceIP: "10.0.0.1", CreatedAt: day(1)}
+	updated := &domain.AuditEntry{Action: domain.AuditActionUpdate, EntityType: domain.AuditEntityRelease, EntityID: "release-1", ActorID: &user.ID, Actor: user.Email,
+		Before: map[string]interface{}{"name": "2024.1"}, After: map[string]interface{}{"name": "2024.2"},
+		Changes: map[string]domain.AuditChange{"name": {Before: "2024.1", After: "2024.2"}}, CreatedAt: day(2)}
+	webhook := &domain.AuditEntry{Action: domain.AuditActionCreate, EntityType: domain.AuditEntityBuild, EntityID: "build-1", Actor: "webhook:github", CreatedAt: day(3)}
+	for _, entry := range []*domain.AuditEntry{created, updated, webhook} {
+		must(t, s.Audit().Create(ctx, entry))
+		if entry.ID == "" {
+			t.Fatalf("Create() did not fill in the ID: %+v", entry)
+		}
+	}
+
+	newestFirst := repository.PageRequest{Sort: repository.Sort{Field: "created_at", Desc: true}}
+	page, err := s.Audit().ListPage(ctx, repository.AuditFilter{}, newestFirst)
+	must(t, err)
+	entries := page.Items
+	expectIDs(t, "ListPage() without filter", ids(entries, entryID), webhook.ID, updated.ID, created.ID)
+	if change, ok := entries[1].Changes["name"]; !ok || change.After != "2024.2" {
+		t.Errorf("ListPage() changes = %+v", entries[1].Changes)
+	}
+	if entries[2].After["name"] != "2024.1" || entries[2].RequestID != "req-1" || entries[2].SourceIP != "10.0.0.1" {
+		t.Errorf("ListPage() entry = %+v", entries[2])
+	}
+
+	page, err = s.Audit().ListPage(ctx, repository.AuditFilter{EntityType: domain.AuditEntityRelease, EntityID: "release-1", Action: domain.AuditActionUpdate}, newestFirst)
+	must(t, err)
+	expectIDs(t, "ListPage() by entity and action", ids(page.Items, entryID), updated.ID)
+
+	limited := newestFirst
+	limited.Limit = 1
+	page, err = s.Audit().ListPage(ctx, repository.AuditFilter{ActorID: &user.ID}, limited)
+	must(t, err)
+	expectIDs(t, "ListPage() by actor ID with limit", ids(page.Items, entryID), updated.ID)
+	if page.Total != 2 || page.NextCursor == "" {
+		t.Errorf("ListPage() by actor ID with limit: total %d, next cursor %q", page.Total, page.NextCursor)
+	}
+
+	page, err = s.Audit().ListPage(ctx, repository.AuditFilter{Actor: "webhook:github"}, newestFirst)
+	must(t, err)
+	expectIDs(t, "ListPage() by actor name", ids(page.Items, entryID), webhook.ID)
+
+	since, until := day(2), day(2)
+	page, err = s.Audit().ListPage(ctx, repository.AuditFilter{Since: &since, Until: &until}, newestFirst)
+	must(t, err)
+	expectIDs(t, "ListPage() by time range", ids(page.Items, entryID), updated.ID)
+
+	var streamed []string
+	must(t, s.Audit().Each(ctx, repository.AuditFilter{}, func(entry *domain.AuditEntry) error {
+		streamed = append(streamed, entry.ID)
+		return nil
+	}))
+	expectIDs(t, "Each()", streamed, created.ID, updated.ID, webhook.ID)
+
+	errStop := errors.New("stop")
+	calls := 0
+	err = s.Audit().Each(ctx, repository.AuditFilter{}, func(entry *domain.AuditEntry) error {
+		calls++
+		return errStop
+	})
+	if !errors.Is(err, errStop) || calls != 1 {
+		t.Errorf("Each() with a failing callback: error = %v after %d calls, want %v after 1", err, calls, errStop)
+	}
+}
+
+func testWebhookDeliveries(t *testing.T, s repository.Store) {
+	ctx := context.Background()
+
+	message := "Registered build"
+	delivery := &domain.WebhookDelivery{Provider: "github", DeliveryID: "delivery-1", Status: domain.WebhookDeliveryProcessed, Message: message}
+	must(t, s.WebhookDeliveries().Create(ctx, delivery))
+	if delivery.ID == "" || delivery.CreatedAt.IsZero() {
+		t.Fatalf("Create() did not fill in ID and creation time: %+v", delivery)
+	}
+
+	// The same delivery ID of another provider is a different delivery
+	must(t, s.WebhookDeliveries().Create(ctx, &domain.WebhookDelivery{Provider: "gitlab", DeliveryID: "delivery-1", Status: domain.WebhookDeliveryIgnored}))
+	expectError(t, "Create() of a redelivery", repository.ErrDuplicate, func() error {
+		return s.WebhookDeliveries().Create(ctx, &domain.WebhookDelivery{Provider: "github", DeliveryID: "delivery-1", Status: domain.WebhookDeliveryIgnored})
+	})
+
+	got, err := s.WebhookDeliveries().Get(ctx, "github", "delivery-1")
+	must(t, err)
+	if got.ID != delivery.ID || got.Status != domain.WebhookDeliveryProcessed || got.Message != message {
+		t.Errorf("Get() = %+v, want %+v", got, delivery)
+	}
+	expectNotFound(t, "Get() of an unknown delivery", func() error {
+		_, err := s.WebhookDeliveries().Get(ctx, "jenkins", "delivery-1")
+		return err
+	})
+}
@@ -0,0 +1,142 @@
+package repositorytest
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"release-management/internal/models/domain"
+	"release-management/internal/repository"
+)
+
+func testBuilds(t *testing.T, s repository.Store) {
+	ctx := context.Background()
+	buildID := func(b *domain.Build) string { return b.ID }
+
+	release := createRelease(t, s, "2024.1")
+	api := createSystem(t, s, "api", nil)
+	web := createSystem(t, s, "web", nil)
+
+	first := &domain.Build{
+		SystemID: api.ID, ReleaseID: &release.ID, Version: "1.0.0", BuildDate: day(1), Changelog: "First release", CreatedAt: day(1),
+		RepositoryURL: "https://git.example.com/api", CommitSHA: "9fceb02d0ae598e95dc970b74767f19372d61af8", Branch: "main",
+		PipelineURL: "https://ci.example.com/runs/1", ImageDigest: "sha256:" + strings.Repeat("a", 64), PackageChecksum: "sha256:" + strings.Repeat("b", 64),
+	}
+	second := &domain.Build{SystemID: api.ID, Version: "1.1.0", BuildDate: day(2), CommitSHA: "9fceb17aa1b2c3d4e5f60718293a4b5c6d7e8f90", CreatedAt: day(2)}
+	third := &domain.Build{SystemID: web.ID, ReleaseID: &release.ID, Version: "2.0.0", BuildDate: day(3), CreatedAt: day(3)}
+	for _, build := range []*domain.Build{first, second, third} {
+		must(t, s.Builds().Create(ctx, build))
+		if build.ID == "" || build.UpdatedAt.IsZero() {
+			t.Fatalf("Create() did not fill in ID and timestamps: %+v", build)
+		}
+	}
+
+	got, err := s.Builds().Get(ctx, first.ID)
+	must(t, err)
+	if got.Version != "1.0.0" || !got.BuildDate.Equal(first.BuildDate) || got.Changelog != "First release" {
+		t.Errorf("Get() = %+v, want %+v", got, first)
+	}
+	if got.RepositoryURL != first.RepositoryURL || got.CommitSHA != first.CommitSHA || got.Branch != "main" ||
+		got.PipelineURL != first.PipelineURL || got.ImageDigest != first.ImageDigest || got.PackageChecksum != first.PackageChecksum {
+		t.Errorf("Get() did not store the provenance: %+v", got)
+	}
+	if got.System == nil || got.System.Name != "api" || got.Release == nil || got.Release.Name != "2024.1" {
+		t.Errorf("Get() did not load the system and release: %+v, %+v", got.System, got.Release)
+	}
+	expectNotFound(t, "Get() of a missing build", func() error {
+		_, err := s.Builds().Get(ctx, "missing")
+		return err
+	})
+
+	builds, err := s.Builds().List(ctx, repository.BuildFilter{})
+	must(t, err)
+	expectIDs(t, "List() without filter", ids(builds, buildID), first.ID, second.ID, third.ID)
+	if builds[1].System == nil || builds[1].Release != nil {
+		t.Errorf("List() relationships of a build without release: %+v, %+v", builds[1].System, builds[1].Release)
+	}
+
+	builds, err = s.Builds().List(ctx, repository.BuildFilter{SystemID: api.ID})
+	must(t, err)
+	expectIDs(t, "List() by system", ids(builds, buildID), first.ID, second.ID)
+
+	builds, err = s.Builds().List(ctx, repository.BuildFilter{ReleaseID: release.ID})
+	must(t, err)
+	expectIDs(t, "List() by release", ids(builds, buildID), first.ID, third.ID)
+
+	builds, err = s.Builds().List(ctx, repository.BuildFilter{CommitSHA: "9fceb"})
+	must(t, err)
+	expectIDs(t, "List() by abbreviated commit", ids(builds, buildID), first.ID, second.ID)
+	builds, err = s.Builds().List(ctx, repository.BuildFilter{CommitSHA: first.CommitSHA})
+	must(t, err)
+	expectIDs(t, "List() by commit", ids(builds, buildID), first.ID)
+	builds, err = s.Builds().List(ctx, repository.BuildFilter{ImageDigest: first.ImageDigest})
+	must(t, err)
+	expectIDs(t, "List() by image digest", ids(builds, buildID), first.ID)
+
+	count, err := s.Builds().Count(ctx, repository.BuildFilter{SystemID: api.ID, ReleaseID: release.ID})
+	must(t, err)
+	if count != 1 {
+		t.Errorf("Count() = %d, want 1", count)
+	}
+
+	// Walk the builds newest build date first, two per page
+	byBuildDate := repository.PageRequest{Limit: 2, Sort: repository.Sort{Field: "build_date", Desc: true}}
+	page, err := s.Builds().ListPage(ctx, repository.BuildFilter{}, byBuildDate)
+	must(t, err)
+	expectIDs(t, "ListPage() first page", ids(page.Items, buildID), third.ID, second.ID)
+	if page.Total != 3 || page.NextCursor == "" {
+		t.Fatalf("ListPage() first page: total %d, next cursor %q", page.Total, page.NextCursor)
+	}
+	if page.Items[0].System == nil || page.Items[0].Release == nil {
+		t.Errorf("ListPage() did not load the system and release: %+v", page.Items[0])
+	}
+	byBuildDate.Cursor = page.NextCursor
+	page, err = s.Builds().ListPage(ctx, repository.BuildFilter{}, byBuildDate)
+	must(t, err)
+	expectIDs(t, "ListPage() second page", ids(page.Items, buildID), first.ID)
+	if page.NextCursor != "" {
+		t.Errorf("ListPage() last page: next cursor %q, want none", page.NextCursor)
+	}
+
+	// A cursor only continues the sort order it was issued for
+	expectError(t, "ListPage() with a cursor of another sort order", repository.ErrInvalidCursor, func() error {
+		_, err := s.Builds().ListPage(ctx, repository.BuildFilter{}, repository.PageRequest{Sort: repository.Sort{Field: "build_date"}, Cursor: byBuildDate.Cursor})
+		return err
+	})
+	expectError(t, "ListPage() with a malformed cursor", repository.ErrInvalidCursor, func() error {
+		_, err := s.Builds().ListPage(ctx, repository.BuildFilter{}, repository.PageRequest{Sort: repository.Sort{Field: "build_date"}, Cursor: "not-a-cursor"})
+		return err
+	})
+
+	builtAfter, builtBefore := day(2), day(3)
+	page, err = s.Builds().ListPage(ctx, repository.BuildFilter{BuiltAfter: &builtAfter, BuiltBefore: &builtBefore}, repository.PageRequest{Sort: repository.Sort{Field: "created_at"}})
+	must(t, err)
+	expectIDs(t, "ListPage() by build date", ids(page.Items, buildID), second.ID, third.ID)
+
+	found, err := s.Builds().FindByVersion(ctx, api.ID, "1.1.0")
+	must(t, err)
+	if found.ID != second.ID {
+		t.Errorf("FindByVersion() = %s, want %s", found.ID, second.ID)
+	}
+	expectNotFound(t, "FindByVersion() of a version of another system", func() error {
+		_, err := s.Builds().FindByVersion(ctx, web.ID, "1.1.0")
+		return err
+	})
+
+	got.Version = "1.0.1"
+	got.ReleaseID = nil
+	must(t, s.Builds().Update(ctx, got))
+	updated, err := s.Builds().Get(ctx, first.ID)
+	must(t, err)
+	if updated.Version != "1.0.1" || updated.ReleaseID != nil {
+		t.Errorf("Update() did not store every field: %+v", updated)
+	}
+	expectNotFound(t, "Update() of a missing build", func() error {
+		return s.Builds().Update(ctx, &domain.Build{ID: "missing", SystemID: api.ID, Version: "1.0.0"})
+	})
+
+	must(t, s.Builds().Delete(ctx, second.ID))
+	expectNotFound(t, "Delete() of a missing build", func() error {
+		return s.Builds().Delete(ctx, second.ID)
+	})
+}
@@ -0,0 +1,111 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+
+	"release-management/internal/models/domain"
+	"release-management/internal/repository"
+)
+
+func testChangeRequests(t *testing.T, s repository.Store) {
+	ctx := context.Background()
+	requestID := func(r *domain.ChangeRequest) string { return r.ID }
+
+	author := createUser(t, s, "author@example.com")
+	approver := createUser(t, s, "approver@example.com")
+	release := createRelease(t, s, "2024.06")
+	prod := createEnvironment(t, s, "Production", release.ID, nil)
+	api := createSystem(t, s, "api", nil)
+	web := createSystem(t, s, "web", nil)
+
+	hotfix := &domain.ChangeRequest{Title: "Hotfix", Description: "Fix checkout", EnvironmentID: prod.ID, Status: domain.ChangeRequestStatusPending,
+		RequiredApprovals: 2, ApproverRole: domain.RoleReleaseManager, AuthorID: author.ID, ExpiresAt: day(3), CreatedAt: day(1),
+		Changes: []domain.ChangeRequestChange{
+			{SystemID: web.ID, FromVersion: "2.0.0", ToVersion: "2.0.1"},
+			{SystemID: api.ID, ToVersion: "1.0.0"},
+		}}
+	launch := &domain.ChangeRequest{Title: "Launch", EnvironmentID: prod.ID, Status: domain.ChangeRequestStatusPending,
+		RequiredApprovals: 1, ApproverRole: domain.RoleReleaseManager, AuthorID: approver.ID, ExpiresAt: day(10), CreatedAt: day(2),
+		Changes: []domain.ChangeRequestChange{{SystemID: api.ID, FromVersion: "1.0.0", ToVersion: "1.1.0"}}}
+	for _, request := range []*domain.ChangeRequest{hotfix, launch} {
+		must(t, s.ChangeRequests().Create(ctx, request))
+		if request.ID == "" || request.UpdatedAt.IsZero() {
+			t.Fatalf("Create() did not fill in ID and timestamps: %+v", request)
+		}
+	}
+
+	// Changes keep the order they were requested in
+	got, err := s.ChangeRequests().Get(ctx, hotfix.ID)
+	must(t, err)
+	if got.Title != "Hotfix" || got.RequiredApprovals != 2 || got.ApproverRole != domain.RoleReleaseManager || got.AuthorID != author.ID ||
+		len(got.Changes) != 2 || got.Changes[0].SystemID != web.ID || got.Changes[0].FromVersion != "2.0.0" || got.Changes[1].ToVersion != "1.0.0" {
+		t.Errorf("Get() = %+v", got)
+	}
+	expectNotFound(t, "Get() of a missing request", func() error {
+		_, err := s.ChangeRequests().Get(ctx, "missing")
+		return err
+	})
+
+	// A user approves a request only once
+	approval := &domain.ChangeRequestApproval{ChangeRequestID: hotfix.ID, UserID: approver.ID}
+	must(t, s.ChangeRequests().AddApproval(ctx, approval))
+	if approval.ID == "" || approval.CreatedAt.IsZero() {
+		t.Errorf("AddApproval() did not fill in ID and creation time: %+v", approval)
+	}
+	expectError(t, "AddApproval() twice", repository.ErrDuplicate, func() error {
+		return s.ChangeRequests().AddApproval(ctx, &domain.ChangeRequestApproval{ChangeRequestID: hotfix.ID, UserID: approver.ID})
+	})
+	for _, body := range []string{"Looks good", "Checked the dashboards"} {
+		must(t, s.ChangeRequests().AddComment(ctx, &domain.ChangeRequestComment{ChangeRequestID: hotfix.ID, UserID: author.ID, Body: body}))
+	}
+	got, err = s.ChangeRequests().Get(ctx, hotfix.ID)
+	must(t, err)
+	if len(got.Approvals) != 1 || got.Approvals[0].UserID != approver.ID || len(got.Comments) != 2 || got.Comments[1].Body != "Checked the dashboards" {
+		t.Errorf("Get() with reviews = %+v", got)
+	}
+
+	// Updates change the status and decision, not the changes
+	hotfix.Status = domain.ChangeRequestStatusRejected
+	hotfix.RejectedBy = &approver.ID
+	hotfix.RejectionReason = "Wrong version"
+	hotfix.Changes = nil
+	must(t, s.ChangeRequests().Update(ctx, hotfix))
+	got, err = s.ChangeRequests().Get(ctx, hotfix.ID)
+	must(t, err)
+	if got.Status != domain.ChangeRequestStatusRejected || got.RejectedBy == nil || *got.RejectedBy != approver.ID || got.RejectionReason != "Wrong version" ||
+		len(got.Changes) != 2 || !got.CreatedAt.Equal(day(1)) {
+		t.Errorf("Get() after Update() = %+v", got)
+	}
+	expectNotFound(t, "Update() of a missing request", func() error {
+		return s.ChangeRequests().Update(ctx, &domain.ChangeRequest{ID: "missing", Title: "Missing", Status: domain.ChangeRequestStatusPending, AuthorID: author.ID})
+	})
+
+	// Reviews lock the request so they see each other's decisions
+	expectLockWaits(t, s, "Lock() of a request", func(tx repository.Store) error {
+		return tx.ChangeRequests().Lock(ctx, launch.ID)
+	})
+	expectNotFound(t, "Lock() of a missing request", func() error {
+		return s.ChangeRequests().Lock(ctx, "missing")
+	})
+
+	newestFirst := repository.PageRequest{Sort: repository.Sort{Field: "created_at", Desc: true}}
+	page, err := s.ChangeRequests().List(ctx, repository.ChangeRequestFilter{}, newestFirst)
+	must(t, err)
+	expectIDs(t, "List()", ids(page.Items, requestID), launch.ID, hotfix.ID)
+	if len(page.Items[1].Changes) != 2 || len(page.Items[1].Comments) != 2 {
+		t.Errorf("List() entry = %+v", page.Items[1])
+	}
+	page, err = s.ChangeRequests().List(ctx, repository.ChangeRequestFilter{Status: domain.ChangeRequestStatusPending, EnvironmentID: prod.ID}, newestFirst)
+	must(t, err)
+	expectIDs(t, "List() of pending requests", ids(page.Items, requestID), launch.ID)
+	page, err = s.ChangeRequests().List(ctx, repository.ChangeRequestFilter{AuthorID: author.ID}, newestFirst)
+	must(t, err)
+	expectIDs(t, "List() of an author", ids(page.Items, requestID), hotfix.ID)
+
+	// Only open requests expire
+	must(t, s.ChangeRequests().Expire(ctx, day(20)))
+	page, err = s.ChangeRequests().List(ctx, repository.ChangeRequestFilter{Status: domain.ChangeRequestStatusExpired}, newestFirst)
+	must(t, err)
+	expectIDs(t, "List() of expired requests", ids(page.Items, requestID), launch.ID)
+}
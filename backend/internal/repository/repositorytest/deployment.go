@@ -0,0 +1,96 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+
+	"release-management/internal/models/domain"
+	"release-management/internal/repository"
+)
+
+func testDeployments(t *testing.T, s repository.Store) {
+	ctx := context.Background()
+	deploymentID := func(d *domain.Deployment) string { return d.ID }
+
+	release := createRelease(t, s, "2024.1")
+	dev := createEnvironment(t, s, "dev", release.ID, nil)
+	prod := createEnvironment(t, s, "prod", release.ID, nil)
+	api := createSystem(t, s, "api", nil)
+	web := createSystem(t, s, "web", nil)
+	user := createUser(t, s, "deployer@example.com")
+
+	first := &domain.Deployment{EnvironmentID: dev.ID, SystemID: api.ID, NewVersion: "1.0.0", UserID: &user.ID, Source: domain.DeploymentSourceManual, CreatedAt: day(1)}
+	second := &domain.Deployment{EnvironmentID: dev.ID, SystemID: api.ID, OldVersion: "1.0.0", NewVersion: "1.1.0", Source: domain.DeploymentSourceSync, CreatedAt: day(2)}
+	third := &domain.Deployment{EnvironmentID: dev.ID, SystemID: web.ID, NewVersion: "2.0.0", Source: domain.DeploymentSourceAPI, CreatedAt: day(3)}
+	fourth := &domain.Deployment{EnvironmentID: prod.ID, SystemID: api.ID, NewVersion: "1.0.0", Source: domain.DeploymentSourcePromotion, CreatedAt: day(4)}
+	for _, deployment := range []*domain.Deployment{first, second, third, fourth} {
+		must(t, s.Deployments().Create(ctx, deployment))
+		if deployment.ID == "" {
+			t.Fatalf("Create() did not fill in the ID: %+v", deployment)
+		}
+	}
+
+	got, err := s.Deployments().Get(ctx, first.ID)
+	must(t, err)
+	if got.NewVersion != "1.0.0" || got.Source != domain.DeploymentSourceManual || !got.CreatedAt.Equal(day(1)) {
+		t.Errorf("Get() = %+v, want %+v", got, first)
+	}
+	if got.System == nil || got.System.Name != "api" || got.User == nil || got.User.Email != user.Email {
+		t.Errorf("Get() did not load the system and user: %+v, %+v", got.System, got.User)
+	}
+	expectNotFound(t, "Get() of a missing deployment", func() error {
+		_, err := s.Deployments().Get(ctx, "missing")
+		return err
+	})
+
+	deployments, err := s.Deployments().List(ctx, repository.DeploymentFilter{EnvironmentID: dev.ID})
+	must(t, err)
+	expectIDs(t, "List() by environment", ids(deployments, deploymentID), third.ID, second.ID, first.ID)
+	if deployments[0].System == nil || deployments[0].User != nil {
+		t.Errorf("List() relationships of a deployment without user: %+v, %+v", deployments[0].System, deployments[0].User)
+	}
+
+	deployments, err = s.Deployments().List(ctx, repository.DeploymentFilter{SystemID: api.ID, OldestFirst: true})
+	must(t, err)
+	expectIDs(t, "List() by system oldest first", ids(deployments, deploymentID), first.ID, second.ID, fourth.ID)
+
+	// Versions match the version put in place as well as the one replaced
+	deployments, err = s.Deployments().List(ctx, repository.DeploymentFilter{Version: "1.0.0"})
+	must(t, err)
+	expectIDs(t, "List() by version", ids(deployments, deploymentID), fourth.ID, second.ID, first.ID)
+
+	since, until := day(2), day(3)
+	deployments, err = s.Deployments().List(ctx, repository.DeploymentFilter{Since: &since, Until: &until})
+	must(t, err)
+	expectIDs(t, "List() by time range", ids(deployments, deploymentID), third.ID, second.ID)
+
+	deployments, err = s.Deployments().List(ctx, repository.DeploymentFilter{Limit: 2})
+	must(t, err)
+	expectIDs(t, "List() with limit", ids(deployments, deploymentID), fourth.ID, third.ID)
+
+	newestFirst := repository.PageRequest{Limit: 2, Sort: repository.Sort{Field: "created_at", Desc: true}}
+	page, err := s.Deployments().ListPage(ctx, repository.DeploymentFilter{EnvironmentID: dev.ID}, newestFirst)
+	must(t, err)
+	expectIDs(t, "ListPage() by environment", ids(page.Items, deploymentID), third.ID, second.ID)
+	if page.Total != 3 || page.NextCursor == "" || page.Items[0].System == nil {
+		t.Errorf("ListPage() by environment: total %d, next cursor %q, system %+v", page.Total, page.NextCursor, page.Items[0].System)
+	}
+	newestFirst.Cursor = page.NextCursor
+	page, err = s.Deployments().ListPage(ctx, repository.DeploymentFilter{EnvironmentID: dev.ID}, newestFirst)
+	must(t, err)
+	expectIDs(t, "ListPage() by environment after the cursor", ids(page.Items, deploymentID), first.ID)
+	if page.NextCursor != "" || page.Items[0].User == nil {
+		t.Errorf("ListPage() last page: next cursor %q, user %+v", page.NextCursor, page.Items[0].User)
+	}
+
+	// History outlives the systems it mentions
+	must(t, s.Systems().Delete(ctx, web.ID))
+	got, err = s.Deployments().Get(ctx, third.ID)
+	must(t, err)
+	if got.SystemID != web.ID || got.System != nil {
+		t.Errorf("Get() of a deployment of a deleted system = %+v, system %+v", got, got.System)
+	}
+	deployments, err = s.Deployments().List(ctx, repository.DeploymentFilter{SystemID: web.ID})
+	must(t, err)
+	expectIDs(t, "List() by deleted system", ids(deployments, deploymentID), third.ID)
+}
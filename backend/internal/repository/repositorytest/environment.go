@@ -0,0 +1,146 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+
+	"release-management/internal/models/domain"
+	"release-management/internal/repository"
+)
+
+func testEnvironments(t *testing.T, s repository.Store) {
+	ctx := context.Background()
+	environmentID := func(e *domain.Environment) string { return e.ID }
+
+	release := createRelease(t, s, "2024.1")
+	other := createRelease(t, s, "2024.2")
+	group := &domain.EnvironmentGroup{Name: "EU"}
+	must(t, s.EnvironmentGroups().Create(ctx, group))
+
+	url := "https://dev.example.com"
+	dev := &domain.Environment{Name: "dev", Type: domain.EnvTypeDev, URL: &url, ReleaseID: release.ID, EnvironmentGroupID: &group.ID, CreatedAt: day(0)}
+	must(t, s.Environments().Create(ctx, dev))
+	if dev.ID == "" || dev.UpdatedAt.IsZero() {
+		t.Fatalf("Create() did not fill in ID and timestamps: %+v", dev)
+	}
+	if dev.Status != domain.EnvStatusPending {
+		t.Errorf("default status = %q, want %q", dev.Status, domain.EnvStatusPending)
+	}
+	prod := &domain.Environment{Name: "prod", Type: domain.EnvTypeProd, Status: domain.EnvStatusActive, ReleaseID: release.ID, CreatedAt: day(1)}
+	must(t, s.Environments().Create(ctx, prod))
+	staging := &domain.Environment{Name: "staging", Type: domain.EnvTypeStaging, ReleaseID: other.ID, EnvironmentGroupID: &group.ID, CreatedAt: day(2)}
+	must(t, s.Environments().Create(ctx, staging))
+
+	got, err := s.Environments().Get(ctx, dev.ID)
+	must(t, err)
+	if got.Name != "dev" || got.Type != domain.EnvTypeDev || got.URL == nil || *got.URL != url || got.EnvironmentGroupID == nil || *got.EnvironmentGroupID != group.ID {
+		t.Errorf("Get() = %+v, want %+v", got, dev)
+	}
+	expectNotFound(t, "Get() of a missing environment", func() error {
+		_, err := s.Environments().Get(ctx, "missing")
+		return err
+	})
+
+	environments, err := s.Environments().List(ctx, repository.EnvironmentFilter{})
+	must(t, err)
+	expectIDs(t, "List() without filter", ids(environments, environmentID), dev.ID, prod.ID, staging.ID)
+
+	environments, err = s.Environments().List(ctx, repository.EnvironmentFilter{ReleaseID: release.ID})
+	must(t, err)
+	expectIDs(t, "List() by release", ids(environments, environmentID), dev.ID, prod.ID)
+
+	environments, err = s.Environments().List(ctx, repository.EnvironmentFilter{EnvironmentGroupID: group.ID})
+	must(t, err)
+	expectIDs(t, "List() by group", ids(environments, environmentID), dev.ID, staging.ID)
+
+	page, err := s.Environments().ListPage(ctx, repository.EnvironmentFilter{Status: domain.EnvStatusPending}, repository.PageRequest{Sort: repository.Sort{Field: "name", Desc: true}})
+	must(t, err)
+	expectIDs(t, "ListPage() by status", ids(page.Items, environmentID), staging.ID, dev.ID)
+
+	page, err = s.Environments().ListPage(ctx, repository.EnvironmentFilter{Type: domain.EnvTypeProd}, repository.PageRequest{Sort: repository.Sort{Field: "created_at"}})
+	must(t, err)
+	expectIDs(t, "ListPage() by type", ids(page.Items, environmentID), prod.ID)
+
+	count, err := s.Environments().Count(ctx, repository.EnvironmentFilter{ReleaseID: other.ID})
+	must(t, err)
+	if count != 1 {
+		t.Errorf("Count() = %d, want 1", count)
+	}
+
+	got.Status = domain.EnvStatusMaintenance
+	got.URL = nil
+	got.EnvironmentGroupID = nil
+	must(t, s.Environments().Update(ctx, got))
+	updated, err := s.Environments().Get(ctx, dev.ID)
+	must(t, err)
+	if updated.Status != domain.EnvStatusMaintenance || updated.URL != nil || updated.EnvironmentGroupID != nil {
+		t.Errorf("Update() did not store every field: %+v", updated)
+	}
+	expectNotFound(t, "Update() of a missing environment", func() error {
+		return s.Environments().Update(ctx, &domain.Environment{ID: "missing", Name: "x", Type: domain.EnvTypeDev, ReleaseID: release.ID})
+	})
+
+	must(t, s.Environments().Delete(ctx, staging.ID))
+	expectNotFound(t, "Delete() of a missing environment", func() error {
+		return s.Environments().Delete(ctx, staging.ID)
+	})
+}
+
+func testEnvironmentSystems(t *testing.T, s repository.Store) {
+	ctx := context.Background()
+	envSystemID := func(es *domain.EnvironmentSystem) string { return es.SystemID }
+
+	release := createRelease(t, s, "2024.1")
+	dev := createEnvironment(t, s, "dev", release.ID, nil)
+	prod := createEnvironment(t, s, "prod", release.ID, nil)
+	api := createSystem(t, s, "api", nil)
+	web := createSystem(t, s, "web", nil)
+
+	apiInDev := &domain.EnvironmentSystem{EnvironmentID: dev.ID, SystemID: api.ID, Version: "1.0.0", CreatedAt: day(0)}
+	must(t, s.Environments().AddSystem(ctx, apiInDev))
+	if apiInDev.ID == "" || apiInDev.UpdatedAt.IsZero() {
+		t.Fatalf("AddSystem() did not fill in ID and timestamps: %+v", apiInDev)
+	}
+	if apiInDev.Status != "active" {
+		t.Errorf("default status = %q, want %q", apiInDev.Status, "active")
+	}
+	must(t, s.Environments().AddSystem(ctx, &domain.EnvironmentSystem{EnvironmentID: dev.ID, SystemID: web.ID, Version: "2.0.0", CreatedAt: day(1)}))
+	must(t, s.Environments().AddSystem(ctx, &domain.EnvironmentSystem{EnvironmentID: prod.ID, SystemID: api.ID, Version: "0.9.0"}))
+
+	envSystems, err := s.Environments().ListSystems(ctx, dev.ID)
+	must(t, err)
+	expectIDs(t, "ListSystems()", ids(envSystems, envSystemID), api.ID, web.ID)
+	if envSystems[0].System == nil || envSystems[0].System.Name != "api" {
+		t.Errorf("ListSystems() did not load the system: %+v", envSystems[0].System)
+	}
+
+	got, err := s.Environments().GetSystem(ctx, prod.ID, api.ID)
+	must(t, err)
+	if got.Version != "0.9.0" || got.System == nil || got.System.ID != api.ID {
+		t.Errorf("GetSystem() = %+v", got)
+	}
+	expectNotFound(t, "GetSystem() of a system that is not deployed", func() error {
+		_, err := s.Environments().GetSystem(ctx, prod.ID, web.ID)
+		return err
+	})
+
+	got.Version = "1.0.0"
+	got.Status = "inactive"
+	must(t, s.Environments().UpdateSystem(ctx, got))
+	updated, err := s.Environments().GetSystem(ctx, prod.ID, api.ID)
+	must(t, err)
+	if updated.Version != "1.0.0" || updated.Status != "inactive" {
+		t.Errorf("UpdateSystem() did not store every field: %+v", updated)
+	}
+	expectNotFound(t, "UpdateSystem() of a missing entry", func() error {
+		return s.Environments().UpdateSystem(ctx, &domain.EnvironmentSystem{ID: "00000000-0000-0000-0000-000000000000", EnvironmentID: prod.ID, SystemID: web.ID})
+	})
+
+	must(t, s.Environments().RemoveSystem(ctx, dev.ID, web.ID))
+	envSystems, err = s.Environments().ListSystems(ctx, dev.ID)
+	must(t, err)
+	expectIDs(t, "ListSystems() after RemoveSystem()", ids(envSystems, envSystemID), api.ID)
+	expectNotFound(t, "RemoveSystem() of a system that is not deployed", func() error {
+		return s.Environments().RemoveSystem(ctx, dev.ID, web.ID)
+	})
+}
@@ -0,0 +1,121 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+
+	"release-management/internal/models/domain"
+	"release-management/internal/repository"
+)
+
+func testEnvironmentGroups(t *testing.T, s repository.Store) {
+	ctx := context.Background()
+	groupID := func(g *domain.EnvironmentGroup) string { return g.ID }
+	environmentID := func(e *domain.Environment) string { return e.ID }
+
+	path := []domain.EnvironmentType{domain.EnvTypeDev, domain.EnvTypeStaging, domain.EnvTypeProd}
+	eu := &domain.EnvironmentGroup{Name: "EU", PromotionPath: path, RequiredRole: domain.RoleReleaseManager, CreatedAt: day(0)}
+	must(t, s.EnvironmentGroups().Create(ctx, eu))
+	if eu.ID == "" || eu.UpdatedAt.IsZero() {
+		t.Fatalf("Create() did not fill in ID and timestamps: %+v", eu)
+	}
+	us := &domain.EnvironmentGroup{Name: "US", CreatedAt: day(1)}
+	must(t, s.EnvironmentGroups().Create(ctx, us))
+
+	// Changing the caller's slice must not change the stored group
+	path[0] = domain.EnvTypeProd
+
+	release := createRelease(t, s, "2024.1")
+	dev := &domain.Environment{Name: "dev", Type: domain.EnvTypeDev, ReleaseID: release.ID, EnvironmentGroupID: &eu.ID, CreatedAt: day(0)}
+	must(t, s.Environments().Create(ctx, dev))
+	prod := &domain.Environment{Name: "prod", Type: domain.EnvTypeProd, ReleaseID: release.ID, EnvironmentGroupID: &eu.ID, CreatedAt: day(1)}
+	must(t, s.Environments().Create(ctx, prod))
+
+	got, err := s.EnvironmentGroups().Get(ctx, eu.ID)
+	must(t, err)
+	if got.Name != "EU" || got.RequiredRole != domain.RoleReleaseManager {
+		t.Errorf("Get() = %+v, want %+v", got, eu)
+	}
+	if len(got.PromotionPath) != 3 || got.PromotionPath[0] != domain.EnvTypeDev || got.PromotionPath[2] != domain.EnvTypeProd {
+		t.Errorf("Get() promotion path = %v", got.PromotionPath)
+	}
+	expectIDs(t, "Get() environments", ids(got.Environments, environmentID), dev.ID, prod.ID)
+	expectNotFound(t, "Get() of a missing group", func() error {
+		_, err := s.EnvironmentGroups().Get(ctx, "missing")
+		return err
+	})
+
+	groups, err := s.EnvironmentGroups().List(ctx)
+	must(t, err)
+	expectIDs(t, "List()", ids(groups, groupID), eu.ID, us.ID)
+
+	page, err := s.EnvironmentGroups().ListPage(ctx, repository.PageRequest{Limit: 1, Sort: repository.Sort{Field: "name", Desc: true}})
+	must(t, err)
+	expectIDs(t, "ListPage() by name descending", ids(page.Items, groupID), us.ID)
+	if page.Total != 2 || page.NextCursor == "" {
+		t.Errorf("ListPage(): total %d, next cursor %q", page.Total, page.NextCursor)
+	}
+	if len(groups[1].Environments) != 0 {
+		t.Errorf("List() environments of an empty group = %v", groups[1].Environments)
+	}
+
+	got.PromotionPath = nil
+	got.RequiredRole = ""
+	must(t, s.EnvironmentGroups().Update(ctx, got))
+	updated, err := s.EnvironmentGroups().Get(ctx, eu.ID)
+	must(t, err)
+	if len(updated.PromotionPath) != 0 || updated.RequiredRole != "" {
+		t.Errorf("Update() did not store every field: %+v", updated)
+	}
+	expectNotFound(t, "Update() of a missing group", func() error {
+		return s.EnvironmentGroups().Update(ctx, &domain.EnvironmentGroup{ID: "missing", Name: "x"})
+	})
+
+	must(t, s.EnvironmentGroups().Delete(ctx, us.ID))
+	expectNotFound(t, "Delete() of a missing group", func() error {
+		return s.EnvironmentGroups().Delete(ctx, us.ID)
+	})
+}
+
+func testRoleGrants(t *testing.T, s repository.Store) {
+	ctx := context.Background()
+	grantUser := func(g *domain.EnvironmentGroupRoleGrant) string { return g.User.Email }
+
+	group := &domain.EnvironmentGroup{Name: "EU"}
+	must(t, s.EnvironmentGroups().Create(ctx, group))
+	alice := createUser(t, s, "alice@example.com")
+	bob := createUser(t, s, "bob@example.com")
+
+	grant := &domain.EnvironmentGroupRoleGrant{EnvironmentGroupID: group.ID, UserID: alice.ID, Role: domain.RoleViewer, CreatedAt: day(0)}
+	must(t, s.EnvironmentGroups().SaveRoleGrant(ctx, grant))
+	if grant.ID == "" {
+		t.Fatalf("SaveRoleGrant() did not fill in the ID: %+v", grant)
+	}
+	must(t, s.EnvironmentGroups().SaveRoleGrant(ctx, &domain.EnvironmentGroupRoleGrant{EnvironmentGroupID: group.ID, UserID: bob.ID, Role: domain.RoleEngineer, CreatedAt: day(1)}))
+
+	// Saving again replaces the role of the existing grant
+	replacement := &domain.EnvironmentGroupRoleGrant{EnvironmentGroupID: group.ID, UserID: alice.ID, Role: domain.RoleReleaseManager}
+	must(t, s.EnvironmentGroups().SaveRoleGrant(ctx, replacement))
+	if replacement.ID != grant.ID {
+		t.Errorf("SaveRoleGrant() created grant %s instead of replacing %s", replacement.ID, grant.ID)
+	}
+
+	got, err := s.EnvironmentGroups().GetRoleGrant(ctx, group.ID, alice.ID)
+	must(t, err)
+	if got.Role != domain.RoleReleaseManager || got.User == nil || got.User.Email != alice.Email {
+		t.Errorf("GetRoleGrant() = %+v", got)
+	}
+
+	grants, err := s.EnvironmentGroups().ListRoleGrants(ctx, group.ID)
+	must(t, err)
+	expectIDs(t, "ListRoleGrants()", ids(grants, grantUser), alice.Email, bob.Email)
+
+	must(t, s.EnvironmentGroups().DeleteRoleGrant(ctx, group.ID, alice.ID))
+	expectNotFound(t, "GetRoleGrant() of a deleted grant", func() error {
+		_, err := s.EnvironmentGroups().GetRoleGrant(ctx, group.ID, alice.ID)
+		return err
+	})
+	expectNotFound(t, "DeleteRoleGrant() of a missing grant", func() error {
+		return s.EnvironmentGroups().DeleteRoleGrant(ctx, group.ID, alice.ID)
+	})
+}
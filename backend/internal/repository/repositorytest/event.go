@@ -0,0 +1,46 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+
+	"release-management/internal/models/domain"
+	"release-management/internal/repository"
+)
+
+func testEvents(t *testing.T, s repository.Store) {
+	ctx := context.Background()
+	eventID := func(e *domain.Event) string { return e.ID }
+
+	var created []*domain.Event
+	for _, eventType := range []domain.EventType{domain.EventReleaseCreated, domain.EventBuildRegistered, domain.EventEnvironmentSystemChanged, domain.EventReleaseDeleted} {
+		event := &domain.Event{Type: eventType, Data: map[string]interface{}{"type": string(eventType)}}
+		must(t, s.Events().Create(ctx, event))
+		if len(created) > 0 && event.Sequence <= created[len(created)-1].Sequence {
+			t.Fatalf("Create() sequence %d does not follow %d", event.Sequence, created[len(created)-1].Sequence)
+		}
+		created = append(created, event)
+	}
+
+	got, err := s.Events().Get(ctx, created[1].ID)
+	must(t, err)
+	if got.Sequence != created[1].Sequence || got.Type != domain.EventBuildRegistered || got.Data["type"] != "build.registered" {
+		t.Errorf("Get() = %+v", got)
+	}
+
+	events, err := s.Events().ListAfter(ctx, 0, 10)
+	must(t, err)
+	expectIDs(t, "ListAfter(0)", ids(events, eventID), created[0].ID, created[1].ID, created[2].ID, created[3].ID)
+
+	events, err = s.Events().ListAfter(ctx, created[1].Sequence, 1)
+	must(t, err)
+	expectIDs(t, "ListAfter(second, 1)", ids(events, eventID), created[2].ID)
+
+	events, err = s.Events().ListLatest(ctx, 2)
+	must(t, err)
+	expectIDs(t, "ListLatest(2)", ids(events, eventID), created[2].ID, created[3].ID)
+
+	events, err = s.Events().ListAfter(ctx, created[3].Sequence, 10)
+	must(t, err)
+	expectIDs(t, "ListAfter(last)", ids(events, eventID))
+}
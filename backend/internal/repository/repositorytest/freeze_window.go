@@ -0,0 +1,99 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+
+	"release-management/internal/models/domain"
+	"release-management/internal/repository"
+)
+
+func testFreezeWindows(t *testing.T, s repository.Store) {
+	ctx := context.Background()
+	windowID := func(w *domain.FreezeWindow) string { return w.ID }
+
+	group := &domain.EnvironmentGroup{Name: "EU"}
+	must(t, s.EnvironmentGroups().Create(ctx, group))
+	prod := domain.EnvTypeProd
+	user := createUser(t, s, "freezer@example.com")
+
+	holidays := &domain.FreezeWindow{Name: "Holidays", Reason: "Skeleton crew", Scope: domain.FreezeScopeGlobal, StartsAt: day(20), EndsAt: day(25),
+		ExemptSystemIDs: []string{"sys-1", "sys-2"}, CreatedBy: &user.ID}
+	codeFreeze := &domain.FreezeWindow{Name: "Code freeze", Scope: domain.FreezeScopeEnvironmentType, EnvironmentType: &prod, StartsAt: day(5), EndsAt: day(10)}
+	regional := &domain.FreezeWindow{Name: "EU launch", Scope: domain.FreezeScopeEnvironmentGroup, EnvironmentGroupID: &group.ID, StartsAt: day(8), EndsAt: day(12),
+		ExemptEnvironmentIDs: []string{"env-1"}}
+	for _, window := range []*domain.FreezeWindow{holidays, codeFreeze, regional} {
+		must(t, s.FreezeWindows().Create(ctx, window))
+		if window.ID == "" || window.CreatedAt.IsZero() {
+			t.Fatalf("Create() did not fill in ID and creation time: %+v", window)
+		}
+	}
+
+	got, err := s.FreezeWindows().Get(ctx, holidays.ID)
+	must(t, err)
+	if got.Reason != "Skeleton crew" || len(got.ExemptSystemIDs) != 2 || got.ExemptSystemIDs[1] != "sys-2" || got.CreatedBy == nil || *got.CreatedBy != user.ID {
+		t.Errorf("Get() = %+v", got)
+	}
+	got, err = s.FreezeWindows().Get(ctx, codeFreeze.ID)
+	must(t, err)
+	if got.EnvironmentType == nil || *got.EnvironmentType != domain.EnvTypeProd || got.EnvironmentGroupID != nil || len(got.ExemptSystemIDs) != 0 {
+		t.Errorf("Get() = %+v", got)
+	}
+	expectNotFound(t, "Get() of a missing window", func() error {
+		_, err := s.FreezeWindows().Get(ctx, "missing")
+		return err
+	})
+
+	// Windows are listed by start, and the period matches the windows overlapping it
+	windows, err := s.FreezeWindows().List(ctx, repository.FreezeWindowFilter{})
+	must(t, err)
+	expectIDs(t, "List()", ids(windows, windowID), codeFreeze.ID, regional.ID, holidays.ID)
+	since, until := day(10), day(21)
+	windows, err = s.FreezeWindows().List(ctx, repository.FreezeWindowFilter{Since: &since, Until: &until})
+	must(t, err)
+	expectIDs(t, "List() overlapping a period", ids(windows, windowID), regional.ID, holidays.ID)
+
+	regional.EndsAt = day(30)
+	regional.ExemptEnvironmentIDs = nil
+	must(t, s.FreezeWindows().Update(ctx, regional))
+	got, err = s.FreezeWindows().Get(ctx, regional.ID)
+	must(t, err)
+	if !got.EndsAt.Equal(day(30)) || len(got.ExemptEnvironmentIDs) != 0 || got.EnvironmentGroupID == nil || *got.EnvironmentGroupID != group.ID {
+		t.Errorf("Get() after Update() = %+v", got)
+	}
+	expectNotFound(t, "Update() of a missing window", func() error {
+		return s.FreezeWindows().Update(ctx, &domain.FreezeWindow{ID: "missing", Name: "Missing", Scope: domain.FreezeScopeGlobal, StartsAt: day(1), EndsAt: day(2)})
+	})
+
+	// The override log outlives its window
+	first := &domain.FreezeOverride{FreezeWindowID: holidays.ID, FreezeWindowName: holidays.Name, EnvironmentID: "env-1", SystemID: "sys-3",
+		OldVersion: "1.0.0", NewVersion: "1.0.1", Justification: "Hotfix for checkout", UserID: &user.ID, CreatedAt: day(21)}
+	second := &domain.FreezeOverride{FreezeWindowID: codeFreeze.ID, FreezeWindowName: codeFreeze.Name, EnvironmentID: "env-2", SystemID: "sys-3",
+		NewVersion: "2.0.0", Justification: "Security patch", CreatedAt: day(6)}
+	for _, override := range []*domain.FreezeOverride{first, second} {
+		must(t, s.FreezeWindows().CreateOverride(ctx, override))
+	}
+	must(t, s.FreezeWindows().Delete(ctx, holidays.ID))
+	expectNotFound(t, "Delete() of a missing window", func() error { return s.FreezeWindows().Delete(ctx, holidays.ID) })
+
+	overrideID := func(o *domain.FreezeOverride) string { return o.ID }
+	newestFirst := repository.PageRequest{Sort: repository.Sort{Field: "created_at", Desc: true}}
+	page, err := s.FreezeWindows().ListOverrides(ctx, repository.FreezeOverrideFilter{}, newestFirst)
+	must(t, err)
+	expectIDs(t, "ListOverrides()", ids(page.Items, overrideID), first.ID, second.ID)
+	if page.Items[0].Justification != "Hotfix for checkout" || page.Items[0].FreezeWindowName != "Holidays" || page.Items[0].UserID == nil {
+		t.Errorf("ListOverrides() entry = %+v", page.Items[0])
+	}
+	page, err = s.FreezeWindows().ListOverrides(ctx, repository.FreezeOverrideFilter{EnvironmentID: "env-2"}, newestFirst)
+	must(t, err)
+	expectIDs(t, "ListOverrides() of an environment", ids(page.Items, overrideID), second.ID)
+	page, err = s.FreezeWindows().ListOverrides(ctx, repository.FreezeOverrideFilter{FreezeWindowID: holidays.ID}, newestFirst)
+	must(t, err)
+	expectIDs(t, "ListOverrides() of a window", ids(page.Items, overrideID), first.ID)
+
+	// Deleting a group deletes the windows scoped to it
+	must(t, s.EnvironmentGroups().Delete(ctx, group.ID))
+	windows, err = s.FreezeWindows().List(ctx, repository.FreezeWindowFilter{})
+	must(t, err)
+	expectIDs(t, "List() after deleting the group", ids(windows, windowID), codeFreeze.ID)
+}
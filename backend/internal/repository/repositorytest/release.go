@@ -0,0 +1,101 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+
+	"release-management/internal/models/domain"
+	"release-management/internal/repository"
+)
+
+func testReleases(t *testing.T, s repository.Store) {
+	ctx := context.Background()
+	releaseID := func(r *domain.Release) string { return r.ID }
+
+	description := "First release"
+	first := &domain.Release{Name: "2024.1", Description: &description, Type: domain.TypeMajor, ReleaseDate: day(10), CreatedAt: day(0)}
+	must(t, s.Releases().Create(ctx, first))
+	if first.ID == "" || first.UpdatedAt.IsZero() {
+		t.Fatalf("Create() did not fill in ID and timestamps: %+v", first)
+	}
+	if first.Status != domain.StatusPlanned {
+		t.Errorf("default status = %q, want %q", first.Status, domain.StatusPlanned)
+	}
+	second := &domain.Release{Name: "2024.2", Type: domain.TypeMinor, ReleaseDate: day(20), CreatedAt: day(1)}
+	must(t, s.Releases().Create(ctx, second))
+
+	got, err := s.Releases().Get(ctx, first.ID)
+	must(t, err)
+	if got.Name != first.Name || got.Type != first.Type || got.Description == nil || *got.Description != description || !got.ReleaseDate.Equal(first.ReleaseDate) {
+		t.Errorf("Get() = %+v, want %+v", got, first)
+	}
+	expectNotFound(t, "Get() of a missing release", func() error {
+		_, err := s.Releases().Get(ctx, "missing")
+		return err
+	})
+
+	releases, err := s.Releases().List(ctx)
+	must(t, err)
+	expectIDs(t, "List()", ids(releases, releaseID), first.ID, second.ID)
+
+	page, err := s.Releases().ListPage(ctx, repository.ReleaseFilter{}, repository.PageRequest{Sort: repository.Sort{Field: "name", Desc: true}})
+	must(t, err)
+	expectIDs(t, "ListPage() by name descending", ids(page.Items, releaseID), second.ID, first.ID)
+
+	page, err = s.Releases().ListPage(ctx, repository.ReleaseFilter{Type: domain.TypeMajor, Status: domain.StatusPlanned}, repository.PageRequest{Sort: repository.Sort{Field: "created_at"}})
+	must(t, err)
+	expectIDs(t, "ListPage() by type and status", ids(page.Items, releaseID), first.ID)
+
+	got.Name = "2024.1-renamed"
+	got.Description = nil
+	must(t, s.Releases().Update(ctx, got))
+	updated, err := s.Releases().Get(ctx, first.ID)
+	must(t, err)
+	if updated.Name != "2024.1-renamed" || updated.Description != nil {
+		t.Errorf("Update() did not store every field: %+v", updated)
+	}
+	if !updated.CreatedAt.Equal(first.CreatedAt) {
+		t.Errorf("Update() changed the creation time to %v", updated.CreatedAt)
+	}
+	expectNotFound(t, "Update() of a missing release", func() error {
+		return s.Releases().Update(ctx, &domain.Release{ID: "missing", Name: "x", Type: domain.TypeMinor})
+	})
+
+	// Transitions only apply to releases that are still in the expected status
+	user := createUser(t, s, "release-manager@example.com")
+	comment := "Kick-off"
+	transition := &domain.ReleaseTransition{ReleaseID: first.ID, FromStatus: domain.StatusPlanned, ToStatus: domain.StatusInProgress, UserID: user.ID, Comment: &comment}
+	must(t, s.Releases().Transition(ctx, transition))
+	if transition.ID == "" || transition.CreatedAt.IsZero() {
+		t.Errorf("Transition() did not fill in ID and creation time: %+v", transition)
+	}
+	moved, err := s.Releases().Get(ctx, first.ID)
+	must(t, err)
+	if moved.Status != domain.StatusInProgress {
+		t.Errorf("status after Transition() = %q, want %q", moved.Status, domain.StatusInProgress)
+	}
+	expectError(t, "Transition() from a stale status", repository.ErrConflict, func() error {
+		return s.Releases().Transition(ctx, &domain.ReleaseTransition{ReleaseID: first.ID, FromStatus: domain.StatusPlanned, ToStatus: domain.StatusCancelled, UserID: user.ID})
+	})
+
+	transitions, err := s.Releases().ListTransitions(ctx, first.ID)
+	must(t, err)
+	if len(transitions) != 1 {
+		t.Fatalf("ListTransitions() returned %d transitions, want 1", len(transitions))
+	}
+	if transitions[0].ToStatus != domain.StatusInProgress || transitions[0].Comment == nil || *transitions[0].Comment != comment {
+		t.Errorf("ListTransitions()[0] = %+v", transitions[0])
+	}
+	if transitions[0].User == nil || transitions[0].User.Email != user.Email {
+		t.Errorf("ListTransitions() did not load the user: %+v", transitions[0].User)
+	}
+
+	must(t, s.Releases().Delete(ctx, second.ID))
+	expectNotFound(t, "Get() of a deleted release", func() error {
+		_, err := s.Releases().Get(ctx, second.ID)
+		return err
+	})
+	expectNotFound(t, "Delete() of a missing release", func() error {
+		return s.Releases().Delete(ctx, second.ID)
+	})
+}
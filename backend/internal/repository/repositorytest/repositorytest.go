@@ -0,0 +1,230 @@
+// Package repositorytest holds the contract test suite that every repository.Store implementation must pass.
+//
+// Run it from a _test.go file of the implementation:
+//
+//	func TestStore(t *testing.T) {
+//		repositorytest.TestStore(t, func(t *testing.T) repository.Store { return memory.NewStore() })
+//	}
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"release-management/internal/models/domain"
+	"release-management/internal/repository"
+)
+
+// TestStore runs the contract suite. newStore must return an empty store for every call.
+func TestStore(t *testing.T, newStore func(t *testing.T) repository.Store) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, s repository.Store)
+	}{
+		{"Releases", testReleases},
+		{"Builds", testBuilds},
+		{"Systems", testSystems},
+		{"Environments", testEnvironments},
+		{"EnvironmentSystems", testEnvironmentSystems},
+		{"EnvironmentGroups", testEnvironmentGroups},
+		{"RoleGrants", testRoleGrants},
+		{"Deployments", testDeployments},
+		{"Users", testUsers},
+		{"APITokens", testAPITokens},
+		{"Audit", testAudit},
+		{"WebhookDeliveries", testWebhookDeliveries},
+		{"WebhookSubscriptions", testWebhookSubscriptions},
+		{"Events", testEvents},
+		{"FreezeWindows", testFreezeWindows},
+		{"ChangeRequests", testChangeRequests},
+		{"Search", testSearch},
+		{"Transactions", testTransactions},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, newStore(t))
+		})
+	}
+}
+
+func testTransactions(t *testing.T, s repository.Store) {
+	ctx := context.Background()
+	errAbort := errors.New("abort")
+
+	var committed domain.Release
+	must(t, s.Transaction(ctx, func(tx repository.Store) error {
+		committed = domain.Release{Name: "Committed", Type: domain.TypeMinor, ReleaseDate: day(1)}
+		return tx.Releases().Create(ctx, &committed)
+	}))
+	if _, err := s.Releases().Get(ctx, committed.ID); err != nil {
+		t.Fatalf("committed release: %v", err)
+	}
+
+	var rolledBack domain.Release
+	err := s.Transaction(ctx, func(tx repository.Store) error {
+		rolledBack = domain.Release{Name: "Rolled back", Type: domain.TypeMinor, ReleaseDate: day(1)}
+		if err := tx.Releases().Create(ctx, &rolledBack); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("Transaction() error = %v, want %v", err, errAbort)
+	}
+	expectNotFound(t, "rolled back release", func() error {
+		_, err := s.Releases().Get(ctx, rolledBack.ID)
+		return err
+	})
+
+	// A failed nested transaction only undoes its own changes
+	var outer, inner domain.Release
+	must(t, s.Transaction(ctx, func(tx repository.Store) error {
+		outer = domain.Release{Name: "Outer", Type: domain.TypeMinor, ReleaseDate: day(1)}
+		if err := tx.Releases().Create(ctx, &outer); err != nil {
+			return err
+		}
+		nestedErr := tx.Transaction(ctx, func(nested repository.Store) error {
+			inner = domain.Release{Name: "Inner", Type: domain.TypeMinor, ReleaseDate: day(1)}
+			if err := nested.Releases().Create(ctx, &inner); err != nil {
+				return err
+			}
+			return errAbort
+		})
+		if !errors.Is(nestedErr, errAbort) {
+			t.Errorf("nested Transaction() error = %v, want %v", nestedErr, errAbort)
+		}
+		return nil
+	}))
+	if _, err := s.Releases().Get(ctx, outer.ID); err != nil {
+		t.Fatalf("outer release: %v", err)
+	}
+	expectNotFound(t, "inner release", func() error {
+		_, err := s.Releases().Get(ctx, inner.ID)
+		return err
+	})
+}
+
+// Helper function to fail the test on an unexpected error
+func must(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// Helper function to check that an operation fails with a repository error
+func expectError(t *testing.T, what string, want error, fn func() error) {
+	t.Helper()
+	if err := fn(); !errors.Is(err, want) {
+		t.Errorf("%s: error = %v, want %v", what, err, want)
+	}
+}
+
+// Helper function to check that an operation fails with ErrNotFound
+func expectNotFound(t *testing.T, what string, fn func() error) {
+	t.Helper()
+	expectError(t, what, repository.ErrNotFound, fn)
+}
+
+// Helper function to check that a transaction taking a lock waits for another transaction holding the same lock to end
+func expectLockWaits(t *testing.T, s repository.Store, what string, lock func(tx repository.Store) error) {
+	t.Helper()
+	ctx := context.Background()
+	locked, release := make(chan struct{}), make(chan struct{})
+	held := make(chan error, 1)
+	go func() {
+		held <- s.Transaction(ctx, func(tx repository.Store) error {
+			err := lock(tx)
+			close(locked)
+			if err != nil {
+				return err
+			}
+			<-release
+			return nil
+		})
+	}()
+	<-locked
+
+	waiting := make(chan error, 1)
+	go func() {
+		waiting <- s.Transaction(ctx, lock)
+	}()
+	select {
+	case err := <-waiting:
+		t.Errorf("%s did not wait for the other transaction: %v", what, err)
+		waiting <- err
+	case <-time.After(100 * time.Millisecond):
+	}
+	close(release)
+	must(t, <-held)
+	must(t, <-waiting)
+}
+
+// Helper function to return a fixed point in time, days after the first of January 2024.
+// Whole seconds survive the round trip through every database.
+func day(days int) time.Time {
+	return time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC).AddDate(0, 0, days)
+}
+
+// Helper function to create a release for tests that need one
+func createRelease(t *testing.T, s repository.Store, name string) *domain.Release {
+	t.Helper()
+	release := &domain.Release{Name: name, Type: domain.TypeMinor, ReleaseDate: day(30)}
+	must(t, s.Releases().Create(context.Background(), release))
+	return release
+}
+
+// Helper function to create a system for tests that need one
+func createSystem(t *testing.T, s repository.Store, name string, parentID *string) *domain.System {
+	t.Helper()
+	systemType := domain.SystemTypeSystem
+	if parentID != nil {
+		systemType = domain.SystemTypeSubsystem
+	}
+	system := &domain.System{Name: name, Type: systemType, ParentID: parentID}
+	must(t, s.Systems().Create(context.Background(), system))
+	return system
+}
+
+// Helper function to create a user for tests that need one
+func createUser(t *testing.T, s repository.Store, email string) *domain.User {
+	t.Helper()
+	user := &domain.User{Email: email, Password: "hash", Role: domain.RoleEngineer}
+	must(t, s.Users().Create(context.Background(), user))
+	return user
+}
+
+// Helper function to create an environment for tests that need one
+func createEnvironment(t *testing.T, s repository.Store, name, releaseID string, groupID *string) *domain.Environment {
+	t.Helper()
+	environment := &domain.Environment{Name: name, Type: domain.EnvTypeDev, ReleaseID: releaseID, EnvironmentGroupID: groupID}
+	must(t, s.Environments().Create(context.Background(), environment))
+	return environment
+}
+
+// Helper function to return the IDs of values in order
+func ids[V any](values []V, id func(*V) string) []string {
+	result := make([]string, len(values))
+	for i := range values {
+		result[i] = id(&values[i])
+	}
+	return result
+}
+
+// Helper function to compare ordered ID lists
+func expectIDs(t *testing.T, what string, got []string, want ...string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Errorf("%s: got %v, want %v", what, got, want)
+		return
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Errorf("%s: got %v, want %v", what, got, want)
+			return
+		}
+	}
+}
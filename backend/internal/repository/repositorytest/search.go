@@ -0,0 +1,67 @@
+package repositorytest
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"release-management/internal/models/domain"
+	"release-management/internal/repository"
+)
+
+func testSearch(t *testing.T, s repository.Store) {
+	ctx := context.Background()
+	resultID := func(r *domain.SearchResult) string { return r.ID }
+	description := "Quarterly payments rollout"
+
+	release := &domain.Release{Name: "Spring release", Description: &description, Type: domain.TypeMinor, ReleaseDate: day(30)}
+	must(t, s.Releases().Create(ctx, release))
+	payments := createSystem(t, s, "payments", nil)
+	checkout := createSystem(t, s, "checkout", nil)
+	build := &domain.Build{SystemID: checkout.ID, ReleaseID: &release.ID, Version: "2.0.0", BuildDate: day(1)}
+	must(t, s.Builds().Create(ctx, build))
+	environment := createEnvironment(t, s, "staging", release.ID, nil)
+
+	results, err := s.Search().Search(ctx, repository.SearchQuery{Terms: []string{"PAY"}})
+	must(t, err)
+	expectIDs(t, "Search() by prefix, name matches first", ids(results, resultID), payments.ID, release.ID)
+	if results[0].Type != domain.SearchResultSystem || results[0].Title != "payments" {
+		t.Errorf("Search() system result = %+v", results[0])
+	}
+	if got := results[1].Highlights["description"]; !strings.Contains(got, domain.HighlightStart+"payments"+domain.HighlightStop) {
+		t.Errorf("Search() release description highlight = %q", got)
+	}
+	if _, ok := results[1].Highlights["name"]; ok {
+		t.Errorf("Search() highlighted a field without matches: %v", results[1].Highlights)
+	}
+
+	// A build is found by the names of its system and release
+	results, err = s.Search().Search(ctx, repository.SearchQuery{Terms: []string{"checkout", "spring"}})
+	must(t, err)
+	expectIDs(t, "Search() of a build by system and release", ids(results, resultID), build.ID)
+	if results[0].Type != domain.SearchResultBuild || results[0].Subtitle != "Spring release" {
+		t.Errorf("Search() build result = %+v", results[0])
+	}
+
+	results, err = s.Search().Search(ctx, repository.SearchQuery{Terms: []string{"spring"}, Types: []domain.SearchResultType{domain.SearchResultRelease, domain.SearchResultEnvironment}})
+	must(t, err)
+	expectIDs(t, "Search() by type", ids(results, resultID), release.ID)
+
+	results, err = s.Search().Search(ctx, repository.SearchQuery{Terms: []string{"stag"}})
+	must(t, err)
+	expectIDs(t, "Search() of an environment", ids(results, resultID), environment.ID)
+
+	results, err = s.Search().Search(ctx, repository.SearchQuery{Terms: []string{"spring"}, Limit: 1})
+	must(t, err)
+	if len(results) != 1 {
+		t.Errorf("Search() with limit returned %d results, want 1", len(results))
+	}
+
+	results, err = s.Search().Search(ctx, repository.SearchQuery{Terms: []string{"payments", "missing"}})
+	must(t, err)
+	expectIDs(t, "Search() requires every term", ids(results, resultID))
+
+	results, err = s.Search().Search(ctx, repository.SearchQuery{Terms: []string{" ", "'"}})
+	must(t, err)
+	expectIDs(t, "Search() without terms", ids(results, resultID))
+}
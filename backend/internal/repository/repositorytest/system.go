@@ -0,0 +1,92 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+
+	"release-management/internal/models/domain"
+	"release-management/internal/repository"
+)
+
+func testSystems(t *testing.T, s repository.Store) {
+	ctx := context.Background()
+	systemID := func(s *domain.System) string { return s.ID }
+
+	platform := &domain.System{Name: "platform", Type: domain.SystemTypeParent, CreatedAt: day(0)}
+	must(t, s.Systems().Create(ctx, platform))
+	if platform.ID == "" || platform.UpdatedAt.IsZero() {
+		t.Fatalf("Create() did not fill in ID and timestamps: %+v", platform)
+	}
+	if platform.Status != domain.StatusActive {
+		t.Errorf("default status = %q, want %q", platform.Status, domain.StatusActive)
+	}
+
+	api := &domain.System{Name: "api", Type: domain.SystemTypeSubsystem, ParentID: &platform.ID, StrictSemver: true, CreatedAt: day(1)}
+	must(t, s.Systems().Create(ctx, api))
+	web := &domain.System{Name: "web", Type: domain.SystemTypeSubsystem, ParentID: &platform.ID, CreatedAt: day(2)}
+	must(t, s.Systems().Create(ctx, web))
+
+	got, err := s.Systems().Get(ctx, api.ID)
+	must(t, err)
+	if got.Name != "api" || got.Type != domain.SystemTypeSubsystem || !got.StrictSemver {
+		t.Errorf("Get() = %+v, want %+v", got, api)
+	}
+	if got.Parent == nil || got.Parent.ID != platform.ID {
+		t.Errorf("Get() did not load the parent: %+v", got.Parent)
+	}
+	expectNotFound(t, "Get() of a missing system", func() error {
+		_, err := s.Systems().Get(ctx, "missing")
+		return err
+	})
+
+	systems, err := s.Systems().List(ctx, repository.SystemFilter{})
+	must(t, err)
+	expectIDs(t, "List() without filter", ids(systems, systemID), platform.ID, api.ID, web.ID)
+
+	systems, err = s.Systems().List(ctx, repository.SystemFilter{ParentID: platform.ID})
+	must(t, err)
+	expectIDs(t, "List() by parent", ids(systems, systemID), api.ID, web.ID)
+
+	systems, err = s.Systems().List(ctx, repository.SystemFilter{Name: "web"})
+	must(t, err)
+	expectIDs(t, "List() by name", ids(systems, systemID), web.ID)
+
+	systems, err = s.Systems().List(ctx, repository.SystemFilter{IDs: []string{web.ID, platform.ID}})
+	must(t, err)
+	expectIDs(t, "List() by IDs", ids(systems, systemID), platform.ID, web.ID)
+
+	page, err := s.Systems().ListPage(ctx, repository.SystemFilter{Type: domain.SystemTypeSubsystem, Status: domain.StatusActive}, repository.PageRequest{Sort: repository.Sort{Field: "name", Desc: true}})
+	must(t, err)
+	expectIDs(t, "ListPage() by type and status", ids(page.Items, systemID), web.ID, api.ID)
+
+	count, err := s.Systems().Count(ctx, repository.SystemFilter{ParentID: platform.ID})
+	must(t, err)
+	if count != 2 {
+		t.Errorf("Count() = %d, want 2", count)
+	}
+
+	got.Status = domain.StatusDeprecated
+	got.StrictSemver = false
+	got.StrictDigests = true
+	must(t, s.Systems().Update(ctx, got))
+	updated, err := s.Systems().Get(ctx, api.ID)
+	must(t, err)
+	if updated.Status != domain.StatusDeprecated || updated.StrictSemver || !updated.StrictDigests {
+		t.Errorf("Update() did not store every field: %+v", updated)
+	}
+	expectNotFound(t, "Update() of a missing system", func() error {
+		return s.Systems().Update(ctx, &domain.System{ID: "missing", Name: "x", Type: domain.SystemTypeParent})
+	})
+
+	expectLockWaits(t, s, "Lock() of a system", func(tx repository.Store) error {
+		return tx.Systems().Lock(ctx, api.ID)
+	})
+	expectNotFound(t, "Lock() of a missing system", func() error {
+		return s.Systems().Lock(ctx, "missing")
+	})
+
+	must(t, s.Systems().Delete(ctx, web.ID))
+	expectNotFound(t, "Delete() of a missing system", func() error {
+		return s.Systems().Delete(ctx, web.ID)
+	})
+}
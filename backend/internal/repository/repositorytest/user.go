@@ -0,0 +1,140 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"release-management/internal/models/domain"
+	"release-management/internal/repository"
+)
+
+func testUsers(t *testing.T, s repository.Store) {
+	ctx := context.Background()
+	userEmail := func(u *domain.User) string { return u.Email }
+
+	zoe := &domain.User{Email: "zoe@example.com", Password: "hash"}
+	must(t, s.Users().Create(ctx, zoe))
+	if zoe.ID == 0 || zoe.UpdatedAt.IsZero() {
+		t.Fatalf("Create() did not fill in ID and timestamps: %+v", zoe)
+	}
+	if zoe.Role != domain.RoleViewer {
+		t.Errorf("default role = %q, want %q", zoe.Role, domain.RoleViewer)
+	}
+	adam := &domain.User{Email: "adam@example.com", Password: "hash", IsAdmin: true, Role: domain.RoleAdmin}
+	must(t, s.Users().Create(ctx, adam))
+	if adam.ID == zoe.ID {
+		t.Errorf("Create() reused ID %d", adam.ID)
+	}
+	expectError(t, "Create() with a taken email address", repository.ErrDuplicate, func() error {
+		return s.Users().Create(ctx, &domain.User{Email: "zoe@example.com", Password: "hash"})
+	})
+
+	got, err := s.Users().Get(ctx, adam.ID)
+	must(t, err)
+	if got.Email != adam.Email || !got.IsAdmin || got.Role != domain.RoleAdmin {
+		t.Errorf("Get() = %+v, want %+v", got, adam)
+	}
+	expectNotFound(t, "Get() of a missing user", func() error {
+		_, err := s.Users().Get(ctx, adam.ID+zoe.ID+1)
+		return err
+	})
+
+	byEmail, err := s.Users().GetByEmail(ctx, "zoe@example.com")
+	must(t, err)
+	if byEmail.ID != zoe.ID {
+		t.Errorf("GetByEmail() = %d, want %d", byEmail.ID, zoe.ID)
+	}
+	expectNotFound(t, "GetByEmail() of an unknown address", func() error {
+		_, err := s.Users().GetByEmail(ctx, "nobody@example.com")
+		return err
+	})
+
+	users, err := s.Users().List(ctx)
+	must(t, err)
+	expectIDs(t, "List()", ids(users, userEmail), adam.Email, zoe.Email)
+
+	byEmail.Role = domain.RoleEngineer
+	must(t, s.Users().Update(ctx, byEmail))
+	updated, err := s.Users().Get(ctx, zoe.ID)
+	must(t, err)
+	if updated.Role != domain.RoleEngineer {
+		t.Errorf("Update() did not store the role: %+v", updated)
+	}
+	expectNotFound(t, "Update() of a missing user", func() error {
+		return s.Users().Update(ctx, &domain.User{ID: adam.ID + zoe.ID + 1, Email: "ghost@example.com", Password: "hash"})
+	})
+}
+
+func testAPITokens(t *testing.T, s repository.Store) {
+	ctx := context.Background()
+	tokenID := func(t *domain.APIToken) string { return t.ID }
+
+	user := createUser(t, s, "ci@example.com")
+	other := createUser(t, s, "other@example.com")
+
+	expiresAt := day(90)
+	older := &domain.APIToken{Name: "ci", Kind: domain.TokenKindService, UserID: user.ID, Prefix: "rm_aaaa", TokenHash: "hash-1",
+		Scopes: []domain.TokenScope{"builds:write", "releases:read"}, ExpiresAt: &expiresAt, CreatedAt: day(0)}
+	must(t, s.APITokens().Create(ctx, older))
+	if older.ID == "" || older.UpdatedAt.IsZero() {
+		t.Fatalf("Create() did not fill in ID and timestamps: %+v", older)
+	}
+	newer := &domain.APIToken{Name: "laptop", Kind: domain.TokenKindPersonal, UserID: user.ID, Prefix: "rm_bbbb", TokenHash: "hash-2", CreatedAt: day(1)}
+	must(t, s.APITokens().Create(ctx, newer))
+	must(t, s.APITokens().Create(ctx, &domain.APIToken{Name: "other", Kind: domain.TokenKindPersonal, UserID: other.ID, Prefix: "rm_cccc", TokenHash: "hash-3"}))
+	expectError(t, "Create() with a taken hash", repository.ErrDuplicate, func() error {
+		return s.APITokens().Create(ctx, &domain.APIToken{Name: "copy", Kind: domain.TokenKindPersonal, UserID: user.ID, Prefix: "rm_aaaa", TokenHash: "hash-1"})
+	})
+
+	got, err := s.APITokens().Get(ctx, older.ID)
+	must(t, err)
+	if got.Name != "ci" || got.Kind != domain.TokenKindService || len(got.Scopes) != 2 || got.ExpiresAt == nil || !got.ExpiresAt.Equal(expiresAt) {
+		t.Errorf("Get() = %+v, want %+v", got, older)
+	}
+	expectNotFound(t, "Get() of a missing token", func() error {
+		_, err := s.APITokens().Get(ctx, "missing")
+		return err
+	})
+
+	byHash, err := s.APITokens().GetByHash(ctx, "hash-2")
+	must(t, err)
+	if byHash.ID != newer.ID {
+		t.Errorf("GetByHash() = %s, want %s", byHash.ID, newer.ID)
+	}
+	expectNotFound(t, "GetByHash() of an unknown hash", func() error {
+		_, err := s.APITokens().GetByHash(ctx, "unknown")
+		return err
+	})
+
+	tokens, err := s.APITokens().ListByUser(ctx, user.ID)
+	must(t, err)
+	expectIDs(t, "ListByUser()", ids(tokens, tokenID), newer.ID, older.ID)
+
+	// Marking a token as used leaves its update time alone
+	usedAt := day(5)
+	must(t, s.APITokens().MarkUsed(ctx, older.ID, usedAt))
+	used, err := s.APITokens().Get(ctx, older.ID)
+	must(t, err)
+	if used.LastUsedAt == nil || !used.LastUsedAt.Equal(usedAt) {
+		t.Errorf("MarkUsed() last used at = %v, want %v", used.LastUsedAt, usedAt)
+	}
+	if !used.UpdatedAt.Equal(got.UpdatedAt) {
+		t.Errorf("MarkUsed() changed the update time from %v to %v", got.UpdatedAt, used.UpdatedAt)
+	}
+	expectNotFound(t, "MarkUsed() of a missing token", func() error {
+		return s.APITokens().MarkUsed(ctx, "missing", usedAt)
+	})
+
+	revokedAt := time.Now().Truncate(time.Second)
+	used.RevokedAt = &revokedAt
+	must(t, s.APITokens().Update(ctx, used))
+	revoked, err := s.APITokens().Get(ctx, older.ID)
+	must(t, err)
+	if revoked.RevokedAt == nil || !revoked.RevokedAt.Equal(revokedAt) || revoked.IsActive(time.Now()) {
+		t.Errorf("Update() did not store the revocation: %+v", revoked)
+	}
+	expectNotFound(t, "Update() of a missing token", func() error {
+		return s.APITokens().Update(ctx, &domain.APIToken{ID: "missing", Name: "x", Kind: domain.TokenKindPersonal, UserID: user.ID, Prefix: "rm_dddd", TokenHash: "hash-4"})
+	})
+}
@@ -0,0 +1,114 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"release-management/internal/models/domain"
+	"release-management/internal/repository"
+)
+
+func testWebhookSubscriptions(t *testing.T, s repository.Store) {
+	ctx := context.Background()
+	deliveryID := func(d *domain.EventDelivery) string { return d.ID }
+
+	subscription := &domain.WebhookSubscription{Name: "Deploy bot", URL: "https://bot.example.com/hooks", Secret: "whsec_1",
+		EventTypes: []domain.EventType{domain.EventBuildRegistered, domain.EventReleaseTransitioned}, Active: true}
+	other := &domain.WebhookSubscription{Name: "Chat", URL: "https://chat.example.com/hooks", Secret: "whsec_2", Active: true}
+	for _, sub := range []*domain.WebhookSubscription{subscription, other} {
+		must(t, s.WebhookSubscriptions().Create(ctx, sub))
+		if sub.ID == "" || sub.CreatedAt.IsZero() {
+			t.Fatalf("Create() did not fill in ID and creation time: %+v", sub)
+		}
+	}
+
+	got, err := s.WebhookSubscriptions().Get(ctx, subscription.ID)
+	must(t, err)
+	if got.Secret != "whsec_1" || len(got.EventTypes) != 2 || got.EventTypes[1] != domain.EventReleaseTransitioned || !got.Active {
+		t.Errorf("Get() = %+v", got)
+	}
+
+	other.Active = false
+	other.EventTypes = []domain.EventType{domain.EventEnvironmentSystemChanged}
+	must(t, s.WebhookSubscriptions().Update(ctx, other))
+	subscriptions, err := s.WebhookSubscriptions().List(ctx)
+	must(t, err)
+	expectIDs(t, "List()", ids(subscriptions, func(s *domain.WebhookSubscription) string { return s.ID }), subscription.ID, other.ID)
+	if subscriptions[1].Active || len(subscriptions[1].EventTypes) != 1 {
+		t.Errorf("List() after Update() = %+v", subscriptions[1])
+	}
+
+	event := &domain.Event{Type: domain.EventBuildRegistered, Data: map[string]interface{}{"build": map[string]interface{}{"version": "1.0.0"}}}
+	must(t, s.Events().Create(ctx, event))
+	if event.ID == "" || event.CreatedAt.IsZero() {
+		t.Fatalf("Events().Create() did not fill in ID and creation time: %+v", event)
+	}
+
+	now := time.Now()
+	due, later := now.Add(-time.Minute), now.Add(time.Hour)
+	first := &domain.EventDelivery{SubscriptionID: subscription.ID, EventID: event.ID, EventType: event.Type, Status: domain.EventDeliveryPending, NextAttemptAt: &due, CreatedAt: day(1)}
+	retrying := &domain.EventDelivery{SubscriptionID: subscription.ID, EventID: event.ID, EventType: event.Type, Status: domain.EventDeliveryRetrying, Attempts: 2, NextAttemptAt: &later, CreatedAt: day(2)}
+	dead := &domain.EventDelivery{SubscriptionID: subscription.ID, EventID: event.ID, EventType: event.Type, Status: domain.EventDeliveryDead, Attempts: 8, LastError: "connection refused", CreatedAt: day(3)}
+	elsewhere := &domain.EventDelivery{SubscriptionID: other.ID, EventID: event.ID, EventType: event.Type, Status: domain.EventDeliveryPending, NextAttemptAt: &due, CreatedAt: day(4)}
+	for _, delivery := range []*domain.EventDelivery{first, retrying, dead, elsewhere} {
+		must(t, s.WebhookSubscriptions().CreateDelivery(ctx, delivery))
+	}
+
+	newestFirst := repository.PageRequest{Sort: repository.Sort{Field: "created_at", Desc: true}}
+	page, err := s.WebhookSubscriptions().ListDeliveries(ctx, repository.EventDeliveryFilter{SubscriptionID: subscription.ID}, newestFirst)
+	must(t, err)
+	expectIDs(t, "ListDeliveries() of a subscription", ids(page.Items, deliveryID), dead.ID, retrying.ID, first.ID)
+	page, err = s.WebhookSubscriptions().ListDeliveries(ctx, repository.EventDeliveryFilter{Status: domain.EventDeliveryDead}, newestFirst)
+	must(t, err)
+	expectIDs(t, "ListDeliveries() of dead deliveries", ids(page.Items, deliveryID), dead.ID)
+	if page.Items[0].LastError != "connection refused" || page.Items[0].Attempts != 8 {
+		t.Errorf("ListDeliveries() entry = %+v", page.Items[0])
+	}
+
+	// Only due deliveries are claimed, and they are not claimed again while leased
+	claimed, err := s.WebhookSubscriptions().ClaimDueDeliveries(ctx, now, time.Minute, 10)
+	must(t, err)
+	expectIDs(t, "ClaimDueDeliveries()", ids(claimed, deliveryID), first.ID, elsewhere.ID)
+	if claimed[0].Event == nil || claimed[0].Event.Type != domain.EventBuildRegistered || claimed[0].Event.Data["build"] == nil {
+		t.Errorf("ClaimDueDeliveries() event = %+v", claimed[0].Event)
+	}
+	if claimed[0].NextAttemptAt == nil || !claimed[0].NextAttemptAt.After(now) {
+		t.Errorf("ClaimDueDeliveries() next attempt = %v, want the end of the lease", claimed[0].NextAttemptAt)
+	}
+	claimed, err = s.WebhookSubscriptions().ClaimDueDeliveries(ctx, now, time.Minute, 10)
+	must(t, err)
+	expectIDs(t, "ClaimDueDeliveries() while leased", ids(claimed, deliveryID))
+	claimed, err = s.WebhookSubscriptions().ClaimDueDeliveries(ctx, now.Add(2*time.Hour), time.Minute, 1)
+	must(t, err)
+	expectIDs(t, "ClaimDueDeliveries() with a limit", ids(claimed, deliveryID), first.ID)
+
+	delivered := now
+	status := 204
+	first.Status = domain.EventDeliveryDelivered
+	first.Attempts = 1
+	first.NextAttemptAt = nil
+	first.ResponseStatus = &status
+	first.DeliveredAt = &delivered
+	must(t, s.WebhookSubscriptions().UpdateDelivery(ctx, first))
+	gotDelivery, err := s.WebhookSubscriptions().GetDelivery(ctx, first.ID)
+	must(t, err)
+	if gotDelivery.Status != domain.EventDeliveryDelivered || gotDelivery.ResponseStatus == nil || *gotDelivery.ResponseStatus != 204 ||
+		gotDelivery.NextAttemptAt != nil || gotDelivery.Event == nil || !gotDelivery.CreatedAt.Equal(day(1)) {
+		t.Errorf("GetDelivery() after UpdateDelivery() = %+v", gotDelivery)
+	}
+
+	// Deleting a subscription removes its delivery log
+	must(t, s.WebhookSubscriptions().Delete(ctx, subscription.ID))
+	expectNotFound(t, "Get() of a deleted subscription", func() error {
+		_, err := s.WebhookSubscriptions().Get(ctx, subscription.ID)
+		return err
+	})
+	expectNotFound(t, "GetDelivery() of a deleted subscription", func() error {
+		_, err := s.WebhookSubscriptions().GetDelivery(ctx, first.ID)
+		return err
+	})
+	expectNotFound(t, "Delete() of a deleted subscription", func() error {
+		return s.WebhookSubscriptions().Delete(ctx, subscription.ID)
+	})
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"release-management/internal/models/domain"
+)
+
+// SearchQuery describes a full-text search
+type SearchQuery struct {
+	// Terms must all match, each as a word prefix
+	Terms []string
+	// Types limits the search to some entity types, empty for all of them
+	Types []domain.SearchResultType
+	// Limit caps the number of results, 0 for no limit
+	Limit int
+}
+
+// SearchRepository searches the names, descriptions, versions and URLs of releases, systems, builds and environments
+type SearchRepository interface {
+	// Search returns the entities matching query, most relevant first.
+	// Builds also match on the name of their system and release.
+	Search(ctx context.Context, query SearchQuery) ([]domain.SearchResult, error)
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+
+	"release-management/internal/models/domain"
+)
+
+// SystemFilter narrows down the systems returned by a SystemRepository. Empty fields match every system.
+type SystemFilter struct {
+	ParentID string
+	Name     string
+	IDs      []string
+	Type     domain.SystemType
+	Status   domain.SystemStatus
+}
+
+// SystemSortFields lists the fields systems can be sorted by
+var SystemSortFields = SortFields[domain.System]{
+	ID: func(s *domain.System) string { return s.ID },
+	Keys: map[string]func(*domain.System) string{
+		"created_at": func(s *domain.System) string { return TimeKey(s.CreatedAt) },
+		"name":       func(s *domain.System) string { return s.Name },
+	},
+}
+
+// SystemRepository stores parent systems, systems and subsystems
+type SystemRepository interface {
+	// List returns the systems matching filter, oldest first
+	List(ctx context.Context, filter SystemFilter) ([]domain.System, error)
+
+	// ListPage returns a page of the systems matching filter, sorted by one of SystemSortFields
+	ListPage(ctx context.Context, filter SystemFilter, page PageRequest) (*Page[domain.System], error)
+
+	// Count returns the number of systems matching filter
+	Count(ctx context.Context, filter SystemFilter) (int64, error)
+
+	// Get returns a system with its parent, or ErrNotFound
+	Get(ctx context.Context, id string) (*domain.System, error)
+
+	// Lock keeps other transactions from locking or changing a system until the current transaction ends,
+	// or returns ErrNotFound. Outside a transaction the lock is released right away.
+	Lock(ctx context.Context, id string) error
+
+	// Create stores a new system and fills in its ID, default status and timestamps
+	Create(ctx context.Context, system *domain.System) error
+
+	// Update stores every field of an existing system or returns ErrNotFound
+	Update(ctx context.Context, system *domain.System) error
+
+	// Delete removes a system or returns ErrNotFound
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+
+	"release-management/internal/models/domain"
+)
+
+// UserRepository stores user accounts
+type UserRepository interface {
+	// List returns every user ordered by email
+	List(ctx context.Context) ([]domain.User, error)
+
+	// Get returns a user or ErrNotFound
+	Get(ctx context.Context, id uint) (*domain.User, error)
+
+	// GetByEmail returns the user with an email address or ErrNotFound
+	GetByEmail(ctx context.Context, email string) (*domain.User, error)
+
+	// Create stores a new user and fills in its ID and timestamps.
+	// ErrDuplicate is returned if the email address is taken.
+	Create(ctx context.Context, user *domain.User) error
+
+	// Update stores every field of an existing user or returns ErrNotFound
+	Update(ctx context.Context, user *domain.User) error
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"release-management/internal/models/domain"
+)
+
+// WebhookDeliveryRepository stores processed CI webhook deliveries so redeliveries are idempotent
+type WebhookDeliveryRepository interface {
+	// Get returns the delivery of a provider with a delivery ID, or ErrNotFound
+	Get(ctx context.Context, provider, deliveryID string) (*domain.WebhookDelivery, error)
+
+	// Create stores a new delivery and fills in its ID and creation time.
+	// ErrDuplicate is returned if the provider's delivery was already stored.
+	Create(ctx context.Context, delivery *domain.WebhookDelivery) error
+}
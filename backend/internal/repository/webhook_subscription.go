@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"release-management/internal/models/domain"
+)
+
+// EventDeliveryFilter narrows down the deliveries returned by a WebhookSubscriptionRepository. Empty fields match every delivery.
+type EventDeliveryFilter struct {
+	SubscriptionID string
+	Status         domain.EventDeliveryStatus
+	EventType      domain.EventType
+}
+
+// EventDeliverySortFields lists the fields event deliveries can be sorted by
+var EventDeliverySortFields = SortFields[domain.EventDelivery]{
+	ID: func(d *domain.EventDelivery) string { return d.ID },
+	Keys: map[string]func(*domain.EventDelivery) string{
+		"created_at": func(d *domain.EventDelivery) string { return TimeKey(d.CreatedAt) },
+	},
+}
+
+// WebhookSubscriptionRepository stores outbound webhook subscriptions with the queue and log of their deliveries
+type WebhookSubscriptionRepository interface {
+	List(ctx context.Context) ([]domain.WebhookSubscription, error)
+
+	// Get returns a subscription by ID, or ErrNotFound
+	Get(ctx context.Context, id string) (*domain.WebhookSubscription, error)
+
+	// Create stores a new subscription and fills in its ID and timestamps
+	Create(ctx context.Context, subscription *domain.WebhookSubscription) error
+
+	// Update writes every field of an existing subscription, returning ErrNotFound if there is none
+	Update(ctx context.Context, subscription *domain.WebhookSubscription) error
+
+	// Delete removes a subscription with its deliveries, returning ErrNotFound if there is none
+	Delete(ctx context.Context, id string) error
+
+	// CreateDelivery queues a new delivery and fills in its ID and timestamps
+	CreateDelivery(ctx context.Context, delivery *domain.EventDelivery) error
+
+	// GetDelivery returns a delivery with its event, or ErrNotFound
+	GetDelivery(ctx context.Context, id string) (*domain.EventDelivery, error)
+
+	// UpdateDelivery writes every field of an existing delivery, returning ErrNotFound if there is none
+	UpdateDelivery(ctx context.Context, delivery *domain.EventDelivery) error
+
+	// ListDeliveries returns a page of the deliveries matching filter, sorted by one of EventDeliverySortFields
+	ListDeliveries(ctx context.Context, filter EventDeliveryFilter, page PageRequest) (*Page[domain.EventDelivery], error)
+
+	// ClaimDueDeliveries returns up to limit pending or retrying deliveries that are due at now, oldest due first, with their events.
+	// Their next attempt is pushed back by lease, so that other dispatchers skip them while they are sent
+	// and they are retried if the dispatcher stops before it updates them.
+	ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.EventDelivery, error)
+}
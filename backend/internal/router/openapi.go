@@ -0,0 +1,368 @@
+package router
+
+import (
+	"net/http"
+
+	"release-management/internal/models/api"
+	"release-management/internal/models/domain"
+	"release-management/internal/openapi"
+	"release-management/internal/repository"
+)
+
+// apiInfo describes the API in the OpenAPI document
+var apiInfo = openapi.Info{
+	Title:       "Release Management API",
+	Version:     "1.0.0",
+	Description: "Releases, systems, builds and the environments they are deployed to.",
+}
+
+// Shared query parameters
+var (
+	releaseIDQuery = openapi.Query("release_id", "Only items of this release")
+	sinceQuery     = openapi.QueryTime("since", "Only entries at or after this time")
+	untilQuery     = openapi.QueryTime("until", "Only entries before this time")
+	historyQueries = []openapi.Parameter{sinceQuery, untilQuery}
+)
+
+// freezeOverride lets a version change through active freeze windows
+var freezeOverride = openapi.Header("X-Freeze-Override", "Justification for a change during an active freeze window, for users who may override it")
+
+// apiRoutes describes every route registered by Setup for the OpenAPI document.
+// A route that is missing here fails the router tests.
+var apiRoutes = []openapi.Route{
+	// Documentation
+	{Method: http.MethodGet, Path: "/api/openapi.json", ID: "getOpenAPI", Summary: "Get this OpenAPI document", Tag: "Documentation", Public: true, Response: map[string]interface{}{}},
+	{Method: http.MethodGet, Path: "/api/docs", ID: "getDocs", Summary: "Browse this document in Swagger UI", Tag: "Documentation", Public: true, Response: "", ContentType: "text/html"},
+	{Method: http.MethodGet, Path: "/health", ID: "getHealth", Summary: "Check that the server is up", Tag: "Documentation", Public: true, Response: map[string]string{}},
+
+	// Authentication
+	{Method: http.MethodPost, Path: "/api/auth/login", ID: "login", Summary: "Log in with email and password", Tag: "Authentication", Public: true, Request: api.LoginRequest{}, Response: api.AuthResponse{}},
+	{Method: http.MethodPost, Path: "/api/auth/register", ID: "register", Summary: "Create an account", Tag: "Authentication", Public: true, Request: api.RegisterRequest{}, Status: http.StatusCreated, Response: api.AuthResponse{}},
+	{Method: http.MethodGet, Path: "/api/me", ID: "getMe", Summary: "Get the current user", Tag: "Authentication", Response: api.UserResponse{}},
+	{Method: http.MethodGet, Path: "/api/dashboard", ID: "getDashboard", Summary: "Check that the caller is authenticated", Tag: "Authentication", Response: map[string]interface{}{}},
+
+	// CI webhooks
+	{
+		Method: http.MethodPost, Path: "/api/hooks/builds/:provider", ID: "receiveBuildWebhook", Tag: "Webhooks", Public: true,
+		Summary:     "Register a build from a CI provider",
+		Description: "The provider is github, gitlab or jenkins. Deliveries are verified with the provider's shared secret and replays of a delivery return the original result with status 200.",
+		Parameters: []openapi.Parameter{
+			openapi.Header("X-Hub-Signature-256", "HMAC-SHA256 signature of the body, for GitHub"),
+			openapi.Header("X-GitHub-Delivery", "Delivery ID, for GitHub"),
+			openapi.Header("X-Gitlab-Token", "Shared secret, for GitLab"),
+			openapi.Header("X-Jenkins-Signature-256", "HMAC-SHA256 signature of the body, for Jenkins"),
+		},
+		Request:  openapi.OneOf{api.GitHubWorkflowRunEvent{}, api.GitLabPipelineEvent{}, api.JenkinsNotification{}},
+		Status:   http.StatusCreated,
+		Response: api.BuildWebhookResponse{},
+	},
+
+	// API tokens
+	{Method: http.MethodGet, Path: "/api/tokens", ID: "listTokens", Summary: "List your API tokens", Tag: "API Tokens", Response: api.ListResponse[api.APITokenResponse]{}},
+	{Method: http.MethodGet, Path: "/api/tokens/:id", ID: "getToken", Summary: "Get an API token", Tag: "API Tokens", Response: api.APITokenResponse{}},
+	{Method: http.MethodPost, Path: "/api/tokens", ID: "createToken", Summary: "Create an API token, returned only once", Tag: "API Tokens", Request: api.APITokenRequest{}, Status: http.StatusCreated, Response: api.APITokenCreatedResponse{}},
+	{Method: http.MethodDelete, Path: "/api/tokens/:id", ID: "revokeToken", Summary: "Revoke an API token", Tag: "API Tokens", Response: api.MessageResponse{}},
+
+	// Users
+	{Method: http.MethodGet, Path: "/api/users", ID: "listUsers", Summary: "List users", Tag: "Users", Response: api.ListResponse[api.UserResponse]{}},
+	{Method: http.MethodPut, Path: "/api/users/:id/role", ID: "updateUserRole", Summary: "Change the global role of a user", Tag: "Users", Request: api.RoleRequest{}, Response: api.UserResponse{}},
+
+	// Releases
+	{
+		Method: http.MethodGet, Path: "/api/releases", ID: "listReleases", Summary: "List releases", Tag: "Releases",
+		Parameters: []openapi.Parameter{openapi.Query("status", "Only releases with this status"), openapi.Query("type", "Only releases of this type")},
+		Paginated:  true, Sort: repository.ReleaseSortFields.Names(),
+		Response: api.ListResponse[api.ReleaseResponse]{},
+	},
+	{Method: http.MethodGet, Path: "/api/releases/:id", ID: "getRelease", Summary: "Get a release with its builds", Tag: "Releases", Response: api.ReleaseResponse{}},
+	{Method: http.MethodPost, Path: "/api/releases", ID: "createRelease", Summary: "Create a release", Tag: "Releases", Request: api.ReleaseRequest{}, Status: http.StatusCreated, Response: api.ReleaseResponse{}},
+	{Method: http.MethodPut, Path: "/api/releases/:id", ID: "updateRelease", Summary: "Update a release", Tag: "Releases", Request: api.ReleaseUpdateRequest{}, Response: api.ReleaseResponse{}},
+	{Method: http.MethodDelete, Path: "/api/releases/:id", ID: "deleteRelease", Summary: "Delete a release", Tag: "Releases", Response: api.MessageResponse{}},
+	{Method: http.MethodGet, Path: "/api/releases/:id/builds", ID: "listReleaseBuilds", Summary: "List the builds of a release", Tag: "Releases", Response: api.ListResponse[api.BuildResponse]{}},
+	{Method: http.MethodGet, Path: "/api/releases/:id/transitions", ID: "listReleaseTransitions", Summary: "List the status changes of a release", Tag: "Releases", Response: api.ListResponse[api.ReleaseTransitionResponse]{}},
+	{Method: http.MethodPost, Path: "/api/releases/:id/transitions", ID: "transitionRelease", Summary: "Move a release to another status", Tag: "Releases", Request: api.ReleaseTransitionRequest{}, Status: http.StatusCreated, Response: api.ReleaseTransitionResponse{}},
+	{
+		Method: http.MethodGet, Path: "/api/releases/:id/compare/:otherId", ID: "compareReleases", Summary: "Compare the system versions of two releases", Tag: "Releases",
+		Parameters: []openapi.Parameter{openapi.QueryEnum("format", "Response format, json by default", "json", "markdown")},
+		Response:   api.ReleaseComparisonResponse{}, TextContentTypes: []string{"text/markdown"},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/releases/:id/notes", ID: "getReleaseNotes", Summary: "Generate the release notes of a release", Tag: "Releases",
+		Description: "Groups the builds of the release by parent system and shows each version next to the version of the latest earlier release containing the system, with the changelogs of the builds. Markdown and HTML are rendered with the templates of a team.",
+		Parameters: []openapi.Parameter{
+			openapi.QueryEnum("format", "Response format, json by default", "json", "markdown", "html"),
+			openapi.Query("team", "Team whose templates render markdown and html, default by default"),
+		},
+		Response: api.ReleaseNotesResponse{}, TextContentTypes: []string{"text/markdown", "text/html"},
+	},
+
+	// Systems
+	{
+		Method: http.MethodGet, Path: "/api/systems", ID: "listSystems", Summary: "List systems", Tag: "Systems",
+		Parameters: []openapi.Parameter{
+			openapi.Query("parent_id", "Only subsystems of this system"),
+			openapi.Query("name", "Only systems with this name"),
+			openapi.Query("type", "Only systems of this type"),
+			openapi.Query("status", "Only systems with this status"),
+		},
+		Paginated: true, Sort: repository.SystemSortFields.Names(),
+		Response: api.ListResponse[api.SystemResponse]{},
+	},
+	{Method: http.MethodGet, Path: "/api/systems/:id", ID: "getSystem", Summary: "Get a system", Tag: "Systems", Response: api.SystemResponse{}},
+	{Method: http.MethodPost, Path: "/api/systems", ID: "createSystem", Summary: "Create a system", Tag: "Systems", Request: api.SystemRequest{}, Status: http.StatusCreated, Response: api.SystemResponse{}},
+	{Method: http.MethodPut, Path: "/api/systems/:id", ID: "updateSystem", Summary: "Update a system", Tag: "Systems", Request: api.SystemUpdateRequest{}, Response: api.SystemResponse{}},
+	{Method: http.MethodDelete, Path: "/api/systems/:id", ID: "deleteSystem", Summary: "Delete a system", Tag: "Systems", Response: api.MessageResponse{}},
+	{Method: http.MethodGet, Path: "/api/systems/:id/subsystems", ID: "listSubsystems", Summary: "List the subsystems of a system", Tag: "Systems", Response: api.ListResponse[api.SystemResponse]{}},
+	{
+		Method: http.MethodGet, Path: "/api/systems/:id/builds", ID: "listSystemBuilds", Summary: "List the builds of a system, newest version first", Tag: "Systems",
+		Parameters: []openapi.Parameter{
+			openapi.Query("range", "Only versions in this range, e.g. >=1.2.0 <2.0.0"),
+			openapi.QueryEnum("latest", "Only the latest version, preferring releases over pre-releases", "true", "false"),
+		},
+		Paginated: true, Sort: []string{"version"},
+		Response: api.ListResponse[api.BuildResponse]{},
+	},
+
+	// Builds
+	{
+		Method: http.MethodGet, Path: "/api/builds", ID: "listBuilds", Summary: "List builds", Tag: "Builds",
+		Parameters: []openapi.Parameter{
+			openapi.Query("system_id", "Only builds of this system"),
+			releaseIDQuery,
+			openapi.QueryTime("built_after", "Only builds built at or after this time"),
+			openapi.QueryTime("built_before", "Only builds built before this time"),
+			openapi.Query("commit_sha", "Only builds of a commit, which may be abbreviated to at least 4 characters"),
+			openapi.Query("image_digest", "Only builds with this container image digest"),
+		},
+		Paginated: true, Sort: repository.BuildSortFields.Names(),
+		Response: api.ListResponse[api.BuildResponse]{},
+	},
+	{Method: http.MethodGet, Path: "/api/builds/:id", ID: "getBuild", Summary: "Get a build", Tag: "Builds", Response: api.BuildResponse{}},
+	{Method: http.MethodPost, Path: "/api/builds", ID: "createBuild", Summary: "Create a build", Tag: "Builds", Request: api.BuildRequest{}, Status: http.StatusCreated, Response: api.BuildResponse{}},
+	{Method: http.MethodPut, Path: "/api/builds/:id", ID: "updateBuild", Summary: "Update a build", Tag: "Builds", Request: api.BuildUpdateRequest{}, Response: api.BuildResponse{}},
+	{Method: http.MethodDelete, Path: "/api/builds/:id", ID: "deleteBuild", Summary: "Delete a build", Tag: "Builds", Response: api.MessageResponse{}},
+
+	// Environments
+	{
+		Method: http.MethodGet, Path: "/api/environments", ID: "listEnvironments", Summary: "List environments", Tag: "Environments",
+		Parameters: []openapi.Parameter{
+			releaseIDQuery,
+			openapi.Query("environment_group_id", "Only environments of this group"),
+			openapi.Query("type", "Only environments of this type"),
+			openapi.Query("status", "Only environments with this status"),
+		},
+		Paginated: true, Sort: repository.EnvironmentSortFields.Names(),
+		Response: api.ListResponse[api.EnvironmentResponse]{},
+	},
+	{Method: http.MethodGet, Path: "/api/environments/:id", ID: "getEnvironment", Summary: "Get an environment with its systems", Tag: "Environments", Response: api.EnvironmentResponse{}},
+	{Method: http.MethodPost, Path: "/api/environments", ID: "createEnvironment", Summary: "Create an environment", Tag: "Environments", Request: api.EnvironmentRequest{}, Status: http.StatusCreated, Response: api.EnvironmentResponse{}},
+	{Method: http.MethodPut, Path: "/api/environments/:id", ID: "updateEnvironment", Summary: "Update an environment", Tag: "Environments", Request: api.EnvironmentUpdateRequest{}, Response: api.EnvironmentResponse{}},
+	{Method: http.MethodDelete, Path: "/api/environments/:id", ID: "deleteEnvironment", Summary: "Delete an environment", Tag: "Environments", Response: api.MessageResponse{}},
+	{Method: http.MethodGet, Path: "/api/environments/:id/systems", ID: "listEnvironmentSystems", Summary: "List the systems of an environment", Tag: "Environments", Response: api.EnvironmentSystemsResponse{}},
+	{Method: http.MethodGet, Path: "/api/environments/:id/systems/:systemId", ID: "getEnvironmentSystem", Summary: "Get a system of an environment with its available versions", Tag: "Environments", Response: api.EnvironmentSystemDetailResponse{}},
+	{Method: http.MethodPost, Path: "/api/environments/:id/systems", ID: "addEnvironmentSystem", Summary: "Add a system and its subsystems to an environment", Tag: "Environments", Parameters: []openapi.Parameter{freezeOverride}, Request: api.EnvironmentSystemRequest{}, Status: http.StatusCreated, Response: api.EnvironmentSystemsAddedResponse{}},
+	{Method: http.MethodPut, Path: "/api/environments/:id/systems/:systemId", ID: "updateEnvironmentSystem", Summary: "Change the version or status of a system in an environment", Tag: "Environments", Parameters: []openapi.Parameter{freezeOverride}, Request: api.EnvironmentSystemUpdateRequest{}, Response: api.SimpleSystemInfo{}},
+	{Method: http.MethodDelete, Path: "/api/environments/:id/systems/:systemId", ID: "removeEnvironmentSystem", Summary: "Remove a system from an environment", Tag: "Environments", Parameters: []openapi.Parameter{freezeOverride}, Response: api.MessageResponse{}},
+	{Method: http.MethodPost, Path: "/api/environments/:id/systems/sync", ID: "syncEnvironmentSystems", Summary: "Set the system versions of an environment to those of its release", Tag: "Environments", Parameters: []openapi.Parameter{freezeOverride}, Response: api.EnvironmentSystemsSyncResponse{}},
+	{
+		Method: http.MethodGet, Path: "/api/environments/:id/history", ID: "listEnvironmentHistory", Summary: "List the deployments of an environment, newest first", Tag: "Environments",
+		Parameters: historyQueries, Paginated: true, Sort: repository.DeploymentSortFields.Names(),
+		Response: api.ListResponse[api.DeploymentResponse]{},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/environments/:id/systems/:systemId/history", ID: "listEnvironmentSystemHistory", Summary: "List the deployments of a system in an environment, newest first", Tag: "Environments",
+		Parameters: historyQueries, Paginated: true, Sort: repository.DeploymentSortFields.Names(),
+		Response: api.ListResponse[api.DeploymentResponse]{},
+	},
+	{
+		Method: http.MethodPost, Path: "/api/environments/:id/systems/:systemId/rollback", ID: "rollbackEnvironmentSystem", Tag: "Environments",
+		Summary:     "Roll a system back to an earlier version",
+		Description: "Without a body the system returns to the version it ran before the last deployment.",
+		Parameters:  []openapi.Parameter{freezeOverride},
+		Request:     api.EnvironmentSystemRollbackRequest{}, OptionalRequest: true,
+		Response: api.RollbackChange{},
+	},
+	{Method: http.MethodPost, Path: "/api/environments/:id/rollback", ID: "rollbackEnvironment", Summary: "Restore every system of an environment to its version at a point in time", Tag: "Environments", Parameters: []openapi.Parameter{freezeOverride}, Request: api.EnvironmentRollbackRequest{}, Response: api.EnvironmentRollbackResponse{}},
+	{
+		Method: http.MethodPost, Path: "/api/environments/:id/promote", ID: "promoteEnvironment", Summary: "Copy the system versions of an environment into the next stage", Tag: "Environments",
+		Parameters: []openapi.Parameter{openapi.QueryEnum("dry_run", "Only report the changes", "true", "false"), freezeOverride},
+		Request:    api.PromotionRequest{}, OptionalRequest: true,
+		Response: api.PromotionResponse{},
+	},
+	{Method: http.MethodGet, Path: "/api/environments/:id/drift", ID: "getEnvironmentDrift", Summary: "Compare an environment with its release", Tag: "Environments", Response: api.EnvironmentDriftReport{}},
+
+	// Environment groups
+	{
+		Method: http.MethodGet, Path: "/api/environment-groups", ID: "listEnvironmentGroups", Summary: "List environment groups", Tag: "Environment Groups",
+		Paginated: true, Sort: repository.EnvironmentGroupSortFields.Names(),
+		Response: api.ListResponse[api.EnvironmentGroupResponse]{},
+	},
+	{Method: http.MethodGet, Path: "/api/environment-groups/:id", ID: "getEnvironmentGroup", Summary: "Get an environment group", Tag: "Environment Groups", Response: api.EnvironmentGroupResponse{}},
+	{Method: http.MethodPost, Path: "/api/environment-groups", ID: "createEnvironmentGroup", Summary: "Create an environment group", Tag: "Environment Groups", Request: api.EnvironmentGroupRequest{}, Status: http.StatusCreated, Response: api.EnvironmentGroupResponse{}},
+	{Method: http.MethodPut, Path: "/api/environment-groups/:id", ID: "updateEnvironmentGroup", Summary: "Update an environment group", Tag: "Environment Groups", Request: api.EnvironmentGroupUpdateRequest{}, Response: api.EnvironmentGroupResponse{}},
+	{Method: http.MethodDelete, Path: "/api/environment-groups/:id", ID: "deleteEnvironmentGroup", Summary: "Delete an environment group", Tag: "Environment Groups", Response: api.MessageResponse{}},
+	{Method: http.MethodGet, Path: "/api/environment-groups/:id/drift", ID: "getEnvironmentGroupDrift", Summary: "Compare every environment of a group with its release", Tag: "Environment Groups", Response: api.EnvironmentGroupDriftReport{}},
+	{Method: http.MethodGet, Path: "/api/environment-groups/:id/roles", ID: "listEnvironmentGroupRoles", Summary: "List the roles granted within an environment group", Tag: "Environment Groups", Response: api.ListResponse[api.EnvironmentGroupRoleGrantResponse]{}},
+	{Method: http.MethodPut, Path: "/api/environment-groups/:id/roles/:userId", ID: "setEnvironmentGroupRole", Summary: "Grant a user a role within an environment group", Tag: "Environment Groups", Request: api.RoleRequest{}, Response: api.EnvironmentGroupRoleGrantResponse{}},
+	{Method: http.MethodDelete, Path: "/api/environment-groups/:id/roles/:userId", ID: "deleteEnvironmentGroupRole", Summary: "Revoke the role of a user within an environment group", Tag: "Environment Groups", Response: api.MessageResponse{}},
+
+	// Search
+	{
+		Method: http.MethodGet, Path: "/api/search", ID: "search", Summary: "Search releases, systems, builds and environments", Tag: "Search",
+		Parameters: []openapi.Parameter{
+			{Name: "q", In: "query", Description: "Words that must all match, each as a word prefix", Required: true, Schema: &openapi.Schema{Type: "string"}},
+			openapi.Query("type", "Comma separated result types: release, system, build, environment"),
+			openapi.QueryInt("limit", "Maximum number of results, 20 by default", 1, 100),
+		},
+		Response: api.ListResponse[api.SearchResultResponse]{},
+	},
+
+	// Catalog manifest
+	{
+		Method: http.MethodGet, Path: "/api/export", ID: "exportManifest", Summary: "Export systems, releases, builds, environment groups and environments as a manifest", Tag: "Manifest",
+		Parameters: []openapi.Parameter{openapi.QueryEnum("format", "Manifest format, yaml by default", "yaml", "json")},
+		Response:   api.Manifest{}, YAML: true,
+	},
+	{
+		Method: http.MethodPost, Path: "/api/import", ID: "importManifest", Summary: "Plan and apply a manifest in one transaction, admins only", Tag: "Manifest",
+		Description: "Entities are matched by name. A section that is left out is not changed, a section that is present lists every entity of its kind and the others are deleted. " +
+			"An invalid manifest is rejected with every problem found and nothing is changed.",
+		Parameters: []openapi.Parameter{
+			openapi.QueryEnum("dry_run", "Only plan the changes", "true", "false"),
+			openapi.Query("change_request", "Approved change request describing the version changes to an environment that only changes through change requests, repeatable"),
+			freezeOverride,
+		},
+		Request: api.Manifest{}, YAML: true,
+		Response: api.ImportResponse{},
+	},
+
+	// Event stream
+	{
+		Method: http.MethodGet, Path: "/api/events/stream", ID: "streamEvents", Summary: "Stream events as Server-Sent Events", Tag: "Events",
+		Description: "Each event is sent with its type as the event name and its sequence as the id. Clients resume with the Last-Event-ID header or parameter " +
+			"from a bounded buffer of recent events, a reset event tells them that some events are no longer buffered and the current state has to be reloaded. " +
+			"Only events about resources the caller can read are streamed.",
+		Parameters: []openapi.Parameter{
+			openapi.Query("type", "Comma separated event types, every readable type by default"),
+			openapi.Query("release_id", "Only events about this release, its builds and environments and their systems"),
+			openapi.Query("environment_group_id", "Only events about the environments of this group and their systems"),
+			openapi.Query("last_event_id", "Resume after this event, for clients that cannot send the Last-Event-ID header"),
+			{Name: "Last-Event-ID", In: "header", Description: "Resume after this event", Schema: &openapi.Schema{Type: "string"}},
+		},
+		Response: api.EventPayload{}, ContentType: "text/event-stream",
+	},
+
+	// Outbound webhooks
+	{Method: http.MethodGet, Path: "/api/webhooks", ID: "listWebhookSubscriptions", Summary: "List webhook subscriptions", Tag: "Webhooks", Response: api.ListResponse[api.WebhookSubscriptionResponse]{}},
+	{Method: http.MethodGet, Path: "/api/webhooks/:id", ID: "getWebhookSubscription", Summary: "Get a webhook subscription", Tag: "Webhooks", Response: api.WebhookSubscriptionResponse{}},
+	{
+		Method: http.MethodPost, Path: "/api/webhooks", ID: "createWebhookSubscription", Summary: "Subscribe a URL to events, the signing secret is returned only once", Tag: "Webhooks",
+		Description: "Every event is posted as JSON with the X-Release-Event, X-Release-Delivery, X-Release-Timestamp and X-Release-Signature headers. " +
+			"The signature is sha256=<hex>, the HMAC-SHA256 of \"<timestamp>.<body>\" with the secret.",
+		Request: api.WebhookSubscriptionRequest{}, Status: http.StatusCreated, Response: api.WebhookSubscriptionCreatedResponse{},
+	},
+	{Method: http.MethodPut, Path: "/api/webhooks/:id", ID: "updateWebhookSubscription", Summary: "Update a webhook subscription", Tag: "Webhooks", Request: api.WebhookSubscriptionRequest{}, Response: api.WebhookSubscriptionResponse{}},
+	{Method: http.MethodDelete, Path: "/api/webhooks/:id", ID: "deleteWebhookSubscription", Summary: "Delete a webhook subscription with its delivery log", Tag: "Webhooks", Response: api.MessageResponse{}},
+	{
+		Method: http.MethodGet, Path: "/api/webhooks/:id/deliveries", ID: "listEventDeliveries", Summary: "Delivery log of a webhook subscription, newest first", Tag: "Webhooks",
+		Parameters: []openapi.Parameter{
+			openapi.QueryEnum("status", "Only deliveries with this status, dead for the dead-letter queue", "pending", "retrying", "delivered", "dead"),
+			openapi.QueryEnum("event_type", "Only deliveries of this event type", eventTypeNames()...),
+		},
+		Paginated: true, Sort: repository.EventDeliverySortFields.Names(),
+		Response: api.ListResponse[api.EventDeliveryResponse]{},
+	},
+	{
+		Method: http.MethodPost, Path: "/api/webhooks/:id/deliveries/:deliveryId/redeliver", ID: "redeliverEvent", Summary: "Queue a delivered or dead-lettered event again", Tag: "Webhooks",
+		Status: http.StatusAccepted, Response: api.EventDeliveryResponse{},
+	},
+
+	// Freeze windows
+	{
+		Method: http.MethodGet, Path: "/api/freeze-windows", ID: "listFreezeWindows", Summary: "Freeze window calendar, ordered by start", Tag: "Freeze Windows",
+		Parameters: []openapi.Parameter{
+			openapi.QueryTime("since", "Only windows that end after this time"),
+			openapi.QueryTime("until", "Only windows that start before this time"),
+			openapi.QueryEnum("active", "Only the windows in force now", "true", "false"),
+			openapi.Query("environment_id", "Only windows that apply to this environment"),
+		},
+		Response: api.ListResponse[api.FreezeWindowResponse]{},
+	},
+	{Method: http.MethodGet, Path: "/api/freeze-windows/:id", ID: "getFreezeWindow", Summary: "Get a freeze window", Tag: "Freeze Windows", Response: api.FreezeWindowResponse{}},
+	{
+		Method: http.MethodPost, Path: "/api/freeze-windows", ID: "createFreezeWindow", Summary: "Create a freeze window", Tag: "Freeze Windows",
+		Description: "While a window is in force, version changes in the environments it covers are rejected with 409 unless they are exempt. " +
+			"Users who may override freezes can retry with a justification in the X-Freeze-Override header, which is logged.",
+		Request: api.FreezeWindowRequest{}, Status: http.StatusCreated, Response: api.FreezeWindowResponse{},
+	},
+	{Method: http.MethodPut, Path: "/api/freeze-windows/:id", ID: "updateFreezeWindow", Summary: "Update a freeze window", Tag: "Freeze Windows", Request: api.FreezeWindowRequest{}, Response: api.FreezeWindowResponse{}},
+	{Method: http.MethodDelete, Path: "/api/freeze-windows/:id", ID: "deleteFreezeWindow", Summary: "Delete a freeze window, its overrides stay in the log", Tag: "Freeze Windows", Response: api.MessageResponse{}},
+	{
+		Method: http.MethodGet, Path: "/api/freeze-overrides", ID: "listFreezeOverrides", Summary: "Log of the changes let through freeze windows, newest first", Tag: "Freeze Windows",
+		Parameters: []openapi.Parameter{
+			openapi.Query("freeze_window_id", "Only overrides of this window"),
+			openapi.Query("environment_id", "Only overrides in this environment"),
+		},
+		Paginated: true, Sort: repository.FreezeOverrideSortFields.Names(),
+		Response: api.ListResponse[api.FreezeOverrideResponse]{},
+	},
+
+	// Change requests
+	{
+		Method: http.MethodGet, Path: "/api/change-requests", ID: "listChangeRequests", Summary: "List change requests, newest first", Tag: "Change Requests",
+		Parameters: []openapi.Parameter{
+			openapi.QueryEnum("status", "Only requests in this status", "pending", "approved", "rejected", "applied", "expired"),
+			openapi.Query("environment_id", "Only requests for this environment"),
+			openapi.Query("author_id", "Only requests made by this user"),
+		},
+		Paginated: true, Sort: repository.ChangeRequestSortFields.Names(),
+		Response: api.ListResponse[api.ChangeRequestResponse]{},
+	},
+	{Method: http.MethodGet, Path: "/api/change-requests/:id", ID: "getChangeRequest", Summary: "Get a change request with its approvals and comments", Tag: "Change Requests", Response: api.ChangeRequestResponse{}},
+	{
+		Method: http.MethodPost, Path: "/api/change-requests", ID: "createChangeRequest", Summary: "Propose version changes to an environment", Tag: "Change Requests",
+		Description: "Environments of the configured types, prod by default, only change by applying an approved change request. " +
+			"Direct changes to them are rejected with 409. A request needs approvals from users other than its author before it expires.",
+		Request: api.ChangeRequestRequest{}, Status: http.StatusCreated, Response: api.ChangeRequestResponse{},
+	},
+	{Method: http.MethodPost, Path: "/api/change-requests/:id/approve", ID: "approveChangeRequest", Summary: "Approve a change request", Tag: "Change Requests", Response: api.ChangeRequestResponse{}},
+	{Method: http.MethodPost, Path: "/api/change-requests/:id/reject", ID: "rejectChangeRequest", Summary: "Reject a change request", Tag: "Change Requests", Request: api.ChangeRequestRejectRequest{}, Response: api.ChangeRequestResponse{}},
+	{Method: http.MethodPost, Path: "/api/change-requests/:id/comments", ID: "commentOnChangeRequest", Summary: "Comment on a change request", Tag: "Change Requests", Request: api.ChangeRequestCommentRequest{}, Status: http.StatusCreated, Response: api.ChangeRequestCommentResponse{}},
+	{
+		Method: http.MethodPost, Path: "/api/change-requests/:id/apply", ID: "applyChangeRequest", Summary: "Apply every change of an approved change request at once", Tag: "Change Requests",
+		Description: "Fails with 409 if a system no longer runs the version the request was made against. Freeze windows still apply.",
+		Parameters:  []openapi.Parameter{freezeOverride}, Response: api.ChangeRequestResponse{},
+	},
+
+	// Audit log
+	{
+		Method: http.MethodGet, Path: "/api/audit", ID: "listAuditEntries", Summary: "Query the audit log, newest first", Tag: "Audit",
+		Parameters: auditQueries,
+		Paginated:  true, Sort: repository.AuditSortFields.Names(),
+		Response: api.ListResponse[api.AuditEntryResponse]{},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/audit/export", ID: "exportAuditEntries", Summary: "Export audit entries oldest first as NDJSON, one entry per line", Tag: "Audit",
+		Parameters: auditQueries,
+		Response:   api.AuditEntryResponse{}, ContentType: "application/x-ndjson",
+	},
+}
+
+// auditQueries are the filters of the audit log endpoints
+var auditQueries = []openapi.Parameter{
+	openapi.QueryEnum("entity", "Only entries of this entity type", "release", "build", "system", "environment", "environment_group", "environment_system", "webhook", "freeze_window", "change_request"),
+	openapi.Query("entity_id", "Only entries of this entity"),
+	openapi.QueryEnum("action", "Only entries of this action", "create", "update", "delete"),
+	openapi.Query("actor", "Only entries by this user ID or actor name, e.g. webhook:github"),
+	sinceQuery,
+	untilQuery,
+}
+
+// Helper function to list the names of the event types for parameter enums
+func eventTypeNames() []string {
+	names := make([]string, len(domain.EventTypes))
+	for i, eventType := range domain.EventTypes {
+		names[i] = string(eventType)
+	}
+	return names
+}
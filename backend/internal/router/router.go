@@ -2,25 +2,41 @@ package router
 
 import (
 	"release-management/internal/config"
+	"release-management/internal/events"
 	"release-management/internal/handlers"
 	"release-management/internal/middleware"
+	"release-management/internal/models/domain"
+	"release-management/internal/openapi"
+	"release-management/internal/repository"
 
 	"github.com/gin-gonic/gin"
 )
 
-func Setup(cfg *config.Config) *gin.Engine {
+func Setup(cfg *config.Config, store repository.Store) *gin.Engine {
 	r := gin.Default()
 
 	// Add CORS middleware
 	r.Use(middleware.CORSMiddleware())
+	r.Use(middleware.RequestIDMiddleware())
 
 	// Initialize handlers
-	authHandler := handlers.NewAuthHandler(cfg)
-	releaseHandler := handlers.NewReleaseHandler()
-	systemHandler := handlers.NewSystemHandler()
-	buildHandler := handlers.NewBuildHandler()
-	environmentHandler := handlers.NewEnvironmentHandler()
-	environmentGroupsHandler := handlers.NewEnvironmentGroupHandler()
+	authHandler := handlers.NewAuthHandler(cfg, store)
+	releaseHandler := handlers.NewReleaseHandler(cfg, store)
+	systemHandler := handlers.NewSystemHandler(store)
+	buildHandler := handlers.NewBuildHandler(store)
+	environmentHandler := handlers.NewEnvironmentHandler(cfg, store)
+	environmentGroupsHandler := handlers.NewEnvironmentGroupHandler(store)
+	buildWebhookHandler := handlers.NewBuildWebhookHandler(cfg, store)
+	apiTokenHandler := handlers.NewAPITokenHandler(store)
+	userHandler := handlers.NewUserHandler(store)
+	auditHandler := handlers.NewAuditHandler(store)
+	searchHandler := handlers.NewSearchHandler(store)
+	manifestHandler := handlers.NewManifestHandler(cfg, store)
+	webhookSubscriptionHandler := handlers.NewWebhookSubscriptionHandler(store)
+	freezeWindowHandler := handlers.NewFreezeWindowHandler(store)
+	changeRequestHandler := handlers.NewChangeRequestHandler(cfg, store)
+	eventHandler := handlers.NewEventHandler(store, events.NewStream(store, cfg.Events.StreamBufferSize))
+	openAPIHandler := handlers.NewOpenAPIHandler(openapi.New(apiInfo, apiRoutes))
 
 	// Public routes
 	auth := r.Group("/api/auth")
@@ -29,9 +45,22 @@ func Setup(cfg *config.Config) *gin.Engine {
 		auth.POST("/register", authHandler.Register)
 	}
 
+	// API documentation, public so that other teams can generate clients from it
+	docs := r.Group("/api")
+	{
+		docs.GET("/openapi.json", openAPIHandler.GetSpec)
+		docs.GET("/docs", openAPIHandler.GetDocs)
+	}
+
+	// CI webhook routes, verified by per-provider secrets instead of user tokens
+	hooks := r.Group("/api/hooks")
+	{
+		hooks.POST("/builds/:provider", buildWebhookHandler.ReceiveBuildWebhook)
+	}
+
 	// Protected routes
 	protected := r.Group("/api")
-	protected.Use(middleware.AuthMiddleware(cfg))
+	protected.Use(middleware.AuthMiddleware(cfg, store))
 	{
 		protected.GET("/me", authHandler.Me)
 		protected.GET("/dashboard", func(c *gin.Context) {
@@ -41,8 +70,27 @@ func Setup(cfg *config.Config) *gin.Engine {
 			})
 		})
 
+		// API token endpoints, managed from a login session only
+		tokens := protected.Group("/tokens")
+		tokens.Use(middleware.RequireSession())
+		{
+			tokens.GET("", apiTokenHandler.GetTokens)
+			tokens.GET("/:id", apiTokenHandler.GetToken)
+			tokens.POST("", apiTokenHandler.CreateToken)
+			tokens.DELETE("/:id", apiTokenHandler.RevokeToken)
+		}
+
+		// User management endpoints
+		users := protected.Group("/users")
+		users.Use(middleware.RequireSession(), middleware.RequireRole(store, domain.RoleAdmin))
+		{
+			users.GET("", userHandler.GetUsers)
+			users.PUT("/:id/role", userHandler.UpdateUserRole)
+		}
+
 		// Release endpoints
 		releases := protected.Group("/releases")
+		releases.Use(middleware.RequireScope("releases"), middleware.RequirePermission(store, "releases", nil))
 		{
 			releases.GET("", releaseHandler.GetReleases)
 			releases.GET("/:id", releaseHandler.GetRelease)
@@ -50,10 +98,15 @@ func Setup(cfg *config.Config) *gin.Engine {
 			releases.PUT("/:id", releaseHandler.UpdateRelease)
 			releases.DELETE("/:id", releaseHandler.DeleteRelease)
 			releases.GET("/:id/builds", releaseHandler.GetReleaseBuilds)
+			releases.GET("/:id/transitions", releaseHandler.GetReleaseTransitions)
+			releases.POST("/:id/transitions", releaseHandler.TransitionRelease)
+			releases.GET("/:id/compare/:otherId", releaseHandler.CompareReleases)
+			releases.GET("/:id/notes", releaseHandler.GetReleaseNotes)
 		}
 
 		// System endpoints
 		systems := protected.Group("/systems")
+		systems.Use(middleware.RequireScope("systems"), middleware.RequirePermission(store, "systems", nil))
 		{
 			systems.GET("", systemHandler.GetSystems)
 			systems.GET("/:id", systemHandler.GetSystem)
@@ -66,6 +119,7 @@ func Setup(cfg *config.Config) *gin.Engine {
 
 		// Build endpoints
 		builds := protected.Group("/builds")
+		builds.Use(middleware.RequireScope("builds"), middleware.RequirePermission(store, "builds", nil))
 		{
 			builds.GET("", buildHandler.GetBuilds)
 			builds.GET("/:id", buildHandler.GetBuild)
@@ -76,6 +130,7 @@ func Setup(cfg *config.Config) *gin.Engine {
 
 		// Environment endpoints
 		environments := protected.Group("/environments")
+		environments.Use(middleware.RequireScope("environments"), middleware.RequirePermission(store, "environments", middleware.EnvironmentGroupOfEnvironment(store)))
 		{
 			environments.GET("", environmentHandler.GetEnvironments)
 			environments.GET("/:id", environmentHandler.GetEnvironment)
@@ -84,22 +139,97 @@ func Setup(cfg *config.Config) *gin.Engine {
 			environments.DELETE("/:id", environmentHandler.DeleteEnvironment)
 
 			// Environment-Systems endpoints
-			environments.GET("/:id/systems", handlers.GetEnvironmentSystems)
-			environments.GET("/:id/systems/:systemId", handlers.GetEnvironmentSystem)
-			environments.POST("/:id/systems", handlers.AddSystemToEnvironment)
-			environments.PUT("/:id/systems/:systemId", handlers.UpdateEnvironmentSystem)
-			environments.DELETE("/:id/systems/:systemId", handlers.RemoveSystemFromEnvironment)
-			environments.POST("/:id/systems/sync", handlers.SyncEnvironmentSystemVersions)
+			environments.GET("/:id/systems", environmentHandler.GetEnvironmentSystems)
+			environments.GET("/:id/systems/:systemId", environmentHandler.GetEnvironmentSystem)
+			environments.POST("/:id/systems", environmentHandler.AddSystemToEnvironment)
+			environments.PUT("/:id/systems/:systemId", environmentHandler.UpdateEnvironmentSystem)
+			environments.DELETE("/:id/systems/:systemId", environmentHandler.RemoveSystemFromEnvironment)
+			environments.POST("/:id/systems/sync", environmentHandler.SyncEnvironmentSystemVersions)
+
+			// Deployment history endpoints
+			environments.GET("/:id/history", environmentHandler.GetEnvironmentHistory)
+			environments.GET("/:id/systems/:systemId/history", environmentHandler.GetEnvironmentSystemHistory)
+			environments.POST("/:id/systems/:systemId/rollback", environmentHandler.RollbackEnvironmentSystem)
+			environments.POST("/:id/rollback", environmentHandler.RollbackEnvironment)
+			environments.POST("/:id/promote", environmentHandler.PromoteEnvironment)
+			environments.GET("/:id/drift", environmentHandler.GetEnvironmentDrift)
 		}
 
 		// Environment Group endpoints
 		environmentGroups := protected.Group("/environment-groups")
+		environmentGroups.Use(middleware.RequireScope("environment-groups"), middleware.RequirePermission(store, "environment-groups", middleware.EnvironmentGroupFromParam))
 		{
 			environmentGroups.GET("", environmentGroupsHandler.GetEnvironmentGroups)
 			environmentGroups.GET("/:id", environmentGroupsHandler.GetEnvironmentGroup)
 			environmentGroups.POST("", environmentGroupsHandler.CreateEnvironmentGroup)
 			environmentGroups.PUT("/:id", environmentGroupsHandler.UpdateEnvironmentGroup)
 			environmentGroups.DELETE("/:id", environmentGroupsHandler.DeleteEnvironmentGroup)
+			environmentGroups.GET("/:id/drift", environmentGroupsHandler.GetEnvironmentGroupDrift)
+
+			// Role grants within an environment group
+			environmentGroups.GET("/:id/roles", environmentGroupsHandler.GetEnvironmentGroupRoles)
+			environmentGroups.PUT("/:id/roles/:userId", middleware.RequireRole(store, domain.RoleAdmin), environmentGroupsHandler.SetEnvironmentGroupRole)
+			environmentGroups.DELETE("/:id/roles/:userId", middleware.RequireRole(store, domain.RoleAdmin), environmentGroupsHandler.DeleteEnvironmentGroupRole)
+		}
+
+		// Search endpoint, limited to the types the caller can read
+		protected.GET("/search", searchHandler.Search)
+
+		// Catalog manifest endpoints. Exports need read access to every resource, imports may delete anything.
+		protected.GET("/export", manifestHandler.ExportManifest)
+		protected.POST("/import",
+			middleware.RequireScope("systems"), middleware.RequireScope("releases"), middleware.RequireScope("builds"),
+			middleware.RequireScope("environment-groups"), middleware.RequireScope("environments"),
+			middleware.RequireRole(store, domain.RoleAdmin),
+			manifestHandler.ImportManifest)
+
+		// Live event stream, limited to the event types the caller can read
+		protected.GET("/events/stream", eventHandler.StreamEvents)
+
+		// Outbound webhook subscription endpoints, limited to admins because subscriptions receive every event
+		webhooks := protected.Group("/webhooks")
+		webhooks.Use(middleware.RequireScope("webhooks"), middleware.RequirePermission(store, "webhooks", nil))
+		{
+			webhooks.GET("", webhookSubscriptionHandler.GetWebhookSubscriptions)
+			webhooks.GET("/:id", webhookSubscriptionHandler.GetWebhookSubscription)
+			webhooks.POST("", webhookSubscriptionHandler.CreateWebhookSubscription)
+			webhooks.PUT("/:id", webhookSubscriptionHandler.UpdateWebhookSubscription)
+			webhooks.DELETE("/:id", webhookSubscriptionHandler.DeleteWebhookSubscription)
+			webhooks.GET("/:id/deliveries", webhookSubscriptionHandler.GetEventDeliveries)
+			webhooks.POST("/:id/deliveries/:deliveryId/redeliver", webhookSubscriptionHandler.RedeliverEvent)
+		}
+
+		// Freeze window endpoints. Active windows block version changes unless the caller overrides them.
+		freezeWindows := protected.Group("/freeze-windows")
+		freezeWindows.Use(middleware.RequireScope("freeze-windows"), middleware.RequirePermission(store, "freeze-windows", nil))
+		{
+			freezeWindows.GET("", freezeWindowHandler.GetFreezeWindows)
+			freezeWindows.GET("/:id", freezeWindowHandler.GetFreezeWindow)
+			freezeWindows.POST("", freezeWindowHandler.CreateFreezeWindow)
+			freezeWindows.PUT("/:id", freezeWindowHandler.UpdateFreezeWindow)
+			freezeWindows.DELETE("/:id", freezeWindowHandler.DeleteFreezeWindow)
+		}
+		protected.GET("/freeze-overrides", middleware.RequireScope("freeze-windows"), middleware.RequirePermission(store, "freeze-windows", nil), freezeWindowHandler.GetFreezeOverrides)
+
+		// Change request endpoints. Environments of the configured types only change by applying an approved request.
+		changeRequests := protected.Group("/change-requests")
+		changeRequests.Use(middleware.RequireScope("change-requests"), middleware.RequirePermission(store, "change-requests", nil))
+		{
+			changeRequests.GET("", changeRequestHandler.GetChangeRequests)
+			changeRequests.GET("/:id", changeRequestHandler.GetChangeRequest)
+			changeRequests.POST("", changeRequestHandler.CreateChangeRequest)
+			changeRequests.POST("/:id/approve", changeRequestHandler.ApproveChangeRequest)
+			changeRequests.POST("/:id/reject", changeRequestHandler.RejectChangeRequest)
+			changeRequests.POST("/:id/comments", changeRequestHandler.CommentOnChangeRequest)
+			changeRequests.POST("/:id/apply", changeRequestHandler.ApplyChangeRequest)
+		}
+
+		// Audit log endpoints
+		auditLog := protected.Group("/audit")
+		auditLog.Use(middleware.RequireScope("audit"), middleware.RequirePermission(store, "audit", nil))
+		{
+			auditLog.GET("", auditHandler.GetAuditEntries)
+			auditLog.GET("/export", auditHandler.ExportAuditEntries)
 		}
 	}
 
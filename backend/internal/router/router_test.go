@@ -0,0 +1,1605 @@
+package router
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"release-management/internal/config"
+	"release-management/internal/models/api"
+	"release-management/internal/models/domain"
+	"release-management/internal/openapi"
+	"release-management/internal/repository"
+	"release-management/internal/repository/memory"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestOpenAPIDescribesEveryRoute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := Setup(&config.Config{}, memory.NewStore())
+	doc := openapi.New(apiInfo, apiRoutes)
+
+	registered := make(map[string]bool)
+	for _, route := range r.Routes() {
+		registered[route.Method+" "+route.Path] = true
+		if !doc.HasOperation(route.Method, route.Path) {
+			t.Errorf("%s %s is missing from apiRoutes", route.Method, route.Path)
+		}
+	}
+
+	operationIDs := make(map[string]bool)
+	for _, route := range apiRoutes {
+		if !registered[route.Method+" "+route.Path] {
+			t.Errorf("apiRoutes describes %s %s, which is not registered", route.Method, route.Path)
+		}
+		if route.ID == "" || operationIDs[route.ID] {
+			t.Errorf("%s %s: operation ID %q is empty or not unique", route.Method, route.Path, route.ID)
+		}
+		operationIDs[route.ID] = true
+	}
+}
+
+func TestOpenAPISchemaReferences(t *testing.T) {
+	doc := openapi.New(apiInfo, apiRoutes)
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("marshal document: %v", err)
+	}
+
+	// Every $ref must point to a component of the document
+	for _, part := range strings.Split(string(data), `"$ref":"#/components/schemas/`)[1:] {
+		name := part[:strings.Index(part, `"`)]
+		if _, ok := doc.Components.Schemas[name]; !ok {
+			t.Errorf("reference to missing schema %q", name)
+		}
+	}
+
+	release := doc.Components.Schemas["ReleaseRequest"]
+	if release == nil || !strings.Contains(strings.Join(release.Required, ","), "release_date") {
+		t.Errorf("ReleaseRequest does not require the fields of its binding tags: %+v", release)
+	}
+	if list := doc.Components.Schemas["ReleaseResponseList"]; list == nil || list.Properties["data"] == nil {
+		t.Errorf("ReleaseResponseList is not the list envelope: %+v", list)
+	}
+}
+
+func TestOpenAPIServed(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := Setup(&config.Config{}, memory.NewStore())
+
+	for path, contentType := range map[string]string{"/api/openapi.json": "application/json", "/api/docs": "text/html"} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != http.StatusOK || !strings.HasPrefix(w.Header().Get("Content-Type"), contentType) {
+			t.Errorf("GET %s = %d %s, want 200 %s", path, w.Code, w.Header().Get("Content-Type"), contentType)
+		}
+	}
+}
+
+// Helper function to create a user with a role and return a login token for them
+func loginAs(t *testing.T, r *gin.Engine, store repository.Store, email string, role domain.Role) string {
+	t.Helper()
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Users().Create(context.Background(), &domain.User{Email: email, Password: string(hash), Role: role}); err != nil {
+		t.Fatal(err)
+	}
+
+	body := `{"email":"` + email + `","password":"secret"}`
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/auth/login", strings.NewReader(body)))
+	var auth api.AuthResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &auth); err != nil || auth.Token == "" {
+		t.Fatalf("login as %s: %d %s", email, w.Code, w.Body.String())
+	}
+	return auth.Token
+}
+
+// Helper function to send an authenticated request
+func serve(r *gin.Engine, token, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestManifestExportAndImport(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := memory.NewStore()
+	r := Setup(&config.Config{JWT: config.JWTConfig{Secret: "test"}}, store)
+	admin := loginAs(t, r, store, "admin@example.com", domain.RoleAdmin)
+	engineer := loginAs(t, r, store, "engineer@example.com", domain.RoleEngineer)
+
+	manifest := `
+releases:
+  - name: "2024.04"
+    type: Minor
+    release_date: "2024-04-01T00:00:00Z"
+systems:
+  - name: payments
+    type: systems
+    builds:
+      - version: "1.0.0"
+        release: "2024.04"
+        build_date: "2024-03-28T12:00:00Z"
+environments:
+  - name: staging
+    type: staging
+    release: "2024.04"
+    systems:
+      - system: payments
+        version: "1.0.0"
+`
+
+	if w := serve(r, engineer, http.MethodPost, "/api/import", manifest); w.Code != http.StatusForbidden {
+		t.Errorf("import as engineer = %d, want 403", w.Code)
+	}
+
+	w := serve(r, admin, http.MethodPost, "/api/import?dry_run=true", manifest)
+	var plan api.ImportResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &plan); err != nil || w.Code != http.StatusOK || !plan.DryRun || plan.Creates != 5 {
+		t.Fatalf("dry run = %d %s", w.Code, w.Body.String())
+	}
+	if w := serve(r, admin, http.MethodGet, "/api/environments", ""); strings.Contains(w.Body.String(), "staging") {
+		t.Fatalf("dry run created environments: %s", w.Body.String())
+	}
+
+	w = serve(r, admin, http.MethodPost, "/api/import", manifest)
+	if err := json.Unmarshal(w.Body.Bytes(), &plan); err != nil || w.Code != http.StatusOK || plan.DryRun || plan.Creates != 5 {
+		t.Fatalf("import = %d %s", w.Code, w.Body.String())
+	}
+	if w := serve(r, admin, http.MethodGet, "/api/audit?entity=environment_system", ""); !strings.Contains(w.Body.String(), `"action":"create"`) {
+		t.Errorf("import was not audited: %s", w.Body.String())
+	}
+
+	// Version changes made by imports are in the deployment history like any other
+	var environments api.ListResponse[api.EnvironmentResponse]
+	w = serve(r, admin, http.MethodGet, "/api/environments", "")
+	if err := json.Unmarshal(w.Body.Bytes(), &environments); err != nil || len(environments.Data) != 1 {
+		t.Fatalf("environments = %d %s", w.Code, w.Body.String())
+	}
+	upgrade := strings.Replace(manifest, `        version: "1.0.0"`, `        version: "1.1.0"`, 1)
+	upgrade = strings.Replace(upgrade, `environments:`, `      - version: "1.1.0"
+        build_date: "2024-04-02T12:00:00Z"
+environments:`, 1)
+	if w := serve(r, admin, http.MethodPost, "/api/import", upgrade); w.Code != http.StatusOK {
+		t.Fatalf("import of an upgrade = %d %s", w.Code, w.Body.String())
+	}
+	w = serve(r, engineer, http.MethodGet, "/api/environments/"+environments.Data[0].ID+"/history", "")
+	var history api.ListResponse[api.DeploymentResponse]
+	if err := json.Unmarshal(w.Body.Bytes(), &history); err != nil || len(history.Data) != 2 || w.Header().Get("X-Total-Count") != "2" {
+		t.Fatalf("history = %d %s", w.Code, w.Body.String())
+	}
+	for _, deployment := range history.Data {
+		if deployment.Source != "import" || deployment.UserID == nil {
+			t.Errorf("deployment = %+v", deployment)
+		}
+	}
+	if !strings.Contains(w.Body.String(), `"old_version":"1.0.0","new_version":"1.1.0"`) {
+		t.Errorf("history does not show the upgrade: %s", w.Body.String())
+	}
+
+	// The builds of a system are paginated newest version first
+	cursor := ""
+	for _, want := range []string{"1.1.0", "1.0.0"} {
+		var builds api.ListResponse[api.BuildResponse]
+		w = serve(r, engineer, http.MethodGet, "/api/systems/"+history.Data[0].SystemID+"/builds?limit=1&cursor="+cursor, "")
+		if err := json.Unmarshal(w.Body.Bytes(), &builds); err != nil || len(builds.Data) != 1 || builds.Data[0].Version != want {
+			t.Fatalf("system builds = %d %s, want %s", w.Code, w.Body.String(), want)
+		}
+		cursor = builds.NextCursor
+	}
+	if cursor != "" {
+		t.Errorf("last page of system builds has a next cursor: %s", w.Body.String())
+	}
+
+	// Both export formats import again without changes
+	for _, format := range []string{"yaml", "json"} {
+		exported := serve(r, engineer, http.MethodGet, "/api/export?format="+format, "")
+		if exported.Code != http.StatusOK || !strings.Contains(exported.Body.String(), "payments") {
+			t.Fatalf("export %s = %d %s", format, exported.Code, exported.Body.String())
+		}
+		w := serve(r, admin, http.MethodPost, "/api/import", exported.Body.String())
+		if err := json.Unmarshal(w.Body.Bytes(), &plan); err != nil || w.Code != http.StatusOK || len(plan.Changes) != 0 {
+			t.Errorf("import of %s export = %d %s", format, w.Code, w.Body.String())
+		}
+	}
+
+	// Dropping a system that was deployed plans and applies alike, and its history stays with the removal added
+	dropped := `{"systems": [], "environments": [{"name": "staging", "type": "staging", "release": "2024.04"}]}`
+	for _, path := range []string{"/api/import?dry_run=true", "/api/import"} {
+		if w := serve(r, admin, http.MethodPost, path, dropped); w.Code != http.StatusOK {
+			t.Fatalf("POST %s without the system = %d %s", path, w.Code, w.Body.String())
+		}
+	}
+	if w := serve(r, engineer, http.MethodGet, "/api/systems/"+history.Data[0].SystemID, ""); w.Code != http.StatusNotFound {
+		t.Errorf("system after the import = %d, want 404", w.Code)
+	}
+	w = serve(r, engineer, http.MethodGet, "/api/environments/"+environments.Data[0].ID+"/history", "")
+	if w.Header().Get("X-Total-Count") != "3" || !strings.Contains(w.Body.String(), `"old_version":"1.1.0","new_version":""`) {
+		t.Errorf("history after the system was dropped = %d %s", w.Code, w.Body.String())
+	}
+
+	// Invalid manifests are rejected with their problems and unknown fields are typos
+	w = serve(r, admin, http.MethodPost, "/api/import", `{"environments": [{"name": "prod", "type": "prod", "release": "2025.01"}]}`)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), `release \"2025.01\" does not exist`) {
+		t.Errorf("invalid manifest = %d %s", w.Code, w.Body.String())
+	}
+	if w := serve(r, admin, http.MethodPost, "/api/import", "releses: []"); w.Code != http.StatusBadRequest {
+		t.Errorf("unknown field = %d %s", w.Code, w.Body.String())
+	}
+}
+
+func TestWebhookSubscriptions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := memory.NewStore()
+	r := Setup(&config.Config{JWT: config.JWTConfig{Secret: "test"}}, store)
+	admin := loginAs(t, r, store, "admin@example.com", domain.RoleAdmin)
+	manager := loginAs(t, r, store, "manager@example.com", domain.RoleReleaseManager)
+
+	if w := serve(r, manager, http.MethodGet, "/api/webhooks", ""); w.Code != http.StatusForbidden {
+		t.Errorf("list as release manager = %d, want 403", w.Code)
+	}
+	if w := serve(r, admin, http.MethodPost, "/api/webhooks", `{"name": "Bot", "url": "ftp://bot.example.com"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("create with an ftp URL = %d, want 400", w.Code)
+	}
+	if w := serve(r, admin, http.MethodPost, "/api/webhooks", `{"name": "Bot", "url": "https://bot.example.com", "event_types": ["build.exploded"]}`); w.Code != http.StatusBadRequest {
+		t.Errorf("create with an unknown event type = %d, want 400", w.Code)
+	}
+
+	w := serve(r, admin, http.MethodPost, "/api/webhooks", `{"name": "Bot", "url": "https://bot.example.com", "event_types": ["build.registered"]}`)
+	var created api.WebhookSubscriptionCreatedResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil || w.Code != http.StatusCreated || !strings.HasPrefix(created.Secret, "whsec_") || !created.Active {
+		t.Fatalf("create = %d %s", w.Code, w.Body.String())
+	}
+	if w := serve(r, admin, http.MethodGet, "/api/webhooks/"+created.ID, ""); strings.Contains(w.Body.String(), created.Secret) {
+		t.Errorf("get shows the secret: %s", w.Body.String())
+	}
+
+	// Registering a build queues a delivery, a release without subscribers does not
+	w = serve(r, admin, http.MethodPost, "/api/systems", `{"name": "payments", "type": "systems"}`)
+	var system api.SystemResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &system); err != nil || w.Code != http.StatusCreated {
+		t.Fatalf("create system = %d %s", w.Code, w.Body.String())
+	}
+	if w := serve(r, admin, http.MethodPost, "/api/builds", `{"system_id": "`+system.ID+`", "version": "1.0.0", "build_date": "2024-05-01T12:00:00Z"}`); w.Code != http.StatusCreated {
+		t.Fatalf("create build = %d %s", w.Code, w.Body.String())
+	}
+
+	w = serve(r, admin, http.MethodGet, "/api/webhooks/"+created.ID+"/deliveries", "")
+	var deliveries api.ListResponse[api.EventDeliveryResponse]
+	if err := json.Unmarshal(w.Body.Bytes(), &deliveries); err != nil || len(deliveries.Data) != 1 ||
+		deliveries.Data[0].EventType != "build.registered" || deliveries.Data[0].Status != "pending" {
+		t.Fatalf("deliveries = %d %s", w.Code, w.Body.String())
+	}
+	delivery := deliveries.Data[0]
+
+	if w := serve(r, admin, http.MethodGet, "/api/webhooks/"+created.ID+"/deliveries?status=lost", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("deliveries with an unknown status = %d, want 400", w.Code)
+	}
+	if w := serve(r, admin, http.MethodPost, "/api/webhooks/"+created.ID+"/deliveries/"+delivery.ID+"/redeliver", ""); w.Code != http.StatusConflict {
+		t.Errorf("redeliver a queued delivery = %d, want 409", w.Code)
+	}
+
+	// A dead-lettered delivery is queued again with a fresh set of attempts
+	dead, err := store.WebhookSubscriptions().GetDelivery(context.Background(), delivery.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dead.Status, dead.Attempts, dead.NextAttemptAt = domain.EventDeliveryDead, 8, nil
+	if err := store.WebhookSubscriptions().UpdateDelivery(context.Background(), dead); err != nil {
+		t.Fatal(err)
+	}
+	w = serve(r, admin, http.MethodPost, "/api/webhooks/"+created.ID+"/deliveries/"+delivery.ID+"/redeliver", "")
+	if err := json.Unmarshal(w.Body.Bytes(), &delivery); err != nil || w.Code != http.StatusAccepted || delivery.Status != "pending" || delivery.Attempts != 0 {
+		t.Errorf("redeliver = %d %s", w.Code, w.Body.String())
+	}
+
+	if w := serve(r, admin, http.MethodPut, "/api/webhooks/"+created.ID, `{"name": "Bot", "url": "https://bot.example.com/v2", "active": false}`); w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"active":false`) {
+		t.Errorf("update = %d %s", w.Code, w.Body.String())
+	}
+	if w := serve(r, admin, http.MethodDelete, "/api/webhooks/"+created.ID, ""); w.Code != http.StatusOK {
+		t.Errorf("delete = %d %s", w.Code, w.Body.String())
+	}
+	if w := serve(r, admin, http.MethodGet, "/api/audit?entity=webhook", ""); strings.Count(w.Body.String(), `"entity_type":"webhook"`) != 3 {
+		t.Errorf("webhook audit entries = %s", w.Body.String())
+	}
+}
+
+func TestEventStream(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := memory.NewStore()
+	r := Setup(&config.Config{JWT: config.JWTConfig{Secret: "test"}, Events: config.EventsConfig{StreamBufferSize: 100}}, store)
+	viewer := loginAs(t, r, store, "viewer@example.com", domain.RoleViewer)
+	manager := loginAs(t, r, store, "manager@example.com", domain.RoleReleaseManager)
+
+	var release, other api.ReleaseResponse
+	for _, created := range []*api.ReleaseResponse{&release, &other} {
+		w := serve(r, manager, http.MethodPost, "/api/releases", `{"name": "2024.04", "type": "Minor", "release_date": "2024-04-01T00:00:00Z"}`)
+		if err := json.Unmarshal(w.Body.Bytes(), created); err != nil || w.Code != http.StatusCreated {
+			t.Fatalf("create release = %d %s", w.Code, w.Body.String())
+		}
+	}
+	if w := serve(r, manager, http.MethodPut, "/api/releases/"+release.ID, `{"description": "April"}`); w.Code != http.StatusOK {
+		t.Fatalf("update release = %d %s", w.Code, w.Body.String())
+	}
+
+	if w := serve(r, viewer, http.MethodGet, "/api/events/stream?type=release.exploded", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("stream with an unknown type = %d, want 400", w.Code)
+	}
+	if w := serve(r, viewer, http.MethodGet, "/api/events/stream?last_event_id=latest", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("stream with an invalid last event ID = %d, want 400", w.Code)
+	}
+
+	// Resuming after the first event replays the events of the release since then, until the client disconnects
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	req := httptest.NewRequest(http.MethodGet, "/api/events/stream?release_id="+release.ID, nil).WithContext(ctx)
+	req.Header.Set("Authorization", "Bearer "+viewer)
+	req.Header.Set("Last-Event-ID", "1")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	body := w.Body.String()
+	if w.Code != http.StatusOK || !strings.HasPrefix(w.Header().Get("Content-Type"), "text/event-stream") {
+		t.Fatalf("stream = %d %s", w.Code, w.Header().Get("Content-Type"))
+	}
+	if !strings.Contains(body, "id:3\nevent:release.updated\n") || !strings.Contains(body, `"description":"April"`) {
+		t.Errorf("stream did not replay the update: %s", body)
+	}
+	if strings.Contains(body, "release.created") || strings.Contains(body, other.ID) {
+		t.Errorf("stream replayed events it should not have: %s", body)
+	}
+}
+
+func TestFreezeWindows(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := memory.NewStore()
+	r := Setup(&config.Config{JWT: config.JWTConfig{Secret: "test"}}, store)
+	manager := loginAs(t, r, store, "manager@example.com", domain.RoleReleaseManager)
+	engineer := loginAs(t, r, store, "engineer@example.com", domain.RoleEngineer)
+
+	var system api.SystemResponse
+	var release api.ReleaseResponse
+	var prod api.EnvironmentResponse
+	w := serve(r, manager, http.MethodPost, "/api/systems", `{"name": "payments", "type": "systems"}`)
+	if err := json.Unmarshal(w.Body.Bytes(), &system); err != nil || w.Code != http.StatusCreated {
+		t.Fatalf("create system = %d %s", w.Code, w.Body.String())
+	}
+	for _, version := range []string{"1.0.0", "1.1.0"} {
+		if w := serve(r, manager, http.MethodPost, "/api/builds", `{"system_id": "`+system.ID+`", "version": "`+version+`", "build_date": "2024-05-01T12:00:00Z"}`); w.Code != http.StatusCreated {
+			t.Fatalf("create build = %d %s", w.Code, w.Body.String())
+		}
+	}
+	w = serve(r, manager, http.MethodPost, "/api/releases", `{"name": "2024.05", "type": "Minor", "release_date": "2024-05-01T00:00:00Z"}`)
+	if err := json.Unmarshal(w.Body.Bytes(), &release); err != nil || w.Code != http.StatusCreated {
+		t.Fatalf("create release = %d %s", w.Code, w.Body.String())
+	}
+	w = serve(r, manager, http.MethodPost, "/api/environments", `{"name": "prod", "type": "prod", "status": "active", "release_id": "`+release.ID+`"}`)
+	if err := json.Unmarshal(w.Body.Bytes(), &prod); err != nil || w.Code != http.StatusCreated {
+		t.Fatalf("create environment = %d %s", w.Code, w.Body.String())
+	}
+	if w := serve(r, engineer, http.MethodPost, "/api/environments/"+prod.ID+"/systems", `{"system_id": "`+system.ID+`", "version": "1.0.0"}`); w.Code != http.StatusCreated {
+		t.Fatalf("add system = %d %s", w.Code, w.Body.String())
+	}
+
+	now := time.Now().UTC()
+	period := `"starts_at": "` + now.Add(-time.Hour).Format(time.RFC3339) + `", "ends_at": "` + now.Add(time.Hour).Format(time.RFC3339) + `"`
+	if w := serve(r, engineer, http.MethodPost, "/api/freeze-windows", `{"name": "Code freeze", "scope": "global", `+period+`}`); w.Code != http.StatusForbidden {
+		t.Errorf("create as engineer = %d, want 403", w.Code)
+	}
+	backwards := `"starts_at": "` + now.Format(time.RFC3339) + `", "ends_at": "` + now.Add(-time.Hour).Format(time.RFC3339) + `"`
+	if w := serve(r, manager, http.MethodPost, "/api/freeze-windows", `{"name": "Code freeze", "scope": "global", `+backwards+`}`); w.Code != http.StatusBadRequest {
+		t.Errorf("create ending before it starts = %d, want 400", w.Code)
+	}
+	if w := serve(r, manager, http.MethodPost, "/api/freeze-windows", `{"name": "Code freeze", "scope": "environment_group", `+period+`}`); w.Code != http.StatusBadRequest {
+		t.Errorf("create without a group = %d, want 400", w.Code)
+	}
+
+	var window api.FreezeWindowResponse
+	w = serve(r, manager, http.MethodPost, "/api/freeze-windows", `{"name": "Code freeze", "reason": "2024.05 launch", "scope": "environment_type", "environment_type": "prod", `+period+`}`)
+	if err := json.Unmarshal(w.Body.Bytes(), &window); err != nil || w.Code != http.StatusCreated || window.EnvironmentType == nil {
+		t.Fatalf("create = %d %s", w.Code, w.Body.String())
+	}
+	w = serve(r, engineer, http.MethodGet, "/api/freeze-windows?active=true&environment_id="+prod.ID, "")
+	if !strings.Contains(w.Body.String(), window.ID) {
+		t.Errorf("calendar of prod = %d %s", w.Code, w.Body.String())
+	}
+
+	// Changes during the freeze are rejected unless someone who may override it gives a reason
+	change := `{"version": "1.1.0"}`
+	path := "/api/environments/" + prod.ID + "/systems/" + system.ID
+	if w := serve(r, engineer, http.MethodPut, path, change); w.Code != http.StatusConflict || !strings.Contains(w.Body.String(), `"freeze_windows"`) {
+		t.Errorf("change during the freeze = %d %s, want 409 with the windows", w.Code, w.Body.String())
+	}
+	overriding := func(token, justification string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, path, strings.NewReader(change))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("X-Freeze-Override", justification)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+	if w := overriding(engineer, "Checkout is down"); w.Code != http.StatusForbidden {
+		t.Errorf("override as engineer = %d, want 403", w.Code)
+	}
+	if w := serve(r, manager, http.MethodPost, "/api/environments/"+prod.ID+"/systems/sync", ""); w.Code != http.StatusConflict {
+		t.Errorf("sync during the freeze = %d, want 409", w.Code)
+	}
+	if w := overriding(manager, "Checkout is down"); w.Code != http.StatusOK {
+		t.Fatalf("override as release manager = %d %s", w.Code, w.Body.String())
+	}
+
+	w = serve(r, engineer, http.MethodGet, "/api/freeze-overrides?environment_id="+prod.ID, "")
+	var overrides api.ListResponse[api.FreezeOverrideResponse]
+	if err := json.Unmarshal(w.Body.Bytes(), &overrides); err != nil || len(overrides.Data) != 1 {
+		t.Fatalf("overrides = %d %s", w.Code, w.Body.String())
+	}
+	if o := overrides.Data[0]; o.FreezeWindowID != window.ID || o.Justification != "Checkout is down" || o.OldVersion != "1.0.0" || o.NewVersion != "1.1.0" || o.UserID == nil {
+		t.Errorf("override = %+v", o)
+	}
+
+	// Exempt systems can still change
+	update := `{"name": "Code freeze", "scope": "environment_type", "environment_type": "prod", "exempt_system_ids": ["` + system.ID + `"], ` + period + `}`
+	if w := serve(r, manager, http.MethodPut, "/api/freeze-windows/"+window.ID, update); w.Code != http.StatusOK {
+		t.Fatalf("update = %d %s", w.Code, w.Body.String())
+	}
+	if w := serve(r, engineer, http.MethodPut, path, `{"version": "1.0.0"}`); w.Code != http.StatusOK {
+		t.Errorf("change of an exempt system = %d %s", w.Code, w.Body.String())
+	}
+}
+
+func TestChangeRequests(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := memory.NewStore()
+	r := Setup(&config.Config{
+		JWT:            config.JWTConfig{Secret: "test"},
+		ChangeRequests: config.ChangeRequestConfig{EnvironmentTypes: []string{"prod"}, RequiredApprovals: 2, ApproverRole: "release-manager", TTL: time.Hour},
+	}, store)
+	author := loginAs(t, r, store, "author@example.com", domain.RoleReleaseManager)
+	reviewer := loginAs(t, r, store, "reviewer@example.com", domain.RoleReleaseManager)
+	admin := loginAs(t, r, store, "admin@example.com", domain.RoleAdmin)
+	engineer := loginAs(t, r, store, "engineer@example.com", domain.RoleEngineer)
+
+	var system api.SystemResponse
+	var release api.ReleaseResponse
+	var prod api.EnvironmentResponse
+	w := serve(r, author, http.MethodPost, "/api/systems", `{"name": "payments", "type": "systems"}`)
+	if err := json.Unmarshal(w.Body.Bytes(), &system); err != nil || w.Code != http.StatusCreated {
+		t.Fatalf("create system = %d %s", w.Code, w.Body.String())
+	}
+	for _, version := range []string{"1.0.0", "1.1.0"} {
+		if w := serve(r, author, http.MethodPost, "/api/builds", `{"system_id": "`+system.ID+`", "version": "`+version+`", "build_date": "2024-05-01T12:00:00Z"}`); w.Code != http.StatusCreated {
+			t.Fatalf("create build = %d %s", w.Code, w.Body.String())
+		}
+	}
+	w = serve(r, author, http.MethodPost, "/api/releases", `{"name": "2024.05", "type": "Minor", "release_date": "2024-05-01T00:00:00Z"}`)
+	if err := json.Unmarshal(w.Body.Bytes(), &release); err != nil || w.Code != http.StatusCreated {
+		t.Fatalf("create release = %d %s", w.Code, w.Body.String())
+	}
+	w = serve(r, author, http.MethodPost, "/api/environments", `{"name": "prod", "type": "prod", "status": "active", "release_id": "`+release.ID+`"}`)
+	if err := json.Unmarshal(w.Body.Bytes(), &prod); err != nil || w.Code != http.StatusCreated {
+		t.Fatalf("create environment = %d %s", w.Code, w.Body.String())
+	}
+
+	// Production only changes through change requests
+	if w := serve(r, admin, http.MethodPost, "/api/environments/"+prod.ID+"/systems", `{"system_id": "`+system.ID+`", "version": "1.0.0"}`); w.Code != http.StatusConflict ||
+		!strings.Contains(w.Body.String(), "change requests") {
+		t.Errorf("direct change to prod = %d %s, want 409", w.Code, w.Body.String())
+	}
+
+	propose := func(token, version string) api.ChangeRequestResponse {
+		t.Helper()
+		var request api.ChangeRequestResponse
+		body := `{"title": "Payments ` + version + `", "environment_id": "` + prod.ID + `", "changes": [{"system_id": "` + system.ID + `", "version": "` + version + `"}]}`
+		w := serve(r, token, http.MethodPost, "/api/change-requests", body)
+		if err := json.Unmarshal(w.Body.Bytes(), &request); err != nil || w.Code != http.StatusCreated {
+			t.Fatalf("create change request = %d %s", w.Code, w.Body.String())
+		}
+		return request
+	}
+	review := func(token, id, action, body string) *httptest.ResponseRecorder {
+		return serve(r, token, http.MethodPost, "/api/change-requests/"+id+"/"+action, body)
+	}
+
+	if w := serve(r, author, http.MethodPost, "/api/change-requests", `{"title": "Bad", "environment_id": "`+prod.ID+`", "changes": [{"system_id": "`+system.ID+`", "version": "9.9.9"}]}`); w.Code != http.StatusBadRequest {
+		t.Errorf("create with an unknown version = %d, want 400", w.Code)
+	}
+	launch := propose(author, "1.1.0")
+	if launch.Status != "pending" || launch.RequiredApprovals != 2 || launch.ApproverRole != "release-manager" || launch.Changes[0].FromVersion != "" {
+		t.Errorf("created = %+v", launch)
+	}
+
+	// Approvers need the approver role and cannot approve their own requests, or approve twice
+	if w := review(author, launch.ID, "approve", ""); w.Code != http.StatusForbidden || !strings.Contains(w.Body.String(), "own") {
+		t.Errorf("self approval = %d %s, want 403", w.Code, w.Body.String())
+	}
+	if w := review(engineer, launch.ID, "approve", ""); w.Code != http.StatusForbidden {
+		t.Errorf("approval as engineer = %d, want 403", w.Code)
+	}
+	if w := review(reviewer, launch.ID, "approve", ""); w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"status":"pending"`) {
+		t.Errorf("first approval = %d %s", w.Code, w.Body.String())
+	}
+	if w := review(reviewer, launch.ID, "approve", ""); w.Code != http.StatusConflict {
+		t.Errorf("second approval by the same user = %d, want 409", w.Code)
+	}
+	if w := review(engineer, launch.ID, "apply", ""); w.Code != http.StatusConflict || !strings.Contains(w.Body.String(), `"required_approvals":2`) {
+		t.Errorf("apply with one approval = %d %s, want 409", w.Code, w.Body.String())
+	}
+	if w := review(admin, launch.ID, "approve", ""); w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"status":"approved"`) {
+		t.Errorf("second approval = %d %s", w.Code, w.Body.String())
+	}
+	if w := review(engineer, launch.ID, "comments", `{"body": "Deploying after the stand-up"}`); w.Code != http.StatusCreated {
+		t.Errorf("comment = %d %s", w.Code, w.Body.String())
+	}
+
+	var applied api.ChangeRequestResponse
+	w = review(engineer, launch.ID, "apply", "")
+	if err := json.Unmarshal(w.Body.Bytes(), &applied); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("apply = %d %s", w.Code, w.Body.String())
+	}
+	if applied.Status != "applied" || applied.AppliedBy == nil || len(applied.Approvals) != 2 || len(applied.Comments) != 1 {
+		t.Errorf("applied = %+v", applied)
+	}
+	w = serve(r, engineer, http.MethodGet, "/api/environments/"+prod.ID+"/systems/"+system.ID+"/history", "")
+	if !strings.Contains(w.Body.String(), `"new_version":"1.1.0"`) || !strings.Contains(w.Body.String(), `"source":"change_request"`) {
+		t.Errorf("history after apply = %d %s", w.Code, w.Body.String())
+	}
+	if w := review(engineer, launch.ID, "apply", ""); w.Code != http.StatusConflict {
+		t.Errorf("apply twice = %d, want 409", w.Code)
+	}
+
+	// A request made against versions that changed since can no longer be applied
+	first, second := propose(author, "1.0.0"), propose(author, "1.0.0")
+	for _, request := range []api.ChangeRequestResponse{first, second} {
+		for _, token := range []string{reviewer, admin} {
+			if w := review(token, request.ID, "approve", ""); w.Code != http.StatusOK {
+				t.Fatalf("approve = %d %s", w.Code, w.Body.String())
+			}
+		}
+	}
+	if w := review(author, first.ID, "apply", ""); w.Code != http.StatusOK {
+		t.Fatalf("apply = %d %s", w.Code, w.Body.String())
+	}
+	if w := review(author, second.ID, "apply", ""); w.Code != http.StatusConflict || !strings.Contains(w.Body.String(), `"current_version":"1.0.0"`) {
+		t.Errorf("apply of a stale request = %d %s, want 409", w.Code, w.Body.String())
+	}
+
+	// Rejected and expired requests are closed
+	rejected := propose(author, "1.1.0")
+	if w := review(reviewer, rejected.ID, "reject", `{"reason": "Wait for the fix"}`); w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"rejection_reason":"Wait for the fix"`) {
+		t.Errorf("reject = %d %s", w.Code, w.Body.String())
+	}
+	if w := review(admin, rejected.ID, "approve", ""); w.Code != http.StatusConflict {
+		t.Errorf("approval of a rejected request = %d, want 409", w.Code)
+	}
+	expiring := propose(author, "1.1.0")
+	stored, err := store.ChangeRequests().Get(context.Background(), expiring.ID)
+	if err != nil {
+		t.Fatalf("get change request: %v", err)
+	}
+	stored.ExpiresAt = time.Now().Add(-time.Minute)
+	if err := store.ChangeRequests().Update(context.Background(), stored); err != nil {
+		t.Fatalf("update change request: %v", err)
+	}
+	if w := review(reviewer, expiring.ID, "approve", ""); w.Code != http.StatusConflict || !strings.Contains(w.Body.String(), "expired") {
+		t.Errorf("approval of an expired request = %d %s, want 409", w.Code, w.Body.String())
+	}
+	w = serve(r, engineer, http.MethodGet, "/api/change-requests?status=expired", "")
+	var expired api.ListResponse[api.ChangeRequestResponse]
+	if err := json.Unmarshal(w.Body.Bytes(), &expired); err != nil || len(expired.Data) != 1 || expired.Data[0].ID != expiring.ID {
+		t.Errorf("expired requests = %d %s", w.Code, w.Body.String())
+	}
+
+	// Concurrent approvals both count, so the request ends up approved instead of pending with every approval used up
+	concurrent := propose(author, "1.1.0")
+	var wg sync.WaitGroup
+	codes := make([]int, 2)
+	for i, token := range []string{reviewer, admin} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			codes[i] = review(token, concurrent.ID, "approve", "").Code
+		}()
+	}
+	wg.Wait()
+	if codes[0] != http.StatusOK || codes[1] != http.StatusOK {
+		t.Errorf("concurrent approvals = %v, want 200 for both", codes)
+	}
+	if w := serve(r, engineer, http.MethodGet, "/api/change-requests/"+concurrent.ID, ""); !strings.Contains(w.Body.String(), `"status":"approved"`) {
+		t.Errorf("request after concurrent approvals = %s", w.Body.String())
+	}
+	if w := review(reviewer, "missing", "approve", ""); w.Code != http.StatusNotFound {
+		t.Errorf("approval of a missing request = %d, want 404", w.Code)
+	}
+	if w := review(reviewer, concurrent.ID, "reject", `{"reason": "Superseded"}`); w.Code != http.StatusOK {
+		t.Fatalf("reject = %d %s", w.Code, w.Body.String())
+	}
+
+	// Imports cannot get around change requests either, unless they reference an approved request that describes their changes
+	imported := `{"environments": [{"name": "prod", "type": "prod", "release": "2024.05", "systems": [{"system": "payments", "version": "1.1.0"}]}]}`
+	if w := serve(r, admin, http.MethodPost, "/api/import", imported); w.Code != http.StatusConflict || !strings.Contains(w.Body.String(), "change requests") {
+		t.Errorf("import into prod = %d %s, want 409", w.Code, w.Body.String())
+	}
+	if w := serve(r, admin, http.MethodGet, "/api/environments/"+prod.ID+"/systems/"+system.ID, ""); !strings.Contains(w.Body.String(), `"version":"1.0.0"`) {
+		t.Errorf("rejected import changed prod: %s", w.Body.String())
+	}
+	if w := serve(r, admin, http.MethodPost, "/api/import?change_request="+rejected.ID, imported); w.Code != http.StatusConflict {
+		t.Errorf("import with a rejected request = %d %s, want 409", w.Code, w.Body.String())
+	}
+	upgrade := propose(author, "1.1.0")
+	if w := serve(r, admin, http.MethodPost, "/api/import?change_request="+upgrade.ID, strings.Replace(imported, "1.1.0", "1.0.0", 1)); w.Code != http.StatusConflict ||
+		!strings.Contains(w.Body.String(), "change_request_id") {
+		t.Errorf("import with a request for other changes = %d %s, want 409", w.Code, w.Body.String())
+	}
+	for _, token := range []string{reviewer, admin} {
+		if w := review(token, upgrade.ID, "approve", ""); w.Code != http.StatusOK {
+			t.Fatalf("approve = %d %s", w.Code, w.Body.String())
+		}
+	}
+	if w := serve(r, admin, http.MethodPost, "/api/import?change_request="+upgrade.ID, imported); w.Code != http.StatusOK {
+		t.Fatalf("import with an approved request = %d %s", w.Code, w.Body.String())
+	}
+	if w := serve(r, engineer, http.MethodGet, "/api/change-requests/"+upgrade.ID, ""); !strings.Contains(w.Body.String(), `"status":"applied"`) {
+		t.Errorf("request after the import = %s", w.Body.String())
+	}
+}
+
+func TestReleaseNotes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := memory.NewStore()
+	templates := t.TempDir()
+	if err := os.WriteFile(filepath.Join(templates, "payments.md.tmpl"), []byte(`{{range .Systems}}{{.SystemName}}: {{.Change}}{{"\n"}}{{end}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	r := Setup(&config.Config{JWT: config.JWTConfig{Secret: "test"}, ReleaseNotes: config.ReleaseNotesConfig{TemplateDir: templates}}, store)
+	token := loginAs(t, r, store, "manager@example.com", domain.RoleReleaseManager)
+
+	create := func(path, body string) string {
+		t.Helper()
+		w := serve(r, token, http.MethodPost, path, body)
+		var created struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil || w.Code != http.StatusCreated {
+			t.Fatalf("create %s = %d %s", path, w.Code, w.Body.String())
+		}
+		return created.ID
+	}
+	april := create("/api/releases", `{"name": "2024.04", "type": "Minor", "release_date": "2024-04-01T00:00:00Z"}`)
+	may := create("/api/releases", `{"name": "2024.05", "type": "Minor", "release_date": "2024-05-01T00:00:00Z"}`)
+	june := create("/api/releases", `{"name": "2024.06", "type": "Minor", "release_date": "2024-06-01T00:00:00Z"}`)
+	platform := create("/api/systems", `{"name": "platform", "type": "parent_systems"}`)
+	apiSystem := create("/api/systems", `{"name": "api", "type": "subsystems", "parent_id": "`+platform+`"}`)
+	worker := create("/api/systems", `{"name": "worker", "type": "subsystems", "parent_id": "`+platform+`"}`)
+	billing := create("/api/systems", `{"name": "billing", "type": "systems"}`)
+	for _, build := range []struct{ system, release, version, changelog string }{
+		{apiSystem, april, "1.0.0", ""},
+		{apiSystem, may, "1.1.0", "Faster <login>"},
+		{worker, may, "2.0.0", ""},
+		{billing, april, "3.0.0", ""},
+		{billing, june, "3.1.0", "Invoices in PDF"},
+	} {
+		create("/api/builds", `{"system_id": "`+build.system+`", "release_id": "`+build.release+`", "version": "`+build.version+`", "build_date": "2024-03-28T12:00:00Z", "changelog": "`+build.changelog+`"}`)
+	}
+
+	// Subsystems are grouped under their parent with the version of the previous release containing them
+	w := serve(r, token, http.MethodGet, "/api/releases/"+may+"/notes", "")
+	var notes api.ReleaseNotesResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &notes); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("notes = %d %s", w.Code, w.Body.String())
+	}
+	if len(notes.Systems) != 1 || notes.Systems[0].SystemName != "platform" || notes.Systems[0].Change != "modified" || len(notes.Systems[0].Subsystems) != 2 {
+		t.Fatalf("notes systems = %+v", notes.Systems)
+	}
+	upgraded, added := notes.Systems[0].Subsystems[0], notes.Systems[0].Subsystems[1]
+	if upgraded.Change != "upgraded" || upgraded.PreviousVersion != "1.0.0" || upgraded.PreviousRelease == nil || upgraded.PreviousRelease.Name != "2024.04" || upgraded.Changelog != "Faster <login>" {
+		t.Errorf("api = %+v", upgraded)
+	}
+	if added.Change != "added" || added.PreviousVersion != "" || notes.Summary["added"] != 1 || notes.Summary["upgraded"] != 1 {
+		t.Errorf("worker = %+v, summary = %v", added, notes.Summary)
+	}
+
+	// The previous release is the latest earlier one containing the system, not the release right before
+	w = serve(r, token, http.MethodGet, "/api/releases/"+june+"/notes?format=markdown", "")
+	if w.Code != http.StatusOK || !strings.HasPrefix(w.Header().Get("Content-Type"), "text/markdown") ||
+		!strings.Contains(w.Body.String(), "`3.0.0` → `3.1.0` since 2024.04") || !strings.Contains(w.Body.String(), "Invoices in PDF") {
+		t.Errorf("markdown notes = %d %s", w.Code, w.Body.String())
+	}
+
+	w = serve(r, token, http.MethodGet, "/api/releases/"+may+"/notes?format=html", "")
+	if w.Code != http.StatusOK || !strings.HasPrefix(w.Header().Get("Content-Type"), "text/html") || !strings.Contains(w.Body.String(), "Faster &lt;login&gt;") {
+		t.Errorf("html notes = %d %s", w.Code, w.Body.String())
+	}
+
+	// Teams render with their own templates
+	if w := serve(r, token, http.MethodGet, "/api/releases/"+may+"/notes?format=markdown&team=payments", ""); w.Code != http.StatusOK || w.Body.String() != "platform: modified\n" {
+		t.Errorf("team notes = %d %q", w.Code, w.Body.String())
+	}
+	if w := serve(r, token, http.MethodGet, "/api/releases/"+may+"/notes?format=html&team=payments", ""); w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "payments.html.tmpl") {
+		t.Errorf("missing team template = %d %s", w.Code, w.Body.String())
+	}
+	if w := serve(r, token, http.MethodGet, "/api/releases/"+may+"/notes?format=markdown&team=../secrets", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("invalid team = %d %s", w.Code, w.Body.String())
+	}
+	if w := serve(r, token, http.MethodGet, "/api/releases/"+may+"/notes?format=pdf", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("invalid format = %d %s", w.Code, w.Body.String())
+	}
+}
+
+func TestBuildProvenance(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := memory.NewStore()
+	r := Setup(&config.Config{JWT: config.JWTConfig{Secret: "test"}, Webhooks: config.WebhookConfig{GitLabSecret: "gitlab-token"}}, store)
+	token := loginAs(t, r, store, "manager@example.com", domain.RoleReleaseManager)
+
+	var system api.SystemResponse
+	w := serve(r, token, http.MethodPost, "/api/systems", `{"name": "payments", "type": "systems"}`)
+	if err := json.Unmarshal(w.Body.Bytes(), &system); err != nil || w.Code != http.StatusCreated {
+		t.Fatalf("create system = %d %s", w.Code, w.Body.String())
+	}
+	digest := "sha256:" + strings.Repeat("a", 64)
+	otherDigest := "sha256:" + strings.Repeat("b", 64)
+	register := func(version, provenance string) *httptest.ResponseRecorder {
+		return serve(r, token, http.MethodPost, "/api/builds", `{"system_id": "`+system.ID+`", "version": "`+version+`", "build_date": "2024-05-01T12:00:00Z"`+provenance+`}`)
+	}
+
+	w = register("1.0.0", `, "repository_url": "https://git.example.com/payments", "commit_sha": "9FCEB02D0AE598E95DC970B74767F19372D61AF8", "branch": "main", "pipeline_url": "https://ci.example.com/runs/1", "image_digest": "`+digest+`"`)
+	var build api.BuildResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &build); err != nil || w.Code != http.StatusCreated ||
+		build.CommitSHA != "9fceb02d0ae598e95dc970b74767f19372d61af8" || build.ImageDigest != digest || build.PipelineURL != "https://ci.example.com/runs/1" {
+		t.Fatalf("register build = %d %s", w.Code, w.Body.String())
+	}
+	for _, invalid := range []string{`, "commit_sha": "main"`, `, "image_digest": "latest"`, `, "pipeline_url": "javascript:alert(1)"`} {
+		if w := register("1.0.1", invalid); w.Code != http.StatusBadRequest {
+			t.Errorf("register build with %s = %d %s", invalid, w.Code, w.Body.String())
+		}
+	}
+
+	// Builds are found by their abbreviated commit and by their image digest
+	for _, query := range []string{"commit_sha=9fceb02", "image_digest=" + digest} {
+		w := serve(r, token, http.MethodGet, "/api/builds?"+query, "")
+		var builds api.ListResponse[api.BuildResponse]
+		if err := json.Unmarshal(w.Body.Bytes(), &builds); err != nil || len(builds.Data) != 1 || builds.Data[0].ID != build.ID {
+			t.Errorf("builds with %s = %d %s", query, w.Code, w.Body.String())
+		}
+	}
+	if w := serve(r, token, http.MethodGet, "/api/builds?commit_sha=zz", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("builds with an invalid commit = %d %s", w.Code, w.Body.String())
+	}
+
+	// Without strict digests a version can be rebuilt into another artifact, but then strict digests cannot be enabled
+	w = register("1.0.0", `, "image_digest": "`+otherDigest+`"`)
+	var rebuild api.BuildResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &rebuild); err != nil || w.Code != http.StatusCreated {
+		t.Fatalf("rebuild = %d %s", w.Code, w.Body.String())
+	}
+	if w := serve(r, token, http.MethodPut, "/api/systems/"+system.ID, `{"strict_digests": true}`); w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), `"conflicting_versions":["1.0.0"]`) {
+		t.Errorf("enable strict digests with conflicts = %d %s", w.Code, w.Body.String())
+	}
+	if w := serve(r, token, http.MethodDelete, "/api/builds/"+rebuild.ID, ""); w.Code != http.StatusOK {
+		t.Fatalf("delete rebuild = %d %s", w.Code, w.Body.String())
+	}
+	if w := serve(r, token, http.MethodPut, "/api/systems/"+system.ID, `{"strict_digests": true}`); w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"strict_digests":true`) {
+		t.Fatalf("enable strict digests = %d %s", w.Code, w.Body.String())
+	}
+
+	// With strict digests a version maps to one artifact, builds without digests are not compared
+	if w := register("1.0.0", `, "image_digest": "`+otherDigest+`"`); w.Code != http.StatusConflict || !strings.Contains(w.Body.String(), build.ID) {
+		t.Errorf("register conflicting build = %d %s", w.Code, w.Body.String())
+	}
+	if w := register("1.0.0", `, "image_digest": "`+digest+`"`); w.Code != http.StatusCreated {
+		t.Errorf("register build with the same digest = %d %s", w.Code, w.Body.String())
+	}
+	w = register("1.1.0", "")
+	var unpinned api.BuildResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &unpinned); err != nil || w.Code != http.StatusCreated {
+		t.Fatalf("register build without digest = %d %s", w.Code, w.Body.String())
+	}
+	if w := serve(r, token, http.MethodPut, "/api/builds/"+unpinned.ID, `{"version": "1.0.0", "image_digest": "`+otherDigest+`"}`); w.Code != http.StatusConflict {
+		t.Errorf("update into a conflicting build = %d %s", w.Code, w.Body.String())
+	}
+
+	// CI webhooks record the provenance the provider sends
+	payload := `{"object_kind": "pipeline", "object_attributes": {"id": 7, "iid": 42, "ref": "main", "sha": "1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b", "status": "success",
+		"finished_at": "2024-05-02 10:00:00 UTC", "url": "https://gitlab.example.com/payments/-/pipelines/7"},
+		"project": {"name": "payments", "path_with_namespace": "shop/payments", "web_url": "https://gitlab.example.com/shop/payments"}}`
+	req := httptest.NewRequest(http.MethodPost, "/api/hooks/builds/gitlab", strings.NewReader(payload))
+	req.Header.Set("X-Gitlab-Token", "gitlab-token")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	var delivery api.BuildWebhookResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &delivery); err != nil || w.Code != http.StatusCreated || delivery.Build == nil {
+		t.Fatalf("gitlab webhook = %d %s", w.Code, w.Body.String())
+	}
+	if delivery.Build.CommitSHA != "1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b" || delivery.Build.Branch != "main" ||
+		delivery.Build.RepositoryURL != "https://gitlab.example.com/shop/payments" || delivery.Build.PipelineURL != "https://gitlab.example.com/payments/-/pipelines/7" {
+		t.Errorf("webhook build provenance = %+v", delivery.Build.BuildProvenance)
+	}
+}
+
+func TestRollback(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := memory.NewStore()
+	r := Setup(&config.Config{JWT: config.JWTConfig{Secret: "test"}}, store)
+	token := loginAs(t, r, store, "manager@example.com", domain.RoleReleaseManager)
+	admin := loginAs(t, r, store, "admin@example.com", domain.RoleAdmin)
+
+	create := func(path, body string, v interface{}) {
+		t.Helper()
+		w := serve(r, token, http.MethodPost, path, body)
+		if err := json.Unmarshal(w.Body.Bytes(), v); err != nil || w.Code != http.StatusCreated {
+			t.Fatalf("POST %s = %d %s", path, w.Code, w.Body.String())
+		}
+	}
+	systems := make(map[string]string)
+	builds := make(map[string]string)
+	for name, versions := range map[string][]string{"api": {"1.0.0", "1.1.0"}, "web": {"2.0.0"}, "worker": {"3.0.0"}, "legacy": {"0.9.0"}} {
+		var system api.SystemResponse
+		create("/api/systems", `{"name": "`+name+`", "type": "systems"}`, &system)
+		systems[name] = system.ID
+		for _, version := range versions {
+			var build api.BuildResponse
+			create("/api/builds", `{"system_id": "`+system.ID+`", "version": "`+version+`", "build_date": "2024-05-01T12:00:00Z"}`, &build)
+			builds[name+"@"+version] = build.ID
+		}
+	}
+	var release api.ReleaseResponse
+	create("/api/releases", `{"name": "2024.05", "type": "Minor", "release_date": "2024-05-01T00:00:00Z"}`, &release)
+	var env api.EnvironmentResponse
+	create("/api/environments", `{"name": "staging", "type": "staging", "status": "active", "release_id": "`+release.ID+`"}`, &env)
+	envPath := "/api/environments/" + env.ID
+
+	change := func(token, method, path, body string) {
+		t.Helper()
+		if w := serve(r, token, method, path, body); w.Code != http.StatusOK && w.Code != http.StatusCreated {
+			t.Fatalf("%s %s = %d %s", method, path, w.Code, w.Body.String())
+		}
+	}
+	for _, deployed := range []string{"api@1.0.0", "web@2.0.0", "legacy@0.9.0"} {
+		name, version, _ := strings.Cut(deployed, "@")
+		change(token, http.MethodPost, envPath+"/systems", `{"system_id": "`+systems[name]+`", "version": "`+version+`"}`)
+	}
+	time.Sleep(5 * time.Millisecond)
+	at := time.Now().UTC().Format(time.RFC3339Nano)
+	time.Sleep(5 * time.Millisecond)
+
+	// Since then api was upgraded, worker added, and web and legacy removed, legacy for good
+	change(token, http.MethodPut, envPath+"/systems/"+systems["api"], `{"version": "1.1.0"}`)
+	change(token, http.MethodPost, envPath+"/systems", `{"system_id": "`+systems["worker"]+`", "version": "3.0.0"}`)
+	change(admin, http.MethodDelete, envPath+"/systems/"+systems["web"], "")
+	change(admin, http.MethodDelete, envPath+"/systems/"+systems["legacy"], "")
+	change(token, http.MethodDelete, "/api/builds/"+builds["legacy@0.9.0"], "")
+	change(admin, http.MethodDelete, "/api/systems/"+systems["legacy"], "")
+
+	// Nothing changes unless every build to restore still exists
+	change(token, http.MethodDelete, "/api/builds/"+builds["web@2.0.0"], "")
+	w := serve(r, token, http.MethodPost, envPath+"/rollback", `{"at": "`+at+`"}`)
+	if w.Code != http.StatusConflict || !strings.Contains(w.Body.String(), `"missing_builds":["web 2.0.0"]`) {
+		t.Errorf("rollback with a missing build = %d %s, want 409", w.Code, w.Body.String())
+	}
+	if w := serve(r, token, http.MethodGet, envPath+"/systems/"+systems["api"], ""); !strings.Contains(w.Body.String(), `"version":"1.1.0"`) {
+		t.Errorf("failed rollback changed api: %s", w.Body.String())
+	}
+	var rebuilt api.BuildResponse
+	create("/api/builds", `{"system_id": "`+systems["web"]+`", "version": "2.0.0", "build_date": "2024-05-01T12:00:00Z"}`, &rebuilt)
+
+	w = serve(r, token, http.MethodPost, envPath+"/rollback", `{"at": "`+at+`"}`)
+	var rollback api.EnvironmentRollbackResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &rollback); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("rollback = %d %s", w.Code, w.Body.String())
+	}
+	wantChanges := map[string]api.RollbackChange{
+		systems["api"]:    {SystemID: systems["api"], SystemName: "api", Action: "rollback", FromVersion: "1.1.0", ToVersion: "1.0.0"},
+		systems["worker"]: {SystemID: systems["worker"], SystemName: "worker", Action: "skipped", FromVersion: "3.0.0"},
+		systems["web"]:    {SystemID: systems["web"], SystemName: "web", Action: "restore", ToVersion: "2.0.0"},
+		systems["legacy"]: {SystemID: systems["legacy"], Action: "skipped", ToVersion: "0.9.0"},
+	}
+	if len(rollback.Changes) != len(wantChanges) {
+		t.Errorf("rollback changes = %+v", rollback.Changes)
+	}
+	for _, got := range rollback.Changes {
+		if got != wantChanges[got.SystemID] {
+			t.Errorf("rollback change = %+v, want %+v", got, wantChanges[got.SystemID])
+		}
+	}
+	for name, version := range map[string]string{"api": "1.0.0", "web": "2.0.0", "worker": "3.0.0"} {
+		if w := serve(r, token, http.MethodGet, envPath+"/systems/"+systems[name], ""); !strings.Contains(w.Body.String(), `"version":"`+version+`"`) {
+			t.Errorf("%s after the rollback = %d %s, want %s", name, w.Code, w.Body.String(), version)
+		}
+	}
+	w = serve(r, token, http.MethodGet, envPath+"/systems/"+systems["web"]+"/history", "")
+	if !strings.Contains(w.Body.String(), `"old_version":"","new_version":"2.0.0","build_id":"`+rebuilt.ID+`"`) || !strings.Contains(w.Body.String(), `"source":"rollback"`) {
+		t.Errorf("history of the restored system = %s", w.Body.String())
+	}
+
+	// Single systems roll back to their previous version, or a version or deployment from their own history
+	var history api.ListResponse[api.DeploymentResponse]
+	w = serve(r, token, http.MethodGet, envPath+"/systems/"+systems["worker"]+"/history", "")
+	if err := json.Unmarshal(w.Body.Bytes(), &history); err != nil || len(history.Data) != 1 {
+		t.Fatalf("worker history = %d %s", w.Code, w.Body.String())
+	}
+	tests := []struct {
+		name   string
+		system string
+		body   string
+		code   int
+		want   string
+	}{
+		{"to the previous version", "api", "", http.StatusOK, `"to_version":"1.1.0"`},
+		{"to the running version", "api", `{"version": "1.1.0"}`, http.StatusConflict, "already running"},
+		{"to a version never deployed", "api", `{"version": "9.9.9"}`, http.StatusBadRequest, "never deployed"},
+		{"to a deployment of another system", "api", `{"deployment_id": "` + history.Data[0].ID + `"}`, http.StatusNotFound, "Deployment not found"},
+		{"without a version before", "worker", "", http.StatusConflict, "no version before"},
+		{"of a system not in the environment", "legacy", "", http.StatusNotFound, "Environment system not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(r, token, http.MethodPost, envPath+"/systems/"+systems[tt.system]+"/rollback", tt.body)
+			if w.Code != tt.code || !strings.Contains(w.Body.String(), tt.want) {
+				t.Errorf("rollback = %d %s, want %d %s", w.Code, w.Body.String(), tt.code, tt.want)
+			}
+		})
+	}
+
+	// Versions whose build was deleted cannot be restored
+	change(token, http.MethodDelete, "/api/builds/"+builds["api@1.0.0"], "")
+	if w := serve(r, token, http.MethodPost, envPath+"/systems/"+systems["api"]+"/rollback", `{"version": "1.0.0"}`); w.Code != http.StatusConflict || !strings.Contains(w.Body.String(), "no longer exists") {
+		t.Errorf("rollback to a deleted build = %d %s, want 409", w.Code, w.Body.String())
+	}
+}
+
+func TestReleaseTransitions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := memory.NewStore()
+	r := Setup(&config.Config{JWT: config.JWTConfig{Secret: "test"}}, store)
+	token := loginAs(t, r, store, "manager@example.com", domain.RoleReleaseManager)
+
+	create := func(path, body string, v interface{}) {
+		t.Helper()
+		w := serve(r, token, http.MethodPost, path, body)
+		if err := json.Unmarshal(w.Body.Bytes(), v); err != nil || w.Code != http.StatusCreated {
+			t.Fatalf("POST %s = %d %s", path, w.Code, w.Body.String())
+		}
+	}
+	var release, other api.ReleaseResponse
+	create("/api/releases", `{"name": "2024.05", "type": "Minor", "release_date": "2024-05-01T00:00:00Z"}`, &release)
+	create("/api/releases", `{"name": "2024.06", "type": "Minor", "release_date": "2024-06-01T00:00:00Z"}`, &other)
+	var payments, web api.SystemResponse
+	create("/api/systems", `{"name": "payments", "type": "systems"}`, &payments)
+	create("/api/systems", `{"name": "web", "type": "systems"}`, &web)
+	var env api.EnvironmentResponse
+	create("/api/environments", `{"name": "staging", "type": "staging", "status": "pending", "release_id": "`+release.ID+`"}`, &env)
+	var build api.BuildResponse
+	create("/api/builds", `{"system_id": "`+web.ID+`", "version": "2.0.0", "build_date": "2024-05-01T12:00:00Z"}`, &build)
+	var added api.EnvironmentSystemsAddedResponse
+	create("/api/environments/"+env.ID+"/systems", `{"system_id": "`+web.ID+`", "version": "2.0.0"}`, &added)
+
+	// Every step runs against the release as the steps before left it
+	tests := []struct {
+		name   string
+		setup  func()
+		body   string
+		code   int
+		want   []string
+		status string
+	}{
+		{name: "unknown status", body: `{"to": "shipped"}`, code: http.StatusBadRequest, want: []string{`"code":"invalid_status"`}, status: "planned"},
+		{name: "missing status", body: `{}`, code: http.StatusBadRequest, status: "planned"},
+		{name: "skipping the lifecycle", body: `{"to": "released"}`, code: http.StatusConflict,
+			want: []string{`"code":"invalid_transition"`, `"allowed_transitions":["in-progress","cancelled"]`}, status: "planned"},
+		{name: "start", body: `{"to": "in-progress", "comment": "Kick-off"}`, code: http.StatusCreated,
+			want: []string{`"from_status":"planned"`, `"to_status":"in-progress"`, `"comment":"Kick-off"`}, status: "in-progress"},
+		{name: "freeze without builds", body: `{"to": "frozen"}`, code: http.StatusConflict,
+			want: []string{`"code":"guard_failed"`, "Release has no builds", "System web in environment staging has no build in this release"}, status: "in-progress"},
+		{name: "freeze without a build of a deployed system", setup: func() {
+			create("/api/builds", `{"system_id": "`+payments.ID+`", "version": "1.0.0", "release_id": "`+release.ID+`", "build_date": "2024-05-01T12:00:00Z"}`, &build)
+		}, body: `{"to": "frozen"}`, code: http.StatusConflict, want: []string{"System web in environment staging has no build in this release"}, status: "in-progress"},
+		{name: "freeze", setup: func() {
+			create("/api/builds", `{"system_id": "`+web.ID+`", "version": "2.1.0", "release_id": "`+release.ID+`", "build_date": "2024-05-01T12:00:00Z"}`, &build)
+		}, body: `{"to": "frozen"}`, code: http.StatusCreated, status: "frozen"},
+		{name: "release with a pending environment", body: `{"to": "released"}`, code: http.StatusConflict,
+			want: []string{`"code":"guard_failed"`, "Environment staging is still pending"}, status: "frozen"},
+		{name: "release", setup: func() {
+			if w := serve(r, token, http.MethodPut, "/api/environments/"+env.ID, `{"status": "active"}`); w.Code != http.StatusOK {
+				t.Fatalf("activate environment = %d %s", w.Code, w.Body.String())
+			}
+		}, body: `{"to": "released"}`, code: http.StatusCreated, status: "released"},
+		{name: "leave a final status", body: `{"to": "in-progress"}`, code: http.StatusConflict,
+			want: []string{`"code":"invalid_transition"`, `"allowed_transitions":[]`}, status: "released"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.setup != nil {
+				tt.setup()
+			}
+			w := serve(r, token, http.MethodPost, "/api/releases/"+release.ID+"/transitions", tt.body)
+			if w.Code != tt.code {
+				t.Errorf("transition = %d %s, want %d", w.Code, w.Body.String(), tt.code)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(w.Body.String(), want) {
+					t.Errorf("transition = %s, want %s", w.Body.String(), want)
+				}
+			}
+			if w := serve(r, token, http.MethodGet, "/api/releases/"+release.ID, ""); !strings.Contains(w.Body.String(), `"status":"`+tt.status+`"`) {
+				t.Errorf("release after the transition = %s, want status %s", w.Body.String(), tt.status)
+			}
+		})
+	}
+
+	w := serve(r, token, http.MethodGet, "/api/releases/"+release.ID+"/transitions", "")
+	var transitions api.ListResponse[api.ReleaseTransitionResponse]
+	if err := json.Unmarshal(w.Body.Bytes(), &transitions); err != nil || len(transitions.Data) != 3 || transitions.Data[2].ToStatus != "released" {
+		t.Errorf("transitions = %d %s", w.Code, w.Body.String())
+	}
+	if w := serve(r, token, http.MethodPut, "/api/releases/"+other.ID, `{"status": "in-progress"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("direct status change = %d %s, want 400", w.Code, w.Body.String())
+	}
+
+	// Of two transitions racing from the same status only one goes through
+	var wg sync.WaitGroup
+	codes := make([]int, 2)
+	for i, to := range []string{"in-progress", "cancelled"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			codes[i] = serve(r, token, http.MethodPost, "/api/releases/"+other.ID+"/transitions", `{"to": "`+to+`"}`).Code
+		}()
+	}
+	wg.Wait()
+	if slices.Sort(codes); codes[0] != http.StatusCreated || codes[1] != http.StatusConflict {
+		t.Errorf("racing transitions = %v, want one 201 and one 409", codes)
+	}
+	w = serve(r, token, http.MethodGet, "/api/releases/"+other.ID+"/transitions", "")
+	if err := json.Unmarshal(w.Body.Bytes(), &transitions); err != nil || len(transitions.Data) != 1 {
+		t.Errorf("transitions after the race = %d %s", w.Code, w.Body.String())
+	}
+}
+
+func TestDeploymentHistory(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := memory.NewStore()
+	r := Setup(&config.Config{JWT: config.JWTConfig{Secret: "test"}}, store)
+	token := loginAs(t, r, store, "manager@example.com", domain.RoleReleaseManager)
+	admin := loginAs(t, r, store, "admin@example.com", domain.RoleAdmin)
+
+	create := func(path, body string, v interface{}) {
+		t.Helper()
+		w := serve(r, token, http.MethodPost, path, body)
+		if err := json.Unmarshal(w.Body.Bytes(), v); err != nil || w.Code != http.StatusCreated {
+			t.Fatalf("POST %s = %d %s", path, w.Code, w.Body.String())
+		}
+	}
+	var release api.ReleaseResponse
+	create("/api/releases", `{"name": "2024.05", "type": "Minor", "release_date": "2024-05-01T00:00:00Z"}`, &release)
+	var system api.SystemResponse
+	create("/api/systems", `{"name": "payments", "type": "systems"}`, &system)
+	var released, hotfix api.BuildResponse
+	create("/api/builds", `{"system_id": "`+system.ID+`", "version": "1.0.0", "release_id": "`+release.ID+`", "build_date": "2024-05-01T12:00:00Z"}`, &released)
+	create("/api/builds", `{"system_id": "`+system.ID+`", "version": "1.1.0", "build_date": "2024-05-02T12:00:00Z"}`, &hotfix)
+	var env api.EnvironmentResponse
+	create("/api/environments", `{"name": "staging", "type": "staging", "status": "active", "release_id": "`+release.ID+`"}`, &env)
+	envPath := "/api/environments/" + env.ID
+	systemPath := envPath + "/systems/" + system.ID
+
+	// Every way of changing a version is recorded with its source, status-only updates are not
+	steps := []struct {
+		token, method, path, body string
+		header                    string
+	}{
+		{token, http.MethodPost, envPath + "/systems", `{"system_id": "` + system.ID + `", "version": "1.1.0"}`, ""},
+		{token, http.MethodPost, envPath + "/systems/sync", "", ""},
+		{token, http.MethodPut, systemPath, `{"version": "1.1.0"}`, "api"},
+		{token, http.MethodPut, systemPath, `{"status": "inactive"}`, ""},
+		{admin, http.MethodDelete, systemPath, "", ""},
+	}
+	for _, step := range steps {
+		req := httptest.NewRequest(step.method, step.path, strings.NewReader(step.body))
+		req.Header.Set("Authorization", "Bearer "+step.token)
+		if step.header != "" {
+			req.Header.Set("X-Deployment-Source", step.header)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != http.StatusOK && w.Code != http.StatusCreated {
+			t.Fatalf("%s %s = %d %s", step.method, step.path, w.Code, w.Body.String())
+		}
+	}
+
+	w := serve(r, token, http.MethodGet, systemPath+"/history", "")
+	var history api.ListResponse[api.DeploymentResponse]
+	if err := json.Unmarshal(w.Body.Bytes(), &history); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("history = %d %s", w.Code, w.Body.String())
+	}
+	want := []struct {
+		source, oldVersion, newVersion, buildID string
+	}{
+		{"manual", "1.1.0", "", ""},
+		{"api", "1.0.0", "1.1.0", hotfix.ID},
+		{"sync", "1.1.0", "1.0.0", released.ID},
+		{"manual", "", "1.1.0", hotfix.ID},
+	}
+	if len(history.Data) != len(want) {
+		t.Fatalf("history = %s, want %d entries", w.Body.String(), len(want))
+	}
+	for i, deployment := range history.Data {
+		buildID := ""
+		if deployment.BuildID != nil {
+			buildID = *deployment.BuildID
+		}
+		if deployment.Source != want[i].source || deployment.OldVersion != want[i].oldVersion || deployment.NewVersion != want[i].newVersion || buildID != want[i].buildID ||
+			deployment.SystemName != "payments" || deployment.UserEmail == "" {
+			t.Errorf("history[%d] = %+v, want %+v", i, deployment, want[i])
+		}
+	}
+
+	// The history is filtered by time and paginated
+	tests := []struct {
+		name  string
+		query string
+		code  int
+		count int
+	}{
+		{"since the first deployment", "?since=" + history.Data[3].CreatedAt.Format(time.RFC3339Nano), http.StatusOK, 4},
+		{"until the first deployment", "?until=" + history.Data[3].CreatedAt.Format(time.RFC3339Nano), http.StatusOK, 1},
+		{"in the future", "?since=" + time.Now().Add(time.Hour).Format(time.RFC3339), http.StatusOK, 0},
+		{"one page", "?limit=2", http.StatusOK, 2},
+		{"invalid since", "?since=yesterday", http.StatusBadRequest, 0},
+		{"invalid until", "?until=2024-13-01", http.StatusBadRequest, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(r, token, http.MethodGet, envPath+"/history"+tt.query, "")
+			var page api.ListResponse[api.DeploymentResponse]
+			if w.Code != tt.code {
+				t.Fatalf("history = %d %s, want %d", w.Code, w.Body.String(), tt.code)
+			}
+			if tt.code == http.StatusOK && (json.Unmarshal(w.Body.Bytes(), &page) != nil || len(page.Data) != tt.count) {
+				t.Errorf("history = %s, want %d entries", w.Body.String(), tt.count)
+			}
+		})
+	}
+	if w := serve(r, token, http.MethodGet, "/api/environments/missing/history", ""); w.Code != http.StatusNotFound {
+		t.Errorf("history of a missing environment = %d, want 404", w.Code)
+	}
+}
+
+func TestPromotion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := memory.NewStore()
+	r := Setup(&config.Config{JWT: config.JWTConfig{Secret: "test"}}, store)
+	token := loginAs(t, r, store, "manager@example.com", domain.RoleReleaseManager)
+
+	create := func(path, body string, v interface{}) {
+		t.Helper()
+		w := serve(r, token, http.MethodPost, path, body)
+		if err := json.Unmarshal(w.Body.Bytes(), v); err != nil || w.Code != http.StatusCreated {
+			t.Fatalf("POST %s = %d %s", path, w.Code, w.Body.String())
+		}
+	}
+	systems := make(map[string]string)
+	builds := make(map[string]string)
+	for name, versions := range map[string][]string{"api": {"1.0.0", "1.1.0"}, "web": {"2.0.0"}, "worker": {"3.0.0"}} {
+		var system api.SystemResponse
+		create("/api/systems", `{"name": "`+name+`", "type": "systems"}`, &system)
+		systems[name] = system.ID
+		for _, version := range versions {
+			var build api.BuildResponse
+			create("/api/builds", `{"system_id": "`+system.ID+`", "version": "`+version+`", "build_date": "2024-05-01T12:00:00Z"}`, &build)
+			builds[name+"@"+version] = build.ID
+		}
+	}
+	var release api.ReleaseResponse
+	create("/api/releases", `{"name": "2024.05", "type": "Minor", "release_date": "2024-05-01T00:00:00Z"}`, &release)
+	var group api.EnvironmentGroupResponse
+	create("/api/environment-groups", `{"name": "shop", "promotion_path": ["dev", "staging", "prod"]}`, &group)
+	environments := make(map[string]string)
+	for _, env := range []struct{ name, kind, status, group string }{
+		{"dev", "dev", "active", group.ID},
+		{"staging", "staging", "active", group.ID},
+		{"staging-eu", "staging", "maintenance", group.ID},
+		{"prod", "prod", "active", group.ID},
+		{"sandbox", "dev", "active", ""},
+	} {
+		body := `{"name": "` + env.name + `", "type": "` + env.kind + `", "status": "` + env.status + `", "release_id": "` + release.ID + `"`
+		if env.group != "" {
+			body += `, "environment_group_id": "` + env.group + `"`
+		}
+		var created api.EnvironmentResponse
+		create("/api/environments", body+"}", &created)
+		environments[env.name] = created.ID
+	}
+	deploy := func(env, deployed string) {
+		t.Helper()
+		name, version, _ := strings.Cut(deployed, "@")
+		var envSystem api.EnvironmentSystemResponse
+		create("/api/environments/"+environments[env]+"/systems", `{"system_id": "`+systems[name]+`", "version": "`+version+`"}`, &envSystem)
+	}
+	deploy("dev", "api@1.1.0")
+	deploy("dev", "web@2.0.0")
+	deploy("staging", "api@1.0.0")
+	deploy("staging", "worker@3.0.0")
+	promote := func(env, body string) *httptest.ResponseRecorder {
+		return serve(r, token, http.MethodPost, "/api/environments/"+environments[env]+"/promote", body)
+	}
+
+	tests := []struct {
+		name string
+		env  string
+		body string
+		code int
+		want string
+	}{
+		{"outside a group", "sandbox", "", http.StatusConflict, "does not belong to an environment group"},
+		{"from the last stage", "prod", "", http.StatusConflict, "has no next stage"},
+		{"into an ambiguous stage", "dev", "", http.StatusConflict, "Several 'staging' environments"},
+		{"into another stage", "dev", `{"target_environment_id": "` + environments["prod"] + `"}`, http.StatusConflict, "is not a 'staging' environment"},
+		{"into an environment in maintenance", "dev", `{"target_environment_id": "` + environments["staging-eu"] + `"}`, http.StatusConflict, "status 'maintenance'"},
+		{"of a missing environment", "missing", "", http.StatusNotFound, "Environment not found"},
+		{"with an invalid body", "dev", `{"dry_run": "yes"}`, http.StatusBadRequest, "error"},
+		{"as a dry run", "dev", `{"target_environment_id": "` + environments["staging"] + `", "dry_run": true}`, http.StatusOK, `"dry_run":true`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := promote(tt.env, tt.body)
+			if w.Code != tt.code || !strings.Contains(w.Body.String(), tt.want) {
+				t.Errorf("promote = %d %s, want %d %s", w.Code, w.Body.String(), tt.code, tt.want)
+			}
+		})
+	}
+	if w := serve(r, token, http.MethodGet, "/api/environments/"+environments["staging"]+"/systems/"+systems["api"], ""); !strings.Contains(w.Body.String(), `"version":"1.0.0"`) {
+		t.Errorf("dry run changed staging: %s", w.Body.String())
+	}
+
+	// Nothing is promoted unless every version still has a build
+	target := `{"target_environment_id": "` + environments["staging"] + `"}`
+	if w := serve(r, token, http.MethodDelete, "/api/builds/"+builds["web@2.0.0"], ""); w.Code != http.StatusOK {
+		t.Fatalf("delete build = %d %s", w.Code, w.Body.String())
+	}
+	if w := promote("dev", target); w.Code != http.StatusConflict || !strings.Contains(w.Body.String(), `"missing_builds":["web 2.0.0"]`) {
+		t.Errorf("promote with a missing build = %d %s, want 409", w.Code, w.Body.String())
+	}
+	var rebuilt api.BuildResponse
+	create("/api/builds", `{"system_id": "`+systems["web"]+`", "version": "2.0.0", "build_date": "2024-05-01T12:00:00Z"}`, &rebuilt)
+
+	w := promote("dev", target)
+	var promotion api.PromotionResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &promotion); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("promote = %d %s", w.Code, w.Body.String())
+	}
+	wantChanges := []api.PromotionChange{
+		{SystemID: systems["api"], SystemName: "api", Action: "update", FromVersion: "1.0.0", ToVersion: "1.1.0"},
+		{SystemID: systems["web"], SystemName: "web", Action: "add", ToVersion: "2.0.0"},
+		{SystemID: systems["worker"], SystemName: "worker", Action: "remove", FromVersion: "3.0.0"},
+	}
+	if promotion.DryRun || promotion.SourceEnvironmentName != "dev" || promotion.TargetEnvironmentName != "staging" || !slices.Equal(promotion.Changes, wantChanges) {
+		t.Errorf("promotion = %+v, want changes %+v", promotion, wantChanges)
+	}
+	for name, version := range map[string]string{"api": "1.1.0", "web": "2.0.0"} {
+		if w := serve(r, token, http.MethodGet, "/api/environments/"+environments["staging"]+"/systems/"+systems[name], ""); !strings.Contains(w.Body.String(), `"version":"`+version+`"`) {
+			t.Errorf("%s after the promotion = %d %s, want %s", name, w.Code, w.Body.String(), version)
+		}
+	}
+	if w := serve(r, token, http.MethodGet, "/api/environments/"+environments["staging"]+"/systems/"+systems["worker"], ""); w.Code != http.StatusNotFound {
+		t.Errorf("worker after the promotion = %d %s, want 404", w.Code, w.Body.String())
+	}
+	w = serve(r, token, http.MethodGet, "/api/environments/"+environments["staging"]+"/systems/"+systems["web"]+"/history", "")
+	if !strings.Contains(w.Body.String(), `"source":"promotion"`) || !strings.Contains(w.Body.String(), `"build_id":"`+rebuilt.ID+`"`) {
+		t.Errorf("history of the promoted system = %s", w.Body.String())
+	}
+
+	// Promoting again changes nothing
+	if w := promote("dev", target); w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"changes":[]`) {
+		t.Errorf("promote again = %d %s", w.Code, w.Body.String())
+	}
+}
+
+func TestDrift(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := memory.NewStore()
+	r := Setup(&config.Config{JWT: config.JWTConfig{Secret: "test"}}, store)
+	token := loginAs(t, r, store, "manager@example.com", domain.RoleReleaseManager)
+
+	create := func(path, body string, v interface{}) {
+		t.Helper()
+		w := serve(r, token, http.MethodPost, path, body)
+		if err := json.Unmarshal(w.Body.Bytes(), v); err != nil || w.Code != http.StatusCreated {
+			t.Fatalf("POST %s = %d %s", path, w.Code, w.Body.String())
+		}
+	}
+	var release api.ReleaseResponse
+	create("/api/releases", `{"name": "2024.05", "type": "Minor", "release_date": "2024-05-01T00:00:00Z"}`, &release)
+	systems := make(map[string]string)
+	builds := make(map[string]string)
+	for name, versions := range map[string][]string{"api": {"1.0.0", "1.1.0"}, "web": {"2.0.0"}, "worker": {"3.0.0"}, "tool": {"5.0.0"}} {
+		var system api.SystemResponse
+		create("/api/systems", `{"name": "`+name+`", "type": "systems"}`, &system)
+		systems[name] = system.ID
+		for _, version := range versions {
+			// The first version of api and the versions of web and worker make up the release
+			releaseID := ""
+			if version != "1.1.0" && name != "tool" {
+				releaseID = `, "release_id": "` + release.ID + `"`
+			}
+			var build api.BuildResponse
+			create("/api/builds", `{"system_id": "`+system.ID+`", "version": "`+version+`", "build_date": "2024-05-01T12:00:00Z"`+releaseID+`}`, &build)
+			builds[name+"@"+version] = build.ID
+		}
+	}
+	var group api.EnvironmentGroupResponse
+	create("/api/environment-groups", `{"name": "shop", "promotion_path": ["dev", "staging"]}`, &group)
+	environments := make(map[string]string)
+	for name, deployed := range map[string][]string{"dev": {"api@1.1.0", "web@2.0.0", "tool@5.0.0"}, "staging": {"api@1.0.0", "web@2.0.0", "worker@3.0.0"}} {
+		var env api.EnvironmentResponse
+		create("/api/environments", `{"name": "`+name+`", "type": "`+name+`", "status": "active", "release_id": "`+release.ID+`", "environment_group_id": "`+group.ID+`"}`, &env)
+		environments[name] = env.ID
+		for _, d := range deployed {
+			system, version, _ := strings.Cut(d, "@")
+			var envSystem api.EnvironmentSystemResponse
+			create("/api/environments/"+env.ID+"/systems", `{"system_id": "`+systems[system]+`", "version": "`+version+`"}`, &envSystem)
+		}
+	}
+
+	inSync := api.EnvironmentDriftReport{MissingSystems: []api.DriftItem{}, ExtraSystems: []api.DriftItem{}, VersionMismatches: []api.DriftItem{}, UnreleasedBuilds: []api.DriftItem{}, InSync: true}
+	tests := []struct {
+		name string
+		env  string
+		want api.EnvironmentDriftReport
+	}{
+		{"of an environment in sync", "staging", inSync},
+		{"of a drifted environment", "dev", api.EnvironmentDriftReport{
+			MissingSystems:    []api.DriftItem{{SystemID: systems["worker"], SystemName: "worker", ReleaseVersion: "3.0.0", BuildID: builds["worker@3.0.0"]}},
+			ExtraSystems:      []api.DriftItem{{SystemID: systems["tool"], SystemName: "tool", DeployedVersion: "5.0.0"}},
+			VersionMismatches: []api.DriftItem{{SystemID: systems["api"], SystemName: "api", DeployedVersion: "1.1.0", ReleaseVersion: "1.0.0"}},
+			UnreleasedBuilds: []api.DriftItem{
+				{SystemID: systems["api"], SystemName: "api", DeployedVersion: "1.1.0", ReleaseVersion: "1.0.0", BuildID: builds["api@1.1.0"]},
+				{SystemID: systems["tool"], SystemName: "tool", DeployedVersion: "5.0.0", BuildID: builds["tool@5.0.0"]},
+			},
+		}},
+	}
+	sortItems := func(items []api.DriftItem) {
+		slices.SortFunc(items, func(a, b api.DriftItem) int { return strings.Compare(a.SystemName, b.SystemName) })
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(r, token, http.MethodGet, "/api/environments/"+environments[tt.env]+"/drift", "")
+			var got api.EnvironmentDriftReport
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil || w.Code != http.StatusOK {
+				t.Fatalf("drift = %d %s", w.Code, w.Body.String())
+			}
+			if got.EnvironmentName != tt.env || got.EnvironmentType != tt.env || got.ReleaseName != "2024.05" {
+				t.Errorf("drift of %s = %+v", tt.env, got)
+			}
+			sortItems(got.UnreleasedBuilds)
+			if got.InSync != tt.want.InSync || !slices.Equal(got.MissingSystems, tt.want.MissingSystems) || !slices.Equal(got.ExtraSystems, tt.want.ExtraSystems) ||
+				!slices.Equal(got.VersionMismatches, tt.want.VersionMismatches) || !slices.Equal(got.UnreleasedBuilds, tt.want.UnreleasedBuilds) {
+				t.Errorf("drift = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+	if w := serve(r, token, http.MethodGet, "/api/environments/missing/drift", ""); w.Code != http.StatusNotFound {
+		t.Errorf("drift of a missing environment = %d %s, want 404", w.Code, w.Body.String())
+	}
+
+	// Groups line up the deployed versions of each system
+	w := serve(r, token, http.MethodGet, "/api/environment-groups/"+group.ID+"/drift", "")
+	var report api.EnvironmentGroupDriftReport
+	if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("group drift = %d %s", w.Code, w.Body.String())
+	}
+	if report.InSync || report.EnvironmentGroupName != "shop" || len(report.Environments) != 2 {
+		t.Errorf("group drift = %+v", report)
+	}
+	wantSystems := []api.SystemVersionMatrixEntry{
+		{SystemID: systems["api"], SystemName: "api", Versions: map[string]string{environments["dev"]: "1.1.0", environments["staging"]: "1.0.0"}},
+		{SystemID: systems["tool"], SystemName: "tool", Versions: map[string]string{environments["dev"]: "5.0.0"}, Consistent: true},
+		{SystemID: systems["web"], SystemName: "web", Versions: map[string]string{environments["dev"]: "2.0.0", environments["staging"]: "2.0.0"}, Consistent: true},
+		{SystemID: systems["worker"], SystemName: "worker", Versions: map[string]string{environments["staging"]: "3.0.0"}, Consistent: true},
+	}
+	if !slices.EqualFunc(report.Systems, wantSystems, func(a, b api.SystemVersionMatrixEntry) bool {
+		return a.SystemID == b.SystemID && a.SystemName == b.SystemName && a.Consistent == b.Consistent && maps.Equal(a.Versions, b.Versions)
+	}) {
+		t.Errorf("group drift systems = %+v, want %+v", report.Systems, wantSystems)
+	}
+	if w := serve(r, token, http.MethodGet, "/api/environment-groups/missing/drift", ""); w.Code != http.StatusNotFound {
+		t.Errorf("drift of a missing group = %d %s, want 404", w.Code, w.Body.String())
+	}
+}
+
+func TestReleaseComparison(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := memory.NewStore()
+	r := Setup(&config.Config{JWT: config.JWTConfig{Secret: "test"}}, store)
+	token := loginAs(t, r, store, "manager@example.com", domain.RoleReleaseManager)
+
+	create := func(path, body string, v interface{}) {
+		t.Helper()
+		w := serve(r, token, http.MethodPost, path, body)
+		if err := json.Unmarshal(w.Body.Bytes(), v); err != nil || w.Code != http.StatusCreated {
+			t.Fatalf("POST %s = %d %s", path, w.Code, w.Body.String())
+		}
+	}
+	var base, target api.ReleaseResponse
+	create("/api/releases", `{"name": "2024.04", "type": "Minor", "release_date": "2024-04-01T00:00:00Z"}`, &base)
+	create("/api/releases", `{"name": "2024.05", "type": "Minor", "release_date": "2024-05-01T00:00:00Z"}`, &target)
+	systems := make(map[string]string)
+	for _, system := range []struct{ name, kind, parent string }{
+		{"api", "systems", ""}, {"web", "systems", ""}, {"worker", "systems", ""}, {"tool", "systems", ""}, {"cron", "systems", ""},
+		{"shop", "parent_systems", ""}, {"cart", "subsystems", "shop"}, {"checkout", "subsystems", "shop"},
+		{"billing", "parent_systems", ""}, {"invoice", "subsystems", "billing"}, {"ledger", "subsystems", "billing"},
+	} {
+		body := `{"name": "` + system.name + `", "type": "` + system.kind + `"`
+		if system.parent != "" {
+			body += `, "parent_id": "` + systems[system.parent] + `"`
+		}
+		var created api.SystemResponse
+		create("/api/systems", body+"}", &created)
+		systems[system.name] = created.ID
+	}
+	for release, deployed := range map[string][]string{
+		base.ID:   {"api@1.0.0", "web@2.0.0", "worker@3.0.0", "cron@4.0.0", "cart@1.0.0", "checkout@1.0.0", "ledger@1.0.0"},
+		target.ID: {"api@1.1.0", "web@1.9.0", "tool@5.0.0", "cron@4.0.0", "cart@1.2.0", "checkout@1.0.0", "invoice@1.0.0", "ledger@2.0.0"},
+	} {
+		for _, d := range deployed {
+			name, version, _ := strings.Cut(d, "@")
+			var build api.BuildResponse
+			create("/api/builds", `{"system_id": "`+systems[name]+`", "release_id": "`+release+`", "version": "`+version+`", "build_date": "2024-05-01T12:00:00Z"}`, &build)
+		}
+	}
+	comparePath := "/api/releases/" + target.ID + "/compare/" + base.ID
+
+	tests := []struct {
+		name string
+		path string
+		code int
+		want []string
+	}{
+		{"of a missing release", "/api/releases/missing/compare/" + base.ID, http.StatusNotFound, []string{"Release not found"}},
+		{"with a missing release", "/api/releases/" + target.ID + "/compare/missing", http.StatusNotFound, []string{"Release to compare with not found"}},
+		{"in an unknown format", comparePath + "?format=csv", http.StatusBadRequest, []string{"Invalid format"}},
+		{"as Markdown", comparePath + "?format=markdown", http.StatusOK, []string{
+			"## Release comparison: 2024.04 → 2024.05\n",
+			"2 added, 1 removed, 3 upgraded, 1 downgraded, 2 unchanged\n",
+			"| System | Change | 2024.04 | 2024.05 |\n",
+			"| api | upgraded | `1.0.0` | `1.1.0` |\n",
+			"| tool | added | – | `5.0.0` |\n",
+			"| worker | removed | `3.0.0` | – |\n",
+			"| shop | upgraded | – | – |\n| ↳ cart | upgraded | `1.0.0` | `1.2.0` |\n| ↳ checkout | unchanged | `1.0.0` | `1.0.0` |\n",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(r, token, http.MethodGet, tt.path, "")
+			if w.Code != tt.code {
+				t.Fatalf("compare = %d %s, want %d", w.Code, w.Body.String(), tt.code)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(w.Body.String(), want) {
+					t.Errorf("compare = %s, want %q", w.Body.String(), want)
+				}
+			}
+		})
+	}
+
+	// Subsystems roll up into their parent, which changes as a whole only when they all change alike
+	w := serve(r, token, http.MethodGet, comparePath, "")
+	var comparison api.ReleaseComparisonResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &comparison); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("compare = %d %s", w.Code, w.Body.String())
+	}
+	if comparison.Base.ID != base.ID || comparison.Target.ID != target.ID {
+		t.Errorf("compared releases = %+v, %+v", comparison.Base, comparison.Target)
+	}
+	wantSummary := map[string]int{"added": 2, "removed": 1, "upgraded": 3, "downgraded": 1, "unchanged": 2}
+	if !maps.Equal(comparison.Summary, wantSummary) {
+		t.Errorf("summary = %v, want %v", comparison.Summary, wantSummary)
+	}
+	var got []string
+	for _, system := range comparison.Systems {
+		got = append(got, system.SystemName+" "+system.Change+" "+system.BaseVersion+" "+system.TargetVersion)
+		for _, sub := range system.Subsystems {
+			got = append(got, "  "+sub.SystemName+" "+sub.Change+" "+sub.BaseVersion+" "+sub.TargetVersion)
+		}
+	}
+	want := []string{
+		"api upgraded 1.0.0 1.1.0",
+		"billing modified  ",
+		"  invoice added  1.0.0",
+		"  ledger upgraded 1.0.0 2.0.0",
+		"cron unchanged 4.0.0 4.0.0",
+		"shop upgraded  ",
+		"  cart upgraded 1.0.0 1.2.0",
+		"  checkout unchanged 1.0.0 1.0.0",
+		"tool added  5.0.0",
+		"web downgraded 2.0.0 1.9.0",
+		"worker removed 3.0.0 ",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("compared systems = %q, want %q", got, want)
+	}
+}
+
+func TestBuildWebhookSignatures(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := memory.NewStore()
+	r := Setup(&config.Config{JWT: config.JWTConfig{Secret: "test"}, Webhooks: config.WebhookConfig{GitHubSecret: "github-secret", JenkinsSecret: "jenkins-secret"}}, store)
+	token := loginAs(t, r, store, "manager@example.com", domain.RoleReleaseManager)
+	if w := serve(r, token, http.MethodPost, "/api/systems", `{"name": "payments", "type": "systems"}`); w.Code != http.StatusCreated {
+		t.Fatalf("create system = %d %s", w.Code, w.Body.String())
+	}
+
+	sign := func(secret, body string) string {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(body))
+		return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	}
+	github := `{"action": "completed", "workflow_run": {"head_branch": "v1.2.0", "run_number": 12, "conclusion": "success", "updated_at": "2024-05-02T10:00:00Z"},
+		"repository": {"name": "payments", "full_name": "shop/payments"}}`
+	jenkins := `{"name": "payments", "build": {"number": 7, "phase": "COMPLETED", "status": "SUCCESS", "parameters": {"VERSION": "1.3.0"}}}`
+	gitlab := `{"object_kind": "pipeline", "object_attributes": {"id": 7, "iid": 42, "ref": "main", "status": "success"}, "project": {"name": "payments"}}`
+
+	// Rejected deliveries are not recorded, so the valid ones that follow are not duplicates
+	tests := []struct {
+		name     string
+		provider string
+		body     string
+		headers  map[string]string
+		code     int
+		want     string
+	}{
+		{"from an unknown provider", "bitbucket", github, nil, http.StatusNotFound, "Unknown webhook provider"},
+		{"from a provider without secret", "gitlab", gitlab, map[string]string{"X-Gitlab-Token": ""}, http.StatusNotFound, "not configured"},
+		{"from github without signature", "github", github, map[string]string{"X-GitHub-Event": "workflow_run", "X-GitHub-Delivery": "gh-1"}, http.StatusUnauthorized, "Invalid webhook signature"},
+		{"from github signed with another secret", "github", github, map[string]string{"X-GitHub-Event": "workflow_run", "X-GitHub-Delivery": "gh-1", "X-Hub-Signature-256": sign("jenkins-secret", github)}, http.StatusUnauthorized, "Invalid webhook signature"},
+		{"from github with a tampered payload", "github", github, map[string]string{"X-GitHub-Event": "workflow_run", "X-GitHub-Delivery": "gh-1", "X-Hub-Signature-256": sign("github-secret", github+" ")}, http.StatusUnauthorized, "Invalid webhook signature"},
+		{"from github with a malformed signature", "github", github, map[string]string{"X-GitHub-Event": "workflow_run", "X-GitHub-Delivery": "gh-1", "X-Hub-Signature-256": "sha256=zz"}, http.StatusUnauthorized, "Invalid webhook signature"},
+		{"from github signed in the jenkins header", "github", github, map[string]string{"X-GitHub-Event": "workflow_run", "X-GitHub-Delivery": "gh-1", "X-Jenkins-Signature-256": sign("github-secret", github)}, http.StatusUnauthorized, "Invalid webhook signature"},
+		{"from github", "github", github, map[string]string{"X-GitHub-Event": "workflow_run", "X-GitHub-Delivery": "gh-1", "X-Hub-Signature-256": sign("github-secret", github)}, http.StatusCreated, `"version":"v1.2.0"`},
+		{"from jenkins without signature", "jenkins", jenkins, map[string]string{"X-Jenkins-Delivery": "jk-1"}, http.StatusUnauthorized, "Invalid webhook signature"},
+		{"from jenkins signed with another secret", "jenkins", jenkins, map[string]string{"X-Jenkins-Delivery": "jk-1", "X-Jenkins-Signature-256": sign("github-secret", jenkins)}, http.StatusUnauthorized, "Invalid webhook signature"},
+		{"from jenkins", "jenkins", jenkins, map[string]string{"X-Jenkins-Delivery": "jk-1", "X-Jenkins-Signature-256": sign("jenkins-secret", jenkins)}, http.StatusCreated, `"version":"1.3.0"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/hooks/builds/"+tt.provider, strings.NewReader(tt.body))
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			if w.Code != tt.code || !strings.Contains(w.Body.String(), tt.want) {
+				t.Errorf("webhook = %d %s, want %d %s", w.Code, w.Body.String(), tt.code, tt.want)
+			}
+		})
+	}
+
+	// GitLab sends its secret token instead of a signature
+	r = Setup(&config.Config{JWT: config.JWTConfig{Secret: "test"}, Webhooks: config.WebhookConfig{GitLabSecret: "gitlab-token"}}, store)
+	for secret, code := range map[string]int{"": http.StatusUnauthorized, "gitlab-tok": http.StatusUnauthorized, "gitlab-token": http.StatusCreated} {
+		req := httptest.NewRequest(http.MethodPost, "/api/hooks/builds/gitlab", strings.NewReader(gitlab))
+		req.Header.Set("X-Gitlab-Token", secret)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != code {
+			t.Errorf("gitlab webhook with token %q = %d %s, want %d", secret, w.Code, w.Body.String(), code)
+		}
+	}
+}
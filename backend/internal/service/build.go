@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+
+	"release-management/internal/models/domain"
+	"release-management/internal/repository"
+	"release-management/internal/version"
+)
+
+// BuildService enforces the rules builds must follow: they belong to a system that is not a parent system,
+// keep that system for life, use semantic versions where the system requires them, are unique per release,
+// carry well-formed provenance and do not reuse a version for another artifact where the system forbids it.
+type BuildService struct {
+	store repository.Store
+}
+
+// NewBuildService creates a build service on a store, which may be a transaction
+func NewBuildService(store repository.Store) *BuildService {
+	return &BuildService{store: store}
+}
+
+// Create validates a new build and stores it
+func (s *BuildService) Create(ctx context.Context, build *domain.Build) error {
+	build.ReleaseID = normalizeID(build.ReleaseID)
+	if err := build.ValidateProvenance(); err != nil {
+		return err
+	}
+
+	system, err := s.lockSystem(ctx, build.SystemID)
+	if err != nil {
+		return err
+	}
+	if system.Type == domain.SystemTypeParent {
+		return domain.ErrBuildForParentSystem
+	}
+	if err := checkVersionScheme(system, build.Version); err != nil {
+		return err
+	}
+	if err := s.checkDigests(ctx, system, build); err != nil {
+		return err
+	}
+	if err := s.checkRelease(ctx, build); err != nil {
+		return err
+	}
+
+	return s.store.Builds().Create(ctx, build)
+}
+
+// Update validates a changed build against its stored state and saves it
+func (s *BuildService) Update(ctx context.Context, build *domain.Build) error {
+	current, err := s.store.Builds().Get(ctx, build.ID)
+	if err != nil {
+		return notFound(err, domain.ErrBuildNotFound)
+	}
+	build.ReleaseID = normalizeID(build.ReleaseID)
+
+	if build.SystemID != current.SystemID {
+		return domain.ErrBuildSystemImmutable
+	}
+	if err := build.ValidateProvenance(); err != nil {
+		return err
+	}
+
+	versionChanged := build.Version != current.Version
+	digestsChanged := build.ImageDigest != current.ImageDigest || build.PackageChecksum != current.PackageChecksum
+	if versionChanged || digestsChanged {
+		system, err := s.lockSystem(ctx, build.SystemID)
+		if err != nil {
+			return err
+		}
+		if versionChanged {
+			if err := checkVersionScheme(system, build.Version); err != nil {
+				return err
+			}
+		}
+		if err := s.checkDigests(ctx, system, build); err != nil {
+			return err
+		}
+	}
+
+	if build.ReleaseID != nil && (current.ReleaseID == nil || *build.ReleaseID != *current.ReleaseID) {
+		if err := s.checkRelease(ctx, build); err != nil {
+			return err
+		}
+	}
+
+	return s.store.Builds().Update(ctx, build)
+}
+
+// Helper function to check that the release of a build exists and has no other build of the same system
+func (s *BuildService) checkRelease(ctx context.Context, build *domain.Build) error {
+	if build.ReleaseID == nil {
+		return nil
+	}
+
+	if _, err := s.store.Releases().Get(ctx, *build.ReleaseID); err != nil {
+		return notFound(err, domain.ErrBuildReleaseNotFound)
+	}
+
+	existing, err := s.store.Builds().List(ctx, repository.BuildFilter{SystemID: build.SystemID, ReleaseID: *build.ReleaseID})
+	if err != nil {
+		return err
+	}
+	for _, other := range existing {
+		if other.ID != build.ID {
+			return domain.ErrDuplicateBuildInRelease
+		}
+	}
+	return nil
+}
+
+// Helper function to lock the system of a build and load it. Within a transaction, builds written to the
+// system at the same time are checked one after the other, so checkDigests sees the builds committed before.
+func (s *BuildService) lockSystem(ctx context.Context, id string) (*domain.System, error) {
+	if err := s.store.Systems().Lock(ctx, id); err != nil {
+		return nil, notFound(err, domain.ErrBuildSystemNotFound)
+	}
+	system, err := s.store.Systems().Get(ctx, id)
+	if err != nil {
+		return nil, notFound(err, domain.ErrBuildSystemNotFound)
+	}
+	return system, nil
+}
+
+// Helper function to check that no other build of a system with strict digests has the version of build
+// with different digests
+func (s *BuildService) checkDigests(ctx context.Context, system *domain.System, build *domain.Build) error {
+	if !system.StrictDigests || (build.ImageDigest == "" && build.PackageChecksum == "") {
+		return nil
+	}
+
+	existing, err := s.store.Builds().List(ctx, repository.BuildFilter{SystemID: build.SystemID})
+	if err != nil {
+		return err
+	}
+	for i := range existing {
+		if existing[i].ID != build.ID && build.ConflictsWith(&existing[i]) {
+			return domain.ErrBuildDigestConflict.WithDetail("version", build.Version).WithDetail("conflicting_build_id", existing[i].ID)
+		}
+	}
+	return nil
+}
+
+// Helper function to check a version against the scheme a system requires
+func checkVersionScheme(system *domain.System, v string) error {
+	if !system.StrictSemver {
+		return nil
+	}
+	if _, err := version.ParseSemVer(v); err != nil {
+		return domain.ErrBuildVersionNotSemVer.WithCause(err)
+	}
+	return nil
+}
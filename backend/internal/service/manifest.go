@@ -0,0 +1,1274 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"release-management/internal/models/domain"
+	"release-management/internal/repository"
+	"release-management/internal/version"
+)
+
+// ManifestService exports the catalog as a manifest and imports manifests. Entities are matched by name:
+// systems, releases, environment groups and environments by their name, builds by their system and version
+// and deployed systems by their environment and system.
+//
+// An import first checks the whole manifest and plans the changes, then applies them on the store the
+// service was created with when asked to. Callers apply a plan atomically by creating the service on a transaction.
+type ManifestService struct {
+	store repository.Store
+}
+
+// NewManifestService creates a manifest service on a store, which may be a transaction
+func NewManifestService(store repository.Store) *ManifestService {
+	return &ManifestService{store: store}
+}
+
+// Export describes the current catalog as a manifest, sorted by name so that exports diff well
+func (s *ManifestService) Export(ctx context.Context) (*domain.Manifest, error) {
+	current, err := loadCatalog(ctx, s.store)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &domain.Manifest{
+		Systems:           []domain.ManifestSystem{},
+		Releases:          []domain.ManifestRelease{},
+		EnvironmentGroups: []domain.ManifestEnvironmentGroup{},
+		Environments:      []domain.ManifestEnvironment{},
+	}
+
+	children := make(map[string][]domain.System)
+	for _, system := range current.systems {
+		if system.ParentID != nil {
+			children[*system.ParentID] = append(children[*system.ParentID], system)
+		}
+	}
+	for _, system := range sortedByName(current.systems, systemName) {
+		if system.ParentID != nil {
+			continue
+		}
+		exported := current.exportSystem(system)
+		for _, subsystem := range sortedByName(children[system.ID], systemName) {
+			exported.Subsystems = append(exported.Subsystems, current.exportSystem(subsystem))
+		}
+		manifest.Systems = append(manifest.Systems, exported)
+	}
+
+	releases := append([]domain.Release(nil), current.releases...)
+	sort.SliceStable(releases, func(i, j int) bool {
+		if !releases[i].ReleaseDate.Equal(releases[j].ReleaseDate) {
+			return releases[i].ReleaseDate.Before(releases[j].ReleaseDate)
+		}
+		return releases[i].Name < releases[j].Name
+	})
+	for _, release := range releases {
+		manifest.Releases = append(manifest.Releases, domain.ManifestRelease{
+			Name:        release.Name,
+			Description: release.Description,
+			ReleaseDate: release.ReleaseDate,
+			Status:      release.Status,
+			Type:        release.Type,
+		})
+	}
+
+	for _, group := range sortedByName(current.groups, groupName) {
+		manifest.EnvironmentGroups = append(manifest.EnvironmentGroups, domain.ManifestEnvironmentGroup{
+			Name:          group.Name,
+			Description:   group.Description,
+			PromotionPath: group.PromotionPath,
+			RequiredRole:  group.RequiredRole,
+		})
+	}
+
+	for _, env := range sortedByName(current.environments, environmentName) {
+		exported := domain.ManifestEnvironment{
+			Name:        env.Name,
+			Type:        env.Type,
+			Status:      env.Status,
+			URL:         env.URL,
+			Description: env.Description,
+			Release:     current.releaseNames[env.ReleaseID],
+		}
+		if env.EnvironmentGroupID != nil {
+			exported.Group = current.groupNames[*env.EnvironmentGroupID]
+		}
+		for _, envSystem := range current.envSystems[env.ID] {
+			exported.Systems = append(exported.Systems, domain.ManifestEnvironmentSystem{
+				System:  current.systemNames[envSystem.SystemID],
+				Version: envSystem.Version,
+				Status:  envSystem.Status,
+			})
+		}
+		sort.SliceStable(exported.Systems, func(i, j int) bool { return exported.Systems[i].System < exported.Systems[j].System })
+		manifest.Environments = append(manifest.Environments, exported)
+	}
+
+	return manifest, nil
+}
+
+// Import plans the changes that bring the catalog in line with a manifest and applies them if apply is set.
+// Nothing is written unless the whole manifest is valid, otherwise domain.ErrInvalidManifest lists every problem.
+func (s *ManifestService) Import(ctx context.Context, manifest *domain.Manifest, apply bool) (*domain.ManifestPlan, error) {
+	current, err := loadCatalog(ctx, s.store)
+	if err != nil {
+		return nil, err
+	}
+
+	imp := newManifestImport(ctx, s.store, manifest, current, apply)
+	imp.validate()
+	if len(imp.problems) > 0 {
+		return nil, domain.ErrInvalidManifest.WithDetail("problems", imp.problems)
+	}
+
+	for _, step := range []func() error{
+		imp.importEnvironmentGroups,
+		imp.importReleases,
+		imp.importSystems,
+		imp.importBuilds,
+		imp.importEnvironments,
+		imp.deleteEnvironments,
+		imp.deleteSystems,
+		imp.deleteReleases,
+		imp.deleteEnvironmentGroups,
+	} {
+		if err := step(); err != nil {
+			return nil, err
+		}
+	}
+
+	imp.plan.Applied = apply
+	return &imp.plan, nil
+}
+
+// catalog is the current state of every entity a manifest describes
+type catalog struct {
+	systems      []domain.System
+	releases     []domain.Release
+	builds       []domain.Build
+	groups       []domain.EnvironmentGroup
+	environments []domain.Environment
+	// envSystems holds the systems deployed to each environment, by environment ID
+	envSystems map[string][]domain.EnvironmentSystem
+
+	systemNames  map[string]string
+	releaseNames map[string]string
+	groupNames   map[string]string
+}
+
+// Helper function to load the current catalog from a store
+func loadCatalog(ctx context.Context, store repository.Store) (*catalog, error) {
+	current := &catalog{
+		envSystems:   make(map[string][]domain.EnvironmentSystem),
+		systemNames:  make(map[string]string),
+		releaseNames: make(map[string]string),
+		groupNames:   make(map[string]string),
+	}
+
+	var err error
+	if current.systems, err = store.Systems().List(ctx, repository.SystemFilter{}); err != nil {
+		return nil, err
+	}
+	if current.releases, err = store.Releases().List(ctx); err != nil {
+		return nil, err
+	}
+	if current.builds, err = store.Builds().List(ctx, repository.BuildFilter{}); err != nil {
+		return nil, err
+	}
+	if current.groups, err = store.EnvironmentGroups().List(ctx); err != nil {
+		return nil, err
+	}
+	if current.environments, err = store.Environments().List(ctx, repository.EnvironmentFilter{}); err != nil {
+		return nil, err
+	}
+	for _, env := range current.environments {
+		if current.envSystems[env.ID], err = store.Environments().ListSystems(ctx, env.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, system := range current.systems {
+		current.systemNames[system.ID] = system.Name
+	}
+	for _, release := range current.releases {
+		current.releaseNames[release.ID] = release.Name
+	}
+	for _, group := range current.groups {
+		current.groupNames[group.ID] = group.Name
+	}
+	return current, nil
+}
+
+// Helper function to describe a system and its builds in a manifest
+func (c *catalog) exportSystem(system domain.System) domain.ManifestSystem {
+	exported := domain.ManifestSystem{
+		Name:          system.Name,
+		Description:   system.Description,
+		Type:          system.Type,
+		Status:        system.Status,
+		StrictSemver:  system.StrictSemver,
+		StrictDigests: system.StrictDigests,
+	}
+
+	builds := c.buildsOf(system.ID)
+	sort.SliceStable(builds, func(i, j int) bool { return version.Compare(builds[i].Version, builds[j].Version) < 0 })
+	for _, build := range builds {
+		exportedBuild := domain.ManifestBuild{Version: build.Version, BuildDate: build.BuildDate}
+		if build.ReleaseID != nil {
+			exportedBuild.Release = c.releaseNames[*build.ReleaseID]
+		}
+		exported.Builds = append(exported.Builds, exportedBuild)
+	}
+	return exported
+}
+
+// Helper function to return the name of an optional release
+func (c *catalog) releaseNameOf(id *string) string {
+	if id == nil {
+		return ""
+	}
+	return c.releaseNames[*id]
+}
+
+// Helper function to return the name of an optional environment group
+func (c *catalog) groupNameOf(id *string) string {
+	if id == nil {
+		return ""
+	}
+	return c.groupNames[*id]
+}
+
+// Helper function to return the builds of a system
+func (c *catalog) buildsOf(systemID string) []domain.Build {
+	var builds []domain.Build
+	for _, build := range c.builds {
+		if build.SystemID == systemID {
+			builds = append(builds, build)
+		}
+	}
+	return builds
+}
+
+// manifestImport holds the state of one import while it is planned and applied
+type manifestImport struct {
+	ctx      context.Context
+	store    repository.Store
+	manifest *domain.Manifest
+	current  *catalog
+	apply    bool
+	plan     domain.ManifestPlan
+	problems []string
+	// removed holds the IDs of the deployed systems the import removes, which may be reached twice
+	removed map[string]bool
+
+	// Entities by name, the current ones followed by the ones the import creates.
+	// Entities created while planning have no ID.
+	systems      map[string]*domain.System
+	releases     map[string]*domain.Release
+	groups       map[string]*domain.EnvironmentGroup
+	environments map[string]*domain.Environment
+
+	// State the manifest asks for, used to check references before anything is written
+	wantSystems  map[string]domain.SystemType
+	wantReleases map[string]bool
+	wantGroups   map[string]bool
+	wantVersions map[string]map[string]bool
+}
+
+// Helper function to index the current catalog and the manifest by name
+func newManifestImport(ctx context.Context, store repository.Store, manifest *domain.Manifest, current *catalog, apply bool) *manifestImport {
+	imp := &manifestImport{
+		ctx:          ctx,
+		store:        store,
+		manifest:     manifest,
+		current:      current,
+		apply:        apply,
+		wantSystems:  make(map[string]domain.SystemType),
+		wantReleases: make(map[string]bool),
+		wantGroups:   make(map[string]bool),
+		wantVersions: make(map[string]map[string]bool),
+		removed:      make(map[string]bool),
+	}
+
+	// Names are only ambiguous for the kinds of entities the manifest uses
+	usesSystems := manifest.Systems != nil || manifest.Environments != nil
+	usesReleases := manifest.Releases != nil || manifest.Systems != nil || manifest.Environments != nil
+	usesGroups := manifest.EnvironmentGroups != nil || manifest.Environments != nil
+	imp.systems = indexByName(imp, current.systems, "system", systemName, usesSystems)
+	imp.releases = indexByName(imp, current.releases, "release", releaseName, usesReleases)
+	imp.groups = indexByName(imp, current.groups, "environment group", groupName, usesGroups)
+	imp.environments = indexByName(imp, current.environments, "environment", environmentName, manifest.Environments != nil)
+
+	if manifest.Systems != nil {
+		walkSystems(manifest.Systems, func(system *domain.ManifestSystem, parent *domain.ManifestSystem) {
+			systemType := system.Type
+			if parent != nil && systemType == "" {
+				systemType = domain.SystemTypeSubsystem
+			}
+			imp.wantSystems[system.Name] = systemType
+		})
+	} else {
+		for _, system := range current.systems {
+			imp.wantSystems[system.Name] = system.Type
+		}
+	}
+
+	if manifest.Releases != nil {
+		for _, release := range manifest.Releases {
+			imp.wantReleases[release.Name] = true
+		}
+	} else {
+		for _, release := range current.releases {
+			imp.wantReleases[release.Name] = true
+		}
+	}
+
+	if manifest.EnvironmentGroups != nil {
+		for _, group := range manifest.EnvironmentGroups {
+			imp.wantGroups[group.Name] = true
+		}
+	} else {
+		for _, group := range current.groups {
+			imp.wantGroups[group.Name] = true
+		}
+	}
+
+	// A system keeps its current builds unless the manifest lists them
+	for name := range imp.wantSystems {
+		versions := make(map[string]bool)
+		if listed := imp.manifestSystem(name); listed != nil && listed.Builds != nil {
+			for _, build := range listed.Builds {
+				versions[build.Version] = true
+			}
+		} else if system, ok := imp.systems[name]; ok {
+			for _, build := range current.buildsOf(system.ID) {
+				versions[build.Version] = true
+			}
+		}
+		imp.wantVersions[name] = versions
+	}
+
+	return imp
+}
+
+// Helper function to record a problem with the manifest
+func (imp *manifestImport) problemf(format string, args ...interface{}) {
+	imp.problems = append(imp.problems, fmt.Sprintf(format, args...))
+}
+
+// Helper function to record a planned change
+func (imp *manifestImport) record(change domain.ManifestChange) {
+	imp.plan.Changes = append(imp.plan.Changes, change)
+}
+
+// Helper function to find a system in the manifest by name
+func (imp *manifestImport) manifestSystem(name string) *domain.ManifestSystem {
+	var found *domain.ManifestSystem
+	walkSystems(imp.manifest.Systems, func(system *domain.ManifestSystem, _ *domain.ManifestSystem) {
+		if found == nil && system.Name == name {
+			found = system
+		}
+	})
+	return found
+}
+
+// Helper function to check the whole manifest against the rules of the catalog before anything is written
+func (imp *manifestImport) validate() {
+	imp.validateEnvironmentGroups()
+	imp.validateReleases()
+	imp.validateSystems()
+	imp.validateEnvironments()
+	imp.validateDeletions()
+}
+
+// Helper function to check the environment groups of the manifest
+func (imp *manifestImport) validateEnvironmentGroups() {
+	seen := make(map[string]bool)
+	for _, group := range imp.manifest.EnvironmentGroups {
+		if !imp.checkName("environment group", group.Name, seen) {
+			continue
+		}
+		if err := domain.ValidatePromotionPath(group.PromotionPath); err != nil {
+			imp.problemf("environment group %q: %s", group.Name, err)
+		}
+		if group.RequiredRole != "" && !group.RequiredRole.IsValid() {
+			imp.problemf("environment group %q: invalid required role %q", group.Name, group.RequiredRole)
+		}
+	}
+}
+
+// Helper function to check the releases of the manifest. Status changes of existing releases have to go
+// through their lifecycle transitions, so the manifest may only repeat the current status.
+func (imp *manifestImport) validateReleases() {
+	seen := make(map[string]bool)
+	for _, release := range imp.manifest.Releases {
+		if !imp.checkName("release", release.Name, seen) {
+			continue
+		}
+		if release.Type == "" {
+			imp.problemf("release %q: type is required", release.Name)
+		}
+		if release.ReleaseDate.IsZero() {
+			imp.problemf("release %q: release_date is required", release.Name)
+		}
+		if release.Status == "" {
+			continue
+		}
+		if !release.Status.IsValid() {
+			imp.problemf("release %q: invalid status %q", release.Name, release.Status)
+			continue
+		}
+		if existing, ok := imp.releases[release.Name]; ok {
+			if existing.Status != release.Status {
+				imp.problemf("release %q: status cannot change from %s to %s in a manifest, use a release transition", release.Name, existing.Status, release.Status)
+			}
+		} else if release.Status != domain.StatusPlanned {
+			imp.problemf("release %q: new releases must be planned", release.Name)
+		}
+	}
+}
+
+// Helper function to check the system hierarchy of the manifest and the builds of each system
+func (imp *manifestImport) validateSystems() {
+	seen := make(map[string]bool)
+	walkSystems(imp.manifest.Systems, func(system *domain.ManifestSystem, parent *domain.ManifestSystem) {
+		if !imp.checkName("system", system.Name, seen) {
+			return
+		}
+		systemType := imp.wantSystems[system.Name]
+
+		if parent == nil {
+			if systemType == domain.SystemTypeSubsystem {
+				imp.problemf("system %q: subsystems must be listed under their parent system", system.Name)
+			} else if !systemType.IsValid() {
+				imp.problemf("system %q: %s", system.Name, domain.ErrInvalidSystemType.Message)
+			}
+		} else {
+			if parent.Type != domain.SystemTypeParent {
+				imp.problemf("system %q: only parent_systems can have subsystems", parent.Name)
+			}
+			if systemType != domain.SystemTypeSubsystem {
+				imp.problemf("system %q: %s", system.Name, domain.ErrChildMustBeSubsystem.Message)
+			}
+			if len(system.Subsystems) > 0 {
+				imp.problemf("system %q: subsystems cannot have subsystems", system.Name)
+			}
+		}
+		if system.Status != "" && !system.Status.IsValid() {
+			imp.problemf("system %q: %s", system.Name, domain.ErrInvalidSystemStatus.Message)
+		}
+
+		if systemType == domain.SystemTypeParent {
+			if len(imp.wantVersions[system.Name]) > 0 {
+				imp.problemf("system %q: %s", system.Name, domain.ErrBuildForParentSystem.Message)
+			}
+			return
+		}
+
+		if system.Builds != nil {
+			imp.validateBuilds(system)
+		}
+		if system.StrictSemver {
+			for v := range imp.wantVersions[system.Name] {
+				if !version.IsSemVer(v) {
+					imp.problemf("build %s@%s: %s", system.Name, v, domain.ErrBuildVersionNotSemVer.Message)
+				}
+			}
+		}
+		if current, ok := imp.systems[system.Name]; ok && system.StrictDigests && !current.StrictDigests {
+			if versions := domain.ConflictingVersions(imp.current.buildsOf(current.ID)); len(versions) > 0 {
+				imp.problemf("system %q: %s: %s", system.Name, domain.ErrBuildsDigestConflict.Message, strings.Join(versions, ", "))
+			}
+		}
+	})
+}
+
+// Helper function to check the builds listed for a system
+func (imp *manifestImport) validateBuilds(system *domain.ManifestSystem) {
+	versions := make(map[string]bool)
+	releases := make(map[string]bool)
+	for _, build := range system.Builds {
+		name := system.Name + "@" + build.Version
+		if build.Version == "" {
+			imp.problemf("system %q: builds need a version", system.Name)
+			continue
+		}
+		if versions[build.Version] {
+			imp.problemf("build %s is listed more than once", name)
+			continue
+		}
+		versions[build.Version] = true
+
+		if build.BuildDate.IsZero() {
+			imp.problemf("build %s: build_date is required", name)
+		}
+		if build.Release == "" {
+			continue
+		}
+		if !imp.wantReleases[build.Release] {
+			imp.problemf("build %s: release %q does not exist", name, build.Release)
+		}
+		if releases[build.Release] {
+			imp.problemf("build %s: %s", name, domain.ErrDuplicateBuildInRelease.Message)
+		}
+		releases[build.Release] = true
+	}
+}
+
+// Helper function to check the environments of the manifest and the systems deployed to them
+func (imp *manifestImport) validateEnvironments() {
+	seen := make(map[string]bool)
+	for _, env := range imp.manifest.Environments {
+		if !imp.checkName("environment", env.Name, seen) {
+			continue
+		}
+		if env.Type == "" {
+			imp.problemf("environment %q: type is required", env.Name)
+		}
+		if env.Status != "" && !env.Status.IsValid() {
+			imp.problemf("environment %q: invalid status %q", env.Name, env.Status)
+		}
+		if env.Release == "" {
+			imp.problemf("environment %q: release is required", env.Name)
+		} else if !imp.wantReleases[env.Release] {
+			imp.problemf("environment %q: release %q does not exist", env.Name, env.Release)
+		}
+		if env.Group != "" && !imp.wantGroups[env.Group] {
+			imp.problemf("environment %q: environment group %q does not exist", env.Name, env.Group)
+		}
+
+		deployed := make(map[string]bool)
+		for _, envSystem := range env.Systems {
+			name := env.Name + "/" + envSystem.System
+			systemType, ok := imp.wantSystems[envSystem.System]
+			switch {
+			case !ok:
+				imp.problemf("environment system %s: system %q does not exist", name, envSystem.System)
+			case systemType == domain.SystemTypeParent:
+				imp.problemf("environment system %s: list the subsystems of parent systems instead", name)
+			case deployed[envSystem.System]:
+				imp.problemf("environment system %s is listed more than once", name)
+			case envSystem.Version == "":
+				imp.problemf("environment system %s: version is required", name)
+			case !imp.wantVersions[envSystem.System][envSystem.Version]:
+				imp.problemf("environment system %s: system %q has no build %s", name, envSystem.System, envSystem.Version)
+			}
+			deployed[envSystem.System] = true
+		}
+	}
+}
+
+// Helper function to check that the entities the manifest deletes are not used by entities it leaves alone
+func (imp *manifestImport) validateDeletions() {
+	if imp.manifest.Releases != nil {
+		for _, build := range imp.current.builds {
+			if build.ReleaseID == nil {
+				continue
+			}
+			releaseName := imp.current.releaseNames[*build.ReleaseID]
+			systemName := imp.current.systemNames[build.SystemID]
+			if imp.wantReleases[releaseName] || !imp.keepsBuildsOf(systemName) {
+				continue
+			}
+			imp.problemf("release %q cannot be deleted because build %s@%s is part of it", releaseName, systemName, build.Version)
+		}
+		if imp.manifest.Environments == nil {
+			for _, env := range imp.current.environments {
+				if releaseName := imp.current.releaseNames[env.ReleaseID]; !imp.wantReleases[releaseName] {
+					imp.problemf("release %q cannot be deleted because environment %q uses it", releaseName, env.Name)
+				}
+			}
+		}
+	}
+
+	if imp.manifest.EnvironmentGroups != nil && imp.manifest.Environments == nil {
+		for _, env := range imp.current.environments {
+			if env.EnvironmentGroupID == nil {
+				continue
+			}
+			if groupName := imp.current.groupNames[*env.EnvironmentGroupID]; !imp.wantGroups[groupName] {
+				imp.problemf("environment group %q cannot be deleted because environment %q belongs to it", groupName, env.Name)
+			}
+		}
+	}
+}
+
+// Helper function to check if a system stays with builds the manifest does not list
+func (imp *manifestImport) keepsBuildsOf(systemName string) bool {
+	if _, ok := imp.wantSystems[systemName]; !ok {
+		return false
+	}
+	listed := imp.manifestSystem(systemName)
+	return listed == nil || listed.Builds == nil
+}
+
+// Helper function to check the name of an entity in the manifest and that it is listed once
+func (imp *manifestImport) checkName(kind, name string, seen map[string]bool) bool {
+	if strings.TrimSpace(name) == "" {
+		imp.problemf("every %s needs a name", kind)
+		return false
+	}
+	if seen[name] {
+		imp.problemf("%s %q is listed more than once", kind, name)
+		return false
+	}
+	seen[name] = true
+	return true
+}
+
+// Helper function to create and update the environment groups of the manifest
+func (imp *manifestImport) importEnvironmentGroups() error {
+	for _, want := range imp.manifest.EnvironmentGroups {
+		current, ok := imp.groups[want.Name]
+		if !ok {
+			group := &domain.EnvironmentGroup{
+				Name:          want.Name,
+				Description:   want.Description,
+				PromotionPath: want.PromotionPath,
+				RequiredRole:  want.RequiredRole,
+			}
+			if imp.apply {
+				if err := imp.store.EnvironmentGroups().Create(imp.ctx, group); err != nil {
+					return err
+				}
+			}
+			imp.groups[want.Name] = group
+			imp.record(domain.ManifestChange{Action: domain.AuditActionCreate, EntityType: domain.AuditEntityEnvironmentGroup, Name: group.Name, EntityID: group.ID, After: group})
+			continue
+		}
+
+		updated := *current
+		var fields []string
+		if !sameText(current.Description, want.Description) {
+			updated.Description = want.Description
+			fields = append(fields, "description")
+		}
+		if !samePromotionPath(current.PromotionPath, want.PromotionPath) {
+			updated.PromotionPath = want.PromotionPath
+			fields = append(fields, "promotion_path")
+		}
+		if current.RequiredRole != want.RequiredRole {
+			updated.RequiredRole = want.RequiredRole
+			fields = append(fields, "required_role")
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		if imp.apply {
+			if err := imp.store.EnvironmentGroups().Update(imp.ctx, &updated); err != nil {
+				return err
+			}
+		}
+		imp.groups[want.Name] = &updated
+		imp.record(domain.ManifestChange{Action: domain.AuditActionUpdate, EntityType: domain.AuditEntityEnvironmentGroup, Name: updated.Name, EntityID: updated.ID, Fields: fields, Before: current, After: &updated})
+	}
+	return nil
+}
+
+// Helper function to create and update the releases of the manifest
+func (imp *manifestImport) importReleases() error {
+	for _, want := range imp.manifest.Releases {
+		current, ok := imp.releases[want.Name]
+		if !ok {
+			release := &domain.Release{
+				Name:        want.Name,
+				Description: want.Description,
+				ReleaseDate: want.ReleaseDate,
+				Status:      domain.StatusPlanned,
+				Type:        want.Type,
+			}
+			if imp.apply {
+				if err := imp.store.Releases().Create(imp.ctx, release); err != nil {
+					return err
+				}
+			}
+			imp.releases[want.Name] = release
+			imp.record(domain.ManifestChange{Action: domain.AuditActionCreate, EntityType: domain.AuditEntityRelease, Name: release.Name, EntityID: release.ID, After: release})
+			continue
+		}
+
+		updated := *current
+		updated.Builds = nil
+		var fields []string
+		if !sameText(current.Description, want.Description) {
+			updated.Description = want.Description
+			fields = append(fields, "description")
+		}
+		if !current.ReleaseDate.Equal(want.ReleaseDate) {
+			updated.ReleaseDate = want.ReleaseDate
+			fields = append(fields, "release_date")
+		}
+		if current.Type != want.Type {
+			updated.Type = want.Type
+			fields = append(fields, "type")
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		if imp.apply {
+			if err := imp.store.Releases().Update(imp.ctx, &updated); err != nil {
+				return err
+			}
+		}
+		imp.releases[want.Name] = &updated
+		imp.record(domain.ManifestChange{Action: domain.AuditActionUpdate, EntityType: domain.AuditEntityRelease, Name: updated.Name, EntityID: updated.ID, Fields: fields, Before: current, After: &updated})
+	}
+	return nil
+}
+
+// Helper function to create and update the systems of the manifest, parents before their subsystems
+func (imp *manifestImport) importSystems() error {
+	for i := range imp.manifest.Systems {
+		if err := imp.importSystem(&imp.manifest.Systems[i], nil); err != nil {
+			return err
+		}
+	}
+	for i := range imp.manifest.Systems {
+		parent := &imp.manifest.Systems[i]
+		for j := range parent.Subsystems {
+			if err := imp.importSystem(&parent.Subsystems[j], parent); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Helper function to create or update a single system of the manifest
+func (imp *manifestImport) importSystem(want *domain.ManifestSystem, parent *domain.ManifestSystem) error {
+	var parentID *string
+	if parent != nil {
+		id := imp.systems[parent.Name].ID
+		parentID = &id
+	}
+
+	current, ok := imp.systems[want.Name]
+	if !ok {
+		system := &domain.System{
+			Name:          want.Name,
+			Description:   want.Description,
+			ParentID:      parentID,
+			Type:          imp.wantSystems[want.Name],
+			Status:        want.Status,
+			StrictSemver:  want.StrictSemver,
+			StrictDigests: want.StrictDigests,
+		}
+		if system.Status == "" {
+			system.Status = domain.StatusActive
+		}
+		if imp.apply {
+			if err := imp.store.Systems().Create(imp.ctx, system); err != nil {
+				return err
+			}
+		}
+		imp.systems[want.Name] = system
+		imp.record(domain.ManifestChange{Action: domain.AuditActionCreate, EntityType: domain.AuditEntitySystem, Name: system.Name, EntityID: system.ID, After: system})
+		return nil
+	}
+
+	updated := *current
+	updated.Parent, updated.Subsystems, updated.Builds = nil, nil, nil
+	var fields []string
+	if !sameText(current.Description, want.Description) {
+		updated.Description = want.Description
+		fields = append(fields, "description")
+	}
+	if imp.currentParentName(current) != parentName(parent) {
+		updated.ParentID = parentID
+		fields = append(fields, "parent")
+	}
+	if systemType := imp.wantSystems[want.Name]; current.Type != systemType {
+		updated.Type = systemType
+		fields = append(fields, "type")
+	}
+	if want.Status != "" && current.Status != want.Status {
+		updated.Status = want.Status
+		fields = append(fields, "status")
+	}
+	if current.StrictSemver != want.StrictSemver {
+		updated.StrictSemver = want.StrictSemver
+		fields = append(fields, "strict_semver")
+	}
+	if current.StrictDigests != want.StrictDigests {
+		updated.StrictDigests = want.StrictDigests
+		fields = append(fields, "strict_digests")
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	if imp.apply {
+		if err := imp.store.Systems().Update(imp.ctx, &updated); err != nil {
+			return err
+		}
+	}
+	imp.systems[want.Name] = &updated
+	imp.record(domain.ManifestChange{Action: domain.AuditActionUpdate, EntityType: domain.AuditEntitySystem, Name: updated.Name, EntityID: updated.ID, Fields: fields, Before: current, After: &updated})
+	return nil
+}
+
+// Helper function to return the name of the current parent of a system
+func (imp *manifestImport) currentParentName(system *domain.System) string {
+	if system.ParentID == nil {
+		return ""
+	}
+	return imp.current.systemNames[*system.ParentID]
+}
+
+// Helper function to return the name of an optional parent in the manifest
+func parentName(parent *domain.ManifestSystem) string {
+	if parent == nil {
+		return ""
+	}
+	return parent.Name
+}
+
+// Helper function to create, update and delete the builds of the systems that list them
+func (imp *manifestImport) importBuilds() error {
+	var err error
+	walkSystems(imp.manifest.Systems, func(system *domain.ManifestSystem, _ *domain.ManifestSystem) {
+		if err == nil && system.Builds != nil {
+			err = imp.importSystemBuilds(system)
+		}
+	})
+	return err
+}
+
+// Helper function to bring the builds of a single system in line with the manifest
+func (imp *manifestImport) importSystemBuilds(want *domain.ManifestSystem) error {
+	system := imp.systems[want.Name]
+	existing := make(map[string]*domain.Build)
+	if system.ID != "" {
+		for _, build := range imp.current.buildsOf(system.ID) {
+			build := build
+			existing[build.Version] = &build
+		}
+	}
+
+	listed := make(map[string]bool)
+	for _, wantBuild := range want.Builds {
+		listed[wantBuild.Version] = true
+		name := want.Name + "@" + wantBuild.Version
+		var releaseID *string
+		if wantBuild.Release != "" {
+			id := imp.releases[wantBuild.Release].ID
+			releaseID = &id
+		}
+
+		current, ok := existing[wantBuild.Version]
+		if !ok {
+			build := &domain.Build{SystemID: system.ID, ReleaseID: releaseID, Version: wantBuild.Version, BuildDate: wantBuild.BuildDate}
+			if imp.apply {
+				if err := imp.store.Builds().Create(imp.ctx, build); err != nil {
+					return err
+				}
+			}
+			imp.record(domain.ManifestChange{Action: domain.AuditActionCreate, EntityType: domain.AuditEntityBuild, Name: name, EntityID: build.ID, After: build})
+			continue
+		}
+
+		updated := *current
+		updated.System, updated.Release = nil, nil
+		var fields []string
+		if imp.current.releaseNameOf(current.ReleaseID) != wantBuild.Release {
+			updated.ReleaseID = releaseID
+			fields = append(fields, "release")
+		}
+		if !current.BuildDate.Equal(wantBuild.BuildDate) {
+			updated.BuildDate = wantBuild.BuildDate
+			fields = append(fields, "build_date")
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		if imp.apply {
+			if err := imp.store.Builds().Update(imp.ctx, &updated); err != nil {
+				return err
+			}
+		}
+		imp.record(domain.ManifestChange{Action: domain.AuditActionUpdate, EntityType: domain.AuditEntityBuild, Name: name, EntityID: updated.ID, Fields: fields, Before: current, After: &updated})
+	}
+
+	for _, build := range imp.current.buildsOf(system.ID) {
+		if system.ID == "" || listed[build.Version] {
+			continue
+		}
+		if err := imp.deleteBuild(want.Name, build); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Helper function to create and update the environments of the manifest and the systems deployed to them
+func (imp *manifestImport) importEnvironments() error {
+	for _, want := range imp.manifest.Environments {
+		releaseID := imp.releases[want.Release].ID
+		var groupID *string
+		if want.Group != "" {
+			id := imp.groups[want.Group].ID
+			groupID = &id
+		}
+
+		current, ok := imp.environments[want.Name]
+		if !ok {
+			env := &domain.Environment{
+				Name:               want.Name,
+				Type:               want.Type,
+				Status:             want.Status,
+				URL:                want.URL,
+				Description:        want.Description,
+				ReleaseID:          releaseID,
+				EnvironmentGroupID: groupID,
+			}
+			if env.Status == "" {
+				env.Status = domain.EnvStatusPending
+			}
+			if imp.apply {
+				if err := imp.store.Environments().Create(imp.ctx, env); err != nil {
+					return err
+				}
+			}
+			imp.environments[want.Name] = env
+			imp.record(domain.ManifestChange{Action: domain.AuditActionCreate, EntityType: domain.AuditEntityEnvironment, Name: env.Name, EntityID: env.ID, After: env})
+		} else {
+			updated := *current
+			updated.EnvironmentSystems = nil
+			var fields []string
+			if current.Type != want.Type {
+				updated.Type = want.Type
+				fields = append(fields, "type")
+			}
+			if want.Status != "" && current.Status != want.Status {
+				updated.Status = want.Status
+				fields = append(fields, "status")
+			}
+			if !sameText(current.URL, want.URL) {
+				updated.URL = want.URL
+				fields = append(fields, "url")
+			}
+			if !sameText(current.Description, want.Description) {
+				updated.Description = want.Description
+				fields = append(fields, "description")
+			}
+			if imp.current.releaseNames[current.ReleaseID] != want.Release {
+				updated.ReleaseID = releaseID
+				fields = append(fields, "release")
+			}
+			if imp.current.groupNameOf(current.EnvironmentGroupID) != want.Group {
+				updated.EnvironmentGroupID = groupID
+				fields = append(fields, "group")
+			}
+			if len(fields) > 0 {
+				if imp.apply {
+					if err := imp.store.Environments().Update(imp.ctx, &updated); err != nil {
+						return err
+					}
+				}
+				imp.environments[want.Name] = &updated
+				imp.record(domain.ManifestChange{Action: domain.AuditActionUpdate, EntityType: domain.AuditEntityEnvironment, Name: updated.Name, EntityID: updated.ID, Fields: fields, Before: current, After: &updated})
+			}
+		}
+
+		if want.Systems != nil {
+			if err := imp.importEnvironmentSystems(&want); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Helper function to bring the systems deployed to a single environment in line with the manifest
+func (imp *manifestImport) importEnvironmentSystems(want *domain.ManifestEnvironment) error {
+	env := imp.environments[want.Name]
+	existing := make(map[string]*domain.EnvironmentSystem)
+	for _, envSystem := range imp.current.envSystems[env.ID] {
+		envSystem := envSystem
+		existing[imp.current.systemNames[envSystem.SystemID]] = &envSystem
+	}
+
+	listed := make(map[string]bool)
+	for _, wantSystem := range want.Systems {
+		listed[wantSystem.System] = true
+		name := want.Name + "/" + wantSystem.System
+
+		current, ok := existing[wantSystem.System]
+		if !ok {
+			envSystem := &domain.EnvironmentSystem{
+				EnvironmentID: env.ID,
+				SystemID:      imp.systems[wantSystem.System].ID,
+				Version:       wantSystem.Version,
+				Status:        wantSystem.Status,
+			}
+			if envSystem.Status == "" {
+				envSystem.Status = "active"
+			}
+			if imp.apply {
+				if err := imp.store.Environments().AddSystem(imp.ctx, envSystem); err != nil {
+					return err
+				}
+			}
+			imp.record(domain.ManifestChange{Action: domain.AuditActionCreate, EntityType: domain.AuditEntityEnvironmentSystem, Name: name, EntityID: envSystem.ID, After: envSystem})
+			continue
+		}
+
+		updated := *current
+		updated.Environment, updated.System = nil, nil
+		var fields []string
+		if current.Version != wantSystem.Version {
+			updated.Version = wantSystem.Version
+			fields = append(fields, "version")
+		}
+		if wantSystem.Status != "" && current.Status != wantSystem.Status {
+			updated.Status = wantSystem.Status
+			fields = append(fields, "status")
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		if imp.apply {
+			if err := imp.store.Environments().UpdateSystem(imp.ctx, &updated); err != nil {
+				return err
+			}
+		}
+		imp.record(domain.ManifestChange{Action: domain.AuditActionUpdate, EntityType: domain.AuditEntityEnvironmentSystem, Name: name, EntityID: updated.ID, Fields: fields, Before: current, After: &updated})
+	}
+
+	for _, envSystem := range imp.current.envSystems[env.ID] {
+		if listed[imp.current.systemNames[envSystem.SystemID]] {
+			continue
+		}
+		if err := imp.removeEnvironmentSystem(want.Name, envSystem); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Helper function to delete the environments the manifest does not list, after removing their systems
+func (imp *manifestImport) deleteEnvironments() error {
+	if imp.manifest.Environments == nil {
+		return nil
+	}
+
+	listed := make(map[string]bool)
+	for _, env := range imp.manifest.Environments {
+		listed[env.Name] = true
+	}
+	for _, env := range imp.current.environments {
+		if listed[env.Name] {
+			continue
+		}
+		for _, envSystem := range imp.current.envSystems[env.ID] {
+			if err := imp.removeEnvironmentSystem(env.Name, envSystem); err != nil {
+				return err
+			}
+		}
+		if imp.apply {
+			if err := imp.store.Environments().Delete(imp.ctx, env.ID); err != nil {
+				return err
+			}
+		}
+		env := env
+		imp.record(domain.ManifestChange{Action: domain.AuditActionDelete, EntityType: domain.AuditEntityEnvironment, Name: env.Name, EntityID: env.ID, Before: &env})
+	}
+	return nil
+}
+
+// Helper function to delete the systems the manifest does not list, subsystems before their parents.
+// Their builds are deleted and they are removed from every environment that is left.
+func (imp *manifestImport) deleteSystems() error {
+	if imp.manifest.Systems == nil {
+		return nil
+	}
+
+	var deleted []domain.System
+	for _, system := range imp.current.systems {
+		if _, ok := imp.wantSystems[system.Name]; !ok {
+			deleted = append(deleted, system)
+		}
+	}
+	sort.SliceStable(deleted, func(i, j int) bool { return deleted[i].ParentID != nil && deleted[j].ParentID == nil })
+
+	for _, system := range deleted {
+		for _, env := range imp.current.environments {
+			if imp.manifest.Environments != nil && imp.isDeletedEnvironment(env.Name) {
+				continue
+			}
+			for _, envSystem := range imp.current.envSystems[env.ID] {
+				if envSystem.SystemID != system.ID {
+					continue
+				}
+				if err := imp.removeEnvironmentSystem(env.Name, envSystem); err != nil {
+					return err
+				}
+			}
+		}
+		for _, build := range imp.current.buildsOf(system.ID) {
+			if err := imp.deleteBuild(system.Name, build); err != nil {
+				return err
+			}
+		}
+
+		// The deployment history of the system is kept, it outlives the system
+		if imp.apply {
+			if err := imp.store.Systems().Delete(imp.ctx, system.ID); err != nil {
+				return err
+			}
+		}
+		system := system
+		imp.record(domain.ManifestChange{Action: domain.AuditActionDelete, EntityType: domain.AuditEntitySystem, Name: system.Name, EntityID: system.ID, Before: &system})
+	}
+	return nil
+}
+
+// Helper function to delete the releases the manifest does not list
+func (imp *manifestImport) deleteReleases() error {
+	if imp.manifest.Releases == nil {
+		return nil
+	}
+
+	for _, release := range imp.current.releases {
+		if imp.wantReleases[release.Name] {
+			continue
+		}
+		if imp.apply {
+			if err := imp.store.Releases().Delete(imp.ctx, release.ID); err != nil {
+				return err
+			}
+		}
+		release := release
+		imp.record(domain.ManifestChange{Action: domain.AuditActionDelete, EntityType: domain.AuditEntityRelease, Name: release.Name, EntityID: release.ID, Before: &release})
+	}
+	return nil
+}
+
+// Helper function to delete the environment groups the manifest does not list
+func (imp *manifestImport) deleteEnvironmentGroups() error {
+	if imp.manifest.EnvironmentGroups == nil {
+		return nil
+	}
+
+	for _, group := range imp.current.groups {
+		if imp.wantGroups[group.Name] {
+			continue
+		}
+		if imp.apply {
+			if err := imp.store.EnvironmentGroups().Delete(imp.ctx, group.ID); err != nil {
+				return err
+			}
+		}
+		group := group
+		group.Environments = nil
+		imp.record(domain.ManifestChange{Action: domain.AuditActionDelete, EntityType: domain.AuditEntityEnvironmentGroup, Name: group.Name, EntityID: group.ID, Before: &group})
+	}
+	return nil
+}
+
+// Helper function to check if the manifest deletes an environment
+func (imp *manifestImport) isDeletedEnvironment(name string) bool {
+	for _, env := range imp.manifest.Environments {
+		if env.Name == name {
+			return false
+		}
+	}
+	return true
+}
+
+// Helper function to delete a build
+func (imp *manifestImport) deleteBuild(systemName string, build domain.Build) error {
+	if imp.apply {
+		if err := imp.store.Builds().Delete(imp.ctx, build.ID); err != nil {
+			return err
+		}
+	}
+	build.System, build.Release = nil, nil
+	imp.record(domain.ManifestChange{Action: domain.AuditActionDelete, EntityType: domain.AuditEntityBuild, Name: systemName + "@" + build.Version, EntityID: build.ID, Before: &build})
+	return nil
+}
+
+// Helper function to remove a system from an environment
+func (imp *manifestImport) removeEnvironmentSystem(envName string, envSystem domain.EnvironmentSystem) error {
+	if imp.removed[envSystem.ID] {
+		return nil
+	}
+	imp.removed[envSystem.ID] = true
+
+	if imp.apply {
+		if err := imp.store.Environments().RemoveSystem(imp.ctx, envSystem.EnvironmentID, envSystem.SystemID); err != nil {
+			return err
+		}
+	}
+	name := envName + "/" + imp.current.systemNames[envSystem.SystemID]
+	envSystem.Environment, envSystem.System = nil, nil
+	imp.record(domain.ManifestChange{Action: domain.AuditActionDelete, EntityType: domain.AuditEntityEnvironmentSystem, Name: name, EntityID: envSystem.ID, Before: &envSystem})
+	return nil
+}
+
+// Helper function to index entities by name, reporting names that more than one entity shares if check is set
+func indexByName[T any](imp *manifestImport, items []T, kind string, name func(*T) string, check bool) map[string]*T {
+	index := make(map[string]*T, len(items))
+	reported := make(map[string]bool)
+	for i := range items {
+		itemName := name(&items[i])
+		if _, ok := index[itemName]; ok {
+			if check && !reported[itemName] {
+				imp.problemf("more than one %s is named %q, rename them before importing a manifest", kind, itemName)
+				reported[itemName] = true
+			}
+			continue
+		}
+		item := items[i]
+		index[itemName] = &item
+	}
+	return index
+}
+
+// Helper function to visit every system of a manifest with its parent, parents before their subsystems
+func walkSystems(systems []domain.ManifestSystem, visit func(system, parent *domain.ManifestSystem)) {
+	for i := range systems {
+		visit(&systems[i], nil)
+		for j := range systems[i].Subsystems {
+			visit(&systems[i].Subsystems[j], &systems[i])
+		}
+	}
+}
+
+// Helper function to return a copy of items sorted by name
+func sortedByName[T any](items []T, name func(*T) string) []T {
+	sorted := append([]T(nil), items...)
+	sort.SliceStable(sorted, func(i, j int) bool { return name(&sorted[i]) < name(&sorted[j]) })
+	return sorted
+}
+
+// Helper function to compare optional text, treating a missing value the same as an empty one
+func sameText(a, b *string) bool {
+	var aText, bText string
+	if a != nil {
+		aText = *a
+	}
+	if b != nil {
+		bText = *b
+	}
+	return aText == bText
+}
+
+// Helper function to compare two promotion paths
+func samePromotionPath(a, b []domain.EnvironmentType) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func systemName(s *domain.System) string           { return s.Name }
+func releaseName(r *domain.Release) string         { return r.Name }
+func groupName(g *domain.EnvironmentGroup) string  { return g.Name }
+func environmentName(e *domain.Environment) string { return e.Name }
@@ -0,0 +1,28 @@
+// Package service holds the business rules of systems and builds.
+//
+// Services work on domain types and a repository.Store, which may be a transaction, so handlers can validate
+// and write in the same transaction as their audit entries. Rule violations are returned as *domain.Error
+// values that callers map to their own responses. Any other error comes from the store.
+package service
+
+import (
+	"errors"
+
+	"release-management/internal/repository"
+)
+
+// Helper function to replace a not found error of the store with a domain error
+func notFound(err error, domainErr error) error {
+	if errors.Is(err, repository.ErrNotFound) {
+		return domainErr
+	}
+	return err
+}
+
+// Helper function to treat an empty reference the same as a missing one
+func normalizeID(id *string) *string {
+	if id == nil || *id == "" {
+		return nil
+	}
+	return id
+}
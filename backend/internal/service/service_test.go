@@ -0,0 +1,295 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"release-management/internal/models/domain"
+	"release-management/internal/repository"
+	"release-management/internal/repository/memory"
+	"release-management/internal/service"
+)
+
+func createSystem(t *testing.T, store repository.Store, system domain.System) *domain.System {
+	t.Helper()
+	if err := service.NewSystemService(store).Create(context.Background(), &system); err != nil {
+		t.Fatalf("create system %s: %v", system.Name, err)
+	}
+	return &system
+}
+
+func TestSystemHierarchy(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	systems := service.NewSystemService(store)
+
+	parent := createSystem(t, store, domain.System{Name: "platform", Type: domain.SystemTypeParent})
+	standalone := createSystem(t, store, domain.System{Name: "billing", Type: domain.SystemTypeSystem})
+	sub := createSystem(t, store, domain.System{Name: "api", Type: domain.SystemTypeSubsystem, ParentID: &parent.ID})
+
+	if sub.Status != domain.StatusActive {
+		t.Errorf("status defaults to %q, want %q", sub.Status, domain.StatusActive)
+	}
+
+	missing := "missing"
+	tests := []struct {
+		name   string
+		system domain.System
+		want   error
+	}{
+		{"invalid type", domain.System{Name: "x", Type: "services"}, domain.ErrInvalidSystemType},
+		{"invalid status", domain.System{Name: "x", Type: domain.SystemTypeSystem, Status: "retired"}, domain.ErrInvalidSystemStatus},
+		{"subsystem without parent", domain.System{Name: "x", Type: domain.SystemTypeSubsystem}, domain.ErrSubsystemRequiresParent},
+		{"system with parent", domain.System{Name: "x", Type: domain.SystemTypeSystem, ParentID: &parent.ID}, domain.ErrChildMustBeSubsystem},
+		{"unknown parent", domain.System{Name: "x", Type: domain.SystemTypeSubsystem, ParentID: &missing}, domain.ErrParentSystemNotFound},
+		{"parent is not a parent system", domain.System{Name: "x", Type: domain.SystemTypeSubsystem, ParentID: &standalone.ID}, domain.ErrParentMustBeParentSystem},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := systems.Create(ctx, &tt.system); !errors.Is(err, tt.want) {
+				t.Errorf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	changed := *parent
+	changed.Type = domain.SystemTypeSystem
+	if _, err := systems.Update(ctx, &changed); !errors.Is(err, domain.ErrTypeChangeWithSubsystems) {
+		t.Errorf("type change with subsystems: got %v", err)
+	}
+
+	if _, err := systems.Delete(ctx, parent.ID); !errors.Is(err, domain.ErrCannotDeleteSystemWithChildren) {
+		t.Errorf("delete with subsystems: got %v", err)
+	}
+}
+
+func TestSystemStatusCascades(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+
+	parent := createSystem(t, store, domain.System{Name: "platform", Type: domain.SystemTypeParent})
+	sub := createSystem(t, store, domain.System{Name: "api", Type: domain.SystemTypeSubsystem, ParentID: &parent.ID})
+
+	parent.Status = domain.StatusDeprecated
+	changes, err := service.NewSystemService(store).Update(ctx, parent)
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if len(changes) != 1 || changes[0].Before.Status != domain.StatusActive || changes[0].After.Status != domain.StatusDeprecated {
+		t.Fatalf("changes = %+v, want the subsystem deprecated", changes)
+	}
+
+	saved, err := store.Systems().Get(ctx, sub.ID)
+	if err != nil {
+		t.Fatalf("get subsystem: %v", err)
+	}
+	if saved.Status != domain.StatusDeprecated {
+		t.Errorf("subsystem status = %q, want %q", saved.Status, domain.StatusDeprecated)
+	}
+}
+
+func TestBuildRules(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	builds := service.NewBuildService(store)
+
+	parent := createSystem(t, store, domain.System{Name: "platform", Type: domain.SystemTypeParent})
+	strict := createSystem(t, store, domain.System{Name: "billing", Type: domain.SystemTypeSystem, StrictSemver: true})
+
+	release := &domain.Release{Name: "2024.1", Type: domain.TypeMajor, Status: domain.StatusInProgress}
+	if err := store.Releases().Create(ctx, release); err != nil {
+		t.Fatalf("create release: %v", err)
+	}
+
+	if err := builds.Create(ctx, &domain.Build{SystemID: parent.ID, Version: "1.0.0"}); !errors.Is(err, domain.ErrBuildForParentSystem) {
+		t.Errorf("build for parent system: got %v", err)
+	}
+	if err := builds.Create(ctx, &domain.Build{SystemID: strict.ID, Version: "nightly"}); !errors.Is(err, domain.ErrBuildVersionNotSemVer) {
+		t.Errorf("non semver build: got %v", err)
+	}
+
+	build := &domain.Build{SystemID: strict.ID, Version: "1.0.0", ReleaseID: &release.ID}
+	if err := builds.Create(ctx, build); err != nil {
+		t.Fatalf("create build: %v", err)
+	}
+	if err := builds.Create(ctx, &domain.Build{SystemID: strict.ID, Version: "1.0.1", ReleaseID: &release.ID}); !errors.Is(err, domain.ErrDuplicateBuildInRelease) {
+		t.Errorf("second build in release: got %v", err)
+	}
+
+	moved := *build
+	moved.SystemID = parent.ID
+	if err := builds.Update(ctx, &moved); !errors.Is(err, domain.ErrBuildSystemImmutable) {
+		t.Errorf("move build: got %v", err)
+	}
+
+	changed := *build
+	changed.Version = "1.0.2"
+	if err := builds.Update(ctx, &changed); err != nil {
+		t.Errorf("update build in its own release: %v", err)
+	}
+}
+
+// Helper function to summarize the changes of a plan as "action entity_type name" lines
+func planSummary(plan *domain.ManifestPlan) []string {
+	summary := make([]string, len(plan.Changes))
+	for i, change := range plan.Changes {
+		summary[i] = fmt.Sprintf("%s %s %s", change.Action, change.EntityType, change.Name)
+	}
+	return summary
+}
+
+func testManifest() *domain.Manifest {
+	spring := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	return &domain.Manifest{
+		Systems: []domain.ManifestSystem{
+			{Name: "platform", Type: domain.SystemTypeParent, Subsystems: []domain.ManifestSystem{
+				{Name: "api", Builds: []domain.ManifestBuild{
+					{Version: "0.9.0", BuildDate: spring.AddDate(0, -1, 0)},
+					{Version: "1.0.0", Release: "2024.04", BuildDate: spring},
+				}},
+			}},
+			{Name: "billing", Type: domain.SystemTypeSystem, Builds: []domain.ManifestBuild{}},
+		},
+		Releases: []domain.ManifestRelease{
+			{Name: "2024.04", Type: domain.TypeMinor, ReleaseDate: spring},
+		},
+		EnvironmentGroups: []domain.ManifestEnvironmentGroup{
+			{Name: "main", PromotionPath: []domain.EnvironmentType{domain.EnvTypeStaging, domain.EnvTypeProd}},
+		},
+		Environments: []domain.ManifestEnvironment{
+			{Name: "staging", Type: domain.EnvTypeStaging, Release: "2024.04", Group: "main", Systems: []domain.ManifestEnvironmentSystem{
+				{System: "api", Version: "0.9.0"},
+			}},
+		},
+	}
+}
+
+func TestManifestImport(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	manifests := service.NewManifestService(store)
+
+	wantCreates := []string{
+		"create environment_group main",
+		"create release 2024.04",
+		"create system platform",
+		"create system billing",
+		"create system api",
+		"create build api@0.9.0",
+		"create build api@1.0.0",
+		"create environment staging",
+		"create environment_system staging/api",
+	}
+
+	// Planning writes nothing
+	plan, err := manifests.Import(ctx, testManifest(), false)
+	if err != nil {
+		t.Fatalf("plan: %v", err)
+	}
+	if got := planSummary(plan); !reflect.DeepEqual(got, wantCreates) || plan.Applied {
+		t.Errorf("plan = %v, want %v", got, wantCreates)
+	}
+	if systems, _ := store.Systems().List(ctx, repository.SystemFilter{}); len(systems) != 0 {
+		t.Fatalf("planning created %d systems", len(systems))
+	}
+
+	plan, err = manifests.Import(ctx, testManifest(), true)
+	if err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	if got := planSummary(plan); !reflect.DeepEqual(got, wantCreates) || !plan.Applied {
+		t.Errorf("applied = %v, want %v", got, wantCreates)
+	}
+	api, err := store.Systems().List(ctx, repository.SystemFilter{Name: "api"})
+	if err != nil || len(api) != 1 || api[0].Type != domain.SystemTypeSubsystem || api[0].ParentID == nil {
+		t.Fatalf("api system = %+v, %v", api, err)
+	}
+
+	// Importing the same manifest or an export of the catalog again changes nothing
+	if plan, err = manifests.Import(ctx, testManifest(), true); err != nil || len(plan.Changes) != 0 {
+		t.Errorf("second import = %v, %v", planSummary(plan), err)
+	}
+	exported, err := manifests.Export(ctx)
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	if plan, err = manifests.Import(ctx, exported, true); err != nil || len(plan.Changes) != 0 {
+		t.Errorf("import of export = %v, %v", planSummary(plan), err)
+	}
+
+	// Changes are matched by name and what the manifest leaves out is deleted
+	changed := testManifest()
+	changed.Systems = changed.Systems[:1]
+	changed.Systems[0].Subsystems[0].Builds = changed.Systems[0].Subsystems[0].Builds[1:]
+	changed.Environments[0].Systems[0].Version = "1.0.0"
+	changed.Environments[0].Status = domain.EnvStatusActive
+	plan, err = manifests.Import(ctx, changed, true)
+	if err != nil {
+		t.Fatalf("apply changes: %v", err)
+	}
+	want := []string{
+		"delete build api@0.9.0",
+		"update environment staging",
+		"update environment_system staging/api",
+		"delete system billing",
+	}
+	if got := planSummary(plan); !reflect.DeepEqual(got, want) {
+		t.Errorf("changes = %v, want %v", got, want)
+	}
+	if fields := plan.Changes[2].Fields; !reflect.DeepEqual(fields, []string{"version"}) {
+		t.Errorf("changed fields = %v, want [version]", fields)
+	}
+
+	// Sections that are left out are not touched
+	if plan, err = manifests.Import(ctx, &domain.Manifest{Releases: changed.Releases}, true); err != nil || len(plan.Changes) != 0 {
+		t.Errorf("partial import = %v, %v", planSummary(plan), err)
+	}
+}
+
+func TestManifestImportRejectsInvalidManifests(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	manifests := service.NewManifestService(store)
+	if _, err := manifests.Import(ctx, testManifest(), true); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+
+	invalid := testManifest()
+	invalid.Systems[0].Builds = []domain.ManifestBuild{{Version: "1.0.0", BuildDate: time.Now()}}
+	invalid.Systems[1].Subsystems = []domain.ManifestSystem{{Name: "ledger"}}
+	invalid.Releases[0].Status = domain.StatusReleased
+	invalid.Environments[0].Release = "2024.05"
+	invalid.Environments[0].Systems[0].Version = "2.0.0"
+
+	_, err := manifests.Import(ctx, invalid, true)
+	var domainErr *domain.Error
+	if !errors.As(err, &domainErr) || !errors.Is(err, domain.ErrInvalidManifest) {
+		t.Fatalf("got %v, want an invalid manifest error", err)
+	}
+	problems, _ := domainErr.Details["problems"].([]string)
+	for _, want := range []string{
+		`release "2024.04": status cannot change from planned to released`,
+		`system "platform": Cannot create builds for parent_systems`,
+		`system "billing": only parent_systems can have subsystems`,
+		`environment "staging": release "2024.05" does not exist`,
+		`environment system staging/api: system "api" has no build 2.0.0`,
+	} {
+		found := false
+		for _, problem := range problems {
+			found = found || strings.HasPrefix(problem, want)
+		}
+		if !found {
+			t.Errorf("problems %q do not include %q", problems, want)
+		}
+	}
+
+	// Nothing was written
+	if plan, err := manifests.Import(ctx, testManifest(), false); err != nil || len(plan.Changes) != 0 {
+		t.Errorf("catalog changed: %v, %v", planSummary(plan), err)
+	}
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+
+	"release-management/internal/models/domain"
+	"release-management/internal/repository"
+	"release-management/internal/version"
+)
+
+// SystemService enforces the rules of the system hierarchy:
+// subsystems need a parent_systems parent, other types have no parent, and a type cannot change
+// while subsystems or builds depend on it.
+type SystemService struct {
+	store repository.Store
+}
+
+// SystemChange pairs the state of a system before and after an update
+type SystemChange struct {
+	Before domain.System
+	After  domain.System
+}
+
+// NewSystemService creates a system service on a store, which may be a transaction
+func NewSystemService(store repository.Store) *SystemService {
+	return &SystemService{store: store}
+}
+
+// Create validates a new system and stores it. The status defaults to active.
+func (s *SystemService) Create(ctx context.Context, system *domain.System) error {
+	if system.Status == "" {
+		system.Status = domain.StatusActive
+	}
+	system.ParentID = normalizeID(system.ParentID)
+
+	if err := s.validate(ctx, system); err != nil {
+		return err
+	}
+
+	return s.store.Systems().Create(ctx, system)
+}
+
+// Update validates a changed system against its stored state and saves it.
+// Changing the status of a parent system changes the status of its subsystems too, which are returned.
+func (s *SystemService) Update(ctx context.Context, system *domain.System) ([]SystemChange, error) {
+	// Builds written while strict digests are enabled must wait for the check of the existing builds below
+	if err := s.store.Systems().Lock(ctx, system.ID); err != nil {
+		return nil, notFound(err, domain.ErrSystemNotFound)
+	}
+	current, err := s.store.Systems().Get(ctx, system.ID)
+	if err != nil {
+		return nil, notFound(err, domain.ErrSystemNotFound)
+	}
+	system.ParentID = normalizeID(system.ParentID)
+
+	if system.Type != current.Type {
+		// Subsystems and builds only make sense for the type they were created under
+		switch current.Type {
+		case domain.SystemTypeParent:
+			count, err := s.store.Systems().Count(ctx, repository.SystemFilter{ParentID: system.ID})
+			if err != nil {
+				return nil, err
+			}
+			if count > 0 {
+				return nil, domain.ErrTypeChangeWithSubsystems
+			}
+		case domain.SystemTypeSystem:
+			count, err := s.store.Builds().Count(ctx, repository.BuildFilter{SystemID: system.ID})
+			if err != nil {
+				return nil, err
+			}
+			if count > 0 {
+				return nil, domain.ErrTypeChangeWithBuilds
+			}
+		}
+	}
+
+	if err := s.validate(ctx, system); err != nil {
+		return nil, err
+	}
+
+	// Only one level of nesting is allowed
+	if system.ParentID != nil {
+		count, err := s.store.Systems().Count(ctx, repository.SystemFilter{ParentID: system.ID})
+		if err != nil {
+			return nil, err
+		}
+		if count > 0 {
+			return nil, domain.ErrCannotMoveSystemWithChildren
+		}
+	}
+
+	// Existing builds must already be valid before strict semver can be enabled
+	if system.StrictSemver && !current.StrictSemver {
+		builds, err := s.store.Builds().List(ctx, repository.BuildFilter{SystemID: system.ID})
+		if err != nil {
+			return nil, err
+		}
+
+		var invalidVersions []string
+		for _, build := range builds {
+			if !version.IsSemVer(build.Version) {
+				invalidVersions = append(invalidVersions, build.Version)
+			}
+		}
+		if len(invalidVersions) > 0 {
+			return nil, domain.ErrBuildsNotSemVer.WithDetail("invalid_versions", invalidVersions)
+		}
+	}
+
+	// Existing builds must already use one artifact per version before strict digests can be enabled
+	if system.StrictDigests && !current.StrictDigests {
+		builds, err := s.store.Builds().List(ctx, repository.BuildFilter{SystemID: system.ID})
+		if err != nil {
+			return nil, err
+		}
+		if versions := domain.ConflictingVersions(builds); len(versions) > 0 {
+			return nil, domain.ErrBuildsDigestConflict.WithDetail("conflicting_versions", versions)
+		}
+	}
+
+	if err := s.store.Systems().Update(ctx, system); err != nil {
+		return nil, err
+	}
+
+	// Subsystems follow the status of their parent
+	var changes []SystemChange
+	if system.Type == domain.SystemTypeParent && system.Status != current.Status {
+		subsystems, err := s.store.Systems().List(ctx, repository.SystemFilter{ParentID: system.ID})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, subsystem := range subsystems {
+			if subsystem.Status == system.Status {
+				continue
+			}
+			change := SystemChange{Before: subsystem, After: subsystem}
+			change.After.Status = system.Status
+			if err := s.store.Systems().Update(ctx, &change.After); err != nil {
+				return nil, err
+			}
+			changes = append(changes, change)
+		}
+	}
+
+	return changes, nil
+}
+
+// Delete removes a system without subsystems and returns it
+func (s *SystemService) Delete(ctx context.Context, id string) (*domain.System, error) {
+	system, err := s.store.Systems().Get(ctx, id)
+	if err != nil {
+		return nil, notFound(err, domain.ErrSystemNotFound)
+	}
+
+	count, err := s.store.Systems().Count(ctx, repository.SystemFilter{ParentID: id})
+	if err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return nil, domain.ErrCannotDeleteSystemWithChildren
+	}
+
+	if err := s.store.Systems().Delete(ctx, id); err != nil {
+		return nil, notFound(err, domain.ErrSystemNotFound)
+	}
+	return system, nil
+}
+
+// Helper function to check the type and status of a system and that its type fits its parent
+func (s *SystemService) validate(ctx context.Context, system *domain.System) error {
+	if !system.Type.IsValid() {
+		return domain.ErrInvalidSystemType
+	}
+	if !system.Status.IsValid() {
+		return domain.ErrInvalidSystemStatus
+	}
+
+	if system.ParentID == nil {
+		if system.Type == domain.SystemTypeSubsystem {
+			return domain.ErrSubsystemRequiresParent
+		}
+		return nil
+	}
+
+	if system.Type != domain.SystemTypeSubsystem {
+		return domain.ErrChildMustBeSubsystem
+	}
+
+	parent, err := s.store.Systems().Get(ctx, *system.ParentID)
+	if err != nil {
+		return notFound(err, domain.ErrParentSystemNotFound)
+	}
+	if parent.Type != domain.SystemTypeParent {
+		return domain.ErrParentMustBeParentSystem
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package version
+
+import (
+	"fmt"
+	"strings"
+)
+
+// constraint represents a single comparison such as ">=1.2.0"
+type constraint struct {
+	operator string
+	version  Version
+}
+
+// Range represents a set of version constraints. Constraints separated by spaces
+// must all match and groups separated by "||" are alternatives, for example
+// ">=1.2.0 <2.0.0 || >=3.0.0". The operators =, !=, >, >=, <, <=, ~ and ^ are supported.
+type Range struct {
+	groups [][]constraint
+}
+
+// operators is ordered so that longer operators are matched first
+var operators = []string{">=", "<=", "!=", ">", "<", "=", "~", "^"}
+
+// ParseRange parses a version range expression
+func ParseRange(s string) (Range, error) {
+	var r Range
+	for _, group := range strings.Split(s, "||") {
+		fields := strings.Fields(group)
+		if len(fields) == 0 {
+			return Range{}, fmt.Errorf("version range '%s' has an empty alternative", s)
+		}
+
+		var constraints []constraint
+		for i := 0; i < len(fields); i++ {
+			field := fields[i]
+
+			// Allow a space between the operator and the version, e.g. ">= 1.2.0"
+			if isOperator(field) && i+1 < len(fields) {
+				i++
+				field += fields[i]
+			}
+
+			c, err := parseConstraint(field)
+			if err != nil {
+				return Range{}, err
+			}
+			constraints = append(constraints, c)
+		}
+		r.groups = append(r.groups, constraints)
+	}
+	return r, nil
+}
+
+// Contains checks if a version string satisfies the range
+func (r Range) Contains(s string) bool {
+	v := Parse(s)
+	for _, group := range r.groups {
+		matches := true
+		for _, c := range group {
+			if !c.matches(v) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
+
+// Helper function to parse a single constraint
+func parseConstraint(s string) (constraint, error) {
+	operator := "="
+	for _, op := range operators {
+		if strings.HasPrefix(s, op) {
+			operator = op
+			break
+		}
+	}
+	versionString := strings.TrimPrefix(s, operator)
+
+	v := Parse(versionString)
+	if versionString == "" || v.Scheme == SchemeUnknown {
+		return constraint{}, fmt.Errorf("'%s' is not a valid version constraint", s)
+	}
+	return constraint{operator: operator, version: v}, nil
+}
+
+// Helper function to check if a string is only an operator
+func isOperator(s string) bool {
+	for _, op := range operators {
+		if s == op {
+			return true
+		}
+	}
+	return false
+}
+
+// Helper function to check if a version satisfies the constraint
+func (c constraint) matches(v Version) bool {
+	if v.Scheme == SchemeUnknown {
+		return false
+	}
+
+	result := v.Compare(c.version)
+	switch c.operator {
+	case "=":
+		return result == 0
+	case "!=":
+		return result != 0
+	case ">":
+		return result > 0
+	case ">=":
+		return result >= 0
+	case "<":
+		return result < 0
+	case "<=":
+		return result <= 0
+	case "~":
+		// Same major and minor version, at least the given patch
+		return result >= 0 && sameLeadingNumbers(v, c.version, 2)
+	case "^":
+		// Same left-most non-zero component, at least the given version
+		return result >= 0 && sameLeadingNumbers(v, c.version, caretPrefixLength(c.version))
+	}
+	return false
+}
+
+// Helper function to check if the first n numbers of two versions are equal
+func sameLeadingNumbers(a, b Version, n int) bool {
+	for i := 0; i < n && i < len(b.Numbers); i++ {
+		if i >= len(a.Numbers) || a.Numbers[i] != b.Numbers[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Helper function to find how many leading numbers a caret constraint pins
+func caretPrefixLength(v Version) int {
+	for i, n := range v.Numbers {
+		if n != 0 {
+			return i + 1
+		}
+	}
+	return len(v.Numbers)
+}
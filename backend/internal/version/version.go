@@ -0,0 +1,277 @@
+package version
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Scheme represents the versioning scheme a version string follows
+type Scheme string
+
+const (
+	SchemeSemVer  Scheme = "semver"
+	SchemeCalVer  Scheme = "calver"
+	SchemeNumeric Scheme = "numeric"
+	SchemeUnknown Scheme = "unknown"
+)
+
+// Years accepted as the first component of a calendar version
+const (
+	minCalVerYear = 1970
+	maxCalVerYear = 9999
+)
+
+var (
+	semverPattern = regexp.MustCompile(`^v?(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+		`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+		`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+	numericPattern = regexp.MustCompile(`^v?\d+(?:\.\d+)*(?:-([0-9A-Za-z.-]+))?$`)
+)
+
+// Version represents a parsed version string
+type Version struct {
+	Original   string
+	Scheme     Scheme
+	Numbers    []uint64
+	PreRelease []string
+	Build      string
+}
+
+// Parse parses a version string. SemVer 2.0 is tried first, then calendar versions
+// such as 2024.03.15 and finally plain dot-separated numbers. Strings that match none
+// of these are returned with SchemeUnknown and are ordered as text.
+func Parse(s string) Version {
+	s = strings.TrimSpace(s)
+
+	if v, err := ParseSemVer(s); err == nil {
+		return v
+	}
+
+	if match := numericPattern.FindStringSubmatch(s); match != nil {
+		core := strings.TrimPrefix(s, "v")
+		if i := strings.Index(core, "-"); i >= 0 {
+			core = core[:i]
+		}
+		v := Version{Original: s, Scheme: SchemeNumeric}
+		for _, part := range strings.Split(core, ".") {
+			n, err := strconv.ParseUint(part, 10, 64)
+			if err != nil {
+				return Version{Original: s, Scheme: SchemeUnknown}
+			}
+			v.Numbers = append(v.Numbers, n)
+		}
+		if match[1] != "" {
+			v.PreRelease = strings.Split(match[1], ".")
+		}
+		if isCalVer(v.Numbers) {
+			v.Scheme = SchemeCalVer
+		}
+		return v
+	}
+
+	return Version{Original: s, Scheme: SchemeUnknown}
+}
+
+// ParseSemVer parses a strict SemVer 2.0 version, optionally prefixed with "v"
+func ParseSemVer(s string) (Version, error) {
+	match := semverPattern.FindStringSubmatch(s)
+	if match == nil {
+		return Version{}, fmt.Errorf("'%s' is not a valid semantic version (expected MAJOR.MINOR.PATCH[-PRERELEASE][+BUILD])", s)
+	}
+
+	v := Version{Original: s, Scheme: SchemeSemVer, Build: match[5]}
+	for _, part := range match[1:4] {
+		n, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return Version{}, fmt.Errorf("'%s' has a version number that is too large", s)
+		}
+		v.Numbers = append(v.Numbers, n)
+	}
+	if match[4] != "" {
+		v.PreRelease = strings.Split(match[4], ".")
+	}
+	return v, nil
+}
+
+// IsSemVer checks if a string is a valid SemVer 2.0 version
+func IsSemVer(s string) bool {
+	_, err := ParseSemVer(s)
+	return err == nil
+}
+
+// Compare orders two version strings.
+// It returns -1 if a < b, 0 if a == b and 1 if a > b.
+func Compare(a, b string) int {
+	return Parse(a).Compare(Parse(b))
+}
+
+// Compare orders v against other following SemVer precedence rules: numbers are
+// compared first, a pre-release sorts before the release it precedes and build
+// metadata is ignored. Versions that could not be parsed sort before parsed ones.
+func (v Version) Compare(other Version) int {
+	if v.Scheme == SchemeUnknown || other.Scheme == SchemeUnknown {
+		switch {
+		case v.Scheme != SchemeUnknown:
+			return 1
+		case other.Scheme != SchemeUnknown:
+			return -1
+		}
+		return compareText(v.Original, other.Original)
+	}
+
+	for i := 0; i < len(v.Numbers) || i < len(other.Numbers); i++ {
+		var a, b uint64
+		if i < len(v.Numbers) {
+			a = v.Numbers[i]
+		}
+		if i < len(other.Numbers) {
+			b = other.Numbers[i]
+		}
+		if a != b {
+			if a < b {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	return comparePreRelease(v.PreRelease, other.PreRelease)
+}
+
+// IsPreRelease checks if the version is a pre-release
+func (v Version) IsPreRelease() bool {
+	return len(v.PreRelease) > 0
+}
+
+// Sort sorts version strings in ascending order
+func Sort(versions []string) {
+	sort.SliceStable(versions, func(i, j int) bool {
+		return Compare(versions[i], versions[j]) < 0
+	})
+}
+
+// Latest returns the highest version, preferring releases over pre-releases
+func Latest(versions []string) (string, bool) {
+	var latest string
+	var latestParsed Version
+	found := false
+	for _, candidate := range versions {
+		parsed := Parse(candidate)
+		if !found ||
+			(latestParsed.IsPreRelease() && !parsed.IsPreRelease()) ||
+			(latestParsed.IsPreRelease() == parsed.IsPreRelease() && parsed.Compare(latestParsed) > 0) {
+			latest = candidate
+			latestParsed = parsed
+			found = true
+		}
+	}
+	return latest, found
+}
+
+// Helper function to compare pre-release identifiers following SemVer rules
+func comparePreRelease(a, b []string) int {
+	switch {
+	case len(a) == 0 && len(b) == 0:
+		return 0
+	case len(a) == 0:
+		return 1
+	case len(b) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(a) && i < len(b); i++ {
+		numA, errA := strconv.ParseUint(a[i], 10, 64)
+		numB, errB := strconv.ParseUint(b[i], 10, 64)
+
+		switch {
+		case errA == nil && errB == nil:
+			if numA != numB {
+				if numA < numB {
+					return -1
+				}
+				return 1
+			}
+		case errA == nil:
+			// Numeric identifiers have lower precedence than alphanumeric ones
+			return -1
+		case errB == nil:
+			return 1
+		default:
+			if result := strings.Compare(a[i], b[i]); result != 0 {
+				return result
+			}
+		}
+	}
+
+	switch {
+	case len(a) < len(b):
+		return -1
+	case len(a) > len(b):
+		return 1
+	}
+	return 0
+}
+
+// Helper function to order unparseable versions, comparing digit runs as numbers
+func compareText(a, b string) int {
+	partsA := splitText(a)
+	partsB := splitText(b)
+
+	for i := 0; i < len(partsA) && i < len(partsB); i++ {
+		numA, errA := strconv.ParseUint(partsA[i], 10, 64)
+		numB, errB := strconv.ParseUint(partsB[i], 10, 64)
+		if errA == nil && errB == nil {
+			if numA != numB {
+				if numA < numB {
+					return -1
+				}
+				return 1
+			}
+			continue
+		}
+		if result := strings.Compare(partsA[i], partsB[i]); result != 0 {
+			return result
+		}
+	}
+
+	switch {
+	case len(partsA) < len(partsB):
+		return -1
+	case len(partsA) > len(partsB):
+		return 1
+	}
+	return 0
+}
+
+// Helper function to split text into runs of digits and non-digits
+func splitText(s string) []string {
+	var parts []string
+	var current strings.Builder
+	lastIsDigit := false
+	for i, r := range s {
+		isDigit := r >= '0' && r <= '9'
+		if i > 0 && isDigit != lastIsDigit {
+			parts = append(parts, current.String())
+			current.Reset()
+		}
+		current.WriteRune(r)
+		lastIsDigit = isDigit
+	}
+	if current.Len() > 0 {
+		parts = append(parts, current.String())
+	}
+	return parts
+}
+
+// Helper function to detect calendar versions such as 2024.03 or 2024.03.15
+func isCalVer(numbers []uint64) bool {
+	if len(numbers) < 2 {
+		return false
+	}
+	year := numbers[0]
+	month := numbers[1]
+	return year >= minCalVerYear && year <= maxCalVerYear && month >= 1 && month <= 12
+}
@@ -0,0 +1,189 @@
+package version
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input      string
+		scheme     Scheme
+		numbers    []uint64
+		preRelease []string
+		build      string
+	}{
+		{"1.2.3", SchemeSemVer, []uint64{1, 2, 3}, nil, ""},
+		{"v1.2.3", SchemeSemVer, []uint64{1, 2, 3}, nil, ""},
+		{"1.0.0-beta.2+exp.sha.5114f85", SchemeSemVer, []uint64{1, 0, 0}, []string{"beta", "2"}, "exp.sha.5114f85"},
+		{" 2.0.0 ", SchemeSemVer, []uint64{2, 0, 0}, nil, ""},
+		{"2024.03.15", SchemeCalVer, []uint64{2024, 3, 15}, nil, ""},
+		{"2024.03", SchemeCalVer, []uint64{2024, 3}, nil, ""},
+		{"2024.03-hotfix.1", SchemeCalVer, []uint64{2024, 3}, []string{"hotfix", "1"}, ""},
+		{"2024.13", SchemeNumeric, []uint64{2024, 13}, nil, ""},
+		{"1.2", SchemeNumeric, []uint64{1, 2}, nil, ""},
+		{"42", SchemeNumeric, []uint64{42}, nil, ""},
+		{"1.2.3.4", SchemeNumeric, []uint64{1, 2, 3, 4}, nil, ""},
+		{"latest", SchemeUnknown, nil, nil, ""},
+		{"release-7", SchemeUnknown, nil, nil, ""},
+		{"1..2", SchemeUnknown, nil, nil, ""},
+		{"", SchemeUnknown, nil, nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got := Parse(tt.input)
+			if got.Scheme != tt.scheme || !slices.Equal(got.Numbers, tt.numbers) || !slices.Equal(got.PreRelease, tt.preRelease) || got.Build != tt.build {
+				t.Errorf("Parse(%q) = %+v", tt.input, got)
+			}
+		})
+	}
+}
+
+func TestParseSemVer(t *testing.T) {
+	for _, valid := range []string{"0.0.0", "1.2.3", "v10.20.30", "1.0.0-alpha", "1.0.0-0.3.7", "1.0.0-x-y-z.--", "1.0.0+20130313144700", "1.0.0-rc.1+build.1"} {
+		if _, err := ParseSemVer(valid); err != nil || !IsSemVer(valid) {
+			t.Errorf("ParseSemVer(%q) = %v, want a valid version", valid, err)
+		}
+	}
+
+	// Malformed versions and the other schemes are not semantic versions
+	for _, invalid := range []string{"", "1", "1.2", "1.2.3.4", "01.2.3", "1.02.3", "1.2.3-", "1.2.3+", "1.2.3-01", "1.2.3-alpha..1", "1.2.3+build..1", "V1.2.3", "2024.03.15", "latest", "99999999999999999999.0.0"} {
+		if _, err := ParseSemVer(invalid); err == nil || IsSemVer(invalid) {
+			t.Errorf("ParseSemVer(%q) succeeded, want an error", invalid)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want int
+	}{
+		{"equal", "1.2.3", "1.2.3", 0},
+		{"v prefix is ignored", "v1.2.3", "1.2.3", 0},
+		{"major", "2.0.0", "10.0.0", -1},
+		{"minor", "1.10.0", "1.9.0", 1},
+		{"patch", "1.0.1", "1.0.0", 1},
+		{"pre-release before its release", "1.0.0-rc.1", "1.0.0", -1},
+		{"pre-release after the previous release", "1.0.0-alpha", "0.9.9", 1},
+		{"numeric pre-release identifiers compare as numbers", "1.0.0-beta.11", "1.0.0-beta.2", 1},
+		{"numeric pre-release identifiers before alphanumeric ones", "1.0.0-1", "1.0.0-alpha", -1},
+		{"longer pre-release after its prefix", "1.0.0-alpha.1", "1.0.0-alpha", 1},
+		{"build metadata is ignored", "1.0.0+build.1", "1.0.0+build.2", 0},
+		{"build metadata is ignored on pre-releases", "1.0.0-rc.1+linux", "1.0.0-rc.1+darwin", 0},
+		{"build metadata does not make a release", "1.0.0-rc.1+build.9", "1.0.0", -1},
+		{"calver by month", "2024.03.15", "2024.04.01", -1},
+		{"calver months compare as numbers", "2024.10", "2024.9", 1},
+		{"calver missing components are zero", "2024.03", "2024.03.0", 0},
+		{"calver hotfix before its release", "2024.03-hotfix.1", "2024.03", -1},
+		{"calver after numeric", "2024.01", "3.5", 1},
+		{"numeric missing components are zero", "1.2", "1.2.0", 0},
+		{"numeric against semver", "1.2", "1.2.1", -1},
+		{"numeric with more components", "1.2.3.4", "1.2.3", 1},
+		{"unknown before parsed", "latest", "0.0.1", -1},
+		{"parsed after unknown", "0.0.1", "latest", 1},
+		{"unknown as text with numbers", "build-10", "build-9", 1},
+		{"unknown equal", "latest", "latest", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Compare(tt.a, tt.b); got != tt.want {
+				t.Errorf("Compare(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+			if got := Compare(tt.b, tt.a); got != -tt.want {
+				t.Errorf("Compare(%q, %q) = %d, want %d", tt.b, tt.a, got, -tt.want)
+			}
+		})
+	}
+}
+
+func TestSort(t *testing.T) {
+	// The precedence example of the SemVer 2.0 specification
+	want := []string{"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0"}
+	versions := []string{"1.0.0", "1.0.0-beta.11", "1.0.0-alpha.beta", "1.0.0-rc.1", "1.0.0-alpha", "1.0.0-beta.2", "1.0.0-alpha.1", "1.0.0-beta"}
+	Sort(versions)
+	if !slices.Equal(versions, want) {
+		t.Errorf("Sort() = %v, want %v", versions, want)
+	}
+
+	versions = []string{"2024.10.01", "snapshot", "2024.9.30", "2024.02"}
+	Sort(versions)
+	if want := []string{"snapshot", "2024.02", "2024.9.30", "2024.10.01"}; !slices.Equal(versions, want) {
+		t.Errorf("Sort() = %v, want %v", versions, want)
+	}
+}
+
+func TestLatest(t *testing.T) {
+	tests := []struct {
+		name     string
+		versions []string
+		want     string
+		found    bool
+	}{
+		{"highest release", []string{"1.0.0", "1.10.0", "1.9.0"}, "1.10.0", true},
+		{"releases before newer pre-releases", []string{"1.0.0", "2.0.0-rc.1", "0.9.0"}, "1.0.0", true},
+		{"highest pre-release without releases", []string{"2.0.0-alpha", "2.0.0-rc.1", "2.0.0-beta.2"}, "2.0.0-rc.1", true},
+		{"build metadata keeps the first", []string{"1.0.0+a", "1.0.0+b"}, "1.0.0+a", true},
+		{"calver", []string{"2024.03.15", "2024.11.01", "2024.04"}, "2024.11.01", true},
+		{"empty", nil, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, found := Latest(tt.versions); got != tt.want || found != tt.found {
+				t.Errorf("Latest(%v) = %q, %v, want %q, %v", tt.versions, got, found, tt.want, tt.found)
+			}
+		})
+	}
+}
+
+func TestRangeContains(t *testing.T) {
+	tests := []struct {
+		rangeExpr string
+		matches   []string
+		misses    []string
+	}{
+		{"1.2.3", []string{"1.2.3", "v1.2.3", "1.2.3+build.1"}, []string{"1.2.4", "1.2.3-rc.1"}},
+		{"=1.2.3", []string{"1.2.3"}, []string{"1.2.2"}},
+		{"!=1.2.3", []string{"1.2.2", "2.0.0"}, []string{"1.2.3", "latest"}},
+		{">=1.2.0 <2.0.0", []string{"1.2.0", "1.9.9"}, []string{"1.1.9", "2.0.0"}},
+		{">= 1.2.0 <= 1.3.0", []string{"1.2.0", "1.3.0"}, []string{"1.3.1"}},
+		{">1.0.0 || <0.5.0", []string{"1.0.1", "0.4.9"}, []string{"1.0.0", "0.5.0", "0.9.0"}},
+		{"^1.2.3", []string{"1.2.3", "1.9.0"}, []string{"1.2.2", "2.0.0", "0.9.0"}},
+		{"^0.2.3", []string{"0.2.3", "0.2.9"}, []string{"0.2.2", "0.3.0", "1.0.0"}},
+		{"^0.0.3", []string{"0.0.3"}, []string{"0.0.2", "0.0.4", "0.1.0"}},
+		{"^0.0.0", []string{"0.0.0"}, []string{"0.0.1", "0.1.0"}},
+		{"~1.2.3", []string{"1.2.3", "1.2.9"}, []string{"1.2.2", "1.3.0", "2.0.0"}},
+		{"~0.2.3", []string{"0.2.3", "0.2.9"}, []string{"0.2.2", "0.3.0"}},
+		{"~0.0.3", []string{"0.0.3", "0.0.9"}, []string{"0.0.2", "0.1.0"}},
+		{">=2024.03 <2024.07", []string{"2024.03.01", "2024.06.30"}, []string{"2024.02.29", "2024.07"}},
+		{"^2024.03", []string{"2024.03.15", "2024.11.01"}, []string{"2024.02.28", "2025.01"}},
+		{">=0.0.0", []string{"0.0.0", "3.1"}, []string{"latest", ""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rangeExpr, func(t *testing.T) {
+			r, err := ParseRange(tt.rangeExpr)
+			if err != nil {
+				t.Fatalf("ParseRange(%q) = %v", tt.rangeExpr, err)
+			}
+			for _, v := range tt.matches {
+				if !r.Contains(v) {
+					t.Errorf("%q does not contain %q", tt.rangeExpr, v)
+				}
+			}
+			for _, v := range tt.misses {
+				if r.Contains(v) {
+					t.Errorf("%q contains %q", tt.rangeExpr, v)
+				}
+			}
+		})
+	}
+}
+
+func TestParseRangeErrors(t *testing.T) {
+	for _, invalid := range []string{"", "   ", ">=1.0.0 ||", "|| <2.0.0", ">=", ">=latest", "^banana", "~", "1.x", ">=1.0.0 <two"} {
+		if _, err := ParseRange(invalid); err == nil {
+			t.Errorf("ParseRange(%q) succeeded, want an error", invalid)
+		}
+	}
+}
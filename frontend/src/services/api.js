@@ -21,14 +21,28 @@ const handleResponse = async (response) => {
   return response.json();
 };
 
+// Fetch every page of a list endpoint and return the items of all pages
+const fetchAll = async (path) => {
+  const items = [];
+  let cursor = '';
+  do {
+    const separator = path.includes('?') ? '&' : '?';
+    const query = `limit=500${cursor ? `&cursor=${encodeURIComponent(cursor)}` : ''}`;
+    const response = await fetch(`${API_BASE_URL}${path}${separator}${query}`, {
+      headers: getAuthHeaders()
+    });
+    const page = await handleResponse(response);
+    items.push(...page.data);
+    cursor = page.next_cursor;
+  } while (cursor);
+  return items;
+};
+
 // Release API functions
 export const releaseService = {
   // Get all releases
   getAllReleases: async () => {
-    const response = await fetch(`${API_BASE_URL}/releases`, {
-      headers: getAuthHeaders()
-    });
-    return handleResponse(response);
+    return fetchAll('/releases');
   },
 
   // Get single release
@@ -70,10 +84,7 @@ export const releaseService = {
 
   // Get builds for a release
   getReleaseBuilds: async (id) => {
-    const response = await fetch(`${API_BASE_URL}/releases/${id}/builds`, {
-      headers: getAuthHeaders()
-    });
-    return handleResponse(response);
+    return fetchAll(`/releases/${id}/builds`);
   }
 };
 
@@ -81,10 +92,7 @@ export const releaseService = {
 export const buildService = {
   // Get all builds
   getAllBuilds: async () => {
-    const response = await fetch(`${API_BASE_URL}/builds`, {
-      headers: getAuthHeaders()
-    });
-    return handleResponse(response);
+    return fetchAll('/builds');
   },
 
   // Get single build
@@ -129,10 +137,7 @@ export const buildService = {
 export const systemService = {
   // Get all systems
   getAllSystems: async () => {
-    const response = await fetch(`${API_BASE_URL}/systems`, {
-      headers: getAuthHeaders()
-    });
-    return handleResponse(response);
+    return fetchAll('/systems');
   },
 
   // Get single system
@@ -174,10 +179,7 @@ export const systemService = {
 
   // Get subsystems for a system
   getSubsystems: async (id) => {
-    const response = await fetch(`${API_BASE_URL}/systems/${id}/subsystems`, {
-      headers: getAuthHeaders()
-    });
-    return handleResponse(response);
+    return fetchAll(`/systems/${id}/subsystems`);
   }
 };
 
@@ -185,10 +187,7 @@ export const systemService = {
 export const environmentService = {
   // Get all environments
   getAllEnvironments: async () => {
-    const response = await fetch(`${API_BASE_URL}/environments`, {
-      headers: getAuthHeaders()
-    });
-    return handleResponse(response);
+    return fetchAll('/environments');
   },
 
   // Get single environment
@@ -232,11 +231,7 @@ export const environmentService = {
 // Environment Group API functions
 export const environmentGroupsService = {
   getEnvironmentGroups: async () => {
-    const response = await fetch(`${API_BASE_URL}/environment-groups`, {
-      headers: getAuthHeaders(),
-      method: 'GET'
-    });
-    return handleResponse(response);
+    return fetchAll('/environment-groups');
   },
 
   getSpecificEnvironmentGroup: async (id) => {